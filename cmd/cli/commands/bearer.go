@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/spf13/cobra"
+)
+
+var (
+	bearerTokenTTLFlag      time.Duration
+	bearerTokenAudienceFlag string
+)
+
+func init() {
+	bearerTokenCmd.Flags().DurationVar(&bearerTokenTTLFlag, "ttl", time.Hour, "Token lifetime")
+	bearerTokenCmd.Flags().StringVar(&bearerTokenAudienceFlag, "audience", "", "ETH address of the node the token is issued for. Defaults to the one specified in the Node address or to your own")
+}
+
+var bearerTokenCmd = &cobra.Command{
+	Use:   "bearer-token",
+	Short: "Issue a token to access the node's REST API",
+	Long: `Issue a token to access the node's REST API.
+
+The token authenticates your key and must be passed using
+"Authorization: Bearer <token>" HTTP header. It is required when the node has
+role-based access control configured. The token is accepted only by the node
+it is issued for, which is specified using "--audience" flag or as the
+"ethAddr@host:port" Node address.`,
+	Example:           "  curl -k -H \"Authorization: Bearer $(sonmcli bearer-token --audience 0x8125721c2413d99a33e351e1f6bb4e56b6b633fd)\" -d '{}' https://localhost:15031/DealManagement/List",
+	Args:              cobra.NoArgs,
+	PersistentPreRunE: loadKeyStoreWrapper,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		if bearerTokenTTLFlag <= 0 {
+			return fmt.Errorf("token lifetime must be positive")
+		}
+
+		key, err := getDefaultKey()
+		if err != nil {
+			return err
+		}
+
+		audience, err := bearerTokenAudience(crypto.PubkeyToAddress(key.PublicKey))
+		if err != nil {
+			return err
+		}
+
+		token, err := auth.NewBearerToken(key, audience, bearerTokenTTLFlag)
+		if err != nil {
+			return fmt.Errorf("cannot create bearer token: %v", err)
+		}
+
+		if isSimpleFormat() {
+			cmd.Println(token)
+		} else {
+			showJSON(cmd, map[string]string{"token": token})
+		}
+
+		return nil
+	},
+}
+
+// bearerTokenAudience returns the ETH address of the node bearer tokens are
+// issued for. Like with gRPC connections, the node is assumed to have the
+// same key when its address is not specified.
+func bearerTokenAudience(self common.Address) (common.Address, error) {
+	if bearerTokenAudienceFlag != "" {
+		if !common.IsHexAddress(bearerTokenAudienceFlag) {
+			return common.Address{}, fmt.Errorf("invalid audience: %s is not an ETH address", bearerTokenAudienceFlag)
+		}
+
+		return common.HexToAddress(bearerTokenAudienceFlag), nil
+	}
+
+	if hasETHAddr(nodeAddress()) {
+		addr, err := auth.NewAddr(nodeAddress())
+		if err != nil {
+			return common.Address{}, err
+		}
+
+		return addr.ETH()
+	}
+
+	return self, nil
+}
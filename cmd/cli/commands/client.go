@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"github.com/sonm-io/core/insonmnia/auth"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util/xgrpc"
 	"golang.org/x/net/context"
@@ -10,8 +11,8 @@ import (
 // newClientConn provides a single point for gPRC's ClientConn configuration.
 //
 // Note that `timeoutFlag`, `nodeAddressFlag` and `creds` are set implicitly because it is global for all CLI-related stuff.
-func newClientConn(ctx context.Context) (*grpc.ClientConn, error) {
-	return xgrpc.NewClient(ctx, nodeAddress(), creds)
+func newClientConn(ctx context.Context, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	return xgrpc.NewClient(ctx, nodeAddress(), creds, opts...)
 }
 
 func newWorkerManagementClient(ctx context.Context) (pb.WorkerManagementClient, error) {
@@ -50,8 +51,19 @@ func newDealsClient(ctx context.Context) (pb.DealManagementClient, error) {
 	return pb.NewDealManagementClient(cc), nil
 }
 
+// newTaskClient creates tasks client, which passes the delegation token to
+// the node if it is specified using `--delegation-token` flag.
 func newTaskClient(ctx context.Context) (pb.TaskManagementClient, error) {
-	cc, err := newClientConn(ctx)
+	var opts []grpc.DialOption
+	if len(delegationTokenFlag) != 0 {
+		if _, err := pb.DecodeDelegationToken(delegationTokenFlag); err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, grpc.WithPerRPCCredentials(auth.NewDelegationCredentials(delegationTokenFlag)))
+	}
+
+	cc, err := newClientConn(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,3 +97,21 @@ func newProfilesClient(ctx context.Context) (pb.ProfilesClient, error) {
 
 	return pb.NewProfilesClient(cc), nil
 }
+
+func newMarketSearchClient(ctx context.Context) (pb.MarketSearchClient, error) {
+	cc, err := newClientConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.NewMarketSearchClient(cc), nil
+}
+
+func newNPPDiagnosticsClient(ctx context.Context) (pb.NPPDiagnosticsClient, error) {
+	cc, err := newClientConn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return pb.NewNPPDiagnosticsClient(cc), nil
+}
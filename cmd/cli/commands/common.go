@@ -5,7 +5,9 @@ import (
 	"crypto/ecdsa"
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -103,6 +105,7 @@ func init() {
 
 	rootCmd.AddCommand(workerMgmtCmd, orderRootCmd, dealRootCmd, taskRootCmd, blacklistRootCmd)
 	rootCmd.AddCommand(loginCmd, tokenRootCmd, versionCmd, autoCompleteCmd, masterRootCmd, profileRootCmd)
+	rootCmd.AddCommand(marketRootCmd, deployCmd, bearerTokenCmd, signImageCmd)
 }
 
 // Root configure and return root command
@@ -180,6 +183,22 @@ func nodeAddress() string {
 	return p
 }
 
+// nodeHost returns the host of the Node address, which may be prefixed with
+// its Ethereum address.
+func nodeHost() (string, error) {
+	addr := nodeAddress()
+	if id := strings.LastIndex(addr, "@"); id >= 0 {
+		addr = addr[id+1:]
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("cannot parse Node address: %v", err)
+	}
+
+	return host, nil
+}
+
 func keystorePath() (string, error) {
 	var err error
 	p := rootCmd.Flag("keystore").Value.String()
@@ -240,12 +259,32 @@ func loadKeyStoreWrapper(_ *cobra.Command, _ []string) error {
 			return err
 		}
 
-		creds = auth.NewWalletAuthenticator(util.NewTLS(TLSConfig), crypto.PubkeyToAddress(sessionKey.PublicKey))
+		creds = util.NewTLS(TLSConfig)
+
+		// Nodes specified in "ethAddr@Endpoint" format are verified by the
+		// client itself, which allows to connect to nodes with keys other
+		// than ours, for example, with role-based access control configured.
+		// Otherwise the node must have the same key.
+		if !hasETHAddr(nodeAddress()) {
+			creds = auth.NewWalletAuthenticator(creds, crypto.PubkeyToAddress(sessionKey.PublicKey))
+		}
 	}
 
 	return nil
 }
 
+// hasETHAddr checks whether the given address is in "ethAddr@Endpoint"
+// format.
+func hasETHAddr(addr string) bool {
+	authAddr, err := auth.NewAddr(addr)
+	if err != nil {
+		return false
+	}
+
+	_, err = authAddr.ETH()
+	return err == nil
+}
+
 // loadKeyStoreIfRequired loads eth keystore if `insecure` flag is not set.
 // this wrapper is required for any command that not require eth keys implicitly
 // but may use TLS to connect to the Node.
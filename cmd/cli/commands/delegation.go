@@ -0,0 +1,178 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/pborman/uuid"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/spf13/cobra"
+)
+
+// delegatableMethods contains Worker methods that can be delegated.
+var delegatableMethods = []string{
+	"StartTask",
+	"StopTask",
+	"TaskStatus",
+	"TaskLogs",
+	"JoinNetwork",
+	"PushTask",
+	"PullTask",
+	"GetDealInfo",
+	"Ping",
+}
+
+var (
+	delegateMethodsFlag []string
+	delegateTTLFlag     time.Duration
+	delegationTokenFlag string
+)
+
+func init() {
+	dealDelegateCmd.Flags().StringSliceVar(&delegateMethodsFlag, "methods", []string{"TaskStatus", "TaskLogs", "GetDealInfo"},
+		fmt.Sprintf("Worker methods to delegate, any of: %s", strings.Join(delegatableMethods, ", ")))
+	dealDelegateCmd.Flags().DurationVar(&delegateTTLFlag, "ttl", 24*time.Hour, "Token lifetime")
+
+	taskRootCmd.PersistentFlags().StringVar(&delegationTokenFlag, "delegation-token", "", "Access deals on behalf of their consumers using the given delegation token")
+
+	dealRootCmd.AddCommand(
+		dealDelegateCmd,
+		dealDelegationsCmd,
+		dealRevokeDelegationCmd,
+	)
+}
+
+var dealDelegateCmd = &cobra.Command{
+	Use:   "delegate <deal_id> <delegate_addr>",
+	Short: "Issue a token allowing another address to manage tasks within the deal",
+	Long: `Issue a token allowing another address to manage tasks within the deal.
+
+The token is signed locally with your key, which must be the deal's consumer
+one. The delegate uses its own node and passes the token to task commands
+using the --delegation-token flag.`,
+	Example: "  sonmcli deal delegate 42 0x8125721c2413d99a33e351e1f6bb4e56b6b633fd --methods=TaskStatus,TaskLogs --ttl=1h",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dealID, err := pb.NewBigIntFromString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid deal ID: %v", err)
+		}
+
+		if !common.IsHexAddress(args[1]) {
+			return fmt.Errorf("invalid delegate address: %s", args[1])
+		}
+
+		methods, err := parseDelegatedMethods(delegateMethodsFlag)
+		if err != nil {
+			return err
+		}
+
+		if delegateTTLFlag <= 0 {
+			return fmt.Errorf("token lifetime must be positive")
+		}
+
+		key, err := getDefaultKey()
+		if err != nil {
+			return err
+		}
+
+		token := &pb.DelegationToken{
+			Id:        uuid.New(),
+			DealID:    dealID,
+			Delegate:  pb.NewEthAddress(common.HexToAddress(args[1])),
+			Methods:   methods,
+			ExpiresAt: &pb.Timestamp{Seconds: time.Now().Add(delegateTTLFlag).Unix()},
+		}
+
+		if err := token.SignWith(key); err != nil {
+			return err
+		}
+
+		encoded, err := token.Encode()
+		if err != nil {
+			return fmt.Errorf("cannot encode delegation token: %v", err)
+		}
+
+		printDelegationToken(cmd, token, encoded)
+		return nil
+	},
+}
+
+var dealDelegationsCmd = &cobra.Command{
+	Use:   "delegations <deal_id>",
+	Short: "Show delegation tokens used to access the deal",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		dealer, err := newDealsClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		dealID, err := pb.NewBigIntFromString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid deal ID: %v", err)
+		}
+
+		reply, err := dealer.DelegationTokens(ctx, dealID)
+		if err != nil {
+			return fmt.Errorf("cannot get delegation tokens: %v", err)
+		}
+
+		printDelegationTokens(cmd, reply)
+		return nil
+	},
+}
+
+var dealRevokeDelegationCmd = &cobra.Command{
+	Use:   "revoke-delegation <deal_id> <token_id>",
+	Short: "Revoke the delegation token",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		dealer, err := newDealsClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		dealID, err := pb.NewBigIntFromString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid deal ID: %v", err)
+		}
+
+		if _, err := dealer.RevokeDelegationToken(ctx, &pb.RevokeDelegationTokenRequest{DealID: dealID, Id: args[1]}); err != nil {
+			return fmt.Errorf("cannot revoke delegation token: %v", err)
+		}
+
+		showOk(cmd)
+		return nil
+	},
+}
+
+func parseDelegatedMethods(methods []string) ([]string, error) {
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("at least one method must be delegated")
+	}
+
+	for _, method := range methods {
+		known := false
+		for _, delegatable := range delegatableMethods {
+			if method == delegatable {
+				known = true
+				break
+			}
+		}
+
+		if !known {
+			return nil, fmt.Errorf("method %s can not be delegated", method)
+		}
+	}
+
+	return methods, nil
+}
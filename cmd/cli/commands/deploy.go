@@ -0,0 +1,349 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/sonm-io/core/cmd/cli/task_config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util/multierror"
+	"github.com/spf13/cobra"
+)
+
+const (
+	deployPollInterval = 5 * time.Second
+	// deployStartAttempts limits task start retries, because the worker may
+	// be not ready to accept tasks right after the deal is opened.
+	deployStartAttempts = 3
+)
+
+var (
+	deployLogsFlag       bool
+	deployNoRollbackFlag bool
+)
+
+func init() {
+	deployCmd.Flags().BoolVar(&deployLogsFlag, "logs", true, "Stream task logs to stderr until the task is running")
+	deployCmd.Flags().BoolVar(&deployNoRollbackFlag, "no-rollback", false, "Do not cancel the order or close the deal on failure")
+}
+
+var deployCmd = &cobra.Command{
+	Use:   "deploy <deployment.yaml>",
+	Short: "Buy resources and start the task on them in a single step",
+	Long: `Buy resources and start the task on them in a single step.
+
+Resources are bought either by quick-buying one of ASK orders specified or by
+placing the BID order and waiting for it to be matched. Then the task is
+started within the deal and its status is watched until it is running.
+
+On failure the order is cancelled or the deal is closed unless --no-rollback
+is specified. Progress is written to stderr, while the result is written to
+stdout.`,
+	Example:           "  sonmcli deploy deployment.yaml --out=json",
+	Args:              cobra.ExactArgs(1),
+	PersistentPreRunE: loadKeyStoreIfRequired,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		deployment, err := task_config.LoadDeployment(args[0])
+		if err != nil {
+			return fmt.Errorf("cannot load deployment definition: %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), deployment.Timeout)
+		defer cancel()
+
+		cc, err := newClientConn(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+		defer cc.Close()
+
+		deployer := &deployer{
+			market:       pb.NewMarketClient(cc),
+			deals:        pb.NewDealManagementClient(cc),
+			tasks:        pb.NewTaskManagementClient(cc),
+			pollInterval: deployPollInterval,
+			rollback:     !deployNoRollbackFlag,
+			progress:     os.Stderr,
+		}
+
+		if deployLogsFlag {
+			deployer.logs = os.Stderr
+		}
+
+		result, err := deployer.Deploy(ctx, deployment)
+		printDeploymentResult(cmd, result)
+
+		return err
+	},
+}
+
+type deploymentResult struct {
+	OrderID    string                   `json:"orderID,omitempty"`
+	DealID     string                   `json:"dealID,omitempty"`
+	TaskID     string                   `json:"taskID,omitempty"`
+	Status     string                   `json:"status,omitempty"`
+	PortMap    map[string]*pb.Endpoints `json:"portMap,omitempty"`
+	Error      string                   `json:"error,omitempty"`
+	RolledBack bool                     `json:"rolledBack,omitempty"`
+}
+
+// deployer performs deployment steps, rolling them back on failure.
+type deployer struct {
+	market pb.MarketClient
+	deals  pb.DealManagementClient
+	tasks  pb.TaskManagementClient
+
+	pollInterval time.Duration
+	rollback     bool
+	// progress receives human-readable progress messages.
+	progress io.Writer
+	// logs receives task logs while waiting for the task to run. Nil
+	// disables logs streaming.
+	logs io.Writer
+}
+
+func (m *deployer) Deploy(ctx context.Context, deployment *task_config.Deployment) (*deploymentResult, error) {
+	result := &deploymentResult{}
+
+	if err := m.deploy(ctx, deployment, result); err != nil {
+		result.Error = err.Error()
+
+		if m.rollback {
+			if rollbackErr := m.rollbackDeployment(result); rollbackErr != nil {
+				m.printf("failed to roll back: %v\n", rollbackErr)
+				return result, fmt.Errorf("%v; rollback has failed: %v", err, rollbackErr)
+			}
+
+			result.RolledBack = len(result.OrderID) != 0 || len(result.DealID) != 0
+		}
+
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (m *deployer) deploy(ctx context.Context, deployment *task_config.Deployment, result *deploymentResult) error {
+	if len(deployment.AskIDs) != 0 {
+		if err := m.quickBuy(ctx, deployment, result); err != nil {
+			return err
+		}
+	} else {
+		if err := m.placeOrder(ctx, deployment, result); err != nil {
+			return err
+		}
+	}
+
+	if err := m.startTask(ctx, deployment, result); err != nil {
+		return err
+	}
+
+	return m.waitRunning(ctx, result)
+}
+
+func (m *deployer) quickBuy(ctx context.Context, deployment *task_config.Deployment, result *deploymentResult) error {
+	errs := multierror.NewMultiError()
+
+	for _, askID := range deployment.AskIDs {
+		id, err := pb.NewBigIntFromString(askID)
+		if err != nil {
+			return fmt.Errorf("invalid ASK order ID %s: %v", askID, err)
+		}
+
+		m.printf("quick-buying ASK order %s\n", askID)
+		deal, err := m.deals.QuickBuy(ctx, &pb.QuickBuyRequest{
+			AskID:    id,
+			Duration: deployment.Order.GetDuration(),
+			Force:    deployment.Force,
+		})
+		if err != nil {
+			m.printf("failed to quick-buy ASK order %s: %v\n", askID, err)
+			errs = multierror.Append(errs, fmt.Errorf("order %s: %v", askID, err))
+			continue
+		}
+
+		result.DealID = deal.GetDeal().GetId().Unwrap().String()
+		m.printf("opened deal %s\n", result.DealID)
+		return nil
+	}
+
+	return fmt.Errorf("cannot quick-buy any of ASK orders: %v", errs.ErrorOrNil())
+}
+
+func (m *deployer) placeOrder(ctx context.Context, deployment *task_config.Deployment, result *deploymentResult) error {
+	order, err := m.market.CreateOrder(ctx, &deployment.Order)
+	if err != nil {
+		return fmt.Errorf("cannot create order on marketplace: %v", err)
+	}
+
+	result.OrderID = order.GetId().Unwrap().String()
+	m.printf("placed BID order %s, waiting for a deal\n", result.OrderID)
+
+	for {
+		order, err := m.market.GetOrderByID(ctx, &pb.ID{Id: result.OrderID})
+		switch {
+		case err != nil:
+			m.printf("failed to get order status: %v\n", err)
+		case !order.GetDealID().IsZero():
+			result.DealID = order.GetDealID().Unwrap().String()
+			m.printf("opened deal %s\n", result.DealID)
+			return nil
+		case order.GetOrderStatus() == pb.OrderStatus_ORDER_INACTIVE:
+			return fmt.Errorf("order %s has been cancelled without a deal", result.OrderID)
+		}
+
+		if err := m.sleep(ctx); err != nil {
+			return fmt.Errorf("failed to wait for a deal: %v", err)
+		}
+	}
+}
+
+func (m *deployer) startTask(ctx context.Context, deployment *task_config.Deployment, result *deploymentResult) error {
+	dealID, err := pb.NewBigIntFromString(result.DealID)
+	if err != nil {
+		return err
+	}
+
+	for attempt := 1; ; attempt++ {
+		reply, err := m.tasks.Start(ctx, &pb.StartTaskRequest{DealID: dealID, Spec: &deployment.Task})
+		if err == nil {
+			result.TaskID = reply.GetId()
+			result.PortMap = reply.GetPortMap()
+			m.printf("started task %s\n", result.TaskID)
+			return nil
+		}
+
+		m.printf("failed to start task (attempt %d/%d): %v\n", attempt, deployStartAttempts, err)
+		if attempt == deployStartAttempts {
+			return fmt.Errorf("cannot start task: %v", err)
+		}
+
+		if err := m.sleep(ctx); err != nil {
+			return fmt.Errorf("cannot start task: %v", err)
+		}
+	}
+}
+
+func (m *deployer) waitRunning(ctx context.Context, result *deploymentResult) error {
+	dealID, err := pb.NewBigIntFromString(result.DealID)
+	if err != nil {
+		return err
+	}
+
+	if m.logs != nil {
+		logsCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		go m.streamLogs(logsCtx, &pb.TaskLogsRequest{
+			Type:   pb.TaskLogsRequest_BOTH,
+			Id:     result.TaskID,
+			DealID: dealID,
+			Follow: true,
+		})
+	}
+
+	for {
+		status, err := m.tasks.Status(ctx, &pb.TaskID{Id: result.TaskID, DealID: dealID})
+		if err != nil {
+			m.printf("failed to get task status: %v\n", err)
+		} else {
+			if current := status.GetStatus().String(); current != result.Status {
+				result.Status = current
+				m.printf("task is %s\n", current)
+			}
+
+			if len(status.GetPortMap()) != 0 {
+				result.PortMap = status.GetPortMap()
+			}
+
+			switch status.GetStatus() {
+			case pb.TaskStatusReply_RUNNING:
+				return nil
+			case pb.TaskStatusReply_FINISHED, pb.TaskStatusReply_BROKEN:
+				return fmt.Errorf("task has stopped with %s status", result.Status)
+			}
+		}
+
+		if err := m.sleep(ctx); err != nil {
+			return fmt.Errorf("failed to wait for the task to run: %v", err)
+		}
+	}
+}
+
+func (m *deployer) streamLogs(ctx context.Context, req *pb.TaskLogsRequest) {
+	client, err := m.tasks.Logs(ctx, req)
+	if err != nil {
+		m.printf("failed to stream task logs: %v\n", err)
+		return
+	}
+
+	// Logs are multiplexed the same way Docker does, but both streams are
+	// written to the same destination here.
+	stdcopy.StdCopy(m.logs, m.logs, &logReader{cli: client})
+}
+
+// rollbackDeployment closes the deal or cancels the order if there is no
+// deal yet.
+//
+// A fresh context is used, because the deployment one may be already
+// expired.
+func (m *deployer) rollbackDeployment(result *deploymentResult) error {
+	ctx, cancel := newTimeoutContext()
+	defer cancel()
+
+	if len(result.DealID) == 0 && len(result.OrderID) != 0 {
+		m.printf("cancelling order %s\n", result.OrderID)
+
+		_, err := m.market.CancelOrder(ctx, &pb.ID{Id: result.OrderID})
+		if err == nil {
+			return nil
+		}
+
+		// The order may be matched right before cancelling.
+		order, orderErr := m.market.GetOrderByID(ctx, &pb.ID{Id: result.OrderID})
+		if orderErr != nil || order.GetDealID().IsZero() {
+			return fmt.Errorf("cannot cancel order %s: %v", result.OrderID, err)
+		}
+
+		result.DealID = order.GetDealID().Unwrap().String()
+	}
+
+	if len(result.DealID) == 0 {
+		return nil
+	}
+
+	m.printf("closing deal %s\n", result.DealID)
+
+	dealID, err := pb.NewBigIntFromString(result.DealID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.deals.Finish(ctx, &pb.DealFinishRequest{Id: dealID, BlacklistType: pb.BlacklistType_BLACKLIST_NOBODY}); err != nil {
+		return fmt.Errorf("cannot close deal %s: %v", result.DealID, err)
+	}
+
+	return nil
+}
+
+func (m *deployer) sleep(ctx context.Context) error {
+	timer := time.NewTimer(m.pollInterval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (m *deployer) printf(format string, args ...interface{}) {
+	if m.progress != nil {
+		fmt.Fprintf(m.progress, format, args...)
+	}
+}
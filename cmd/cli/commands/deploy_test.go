@@ -0,0 +1,158 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/cmd/cli/task_config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeMarketClient struct {
+	pb.MarketClient
+
+	// polls is the number of order status requests before it is matched.
+	polls     int
+	cancelled []string
+}
+
+func (m *fakeMarketClient) CreateOrder(ctx context.Context, in *pb.BidOrder, opts ...grpc.CallOption) (*pb.Order, error) {
+	return &pb.Order{Id: pb.NewBigIntFromInt(10)}, nil
+}
+
+func (m *fakeMarketClient) GetOrderByID(ctx context.Context, in *pb.ID, opts ...grpc.CallOption) (*pb.Order, error) {
+	if m.polls > 0 {
+		m.polls--
+		return &pb.Order{Id: pb.NewBigIntFromInt(10), OrderStatus: pb.OrderStatus_ORDER_ACTIVE}, nil
+	}
+
+	return &pb.Order{Id: pb.NewBigIntFromInt(10), DealID: pb.NewBigIntFromInt(20)}, nil
+}
+
+func (m *fakeMarketClient) CancelOrder(ctx context.Context, in *pb.ID, opts ...grpc.CallOption) (*pb.Empty, error) {
+	m.cancelled = append(m.cancelled, in.GetId())
+	return &pb.Empty{}, nil
+}
+
+type fakeDealsClient struct {
+	pb.DealManagementClient
+
+	unavailable map[string]bool
+	finished    []string
+}
+
+func (m *fakeDealsClient) QuickBuy(ctx context.Context, in *pb.QuickBuyRequest, opts ...grpc.CallOption) (*pb.DealInfoReply, error) {
+	if m.unavailable[in.GetAskID().Unwrap().String()] {
+		return nil, errors.New("order is not available")
+	}
+
+	return &pb.DealInfoReply{Deal: &pb.Deal{Id: pb.NewBigIntFromInt(30)}}, nil
+}
+
+func (m *fakeDealsClient) Finish(ctx context.Context, in *pb.DealFinishRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	m.finished = append(m.finished, in.GetId().Unwrap().String())
+	return &pb.Empty{}, nil
+}
+
+type fakeTasksClient struct {
+	pb.TaskManagementClient
+
+	statuses []pb.TaskStatusReply_Status
+}
+
+func (m *fakeTasksClient) Start(ctx context.Context, in *pb.StartTaskRequest, opts ...grpc.CallOption) (*pb.StartTaskReply, error) {
+	return &pb.StartTaskReply{Id: "task-" + in.GetDealID().Unwrap().String()}, nil
+}
+
+func (m *fakeTasksClient) Status(ctx context.Context, in *pb.TaskID, opts ...grpc.CallOption) (*pb.TaskStatusReply, error) {
+	status := m.statuses[0]
+	if len(m.statuses) > 1 {
+		m.statuses = m.statuses[1:]
+	}
+
+	return &pb.TaskStatusReply{Status: status}, nil
+}
+
+func newTestDeployer(market pb.MarketClient, deals pb.DealManagementClient, tasks pb.TaskManagementClient) *deployer {
+	return &deployer{
+		market:       market,
+		deals:        deals,
+		tasks:        tasks,
+		pollInterval: time.Millisecond,
+		rollback:     true,
+		progress:     ioutil.Discard,
+	}
+}
+
+func TestDeployPlacesOrderAndWaitsForTask(t *testing.T) {
+	market := &fakeMarketClient{polls: 2}
+	tasks := &fakeTasksClient{statuses: []pb.TaskStatusReply_Status{
+		pb.TaskStatusReply_SPOOLING,
+		pb.TaskStatusReply_SPAWNING,
+		pb.TaskStatusReply_RUNNING,
+	}}
+
+	deployer := newTestDeployer(market, &fakeDealsClient{}, tasks)
+
+	result, err := deployer.Deploy(context.Background(), &task_config.Deployment{})
+	require.NoError(t, err)
+
+	assert.Equal(t, &deploymentResult{
+		OrderID: "10",
+		DealID:  "20",
+		TaskID:  "task-20",
+		Status:  "RUNNING",
+	}, result)
+	assert.Empty(t, market.cancelled)
+}
+
+func TestDeployQuickBuysFirstAvailableAsk(t *testing.T) {
+	deals := &fakeDealsClient{unavailable: map[string]bool{"1": true}}
+	tasks := &fakeTasksClient{statuses: []pb.TaskStatusReply_Status{pb.TaskStatusReply_RUNNING}}
+
+	deployer := newTestDeployer(&fakeMarketClient{}, deals, tasks)
+
+	result, err := deployer.Deploy(context.Background(), &task_config.Deployment{AskIDs: []string{"1", "2"}})
+	require.NoError(t, err)
+
+	assert.Equal(t, "30", result.DealID)
+	assert.Empty(t, result.OrderID)
+}
+
+func TestDeployClosesDealWhenTaskFails(t *testing.T) {
+	deals := &fakeDealsClient{}
+	tasks := &fakeTasksClient{statuses: []pb.TaskStatusReply_Status{
+		pb.TaskStatusReply_SPAWNING,
+		pb.TaskStatusReply_BROKEN,
+	}}
+
+	deployer := newTestDeployer(&fakeMarketClient{}, deals, tasks)
+
+	result, err := deployer.Deploy(context.Background(), &task_config.Deployment{AskIDs: []string{"1"}})
+	require.Error(t, err)
+
+	assert.True(t, result.RolledBack)
+	assert.Equal(t, "BROKEN", result.Status)
+	assert.Equal(t, []string{"30"}, deals.finished)
+}
+
+func TestDeployCancelsOrderOnTimeout(t *testing.T) {
+	market := &fakeMarketClient{polls: 1000000}
+	deployer := newTestDeployer(market, &fakeDealsClient{}, &fakeTasksClient{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	result, err := deployer.Deploy(ctx, &task_config.Deployment{})
+	require.Error(t, err)
+
+	assert.True(t, result.RolledBack)
+	assert.Empty(t, result.DealID)
+	assert.Equal(t, []string{"10"}, market.cancelled)
+}
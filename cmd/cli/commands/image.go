@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/docker/distribution/reference"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/spf13/cobra"
+)
+
+var signImageCmd = &cobra.Command{
+	Use:   "sign-image <image@digest>",
+	Short: "Sign the image to allow workers trusting your key to run it",
+	Long: `Sign the image to allow workers trusting your key to run it.
+
+The signature must be pushed into the image repository as a manifest tagged
+with the printed tag, which has a layer annotated with the printed annotation.`,
+	Example:           "  sonmcli sign-image sonm/app@sha256:b5f9a9e47fa319607ed339789ef6692d4937ae5910b86e0ab929d035849e491e",
+	Args:              cobra.ExactArgs(1),
+	PersistentPreRunE: loadKeyStoreWrapper,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, err := reference.ParseAnyReference(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid image reference: %v", err)
+		}
+
+		digested, ok := ref.(reference.Digested)
+		if !ok {
+			return fmt.Errorf("image reference must contain the digest")
+		}
+
+		key, err := getDefaultKey()
+		if err != nil {
+			return err
+		}
+
+		sign, err := auth.SignImage(key, digested.Digest().String())
+		if err != nil {
+			return err
+		}
+
+		signature := hex.EncodeToString(sign)
+		tag := auth.ImageSignatureTag(digested.Digest().String())
+		if isSimpleFormat() {
+			cmd.Printf("Tag:        %s\r\n", tag)
+			cmd.Printf("Annotation: %s=%s\r\n", auth.ImageSignatureAnnotation, signature)
+		} else {
+			showJSON(cmd, map[string]string{
+				"tag":       tag,
+				"signature": signature,
+			})
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,443 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/cmd/cli/task_config"
+	pb "github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	marketTypeFlag         string
+	marketAuthorFlag       string
+	marketCounterpartyFlag []string
+	marketPriceMinFlag     string
+	marketPriceMaxFlag     string
+	marketDurationMinFlag  time.Duration
+	marketDurationMaxFlag  time.Duration
+	marketNetflagsFlag     []string
+	marketIdentityFlag     string
+	marketBenchmarksFlag   []string
+	marketRoleFlag         string
+	marketCountryFlag      []string
+	marketNameFlag         string
+	marketSortFlag         []string
+	marketLimitFlag        uint64
+	marketOffsetFlag       uint64
+)
+
+func init() {
+	for _, cmd := range []*cobra.Command{marketOrdersCmd, marketMatchingCmd, marketMatchCmd, marketProfilesCmd} {
+		cmd.Flags().Uint64Var(&marketLimitFlag, "limit", 10, "Number of entries to show")
+		cmd.Flags().Uint64Var(&marketOffsetFlag, "offset", 0, "Number of entries to skip")
+	}
+
+	for _, cmd := range []*cobra.Command{marketOrdersCmd, marketMatchCmd, marketProfilesCmd} {
+		cmd.Flags().StringSliceVar(&marketSortFlag, "sort", nil, "Sort by the given fields, e.g. \"Price:asc,CreatedTS:desc\"")
+	}
+
+	marketOrdersCmd.Flags().StringVar(&marketTypeFlag, "type", "ask", "Order type: `ask`, `bid` or `any`")
+	marketOrdersCmd.Flags().StringVar(&marketAuthorFlag, "author", "", "Show only orders created by the given address")
+	marketOrdersCmd.Flags().StringSliceVar(&marketCounterpartyFlag, "counterparty", nil, "Show only orders with the given counterparties, use `none` for orders available for everyone")
+	marketOrdersCmd.Flags().StringVar(&marketPriceMinFlag, "price-min", "", "Minimum price, e.g. \"0.1 USD/h\"")
+	marketOrdersCmd.Flags().StringVar(&marketPriceMaxFlag, "price-max", "", "Maximum price, e.g. \"1.5 USD/h\"")
+	marketOrdersCmd.Flags().DurationVar(&marketDurationMinFlag, "duration-min", 0, "Minimum order duration")
+	marketOrdersCmd.Flags().DurationVar(&marketDurationMaxFlag, "duration-max", 0, "Maximum order duration")
+	marketOrdersCmd.Flags().StringSliceVar(&marketNetflagsFlag, "netflags", nil, "Required network capabilities: `overlay`, `outbound`, `incoming` or `sandbox`")
+	marketOrdersCmd.Flags().StringVar(&marketIdentityFlag, "identity", "", "Minimum identity level of order creators, e.g. `registered`")
+	marketOrdersCmd.Flags().StringArrayVar(&marketBenchmarksFlag, "benchmark", nil, "Benchmark range as \"code=min..max\", \"code=min..\" or \"code=value\", may be repeated")
+
+	marketProfilesCmd.Flags().StringVar(&marketRoleFlag, "role", "any", "Profile role: `supplier`, `consumer` or `any`")
+	marketProfilesCmd.Flags().StringVar(&marketIdentityFlag, "identity", "", "Minimum identity level, e.g. `registered`")
+	marketProfilesCmd.Flags().StringSliceVar(&marketCountryFlag, "country", nil, "Show only profiles from the given countries")
+	marketProfilesCmd.Flags().StringVar(&marketNameFlag, "name", "", "Name or address pattern, \"%\" matches any sequence of characters")
+
+	marketRootCmd.AddCommand(
+		marketOrdersCmd,
+		marketMatchingCmd,
+		marketMatchCmd,
+		marketProfilesCmd,
+	)
+}
+
+var marketRootCmd = &cobra.Command{
+	Use:               "market",
+	Short:             "Browse the Marketplace",
+	PersistentPreRunE: loadKeyStoreIfRequired,
+}
+
+var marketOrdersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Search for active orders",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		req, err := marketOrdersRequestFromFlags()
+		if err != nil {
+			return err
+		}
+
+		client, err := newMarketSearchClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		reply, err := client.Orders(ctx, req)
+		if err != nil {
+			return fmt.Errorf("cannot search for orders: %v", err)
+		}
+
+		printMarketOrdersList(cmd, reply)
+		return nil
+	},
+}
+
+var marketMatchingCmd = &cobra.Command{
+	Use:   "matching <order_id>",
+	Short: "Show orders matching the given one",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		id, err := pb.NewBigIntFromString(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid order ID: %v", err)
+		}
+
+		client, err := newMarketSearchClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		reply, err := client.Matching(ctx, &pb.MatchingOrdersRequest{
+			Id:        id,
+			Limit:     marketLimitFlag,
+			Offset:    marketOffsetFlag,
+			WithCount: true,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot get matching orders: %v", err)
+		}
+
+		printMarketOrdersList(cmd, reply)
+		return nil
+	},
+}
+
+var marketMatchCmd = &cobra.Command{
+	Use:   "match <bid.yaml>",
+	Short: "Show ASK orders that would satisfy the given BID order",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		bid := &pb.BidOrder{}
+		if err := task_config.LoadFromFile(args[0], bid); err != nil {
+			return fmt.Errorf("cannot load order definition: %v", err)
+		}
+
+		sortings, err := parseSortings(marketSortFlag)
+		if err != nil {
+			return err
+		}
+
+		client, err := newMarketSearchClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		reply, err := client.MatchBid(ctx, &pb.MatchBidRequest{
+			Bid:       bid,
+			Limit:     marketLimitFlag,
+			Offset:    marketOffsetFlag,
+			Sortings:  sortings,
+			WithCount: true,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot match order: %v", err)
+		}
+
+		printMarketOrdersList(cmd, reply)
+		return nil
+	},
+}
+
+var marketProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "Search for profiles",
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		req, err := marketProfilesRequestFromFlags()
+		if err != nil {
+			return err
+		}
+
+		client, err := newProfilesClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		reply, err := client.List(ctx, req)
+		if err != nil {
+			return fmt.Errorf("cannot search for profiles: %v", err)
+		}
+
+		printProfilesList(cmd, reply)
+		return nil
+	},
+}
+
+func marketOrdersRequestFromFlags() (*pb.MarketOrdersRequest, error) {
+	orderType, err := parseOrderType(marketTypeFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	sortings, err := parseSortings(marketSortFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &pb.OrdersRequest{
+		Type:      orderType,
+		Status:    pb.OrderStatus_ORDER_ACTIVE,
+		Limit:     marketLimitFlag,
+		Offset:    marketOffsetFlag,
+		Sortings:  sortings,
+		WithCount: true,
+	}
+
+	if len(marketAuthorFlag) != 0 {
+		addr, err := util.HexToAddress(marketAuthorFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.AuthorID = pb.NewEthAddress(addr)
+	}
+
+	for _, value := range marketCounterpartyFlag {
+		if value == "none" {
+			filter.CounterpartyID = append(filter.CounterpartyID, pb.NewEthAddress(common.Address{}))
+			continue
+		}
+
+		addr, err := util.HexToAddress(value)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.CounterpartyID = append(filter.CounterpartyID, pb.NewEthAddress(addr))
+	}
+
+	if len(marketPriceMinFlag) != 0 || len(marketPriceMaxFlag) != 0 {
+		filter.Price = &pb.MaxMinBig{}
+
+		if len(marketPriceMinFlag) != 0 {
+			price := &pb.Price{}
+			if err := price.LoadFromString(marketPriceMinFlag); err != nil {
+				return nil, err
+			}
+
+			filter.Price.Min = price.GetPerSecond()
+		}
+
+		if len(marketPriceMaxFlag) != 0 {
+			price := &pb.Price{}
+			if err := price.LoadFromString(marketPriceMaxFlag); err != nil {
+				return nil, err
+			}
+
+			filter.Price.Max = price.GetPerSecond()
+		}
+	}
+
+	if marketDurationMinFlag != 0 || marketDurationMaxFlag != 0 {
+		filter.Duration = &pb.MaxMinUint64{
+			Min: uint64(marketDurationMinFlag.Seconds()),
+			Max: uint64(marketDurationMaxFlag.Seconds()),
+		}
+	}
+
+	if len(marketNetflagsFlag) != 0 {
+		netflags, err := parseNetflags(marketNetflagsFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Netflags = &pb.CmpUint64{Value: netflags.GetFlags(), Operator: pb.CmpOp_GTE}
+	}
+
+	if len(marketIdentityFlag) != 0 {
+		level, err := parseIdentityLevel(marketIdentityFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		for ; level <= pb.IdentityLevel_PROFESSIONAL; level++ {
+			filter.CreatorIdentityLevel = append(filter.CreatorIdentityLevel, level)
+		}
+	}
+
+	benchmarks, err := parseBenchmarkRanges(marketBenchmarksFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.MarketOrdersRequest{Filter: filter, Benchmarks: benchmarks}, nil
+}
+
+func marketProfilesRequestFromFlags() (*pb.ProfilesRequest, error) {
+	role, ok := map[string]pb.ProfileRole{
+		"any":      pb.ProfileRole_AnyRole,
+		"supplier": pb.ProfileRole_Supplier,
+		"consumer": pb.ProfileRole_Consumer,
+	}[strings.ToLower(marketRoleFlag)]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile role \"%s\"", marketRoleFlag)
+	}
+
+	sortings, err := parseSortings(marketSortFlag)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &pb.ProfilesRequest{
+		Role:       role,
+		Country:    marketCountryFlag,
+		Identifier: marketNameFlag,
+		Limit:      marketLimitFlag,
+		Offset:     marketOffsetFlag,
+		Sortings:   sortings,
+		WithCount:  true,
+	}
+
+	if len(marketIdentityFlag) != 0 {
+		level, err := parseIdentityLevel(marketIdentityFlag)
+		if err != nil {
+			return nil, err
+		}
+
+		req.IdentityLevel = level
+	}
+
+	return req, nil
+}
+
+func parseOrderType(value string) (pb.OrderType, error) {
+	orderType, ok := pb.OrderType_value[strings.ToUpper(value)]
+	if !ok {
+		return pb.OrderType_ANY, fmt.Errorf("unknown order type \"%s\"", value)
+	}
+
+	return pb.OrderType(orderType), nil
+}
+
+func parseIdentityLevel(value string) (pb.IdentityLevel, error) {
+	level, ok := pb.IdentityLevel_value[strings.ToUpper(value)]
+	if !ok {
+		return pb.IdentityLevel_UNKNOWN, fmt.Errorf("unknown identity level \"%s\"", value)
+	}
+
+	return pb.IdentityLevel(level), nil
+}
+
+func parseNetflags(values []string) (*pb.NetFlags, error) {
+	netflags := &pb.NetFlags{}
+	for _, value := range values {
+		switch strings.ToLower(value) {
+		case "overlay":
+			netflags.SetOverlay(true)
+		case "outbound":
+			netflags.SetOutbound(true)
+		case "incoming":
+			netflags.SetIncoming(true)
+		case "sandbox":
+			netflags.SetSandbox(true)
+		default:
+			return nil, fmt.Errorf("unknown network flag \"%s\"", value)
+		}
+	}
+
+	return netflags, nil
+}
+
+// parseSortings parses sorting options in "field[:asc|desc]" form.
+func parseSortings(values []string) ([]*pb.SortingOption, error) {
+	var sortings []*pb.SortingOption
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 2)
+		sorting := &pb.SortingOption{Field: parts[0], Order: pb.SortingOrder_Asc}
+
+		if len(parts) == 2 {
+			switch strings.ToLower(parts[1]) {
+			case "asc":
+			case "desc":
+				sorting.Order = pb.SortingOrder_Desc
+			default:
+				return nil, fmt.Errorf("unknown sorting order \"%s\"", parts[1])
+			}
+		}
+
+		sortings = append(sortings, sorting)
+	}
+
+	return sortings, nil
+}
+
+// parseBenchmarkRanges parses benchmark ranges in "code=min..max",
+// "code=min.." or "code=value" form.
+func parseBenchmarkRanges(values []string) (map[string]*pb.MaxMinUint64, error) {
+	ranges := map[string]*pb.MaxMinUint64{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || len(parts[0]) == 0 {
+			return nil, fmt.Errorf("invalid benchmark range \"%s\": must be in \"code=min..max\" form", value)
+		}
+
+		var bounds []string
+		if strings.Contains(parts[1], "..") {
+			bounds = strings.SplitN(parts[1], "..", 2)
+		} else {
+			bounds = []string{parts[1], parts[1]}
+		}
+
+		benchRange := &pb.MaxMinUint64{}
+		if len(bounds[0]) != 0 {
+			min, err := strconv.ParseUint(bounds[0], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid benchmark \"%s\" lower bound: %v", parts[0], err)
+			}
+
+			benchRange.Min = min
+		}
+
+		if len(bounds[1]) != 0 {
+			max, err := strconv.ParseUint(bounds[1], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid benchmark \"%s\" upper bound: %v", parts[0], err)
+			}
+
+			if max < benchRange.Min {
+				return nil, fmt.Errorf("invalid benchmark \"%s\" range: upper bound is less than lower one", parts[0])
+			}
+
+			benchRange.Max = max
+		}
+
+		ranges[parts[0]] = benchRange
+	}
+
+	return ranges, nil
+}
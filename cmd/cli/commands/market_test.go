@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"testing"
+
+	pb "github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBenchmarkRanges(t *testing.T) {
+	ranges, err := parseBenchmarkRanges([]string{
+		"gpu-eth-hashrate=100..200",
+		"cpu-cores=4..",
+		"gpu-count=2",
+		"ram-size=..1024",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]*pb.MaxMinUint64{
+		"gpu-eth-hashrate": {Min: 100, Max: 200},
+		"cpu-cores":        {Min: 4},
+		"gpu-count":        {Min: 2, Max: 2},
+		"ram-size":         {Max: 1024},
+	}, ranges)
+}
+
+func TestParseBenchmarkRangesErrors(t *testing.T) {
+	for _, value := range []string{"gpu-count", "=1", "gpu-count=a..", "gpu-count=5..1"} {
+		_, err := parseBenchmarkRanges([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseSortings(t *testing.T) {
+	sortings, err := parseSortings([]string{"Price", "CreatedTS:desc"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []*pb.SortingOption{
+		{Field: "Price", Order: pb.SortingOrder_Asc},
+		{Field: "CreatedTS", Order: pb.SortingOrder_Desc},
+	}, sortings)
+
+	_, err = parseSortings([]string{"Price:up"})
+	assert.Error(t, err)
+}
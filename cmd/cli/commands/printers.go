@@ -74,6 +74,9 @@ func printTaskStatus(cmd *cobra.Command, id string, taskStatus *pb.TaskStatusRep
 					cmd.Printf("        Tx/Rx dropped: %d/%d\r\n", net.TxDropped, net.RxDropped)
 				}
 			}
+			if violations := taskStatus.GetUsage().GetEgressViolations(); violations != 0 {
+				cmd.Printf("    Egress violations: %d\r\n", violations)
+			}
 		}
 
 		if len(taskStatus.GetPortMap()) > 0 {
@@ -96,6 +99,7 @@ func printTaskStatus(cmd *cobra.Command, id string, taskStatus *pb.TaskStatusRep
 			v["cpu"] = fmt.Sprintf("%d", taskStatus.GetUsage().GetCpu().GetTotal())
 			v["mem"] = fmt.Sprintf("%d", taskStatus.GetUsage().GetMemory().GetMaxUsage())
 			v["net"] = taskStatus.GetUsage().GetNetwork()
+			v["egress_violations"] = fmt.Sprintf("%d", taskStatus.GetUsage().GetEgressViolations())
 		}
 
 		showJSON(cmd, v)
@@ -170,6 +174,7 @@ func printDeviceList(cmd *cobra.Command, dev *pb.DevicesReply) {
 		cmd.Println("Network:")
 		cmd.Printf("  Incoming: %v\r\n", dev.GetNetwork().GetNetFlags().GetIncoming())
 		cmd.Printf("  Overlay:  %v\r\n", dev.GetNetwork().GetNetFlags().GetOverlay())
+		cmd.Printf("  Sandbox:  %v\r\n", dev.GetNetwork().GetNetFlags().GetSandbox())
 		cmd.Printf("  In:       %s\r\n", netIn)
 		cmd.Printf("  Out:      %s\r\n", netOut)
 
@@ -184,6 +189,8 @@ func printDeviceList(cmd *cobra.Command, dev *pb.DevicesReply) {
 		cmd.Println("Storage:")
 		cmd.Printf("  Volume: %s\r\n", storageAvailable)
 		printBenchmarkGroup(cmd, dev.GetStorage().GetBenchmarks())
+
+		cmd.Printf("Isolation: %s\r\n", strings.ToLower(dev.GetIsolation().String()))
 	} else {
 		showJSON(cmd, dev)
 	}
@@ -209,6 +216,33 @@ func printOrdersList(cmd *cobra.Command, orders []*pb.Order) {
 	}
 }
 
+func printMarketOrdersList(cmd *cobra.Command, reply *pb.DWHOrdersReply) {
+	if !isSimpleFormat() {
+		showJSON(cmd, reply)
+		return
+	}
+
+	if len(reply.GetOrders()) == 0 {
+		cmd.Println("No orders found")
+		return
+	}
+
+	cmd.Println("        ID | type |        price        |   duration |                   author                   | identity")
+	for _, order := range reply.GetOrders() {
+		cmd.Printf("%10s | %4s | %13s USD/sec | %10s | %s | %s\r\n",
+			order.GetOrder().GetId().Unwrap().String(),
+			order.GetOrder().GetOrderType().String(),
+			order.GetOrder().GetPrice().ToPriceString(),
+			(time.Second * time.Duration(order.GetOrder().GetDuration())).String(),
+			order.GetOrder().GetAuthorID().Unwrap().Hex(),
+			pb.IdentityLevel(order.GetCreatorIdentityLevel()).String())
+	}
+
+	if reply.GetCount() > uint64(len(reply.GetOrders())) {
+		cmd.Printf("Shown %d of %d orders\r\n", len(reply.GetOrders()), reply.GetCount())
+	}
+}
+
 func printOrderDetails(cmd Printer, order *pb.Order) {
 	if isSimpleFormat() {
 		cmd.Printf("ID:              %s\r\n", order.Id)
@@ -583,3 +617,176 @@ func printProfileInfo(cmd *cobra.Command, p *pb.Profile) {
 		showJSON(cmd, p)
 	}
 }
+
+func printProfilesList(cmd *cobra.Command, reply *pb.ProfilesReply) {
+	if !isSimpleFormat() {
+		showJSON(cmd, reply)
+		return
+	}
+
+	if len(reply.GetProfiles()) == 0 {
+		cmd.Println("No profiles found")
+		return
+	}
+
+	for _, p := range reply.GetProfiles() {
+		level := pb.IdentityLevel(int32(p.GetIdentityLevel()))
+		cmd.Printf("%s (%s)\r\n", p.GetUserID().Unwrap().Hex(), level.String())
+		if len(p.GetName()) > 0 {
+			cmd.Printf("  Name:          %s\r\n", p.GetName())
+		}
+		if len(p.GetCountry()) > 0 {
+			cmd.Printf("  Country:       %s\r\n", p.GetCountry())
+		}
+		cmd.Printf("  Active orders: %d Bids, %d Asks\r\n", p.GetActiveBids(), p.GetActiveAsks())
+	}
+
+	if reply.GetCount() > uint64(len(reply.GetProfiles())) {
+		cmd.Printf("Shown %d of %d profiles\r\n", len(reply.GetProfiles()), reply.GetCount())
+	}
+}
+
+func printDeploymentResult(cmd *cobra.Command, result *deploymentResult) {
+	if !isSimpleFormat() {
+		showJSON(cmd, result)
+		return
+	}
+
+	if len(result.OrderID) != 0 {
+		cmd.Printf("Order ID: %s\r\n", result.OrderID)
+	}
+	if len(result.DealID) != 0 {
+		cmd.Printf("Deal ID:  %s\r\n", result.DealID)
+	}
+	if len(result.TaskID) != 0 {
+		cmd.Printf("Task ID:  %s\r\n", result.TaskID)
+		cmd.Printf("Status:   %s\r\n", result.Status)
+	}
+
+	if len(result.PortMap) != 0 {
+		cmd.Println("Ports:")
+		for containerPort, endpoints := range result.PortMap {
+			for _, endpoint := range endpoints.GetEndpoints() {
+				cmd.Printf("  %s: %s:%d\r\n", containerPort, endpoint.GetAddr(), endpoint.GetPort())
+			}
+		}
+	}
+
+	if result.RolledBack {
+		cmd.Println("Deployment has been rolled back")
+	}
+}
+
+func printNPPReport(cmd *cobra.Command, report *pb.NPPReport) {
+	if !isSimpleFormat() {
+		showJSON(cmd, report)
+		return
+	}
+
+	for _, stage := range report.GetStages() {
+		status := "OK"
+		if len(stage.GetError()) != 0 {
+			status = fmt.Sprintf("FAILED: %s", stage.GetError())
+		}
+
+		cmd.Printf("%s: %s\r\n", strings.Title(stage.GetName()), status)
+		cmd.Printf("  Duration:      %s\r\n", stage.GetDuration().Unwrap().String())
+		if server := stage.GetServer(); len(server) != 0 {
+			cmd.Printf("  Server:        %s\r\n", server)
+		}
+		if remoteAddr := stage.GetRemoteAddr(); len(remoteAddr) != 0 {
+			cmd.Printf("  Remote addr:   %s\r\n", remoteAddr)
+		}
+		if publicAddr := stage.GetPublicAddr(); publicAddr != nil {
+			cmd.Printf("  Public addr:   %s\r\n", formatNetAddr(publicAddr))
+		}
+		for _, privateAddr := range stage.GetPrivateAddrs() {
+			cmd.Printf("  Private addr:  %s\r\n", formatNetAddr(privateAddr))
+		}
+		for _, attempt := range stage.GetAttempts() {
+			direction := "outgoing"
+			if attempt.GetIncoming() {
+				direction = "incoming"
+			}
+
+			status := "OK"
+			if len(attempt.GetError()) != 0 {
+				status = attempt.GetError()
+			}
+
+			cmd.Printf("  Attempt:       %s %s in %s: %s\r\n", direction, attempt.GetAddr(), attempt.GetDuration().Unwrap().String(), status)
+		}
+	}
+
+	cmd.Println()
+	if len(report.GetPath()) == 0 {
+		cmd.Printf("Path:          none\r\n")
+	} else {
+		cmd.Printf("Path:          %s\r\n", report.GetPath())
+	}
+
+	for _, rtt := range report.GetRTT() {
+		cmd.Printf("RTT:           %s\r\n", rtt.Unwrap().String())
+	}
+
+	if rate := report.GetUploadRate(); rate != 0 {
+		cmd.Printf("Upload rate:   %s\r\n", datasize.NewBitRate(8*rate).HumanReadable())
+	}
+	if rate := report.GetDownloadRate(); rate != 0 {
+		cmd.Printf("Download rate: %s\r\n", datasize.NewBitRate(8*rate).HumanReadable())
+	}
+	if err := report.GetError(); len(err) != 0 {
+		cmd.Printf("Error:         %s\r\n", err)
+	}
+}
+
+func formatNetAddr(addr *pb.Addr) string {
+	netAddr, err := addr.IntoTCP()
+	if err != nil {
+		return addr.String()
+	}
+
+	return netAddr.String()
+}
+
+func printDelegationToken(cmd *cobra.Command, token *pb.DelegationToken, encoded string) {
+	if !isSimpleFormat() {
+		showJSON(cmd, map[string]interface{}{"token": encoded, "details": token})
+		return
+	}
+
+	cmd.Printf("Token ID:   %s\r\n", token.GetId())
+	cmd.Printf("Delegate:   %s\r\n", token.GetDelegate().Unwrap().Hex())
+	cmd.Printf("Methods:    %s\r\n", strings.Join(token.GetMethods(), ", "))
+	cmd.Printf("Expires at: %s\r\n", token.GetExpiresAt().Unix().Format(time.RFC3339))
+	cmd.Printf("Token:      %s\r\n", encoded)
+}
+
+func printDelegationTokens(cmd *cobra.Command, reply *pb.DelegationTokensReply) {
+	if !isSimpleFormat() {
+		showJSON(cmd, reply)
+		return
+	}
+
+	if len(reply.GetTokens()) == 0 {
+		cmd.Println("No delegation tokens have been used")
+		return
+	}
+
+	for _, status := range reply.GetTokens() {
+		token := status.GetToken()
+		state := "active"
+		switch {
+		case status.GetRevoked():
+			state = "revoked"
+		case token.IsExpired(time.Now()):
+			state = "expired"
+		}
+
+		cmd.Printf("%s (%s)\r\n", token.GetId(), state)
+		cmd.Printf("  Delegate:     %s\r\n", token.GetDelegate().Unwrap().Hex())
+		cmd.Printf("  Methods:      %s\r\n", strings.Join(token.GetMethods(), ", "))
+		cmd.Printf("  Expires at:   %s\r\n", token.GetExpiresAt().Unix().Format(time.RFC3339))
+		cmd.Printf("  Last used at: %s\r\n", status.GetLastUsedAt().Unix().Format(time.RFC3339))
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"net"
 	"os"
+	"os/exec"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +21,7 @@ import (
 	"github.com/sonm-io/core/insonmnia/structs"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
+	"github.com/sonm-io/core/util/xnet"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/metadata"
 )
@@ -34,6 +37,11 @@ func init() {
 
 	taskPullCmd.Flags().StringVar(&taskPullOutput, "output", "", "file to output")
 
+	taskSSHCmd.Flags().Uint16Var(&taskSSHProxyPort, "proxy-port", 15032, "Node's SSH proxy port")
+
+	taskUDPForwardCmd.Flags().StringVar(&taskUDPForwardListen, "listen", "", "Local address to listen on, defaults to 127.0.0.1:<port>")
+	taskUDPForwardCmd.Flags().Uint16Var(&taskUDPForwardProxyPort, "proxy-port", 15033, "Node's UDP proxy port")
+
 	taskRootCmd.AddCommand(
 		taskListCmd,
 		taskStartCmd,
@@ -43,10 +51,18 @@ func init() {
 		taskPullCmd,
 		taskPushCmd,
 		taskJoinNetworkCmd,
+		taskSSHCmd,
+		taskUDPForwardCmd,
 	)
 }
 
-var taskPullOutput string
+var (
+	taskPullOutput   string
+	taskSSHProxyPort uint16
+
+	taskUDPForwardListen    string
+	taskUDPForwardProxyPort uint16
+)
 
 var taskRootCmd = &cobra.Command{
 	Use:               "task",
@@ -580,3 +596,131 @@ var taskPushCmd = &cobra.Command{
 		}
 	},
 }
+
+var taskSSHCmd = &cobra.Command{
+	Use:   "ssh <deal_id> <task_id> [-- ssh_args...]",
+	Short: "Open SSH session to the task",
+	Long: `Open SSH session to the task using the key specified in its spec.
+
+The session is tunnelled to the worker through the Node acting as SSH jump
+host, so the worker is reachable even if it is behind NAT. Arguments after
+"--" are passed to ssh as is, for example to forward the task's port 8080:
+
+    sonmcli task ssh <deal_id> <task_id> -- -L 8080:localhost:8080
+
+Files can be copied with scp or sftp using the same jump host:
+
+    scp -J sonm@localhost:15032 <file> <task_id>@<worker_address>:/tmp/`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		dealID, err := util.ParseBigInt(args[0])
+		if err != nil {
+			return err
+		}
+
+		dealCli, err := newDealsClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		deal, err := dealCli.Status(ctx, pb.NewBigInt(dealID))
+		if err != nil {
+			return fmt.Errorf("cannot get deal info: %v", err)
+		}
+
+		host, err := nodeHost()
+		if err != nil {
+			return err
+		}
+
+		jumpHost := "sonm@" + net.JoinHostPort(host, strconv.Itoa(int(taskSSHProxyPort)))
+		destination := args[1] + "@" + deal.GetDeal().GetSupplierID().Unwrap().Hex()
+
+		ssh := exec.Command("ssh", append([]string{"-J", jumpHost, destination}, args[2:]...)...)
+		ssh.Stdin = os.Stdin
+		ssh.Stdout = os.Stdout
+		ssh.Stderr = os.Stderr
+
+		return ssh.Run()
+	},
+}
+
+var taskUDPForwardCmd = &cobra.Command{
+	Use:   "udp-forward <deal_id> <task_id> <port>",
+	Short: "Forward local UDP port to the task",
+	Long: `Forward local UDP port to the published UDP port of the task.
+
+Datagrams are tunnelled to the worker through the Node using UDP hole
+punching or the Relay, so the task is reachable even if the worker is behind
+NAT. Each local peer gets its own session, which is closed after 2 minutes
+of inactivity.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx, cancel := newTimeoutContext()
+		defer cancel()
+
+		dealID, err := util.ParseBigInt(args[0])
+		if err != nil {
+			return err
+		}
+
+		port, err := strconv.ParseUint(args[2], 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid port: %v", err)
+		}
+
+		dealCli, err := newDealsClient(ctx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		deal, err := dealCli.Status(ctx, pb.NewBigInt(dealID))
+		if err != nil {
+			return fmt.Errorf("cannot get deal info: %v", err)
+		}
+
+		host, err := nodeHost()
+		if err != nil {
+			return err
+		}
+
+		listenAddr := taskUDPForwardListen
+		if len(listenAddr) == 0 {
+			listenAddr = net.JoinHostPort("127.0.0.1", args[2])
+		}
+
+		conn, err := net.ListenPacket("udp", listenAddr)
+		if err != nil {
+			return fmt.Errorf("cannot listen: %v", err)
+		}
+		defer conn.Close()
+
+		proxyAddr := net.JoinHostPort(host, strconv.Itoa(int(taskUDPForwardProxyPort)))
+		request := fmt.Sprintf("%s/%s/%d", deal.GetDeal().GetSupplierID().Unwrap().Hex(), args[1], port)
+
+		cmd.Printf("Forwarding %s to UDP port %d of task %s\n", conn.LocalAddr(), port, args[1])
+
+		return xnet.ServeDatagrams(conn, func(addr net.Addr, datagram []byte) (net.Conn, error) {
+			remote, err := net.Dial("udp", proxyAddr)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := xnet.DatagramHandshake(remote, request, 30*time.Second); err != nil {
+				cmd.Printf("Failed to forward datagrams from %s: %v\n", addr, err)
+				remote.Close()
+				return nil, err
+			}
+
+			if _, err := remote.Write(datagram); err != nil {
+				remote.Close()
+				return nil, err
+			}
+
+			return remote, nil
+		}, 2*time.Minute)
+	},
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/sonm-io/core/insonmnia/auth"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util"
+	"github.com/sonm-io/core/util/datasize"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc/metadata"
 	"gopkg.in/yaml.v2"
@@ -19,6 +20,10 @@ var (
 	worker       pb.WorkerManagementClient
 	workerCtx    context.Context
 	workerCancel context.CancelFunc
+
+	workerPingCountFlag uint32
+	workerPingSizeFlag  string
+	workerPingDealFlag  string
 )
 
 func workerPreRunE(cmd *cobra.Command, args []string) error {
@@ -57,6 +62,10 @@ func workerPostRun(_ *cobra.Command, _ []string) {
 }
 
 func init() {
+	workerPingCmd.PersistentFlags().Uint32Var(&workerPingCountFlag, "count", 4, "Number of RTT measurements")
+	workerPingCmd.PersistentFlags().StringVar(&workerPingSizeFlag, "size", "4MiB", "Amount of data to transfer in each direction to measure throughput, zero to disable")
+	workerPingCmd.PersistentFlags().StringVar(&workerPingDealFlag, "deal", "", "Deal with the worker, required to measure the download throughput unless you manage the worker")
+
 	workerMgmtCmd.AddCommand(
 		workerStatusCmd,
 		askPlansRootCmd,
@@ -69,6 +78,7 @@ func init() {
 		workerScheduleMaintenanceCmd,
 		workerNextMaintenanceCmd,
 		workerDebugStateCmd,
+		workerPingCmd,
 	)
 }
 
@@ -238,3 +248,42 @@ var workerDebugStateCmd = &cobra.Command{
 		return nil
 	},
 }
+
+var workerPingCmd = &cobra.Command{
+	Use:   "ping <eth_addr>",
+	Short: "Diagnose connection to the worker and measure its quality",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, err := auth.NewAddr(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid address specified: %v", err)
+		}
+
+		size := datasize.ByteSize{}
+		if err := size.UnmarshalText([]byte(workerPingSizeFlag)); err != nil {
+			return fmt.Errorf("invalid size specified: %v", err)
+		}
+
+		diagnostics, err := newNPPDiagnosticsClient(workerCtx)
+		if err != nil {
+			return fmt.Errorf("cannot create client connection: %v", err)
+		}
+
+		ctx := workerCtx
+		if len(workerPingDealFlag) != 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, "deal", workerPingDealFlag)
+		}
+
+		report, err := diagnostics.Diagnose(ctx, &pb.NPPDiagnoseRequest{
+			Addr:  addr.String(),
+			Pings: workerPingCountFlag,
+			Size:  size.Bytes(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diagnose connection: %v", err)
+		}
+
+		printNPPReport(cmd, report)
+		return nil
+	},
+}
@@ -4,7 +4,9 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/sonm-io/core/proto"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -199,3 +201,46 @@ container:
 
 	assert.Equal(t, []string{"80:80"}, cfg.GetContainer().GetExpose())
 }
+
+func TestLoadDeployment(t *testing.T) {
+	createTestConfigFile(`
+ask_ids:
+  - 42
+timeout: 5m
+order:
+  duration: 8h
+  price: 2.5 USD/h
+  identity: registered
+  resources:
+    benchmarks:
+      cpu-cores: 2
+task:
+  container:
+    image: user/image:v1
+    ssh_key: ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQD
+`)
+	defer deleteTestConfigFile()
+
+	cfg, err := LoadDeployment(testCfgPath)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"42"}, cfg.AskIDs)
+	assert.Equal(t, 5*time.Minute, cfg.Timeout)
+	assert.Equal(t, 8*time.Hour, cfg.Order.GetDuration().Unwrap())
+	assert.Equal(t, sonm.IdentityLevel_REGISTERED, cfg.Order.GetIdentity())
+	assert.Equal(t, uint64(2), cfg.Order.GetResources().GetBenchmarks()["cpu-cores"])
+	assert.Equal(t, "user/image:v1", cfg.Task.GetContainer().GetImage())
+	assert.Equal(t, "ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABAQD", cfg.Task.GetContainer().GetSshKey())
+}
+
+func TestLoadDeploymentRequiresPrice(t *testing.T) {
+	createTestConfigFile(`
+task:
+  container:
+    image: user/image:v1
+`)
+	defer deleteTestConfigFile()
+
+	_, err := LoadDeployment(testCfgPath)
+	require.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package task_config
+
+import (
+	"errors"
+	"time"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util/config"
+)
+
+const (
+	defaultDeploymentTimeout = 30 * time.Minute
+)
+
+// Deployment describes a workload that is deployed on the Marketplace in a
+// single step: resources are bought either by placing the BID order or by
+// quick-buying one of the given ASK orders and then the task is started
+// within the deal.
+type Deployment struct {
+	// Order describes required resources. Its price and duration are also
+	// used when quick-buying.
+	Order sonm.BidOrder
+	// Task describes the task to be started after the deal is opened.
+	Task sonm.TaskSpec
+	// AskIDs contains ASK orders to quick-buy, which are tried in order.
+	// When empty the BID order is placed and matched automatically.
+	AskIDs []string
+	// Force allows to open deals without checking worker availability.
+	Force bool
+	// Timeout limits the entire deployment.
+	Timeout time.Duration
+}
+
+func LoadDeployment(path string) (*Deployment, error) {
+	cfg := &Deployment{}
+	if err := config.LoadWith(cfg, path, config.SnakeToLower); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultDeploymentTimeout
+	}
+
+	return cfg, nil
+}
+
+func (m *Deployment) Validate() error {
+	if len(m.AskIDs) == 0 && m.Order.GetPrice().GetPerSecond() == nil {
+		return errors.New("order price is required unless ASK orders to quick-buy are specified")
+	}
+
+	if err := m.Order.Validate(); err != nil {
+		return err
+	}
+
+	return m.Task.Validate()
+}
@@ -18,7 +18,9 @@ var (
 )
 
 func main() {
-	cmd.NewCmd("optimus", appVersion, &configFlag, &versionFlag, run).Execute()
+	c := cmd.NewCmd("optimus", appVersion, &configFlag, &versionFlag, run)
+	c.AddCommand(simulateCmd)
+	c.Execute()
 }
 
 func run() error {
@@ -27,17 +29,7 @@ func run() error {
 		return fmt.Errorf("failed to parse config: %v", err)
 	}
 
-	zapConfig := zap.Config{
-		Level:            zap.NewAtomicLevelAt(cfg.Logging.LogLevel().Zap()),
-		Development:      false,
-		Encoding:         "console",
-		EncoderConfig:    zap.NewDevelopmentEncoderConfig(),
-		OutputPaths:      []string{"stdout"},
-		ErrorOutputPaths: []string{"stderr"},
-	}
-	zapConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
-
-	log, err := zapConfig.Build()
+	log, err := newLogger(cfg.Logging.LogLevel().Zap(), "stdout")
 	if err != nil {
 		return err
 	}
@@ -50,3 +42,17 @@ func run() error {
 
 	return bot.Run(ctx)
 }
+
+func newLogger(level zapcore.Level, output string) (*zap.Logger, error) {
+	zapConfig := zap.Config{
+		Level:            zap.NewAtomicLevelAt(level),
+		Development:      false,
+		Encoding:         "console",
+		EncoderConfig:    zap.NewDevelopmentEncoderConfig(),
+		OutputPaths:      []string{output},
+		ErrorOutputPaths: []string{"stderr"},
+	}
+	zapConfig.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+
+	return zapConfig.Build()
+}
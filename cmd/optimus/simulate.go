@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/optimus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	jsonOutputFlag bool
+)
+
+func init() {
+	simulateCmd.Flags().BoolVar(&jsonOutputFlag, "json", false, "Print the report in JSON format")
+}
+
+var simulateCmd = &cobra.Command{
+	Use:   "simulate <SNAPSHOT_PATH>...",
+	Short: "Replay recorded market snapshots using all configured optimization models",
+	Long: "Replay recorded market snapshots using all configured optimization models.\n" +
+		"Snapshot paths can be either files or directories containing \"*.json\" snapshots.\n" +
+		"The \"--config\" flag must point to the simulation config in this case.",
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := simulate(args); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	},
+}
+
+func simulate(args []string) error {
+	if configFlag == "" {
+		return fmt.Errorf("required flag `config` has not been set")
+	}
+
+	cfg, err := optimus.LoadSimulationConfig(configFlag)
+	if err != nil {
+		return fmt.Errorf("failed to parse config: %v", err)
+	}
+
+	paths, err := optimus.SnapshotPaths(args)
+	if err != nil {
+		return fmt.Errorf("failed to collect snapshots: %v", err)
+	}
+
+	// Logs go to the stderr to keep the report clean.
+	log, err := newLogger(cfg.Logging.LogLevel().Zap(), "stderr")
+	if err != nil {
+		return err
+	}
+
+	ctx := ctxlog.WithLogger(context.Background(), log)
+	report, err := optimus.NewSimulation(cfg, optimus.WithLog(log.Sugar())).Run(ctx, paths)
+	if err != nil {
+		return fmt.Errorf("failed to simulate: %v", err)
+	}
+
+	if jsonOutputFlag {
+		return json.NewEncoder(os.Stdout).Encode(report)
+	}
+
+	return report.WriteTable(os.Stdout)
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/sonm-io/core/cmd"
 	"github.com/sonm-io/core/insonmnia/logging"
 	"github.com/sonm-io/core/insonmnia/npp/relay"
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/sonm-io/core/util/xgrpc"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -28,6 +31,22 @@ func start() error {
 	options := []relay.Option{
 		relay.WithLogger(log.G(ctx)),
 	}
+
+	if len(cfg.ACL.DWH) != 0 {
+		certificate, TLSConfig, err := util.NewHitlessCertRotator(ctx, cfg.Monitor.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to construct TLS credentials: %s", err)
+		}
+		defer certificate.Close()
+
+		cc, err := xgrpc.NewClient(ctx, cfg.ACL.DWH, util.NewTLS(TLSConfig))
+		if err != nil {
+			return fmt.Errorf("failed to connect to DWH: %s", err)
+		}
+		defer cc.Close()
+
+		options = append(options, relay.WithClientVerifier(relay.DealsVerifier, relay.NewDealsVerifier(sonm.NewDWHClient(cc))))
+	}
 	server, err := relay.NewServer(*cfg, options...)
 	if err != nil {
 		return fmt.Errorf("failed to construct a Relay server: %s", err)
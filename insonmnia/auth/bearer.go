@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	bearerTokenPrefix = "sonm-bearer:"
+)
+
+// NewBearerToken constructs a token that authenticates the owner of the
+// given key to the node with the specified ETH address (audience) until the
+// token expires.
+//
+// The token has "<ETH address>.<audience ETH address>.<Unix expiration
+// time>.<hex signature>" format and is intended to be passed using
+// "Authorization: Bearer" HTTP header for APIs that have no transport
+// authentication.
+func NewBearerToken(key *ecdsa.PrivateKey, audience common.Address, ttl time.Duration) (string, error) {
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	expiresAt := time.Now().Add(ttl).Unix()
+
+	sign, err := crypto.Sign(bearerTokenHash(addr, audience, expiresAt), key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign bearer token: %v", err)
+	}
+
+	return fmt.Sprintf("%s.%s.%d.%s", addr.Hex(), audience.Hex(), expiresAt, hex.EncodeToString(sign)), nil
+}
+
+// VerifyBearerToken verifies the token previously constructed using
+// "NewBearerToken", returning the authenticated ETH address.
+//
+// Tokens issued for nodes other than the given audience are rejected, so a
+// token leaked to one node can not be replayed against another.
+func VerifyBearerToken(token string, audience common.Address, now time.Time) (common.Address, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return common.Address{}, errors.New("malformed bearer token")
+	}
+
+	if !common.IsHexAddress(parts[0]) {
+		return common.Address{}, errors.New("malformed bearer token address")
+	}
+	addr := common.HexToAddress(parts[0])
+
+	if !common.IsHexAddress(parts[1]) {
+		return common.Address{}, errors.New("malformed bearer token audience")
+	}
+	tokenAudience := common.HexToAddress(parts[1])
+
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return common.Address{}, errors.New("malformed bearer token expiration time")
+	}
+
+	sign, err := hex.DecodeString(parts[3])
+	if err != nil {
+		return common.Address{}, errors.New("malformed bearer token signature")
+	}
+
+	if !equalAddresses(tokenAudience, audience) {
+		return common.Address{}, fmt.Errorf("bearer token is issued for %s", tokenAudience.Hex())
+	}
+
+	if now.Unix() >= expiresAt {
+		return common.Address{}, errors.New("bearer token has expired")
+	}
+
+	publicKey, err := crypto.SigToPub(bearerTokenHash(addr, tokenAudience, expiresAt), sign)
+	if err != nil {
+		return common.Address{}, errors.New("invalid bearer token signature")
+	}
+
+	if !equalAddresses(crypto.PubkeyToAddress(*publicKey), addr) {
+		return common.Address{}, errors.New("invalid bearer token signature for provided ETH address")
+	}
+
+	return addr, nil
+}
+
+func bearerTokenHash(addr, audience common.Address, expiresAt int64) []byte {
+	timestamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(timestamp, uint64(expiresAt))
+
+	var data []byte
+	data = append(data, []byte(bearerTokenPrefix)...)
+	data = append(data, addr.Bytes()...)
+	data = append(data, audience.Bytes()...)
+	data = append(data, timestamp...)
+
+	return chainhash.DoubleHashB(data)
+}
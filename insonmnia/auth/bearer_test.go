@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBearerToken(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	nodeKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	audience := crypto.PubkeyToAddress(nodeKey.PublicKey)
+
+	token, err := NewBearerToken(key, audience, time.Hour)
+	require.NoError(t, err)
+
+	addr, err := VerifyBearerToken(token, audience, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), addr)
+
+	_, err = VerifyBearerToken(token, audience, time.Now().Add(2*time.Hour))
+	assert.Error(t, err)
+}
+
+func TestBearerTokenTampered(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	nodeKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	audience := crypto.PubkeyToAddress(nodeKey.PublicKey)
+	otherAudience := crypto.PubkeyToAddress(other.PublicKey)
+
+	token, err := NewBearerToken(key, audience, time.Hour)
+	require.NoError(t, err)
+
+	parts := strings.Split(token, ".")
+
+	// Another address.
+	_, err = VerifyBearerToken(otherAudience.Hex()+"."+parts[1]+"."+parts[2]+"."+parts[3], audience, time.Now())
+	assert.Error(t, err)
+
+	// Another node.
+	_, err = VerifyBearerToken(token, otherAudience, time.Now())
+	assert.Error(t, err)
+
+	// Retargeted to another node.
+	_, err = VerifyBearerToken(parts[0]+"."+otherAudience.Hex()+"."+parts[2]+"."+parts[3], otherAudience, time.Now())
+	assert.Error(t, err)
+
+	// Prolonged expiration time.
+	_, err = VerifyBearerToken(parts[0]+"."+parts[1]+".9999999999."+parts[3], audience, time.Now())
+	assert.Error(t, err)
+
+	_, err = VerifyBearerToken("garbage", audience, time.Now())
+	assert.Error(t, err)
+}
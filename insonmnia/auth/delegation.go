@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+)
+
+// DelegationTokenMetadataKey is the gRPC metadata key used to pass encoded
+// delegation tokens.
+const DelegationTokenMetadataKey = "delegation-token"
+
+// DelegationTokenFromContext extracts the encoded delegation token from the
+// incoming context metadata. An empty string is returned if there is no
+// token provided.
+func DelegationTokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	tokens := md[DelegationTokenMetadataKey]
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	return tokens[0]
+}
+
+type delegationCredentials struct {
+	token func(ctx context.Context) string
+}
+
+// NewDelegationCredentials constructs per-RPC credentials that attach the
+// given encoded delegation token to each call.
+func NewDelegationCredentials(token string) credentials.PerRPCCredentials {
+	return &delegationCredentials{
+		token: func(ctx context.Context) string {
+			return token
+		},
+	}
+}
+
+// NewDelegationForwarder constructs per-RPC credentials that forward the
+// delegation token from the incoming context of the call, if any.
+//
+// This is useful for proxies, which receive the token from their clients and
+// call upstream servers within the same context.
+func NewDelegationForwarder() credentials.PerRPCCredentials {
+	return &delegationCredentials{
+		token: DelegationTokenFromContext,
+	}
+}
+
+func (m *delegationCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token := m.token(ctx)
+	if len(token) == 0 {
+		return nil, nil
+	}
+
+	return map[string]string{DelegationTokenMetadataKey: token}, nil
+}
+
+// RequireTransportSecurity returns false, because tokens are signed and
+// bound to the delegate's wallet, which is verified by the transport layer
+// itself.
+func (m *delegationCredentials) RequireTransportSecurity() bool {
+	return false
+}
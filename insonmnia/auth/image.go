@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	imageSignaturePrefix = "sonm-image:"
+	// ImageSignatureAnnotation is the annotation of signature manifest
+	// layers containing hex-encoded image signatures.
+	ImageSignatureAnnotation = "io.sonm.image.signature"
+)
+
+// ImageSignatureTag returns the tag signatures of the image manifest with
+// the given digest are pushed with.
+//
+// Like cosign does, signatures are stored in the image repository as a
+// separate manifest tagged "<algorithm>-<hex>.sig", which layers are
+// annotated with signatures made by "SignImage". This allows a single image
+// to be signed by several publishers.
+func ImageSignatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
+
+// SignImage constructs a detached signature of the image manifest with the
+// given digest, like "sha256:...".
+func SignImage(key *ecdsa.PrivateKey, digest string) ([]byte, error) {
+	sign, err := crypto.Sign(imageSignatureHash(digest), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign image: %v", err)
+	}
+
+	return sign, nil
+}
+
+// RecoverImageSigner returns the ETH address of the publisher that has
+// signed the image manifest with the given digest.
+func RecoverImageSigner(digest string, sign []byte) (common.Address, error) {
+	if len(digest) == 0 {
+		return common.Address{}, errors.New("image digest is required")
+	}
+
+	publicKey, err := crypto.SigToPub(imageSignatureHash(digest), sign)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid image signature: %v", err)
+	}
+
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+func imageSignatureHash(digest string) []byte {
+	return chainhash.DoubleHashB([]byte(imageSignaturePrefix + digest))
+}
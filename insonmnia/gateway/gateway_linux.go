@@ -58,8 +58,9 @@ func NewGateway(ctx context.Context) (*Gateway, error) {
 		return nil, ErrIPVSFailed
 	}
 
-	if gateway.ipvs.Flush() != nil {
-		log.G(ctx).Error("failed to clean up IPVS pools - ensure `ip_vs` is loaded")
+	// Ensure the module is loaded without touching services of others.
+	if _, err := gateway.ipvs.GetPools(); err != nil {
+		log.G(ctx).Error("failed to query IPVS pools - ensure `ip_vs` is loaded", zap.Error(err))
 		gateway.Close()
 		return nil, ErrIPVSFailed
 	}
@@ -67,6 +68,54 @@ func NewGateway(ctx context.Context) (*Gateway, error) {
 	return gateway, nil
 }
 
+// RemoveStaleServices removes virtual services bound to the given host
+// within the given inclusive port range, which are not registered with this
+// gateway. This cleans up services left by a previous instance that has not
+// been shut down gracefully, keeping services of other IPVS users intact.
+func (g *Gateway) RemoveStaleServices(host string, from, to uint16) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	addr := net.ParseIP(host)
+	if addr == nil {
+		return fmt.Errorf("invalid host: %s", host)
+	}
+
+	pools, err := g.ipvs.GetPools()
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		svc := pool.Service
+		if svc.FWMark != 0 || svc.Port < from || svc.Port > to || !addr.Equal(net.ParseIP(svc.VIP)) {
+			continue
+		}
+
+		if g.isRegistered(addr, svc.Port, svc.Proto) {
+			continue
+		}
+
+		log.G(g.ctx).Info("removing stale virtual service", zap.String("host", svc.VIP), zap.Uint16("port", svc.Port))
+
+		if err := g.ipvs.DelService(svc.VIP, svc.Port, svc.Proto); err != nil {
+			return fmt.Errorf("failed to remove stale virtual service %s:%d: %v", svc.VIP, svc.Port, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *Gateway) isRegistered(host net.IP, port, protocol uint16) bool {
+	for _, vs := range g.services {
+		if vs.options.host.Equal(host) && vs.options.Port == port && vs.options.protocol == protocol {
+			return true
+		}
+	}
+
+	return false
+}
+
 // TODO (3Hren): func (g *Gateway) GetServices() ([]string, error).
 // TODO (3Hren): func (g *Gateway) GetBackends(vsID string) ([]string, error).
 // TODO (3Hren): func (g *Gateway) GetBackend(vsID, rsID string) (*RealOptions, error).
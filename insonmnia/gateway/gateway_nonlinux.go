@@ -2,16 +2,47 @@
 
 package gateway
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
 const (
 	PlatformSupportIPVS = false
 )
 
+var (
+	ErrIPVSNotSupported = errors.New("IPVS is not supported on this platform")
+)
+
 type Gateway struct{}
 
 func NewGateway(context.Context) (*Gateway, error) {
 	return &Gateway{}, nil
 }
 
+func (g *Gateway) CreateService(vsID string, options *ServiceOptions) error {
+	return ErrIPVSNotSupported
+}
+
+func (g *Gateway) CreateBackend(vsID, rsID string, options *RealOptions) error {
+	return ErrIPVSNotSupported
+}
+
+func (g *Gateway) RemoveService(vsID string) (*ServiceOptions, error) {
+	return nil, ErrIPVSNotSupported
+}
+
+func (g *Gateway) RemoveBackend(vsID, rsID string) (*RealOptions, error) {
+	return nil, ErrIPVSNotSupported
+}
+
+func (g *Gateway) RemoveStaleServices(host string, from, to uint16) error {
+	return ErrIPVSNotSupported
+}
+
+func (g *Gateway) GetMetrics(vsID string) (*Metrics, error) {
+	return nil, ErrIPVSNotSupported
+}
+
 func (g *Gateway) Close() {}
@@ -59,3 +59,32 @@ func (p *PortPool) Retain(ID string) error {
 
 	return nil
 }
+
+// Reserve assigns the specified port to the given ID, which is useful for
+// restoring previously made assignments.
+func (p *PortPool) Reserve(ID string, port uint16) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, exists := p.used[ID]; exists {
+		return errors.New("named port is already in use")
+	}
+
+	found := false
+	for size := p.queue.Size(); size > 0; size-- {
+		v := p.queue.Dequeue().(uint16)
+		if v == port && !found {
+			found = true
+			continue
+		}
+		p.queue.Enqueue(v)
+	}
+
+	if !found {
+		return errors.New("port is not available for allocation")
+	}
+
+	p.used[ID] = port
+
+	return nil
+}
@@ -56,3 +56,26 @@ func TestPoolAssignRetainAssign(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, port == 10)
 }
+
+func TestPoolReserve(t *testing.T) {
+	p := NewPortPool(10, 3)
+
+	assert.NoError(t, p.Reserve("0", 11))
+	assert.Error(t, p.Reserve("0", 12))
+	assert.Error(t, p.Reserve("1", 11))
+	assert.Error(t, p.Reserve("1", 20))
+
+	for _, id := range []string{"1", "2"} {
+		port, err := p.Assign(id)
+		assert.NoError(t, err)
+		assert.True(t, port == 10 || port == 12)
+	}
+
+	_, err := p.Assign("3")
+	assert.Error(t, err)
+
+	assert.NoError(t, p.Retain("0"))
+	port, err := p.Assign("3")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(11), port)
+}
@@ -15,6 +15,15 @@ type nodeConfig struct {
 	HttpBindPort            uint16 `yaml:"http_bind_port" default:"15031"`
 	BindPort                uint16 `yaml:"bind_port" default:"15030"`
 	AllowInsecureConnection bool   `yaml:"allow_insecure_connection" default:"false"`
+	// SSHProxyBindPort is a loopback port of the SSH jump host, which
+	// tunnels SSH sessions to tasks through NPP.
+	SSHProxyBindPort uint16 `yaml:"ssh_proxy_bind_port" default:"15032"`
+	// UDPProxyBindPort is a loopback port of the UDP proxy, which forwards
+	// datagrams to tasks through NPP.
+	UDPProxyBindPort uint16 `yaml:"udp_proxy_bind_port" default:"15033"`
+	// RBAC enables access to the node's API for clients with keys other
+	// than the node's one. Optional.
+	RBAC *RBACConfig `yaml:"rbac"`
 }
 
 type Config struct {
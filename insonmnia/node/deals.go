@@ -226,6 +226,30 @@ func (d *dealsAPI) CancelChangeRequest(ctx context.Context, id *pb.BigInt) (*pb.
 	return &pb.Empty{}, nil
 }
 
+func (d *dealsAPI) DelegationTokens(ctx context.Context, id *pb.BigInt) (*pb.DelegationTokensReply, error) {
+	worker, closer, err := d.remotes.getWorkerClientForDeal(ctx, id.Unwrap().String())
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	return worker.DelegationTokens(ctx, &pb.ID{Id: id.Unwrap().String()})
+}
+
+func (d *dealsAPI) RevokeDelegationToken(ctx context.Context, req *pb.RevokeDelegationTokenRequest) (*pb.Empty, error) {
+	if req.GetDealID().IsZero() {
+		return nil, errors.New("deal ID is required")
+	}
+
+	worker, closer, err := d.remotes.getWorkerClientForDeal(ctx, req.GetDealID().Unwrap().String())
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	return worker.RevokeDelegationToken(ctx, req)
+}
+
 func invertOrderType(s pb.OrderType) pb.OrderType {
 	if s == pb.OrderType_ASK {
 		return pb.OrderType_BID
@@ -0,0 +1,154 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/proto"
+)
+
+// orderSortingFields contains DWH order columns allowed for sorting. DWH
+// puts sorting fields into queries as is, so they must be checked.
+var orderSortingFields = map[string]bool{
+	"Id":                   true,
+	"CreatedTS":            true,
+	"Duration":             true,
+	"Price":                true,
+	"Netflags":             true,
+	"IdentityLevel":        true,
+	"CreatorIdentityLevel": true,
+}
+
+type marketSearchAPI struct {
+	remotes *remoteOptions
+}
+
+func newMarketSearchAPI(opts *remoteOptions) sonm.MarketSearchServer {
+	return &marketSearchAPI{remotes: opts}
+}
+
+func (m *marketSearchAPI) Orders(ctx context.Context, req *sonm.MarketOrdersRequest) (*sonm.DWHOrdersReply, error) {
+	filter := req.GetFilter()
+	if filter == nil {
+		filter = &sonm.OrdersRequest{}
+	}
+
+	if err := validateSortings(filter.GetSortings()); err != nil {
+		return nil, err
+	}
+
+	if len(req.GetBenchmarks()) > 0 {
+		benchmarks, err := m.benchmarksByID(req.GetBenchmarks())
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Benchmarks = benchmarks
+	}
+
+	return m.remotes.dwh.GetOrders(ctx, filter)
+}
+
+func (m *marketSearchAPI) Matching(ctx context.Context, req *sonm.MatchingOrdersRequest) (*sonm.DWHOrdersReply, error) {
+	if req.GetId().IsZero() {
+		return nil, fmt.Errorf("order ID is required")
+	}
+
+	return m.remotes.dwh.GetMatchingOrders(ctx, req)
+}
+
+// MatchBid mimics the DWH matching rules for BID orders, but uses the given
+// order definition instead of a placed one.
+func (m *marketSearchAPI) MatchBid(ctx context.Context, req *sonm.MatchBidRequest) (*sonm.DWHOrdersReply, error) {
+	bid := req.GetBid()
+	if bid == nil {
+		return nil, fmt.Errorf("BID order is required")
+	}
+
+	if err := validateSortings(req.GetSortings()); err != nil {
+		return nil, err
+	}
+
+	benchmarks := map[string]*sonm.MaxMinUint64{}
+	for code, value := range bid.GetResources().GetBenchmarks() {
+		benchmarks[code] = &sonm.MaxMinUint64{Min: value}
+	}
+
+	benchmarksByID, err := m.benchmarksByID(benchmarks)
+	if err != nil {
+		return nil, err
+	}
+
+	netflags := &sonm.NetFlags{}
+	netflags.SetOverlay(bid.GetResources().GetNetwork().GetOverlay())
+	netflags.SetOutbound(bid.GetResources().GetNetwork().GetOutbound())
+	netflags.SetIncoming(bid.GetResources().GetNetwork().GetIncoming())
+
+	var identityLevels []sonm.IdentityLevel
+	for level := bid.GetIdentity(); level <= sonm.IdentityLevel_PROFESSIONAL; level++ {
+		identityLevels = append(identityLevels, level)
+	}
+
+	owner := sonm.NewEthAddress(crypto.PubkeyToAddress(m.remotes.key.PublicKey))
+
+	sortings := req.GetSortings()
+	if len(sortings) == 0 {
+		sortings = []*sonm.SortingOption{{Field: "Price", Order: sonm.SortingOrder_Asc}}
+	}
+
+	filter := &sonm.OrdersRequest{
+		Type:                 sonm.OrderType_ASK,
+		Status:               sonm.OrderStatus_ORDER_ACTIVE,
+		AuthorID:             bid.GetCounterparty(),
+		CounterpartyID:       []*sonm.EthAddress{sonm.NewEthAddress(common.Address{}), owner},
+		Duration:             &sonm.MaxMinUint64{Min: uint64(bid.GetDuration().Unwrap().Seconds())},
+		Netflags:             &sonm.CmpUint64{Value: netflags.GetFlags(), Operator: sonm.CmpOp_GTE},
+		CreatorIdentityLevel: identityLevels,
+		Benchmarks:           benchmarksByID,
+		Limit:                req.GetLimit(),
+		Offset:               req.GetOffset(),
+		Sortings:             sortings,
+		WithCount:            req.GetWithCount(),
+		SenderIDs:            []*sonm.EthAddress{owner},
+	}
+
+	if price := bid.GetPrice().GetPerSecond(); price != nil {
+		filter.Price = &sonm.MaxMinBig{Max: price}
+	}
+
+	return m.remotes.dwh.GetOrders(ctx, filter)
+}
+
+func (m *marketSearchAPI) benchmarksByID(benchmarks map[string]*sonm.MaxMinUint64) (map[uint64]*sonm.MaxMinUint64, error) {
+	known := m.remotes.benchList.MapByCode()
+
+	result := map[uint64]*sonm.MaxMinUint64{}
+	for code, value := range benchmarks {
+		bench, ok := known[code]
+		if !ok {
+			return nil, fmt.Errorf("unknown benchmark code \"%s\"", code)
+		}
+
+		// DWH treats zero max as the upper bound unless it is less than
+		// min, so ranges without bounds at all must be omitted.
+		if value.GetMin() == 0 && value.GetMax() == 0 {
+			continue
+		}
+
+		result[bench.GetID()] = value
+	}
+
+	return result, nil
+}
+
+func validateSortings(sortings []*sonm.SortingOption) error {
+	for _, sorting := range sortings {
+		if !orderSortingFields[sorting.GetField()] {
+			return fmt.Errorf("unsupported sorting field \"%s\"", sorting.GetField())
+		}
+	}
+
+	return nil
+}
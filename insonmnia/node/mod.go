@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/tls"
 	"fmt"
 
 	"github.com/sonm-io/core/util"
@@ -28,7 +29,7 @@ func New(ctx context.Context, cfg *Config, options ...Option) (*Node, error) {
 		return nil, fmt.Errorf("failed to load Ethereum keys: %v", err)
 	}
 
-	transportCredentials, err := newTLS(ctx, key)
+	transportCredentials, tlsConfig, err := newTLS(ctx, key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create certificate: %v", err)
 	}
@@ -45,14 +46,21 @@ func New(ctx context.Context, cfg *Config, options ...Option) (*Node, error) {
 		WithGRPCServer(),
 		WithRESTServer(),
 		WithGRPCServerMetrics(),
+		WithSSHProxy(remoteOptions.nppDialer, key),
+		WithUDPProxy(remoteOptions.nppDialer),
 		WithServerLog(log),
 	}
 
-	if cfg.Node.AllowInsecureConnection {
+	switch {
+	case cfg.Node.AllowInsecureConnection && cfg.Node.RBAC != nil:
+		return nil, fmt.Errorf("RBAC requires secure connections to authenticate clients")
+	case cfg.Node.AllowInsecureConnection:
 		// This is intentional.
 		// Enabling insecure mode is disrespectful.
 		log.Warn("--- INSECURE SERVER MODE ACTIVATED, YOUR CONNECTIONS WILL **NOT** BE ENCRYPTED ---")
-	} else {
+	case cfg.Node.RBAC != nil:
+		serverOptions = append(serverOptions, WithRBAC(cfg.Node.RBAC, transportCredentials, tlsConfig, key))
+	default:
 		serverOptions = append(serverOptions,
 			WithGRPCSecure(transportCredentials, key),
 			WithRESTSecure(key),
@@ -75,12 +83,17 @@ func (m *Node) Serve(ctx context.Context) error {
 	return m.server.Serve(ctx)
 }
 
-// NewTLS constructs new transport credentials using specified private key.
+// NewTLS constructs new transport credentials using specified private key
+// along with the underlying TLS config.
 // The credentials will be automatically refreshed while the given context
 // is active.
 // Indented to be used in top-level function with long-living background
 // context.
-func newTLS(ctx context.Context, privateKey *ecdsa.PrivateKey) (credentials.TransportCredentials, error) {
+func newTLS(ctx context.Context, privateKey *ecdsa.PrivateKey) (credentials.TransportCredentials, *tls.Config, error) {
 	_, tlsConfig, err := util.NewHitlessCertRotator(ctx, privateKey)
-	return util.NewTLS(tlsConfig), err
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return util.NewTLS(tlsConfig), tlsConfig, nil
 }
@@ -0,0 +1,138 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util/xgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	defaultPings = 4
+	// maxPingChunkSize is the maximum payload size the worker accepts in a
+	// single Ping request.
+	maxPingChunkSize = 1 << 20
+)
+
+type nppAPI struct {
+	remotes *remoteOptions
+}
+
+func newNPPAPI(opts *remoteOptions) sonm.NPPDiagnosticsServer {
+	return &nppAPI{
+		remotes: opts,
+	}
+}
+
+func (m *nppAPI) Diagnose(ctx context.Context, request *sonm.NPPDiagnoseRequest) (*sonm.NPPReport, error) {
+	addr, err := auth.NewAddr(request.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse worker address: %v", err)
+	}
+
+	ethAddr, err := addr.ETH()
+	if err != nil {
+		return nil, fmt.Errorf("worker ETH address is required: %v", err)
+	}
+
+	report, conn := m.remotes.nppDialer.Diagnose(ctx, *addr)
+	if conn == nil {
+		report.Error = "failed to connect to the worker using any of the stages"
+		return report, nil
+	}
+
+	cc, err := xgrpc.NewClient(ctx, "-", auth.NewWalletAuthenticator(m.remotes.credentials, ethAddr), xgrpc.WithConn(conn),
+		grpc.WithPerRPCCredentials(auth.NewDelegationForwarder()))
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	defer cc.Close()
+
+	// Measuring the download throughput requires the deal with the worker,
+	// which is forwarded if specified.
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md["deal"]) != 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("deal", md["deal"][0]))
+	}
+
+	if err := m.measure(ctx, sonm.NewWorkerManagementClient(cc), request, report); err != nil {
+		report.Error = err.Error()
+	}
+
+	return report, nil
+}
+
+// measure measures RTT and throughput over the connection established,
+// filling the report.
+func (m *nppAPI) measure(ctx context.Context, worker sonm.WorkerManagementClient, request *sonm.NPPDiagnoseRequest, report *sonm.NPPReport) error {
+	pings := request.Pings
+	if pings == 0 {
+		pings = defaultPings
+	}
+
+	for id := uint32(0); id < pings; id++ {
+		startedAt := time.Now()
+		if _, err := worker.Ping(ctx, &sonm.PingRequest{}); err != nil {
+			return fmt.Errorf("failed to measure RTT: %v", err)
+		}
+
+		report.RTT = append(report.RTT, &sonm.Duration{Nanoseconds: int64(time.Since(startedAt))})
+	}
+
+	if request.Size == 0 {
+		return nil
+	}
+
+	uploadRate, err := measureRate(request.Size, func(size uint64) error {
+		_, err := worker.Ping(ctx, &sonm.PingRequest{Payload: make([]byte, size)})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to measure upload throughput: %v", err)
+	}
+
+	report.UploadRate = uploadRate
+
+	downloadRate, err := measureRate(request.Size, func(size uint64) error {
+		_, err := worker.Ping(ctx, &sonm.PingRequest{ReplySize: size})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to measure download throughput: %v", err)
+	}
+
+	report.DownloadRate = downloadRate
+
+	return nil
+}
+
+// measureRate transfers the given number of bytes in chunks using the
+// provided function, returning the throughput in bytes per second.
+func measureRate(size uint64, transfer func(size uint64) error) (uint64, error) {
+	startedAt := time.Now()
+
+	for remaining := size; remaining > 0; {
+		chunkSize := remaining
+		if chunkSize > maxPingChunkSize {
+			chunkSize = maxPingChunkSize
+		}
+
+		if err := transfer(chunkSize); err != nil {
+			return 0, err
+		}
+
+		remaining -= chunkSize
+	}
+
+	elapsed := time.Since(startedAt)
+	if elapsed <= 0 {
+		return 0, nil
+	}
+
+	return uint64(float64(size) / elapsed.Seconds()), nil
+}
@@ -0,0 +1,28 @@
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeasureRateSplitsIntoChunks(t *testing.T) {
+	var chunks []uint64
+	_, err := measureRate(2*maxPingChunkSize+1, func(size uint64) error {
+		chunks = append(chunks, size)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{maxPingChunkSize, maxPingChunkSize, 1}, chunks)
+}
+
+func TestMeasureRateFails(t *testing.T) {
+	_, err := measureRate(1, func(size uint64) error {
+		return errors.New("broken pipe")
+	})
+
+	assert.Error(t, err)
+}
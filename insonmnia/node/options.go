@@ -3,7 +3,10 @@ package node
 import (
 	"crypto/ecdsa"
 	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/util/rest"
@@ -37,6 +40,11 @@ type serverOptions struct {
 	allowREST         bool
 	optionsREST       []rest.Option
 	exposeGRPCMetrics bool
+	rbac              *RBACConfig
+	owner             common.Address
+	sshDialer         sshDialer
+	sshKey            *ecdsa.PrivateKey
+	udpDialer         udpDialer
 	log               *zap.Logger
 }
 
@@ -82,6 +90,57 @@ func WithRESTSecure(key *ecdsa.PrivateKey) ServerOption {
 	}
 }
 
+// WithRBAC activates role-based access control for both gRPC and REST
+// servers.
+//
+// It must be used instead of "WithGRPCSecure" and "WithRESTSecure", because
+// clients other than the owner of the given key must be able to connect.
+// Since such clients do not know the key the REST codec is derived from,
+// the REST server is exposed over TLS using the given config instead.
+func WithRBAC(cfg *RBACConfig, credentials credentials.TransportCredentials, tlsConfig *tls.Config, key *ecdsa.PrivateKey) ServerOption {
+	return func(o *serverOptions) error {
+		if err := cfg.Validate(); err != nil {
+			return fmt.Errorf("invalid RBAC config: %v", err)
+		}
+		if tlsConfig == nil {
+			return fmt.Errorf("RBAC requires TLS config for the REST server")
+		}
+
+		// REST clients are authenticated by bearer tokens rather than by
+		// their certificates.
+		restTLSConfig := tlsConfig.Clone()
+		restTLSConfig.ClientAuth = tls.NoClientCert
+
+		o.rbac = cfg
+		o.owner = crypto.PubkeyToAddress(key.PublicKey)
+		o.optionsGRPC = append(o.optionsGRPC, xgrpc.Credentials(credentials))
+		o.optionsREST = append(o.optionsREST,
+			rest.WithTLS(restTLSConfig),
+			rest.WithAuthenticator(newBearerAuthenticator(o.owner)),
+		)
+		return nil
+	}
+}
+
+// WithSSHProxy activates the SSH jump host, which forwards connections to
+// workers using the given dialer.
+func WithSSHProxy(dialer sshDialer, key *ecdsa.PrivateKey) ServerOption {
+	return func(o *serverOptions) error {
+		o.sshDialer = dialer
+		o.sshKey = key
+		return nil
+	}
+}
+
+// WithUDPProxy activates the UDP proxy, which forwards datagrams to tasks
+// using the given dialer.
+func WithUDPProxy(dialer udpDialer) ServerOption {
+	return func(o *serverOptions) error {
+		o.udpDialer = dialer
+		return nil
+	}
+}
+
 func WithGRPCServerMetrics() ServerOption {
 	return func(o *serverOptions) error {
 		o.exposeGRPCMetrics = true
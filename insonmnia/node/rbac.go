@@ -0,0 +1,192 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/util/rest"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RBACConfig describes role-based access control for the node's API.
+//
+// The node's own key always has full access, while other clients are
+// allowed to call only methods permitted by their roles. gRPC clients are
+// authenticated using their TLS certificates, while REST clients must pass
+// bearer tokens using "Authorization" HTTP header.
+type RBACConfig struct {
+	// Roles maps role names to permitted methods. Each permission is either
+	// a fully-qualified method name, like "/sonm.DealManagement/List", all
+	// methods of the service, like "/sonm.DealManagement/*", or "*" for
+	// everything.
+	Roles map[string][]string `yaml:"roles"`
+	// Users maps clients' ETH addresses to their role names.
+	Users map[string]string `yaml:"users"`
+}
+
+func (m *RBACConfig) Validate() error {
+	for role, permissions := range m.Roles {
+		for _, permission := range permissions {
+			if err := validatePermission(permission); err != nil {
+				return fmt.Errorf("invalid permission for role \"%s\": %v", role, err)
+			}
+		}
+	}
+
+	for user, role := range m.Users {
+		if !common.IsHexAddress(user) {
+			return fmt.Errorf("invalid user address: %s", user)
+		}
+
+		if _, ok := m.Roles[role]; !ok {
+			return fmt.Errorf("unknown role \"%s\" for user %s", role, user)
+		}
+	}
+
+	return nil
+}
+
+// Permissions returns permissions of each user.
+func (m *RBACConfig) Permissions() map[common.Address][]string {
+	permissions := map[common.Address][]string{}
+	for user, role := range m.Users {
+		addr := common.HexToAddress(user)
+		permissions[addr] = append(permissions[addr], m.Roles[role]...)
+	}
+
+	return permissions
+}
+
+func validatePermission(permission string) error {
+	if permission == "*" {
+		return nil
+	}
+
+	parts := strings.Split(permission, "/")
+	if len(parts) != 3 || len(parts[0]) != 0 || len(parts[1]) == 0 || len(parts[2]) == 0 {
+		return fmt.Errorf("permission must be either \"*\" or in \"/package.Service/Method\" format, got \"%s\"", permission)
+	}
+
+	return nil
+}
+
+func permits(permission string, method string) bool {
+	switch {
+	case permission == "*":
+		return true
+	case strings.HasSuffix(permission, "/*"):
+		return strings.HasPrefix(method, strings.TrimSuffix(permission, "*"))
+	default:
+		return permission == method
+	}
+}
+
+// walletsAuthorization allows calls from any of the specified wallets.
+type walletsAuthorization struct {
+	wallets map[common.Address]bool
+}
+
+func newWalletsAuthorization(wallets ...common.Address) auth.Authorization {
+	m := &walletsAuthorization{
+		wallets: map[common.Address]bool{},
+	}
+
+	for _, wallet := range wallets {
+		m.wallets[wallet] = true
+	}
+
+	return m
+}
+
+func (m *walletsAuthorization) Authorize(ctx context.Context, request interface{}) error {
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if !m.wallets[*wallet] {
+		return status.Errorf(codes.PermissionDenied, "the wallet %s has no access", wallet.Hex())
+	}
+
+	return nil
+}
+
+// newRBACAuthorization constructs the auth router that allows each of the
+// given methods to be called by the owner and by users with suitable
+// permissions. Unknown methods are allowed for the owner only.
+func newRBACAuthorization(cfg *RBACConfig, owner common.Address, methods []string, log *zap.Logger) *auth.AuthRouter {
+	permissions := cfg.Permissions()
+
+	options := []auth.EventAuthorizationOption{
+		auth.WithLog(log),
+		auth.WithFallback(newWalletsAuthorization(owner)),
+	}
+
+	for _, method := range methods {
+		wallets := []common.Address{owner}
+		for wallet, userPermissions := range permissions {
+			for _, permission := range userPermissions {
+				if permits(permission, method) {
+					wallets = append(wallets, wallet)
+					break
+				}
+			}
+		}
+
+		options = append(options, auth.Allow(method).With(newWalletsAuthorization(wallets...)))
+	}
+
+	return auth.NewEventAuthorization(context.Background(), options...)
+}
+
+// serviceMethods returns fully-qualified names of all methods of the given
+// services.
+func serviceMethods(services Services) ([]string, error) {
+	server := grpc.NewServer()
+	if err := services.RegisterGRPC(server); err != nil {
+		return nil, err
+	}
+
+	var methods []string
+	for service, info := range server.GetServiceInfo() {
+		for _, method := range info.Methods {
+			methods = append(methods, "/"+service+"/"+method.Name)
+		}
+	}
+
+	sort.Strings(methods)
+
+	return methods, nil
+}
+
+// newBearerAuthenticator constructs an authenticator of REST requests
+// using bearer tokens issued for the node with the given ETH address, making
+// the authenticated wallet available the same way as the TLS transport does.
+func newBearerAuthenticator(node common.Address) rest.Authenticator {
+	return func(request *http.Request) (context.Context, error) {
+		header := request.Header.Get("Authorization")
+		if !strings.HasPrefix(header, "Bearer ") {
+			return nil, fmt.Errorf("bearer token is required")
+		}
+
+		wallet, err := auth.VerifyBearerToken(strings.TrimPrefix(header, "Bearer "), node, time.Now())
+		if err != nil {
+			return nil, err
+		}
+
+		return peer.NewContext(request.Context(), &peer.Peer{
+			AuthInfo: auth.EthAuthInfo{TLS: credentials.TLSInfo{}, Wallet: wallet},
+		}), nil
+	}
+}
@@ -0,0 +1,131 @@
+package node
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func walletContext(wallet common.Address) context.Context {
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: auth.EthAuthInfo{TLS: credentials.TLSInfo{}, Wallet: wallet},
+	})
+}
+
+func TestRBACConfigValidate(t *testing.T) {
+	cfg := &RBACConfig{
+		Roles: map[string][]string{
+			"viewer": {"/sonm.DealManagement/List", "/sonm.Market/*"},
+		},
+		Users: map[string]string{
+			"0x8125721c2413d99a33e351e1f6bb4e56b6b633fd": "viewer",
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Users["0x8125721c2413d99a33e351e1f6bb4e56b6b633fd"] = "operator"
+	assert.Error(t, cfg.Validate())
+
+	cfg.Users = map[string]string{"not an address": "viewer"}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Users = nil
+	cfg.Roles["viewer"] = []string{"sonm.Market"}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestRBACAuthorization(t *testing.T) {
+	owner := common.HexToAddress("0x100")
+	viewer := common.HexToAddress("0x200")
+	operator := common.HexToAddress("0x300")
+	stranger := common.HexToAddress("0x400")
+
+	cfg := &RBACConfig{
+		Roles: map[string][]string{
+			"viewer":   {"/sonm.DealManagement/List", "/sonm.Market/*"},
+			"operator": {"*"},
+		},
+		Users: map[string]string{
+			viewer.Hex():   "viewer",
+			operator.Hex(): "operator",
+		},
+	}
+
+	methods := []string{
+		"/sonm.DealManagement/List",
+		"/sonm.Market/GetOrders",
+		"/sonm.TokenManagement/Transfer",
+	}
+
+	router := newRBACAuthorization(cfg, owner, methods, zap.NewNop())
+
+	cases := []struct {
+		wallet  common.Address
+		method  string
+		allowed bool
+	}{
+		{owner, "/sonm.TokenManagement/Transfer", true},
+		{owner, "/sonm.Unknown/Method", true},
+		{viewer, "/sonm.DealManagement/List", true},
+		{viewer, "/sonm.Market/GetOrders", true},
+		{viewer, "/sonm.TokenManagement/Transfer", false},
+		{operator, "/sonm.TokenManagement/Transfer", true},
+		{operator, "/sonm.Unknown/Method", false},
+		{stranger, "/sonm.DealManagement/List", false},
+	}
+
+	for _, c := range cases {
+		err := router.Authorize(walletContext(c.wallet), auth.Event(c.method), nil)
+		if c.allowed {
+			assert.NoError(t, err, "%s -> %s", c.wallet.Hex(), c.method)
+		} else {
+			assert.Error(t, err, "%s -> %s", c.wallet.Hex(), c.method)
+		}
+	}
+
+	assert.Error(t, router.Authorize(context.Background(), auth.Event("/sonm.DealManagement/List"), nil))
+}
+
+func TestBearerAuthenticator(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	nodeKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	bearerAuthenticator := newBearerAuthenticator(crypto.PubkeyToAddress(nodeKey.PublicKey))
+
+	token, err := auth.NewBearerToken(key, crypto.PubkeyToAddress(key.PublicKey), time.Minute)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest("POST", "http://localhost/DealManagement/List", nil)
+	require.NoError(t, err)
+
+	_, err = bearerAuthenticator(request)
+	assert.Error(t, err)
+
+	// Tokens issued for other nodes are rejected.
+	request.Header.Set("Authorization", "Bearer "+token)
+	_, err = bearerAuthenticator(request)
+	assert.Error(t, err)
+
+	token, err = auth.NewBearerToken(key, crypto.PubkeyToAddress(nodeKey.PublicKey), time.Minute)
+	require.NoError(t, err)
+
+	request.Header.Set("Authorization", "Bearer "+token)
+	ctx, err := bearerAuthenticator(request)
+	require.NoError(t, err)
+
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), *wallet)
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sonm-io/core/util"
 	"github.com/sonm-io/core/util/xgrpc"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
@@ -34,6 +35,8 @@ type remoteOptions struct {
 	eth           blockchain.API
 	dwh           sonm.DWHClient
 	workerCreator workerClientCreator
+	nppDialer     *npp.Dialer
+	credentials   credentials.TransportCredentials
 
 	benchList    benchmarks.BenchList
 	orderMatcher matcher.Matcher
@@ -84,7 +87,8 @@ func (re *remoteOptions) isWorkerAvailable(ctx context.Context, addr common.Addr
 func newRemoteOptions(ctx context.Context, cfg *Config, key *ecdsa.PrivateKey, credentials credentials.TransportCredentials, log *zap.SugaredLogger) (*remoteOptions, error) {
 	nppDialerOptions := []npp.Option{
 		npp.WithRendezvous(cfg.NPP.Rendezvous, credentials),
-		npp.WithRelayDialer(&relay.Dialer{Addrs: cfg.NPP.Relay.Endpoints, Log: log.Desugar()}),
+		npp.WithRelayDialer(&relay.Dialer{Addrs: cfg.NPP.Relay.Endpoints, Key: key, Log: log.Desugar()}),
+		npp.WithMultiplexing(cfg.NPP.Mux),
 		npp.WithLogger(log.Desugar()),
 	}
 	nppDialer, err := npp.NewDialer(nppDialerOptions...)
@@ -105,7 +109,10 @@ func newRemoteOptions(ctx context.Context, cfg *Config, key *ecdsa.PrivateKey, c
 			return nil, nil, err
 		}
 
-		cc, err := xgrpc.NewClient(ctx, "-", auth.NewWalletAuthenticator(credentials, ethAddr), xgrpc.WithConn(conn))
+		// Delegation tokens are forwarded to allow delegates to access
+		// deals using their own nodes.
+		cc, err := xgrpc.NewClient(ctx, "-", auth.NewWalletAuthenticator(credentials, ethAddr), xgrpc.WithConn(conn),
+			grpc.WithPerRPCCredentials(auth.NewDelegationForwarder()))
 		if err != nil {
 			return nil, nil, err
 		}
@@ -158,6 +165,8 @@ func newRemoteOptions(ctx context.Context, cfg *Config, key *ecdsa.PrivateKey, c
 		eth:           eth,
 		dwh:           dwh,
 		workerCreator: workerFactory,
+		nppDialer:     nppDialer,
+		credentials:   credentials,
 		benchList:     benchList,
 		orderMatcher:  orderMatcher,
 		log:           log,
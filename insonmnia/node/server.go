@@ -6,7 +6,10 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/grpc-ecosystem/go-grpc-middleware"
 	"github.com/grpc-ecosystem/go-grpc-prometheus"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util/defergroup"
 	"github.com/sonm-io/core/util/rest"
 	"github.com/sonm-io/core/util/xgrpc"
@@ -20,29 +23,37 @@ import (
 type LocalEndpoints struct {
 	GRPC []net.Addr
 	REST []net.Addr
+	SSH  []net.Addr
+	UDP  []net.Addr
 }
 
 type serverNetwork struct {
 	mu            sync.Mutex
 	ListenersGRPC []net.Listener
 	ListenersREST []net.Listener
+	ListenersSSH  []net.Listener
+	ConnsUDP      []net.PacketConn
 }
 
 func (m *serverNetwork) Pop() *serverNetwork {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if m.ListenersGRPC == nil && m.ListenersREST == nil {
+	if m.ListenersGRPC == nil && m.ListenersREST == nil && m.ListenersSSH == nil && m.ConnsUDP == nil {
 		return nil
 	}
 
 	network := &serverNetwork{
 		ListenersGRPC: m.ListenersGRPC,
 		ListenersREST: m.ListenersREST,
+		ListenersSSH:  m.ListenersSSH,
+		ConnsUDP:      m.ConnsUDP,
 	}
 
 	m.ListenersGRPC = nil
 	m.ListenersREST = nil
+	m.ListenersSSH = nil
+	m.ConnsUDP = nil
 
 	return network
 }
@@ -64,6 +75,8 @@ type Server struct {
 	// Servers for processing requests.
 	serverGRPC *grpc.Server
 	serverREST *rest.Server
+	serverSSH  *sshProxy
+	serverUDP  *udpProxy
 
 	log *zap.SugaredLogger
 }
@@ -109,13 +122,62 @@ func newServer(cfg nodeConfig, services Services, options ...ServerOption) (*Ser
 		},
 	}
 
+	if opts.sshDialer != nil {
+		listenersSSH, err := xnet.ListenLoopback("tcp", cfg.SSHProxyBindPort)
+		if err != nil {
+			return nil, err
+		}
+		dg.Defer(func() { closeListeners(listenersSSH) })
+
+		m.serverSSH, err = newSSHProxy(opts.sshDialer, opts.sshKey, opts.log)
+		if err != nil {
+			return nil, err
+		}
+
+		m.network.ListenersSSH = listenersSSH
+		m.endpoints.SSH = toLocalAddrs(listenersSSH)
+	}
+
+	if opts.udpDialer != nil {
+		connsUDP, err := xnet.ListenLoopbackPacket("udp", cfg.UDPProxyBindPort)
+		if err != nil {
+			return nil, err
+		}
+		dg.Defer(func() { closePacketConns(connsUDP) })
+
+		m.serverUDP = newUDPProxy(opts.udpDialer, opts.log)
+		m.network.ConnsUDP = connsUDP
+		m.endpoints.UDP = toLocalPacketAddrs(connsUDP)
+	}
+
+	var authorization *auth.AuthRouter
+	if opts.rbac != nil {
+		methods, err := serviceMethods(services)
+		if err != nil {
+			return nil, err
+		}
+
+		authorization = newRBACAuthorization(opts.rbac, opts.owner, methods, opts.log)
+	}
+
 	if opts.allowGRPC {
-		options := append([]xgrpc.ServerOption{
+		options := []xgrpc.ServerOption{
 			xgrpc.DefaultTraceInterceptor(),
 			xgrpc.RequestLogInterceptor(m.log.Desugar()),
+		}
+
+		if authorization != nil {
+			options = append(options,
+				xgrpc.AuthorizationInterceptor(authorization),
+				xgrpc.StreamAuthorizationInterceptor(authorization),
+			)
+		}
+
+		options = append(options,
 			xgrpc.VerifyInterceptor(),
 			xgrpc.UnaryServerInterceptor(services.Interceptor()),
-		}, opts.optionsGRPC...)
+		)
+		options = append(options, opts.optionsGRPC...)
 
 		m.serverGRPC = xgrpc.NewServer(m.log.Desugar(), options...)
 		if err := services.RegisterGRPC(m.serverGRPC); err != nil {
@@ -126,10 +188,20 @@ func newServer(cfg nodeConfig, services Services, options ...ServerOption) (*Ser
 	}
 
 	if opts.allowREST {
-		options := append([]rest.Option{
+		interceptor := services.Interceptor()
+		if authorization != nil {
+			interceptor = grpc_middleware.ChainUnaryServer(xgrpc.AuthUnaryServerInterceptor(authorization), interceptor)
+		}
+
+		options := []rest.Option{
 			rest.WithLog(opts.log),
-			rest.WithInterceptor(services.Interceptor()),
-		}, opts.optionsREST...)
+			rest.WithInterceptor(interceptor),
+			rest.WithStreamLookup(sonm.StreamDesc),
+		}
+		if authorization != nil {
+			options = append(options, rest.WithStreamInterceptor(xgrpc.AuthStreamServerInterceptor(authorization)))
+		}
+		options = append(options, opts.optionsREST...)
 
 		m.serverREST = rest.NewServer(options...)
 		if err := services.RegisterREST(m.serverREST); err != nil {
@@ -170,7 +242,13 @@ func (m *Server) Serve(ctx context.Context) error {
 	wg.Go(func() error {
 		return m.serveHTTP(ctx, network.ListenersREST...)
 	})
-	// TODO: Also add debug server and ssh.
+	wg.Go(func() error {
+		return m.serveSSH(ctx, network.ListenersSSH...)
+	})
+	wg.Go(func() error {
+		return m.serveUDP(ctx, network.ConnsUDP...)
+	})
+	// TODO: Also add debug server.
 
 	<-ctx.Done()
 
@@ -210,6 +288,48 @@ func (m *Server) serveHTTP(ctx context.Context, listeners ...net.Listener) error
 	return m.serverREST.Serve(listeners...)
 }
 
+func (m *Server) serveSSH(ctx context.Context, listeners ...net.Listener) error {
+	if m.serverSSH == nil {
+		return nil
+	}
+
+	wg := errgroup.Group{}
+
+	for id := range listeners {
+		listener := listeners[id]
+
+		wg.Go(func() error {
+			m.log.Infof("exposing SSH proxy on %s", listener.Addr().String())
+			return m.serverSSH.Serve(listener)
+		})
+	}
+
+	defer m.log.Infof("stopped SSH proxy on %s", formatListeners(listeners))
+
+	return wg.Wait()
+}
+
+func (m *Server) serveUDP(ctx context.Context, conns ...net.PacketConn) error {
+	if m.serverUDP == nil {
+		return nil
+	}
+
+	wg := errgroup.Group{}
+
+	for id := range conns {
+		conn := conns[id]
+
+		wg.Go(func() error {
+			m.log.Infof("exposing UDP proxy on %s", conn.LocalAddr().String())
+			return m.serverUDP.Serve(ctx, conn)
+		})
+	}
+
+	defer m.log.Infof("stopped UDP proxy on %s", formatAddrs(toLocalPacketAddrs(conns)))
+
+	return wg.Wait()
+}
+
 func (m *Server) close() {
 	if m.serverGRPC != nil {
 		m.serverGRPC.Stop()
@@ -217,6 +337,12 @@ func (m *Server) close() {
 	if m.serverREST != nil {
 		m.serverREST.Close()
 	}
+	if m.serverSSH != nil {
+		m.serverSSH.Close()
+	}
+	if m.serverUDP != nil {
+		m.serverUDP.Close()
+	}
 }
 
 // TODO: Compose those three functions into a separate struct.
@@ -229,13 +355,26 @@ func toLocalAddrs(listeners []net.Listener) []net.Addr {
 	return addrs
 }
 
+func toLocalPacketAddrs(conns []net.PacketConn) []net.Addr {
+	var addrs []net.Addr
+	for id := range conns {
+		addrs = append(addrs, conns[id].LocalAddr())
+	}
+
+	return addrs
+}
+
 func formatListeners(listeners []net.Listener) string {
-	var addrs []string
-	for _, addr := range toLocalAddrs(listeners) {
-		addrs = append(addrs, addr.String())
+	return formatAddrs(toLocalAddrs(listeners))
+}
+
+func formatAddrs(addrs []net.Addr) string {
+	var strs []string
+	for _, addr := range addrs {
+		strs = append(strs, addr.String())
 	}
 
-	return fmt.Sprintf("[%s]", strings.Join(addrs, ", "))
+	return fmt.Sprintf("[%s]", strings.Join(strs, ", "))
 }
 
 func closeListeners(listeners []net.Listener) {
@@ -243,3 +382,9 @@ func closeListeners(listeners []net.Listener) {
 		listeners[id].Close()
 	}
 }
+
+func closePacketConns(conns []net.PacketConn) {
+	for id := range conns {
+		conns[id].Close()
+	}
+}
@@ -15,6 +15,8 @@ type services struct {
 	token     sonm.TokenManagementServer
 	blacklist sonm.BlacklistServer
 	profile   sonm.ProfilesServer
+	search    sonm.MarketSearchServer
+	npp       sonm.NPPDiagnosticsServer
 }
 
 func newServices(options *remoteOptions) *services {
@@ -27,6 +29,8 @@ func newServices(options *remoteOptions) *services {
 		token:     newTokenManagementAPI(options),
 		blacklist: newBlacklistAPI(options),
 		profile:   newProfileAPI(options),
+		search:    newMarketSearchAPI(options),
+		npp:       newNPPAPI(options),
 	}
 }
 
@@ -43,6 +47,8 @@ func (m *services) RegisterGRPC(server *grpc.Server) error {
 	sonm.RegisterTokenManagementServer(server, m.token)
 	sonm.RegisterBlacklistServer(server, m.blacklist)
 	sonm.RegisterProfilesServer(server, m.profile)
+	sonm.RegisterMarketSearchServer(server, m.search)
+	sonm.RegisterNPPDiagnosticsServer(server, m.npp)
 
 	return nil
 }
@@ -76,6 +82,12 @@ func (m *services) RegisterREST(server *rest.Server) error {
 	if err := server.RegisterService((*sonm.ProfilesServer)(nil), m.profile); err != nil {
 		return err
 	}
+	if err := server.RegisterService((*sonm.MarketSearchServer)(nil), m.search); err != nil {
+		return err
+	}
+	if err := server.RegisterService((*sonm.NPPDiagnosticsServer)(nil), m.npp); err != nil {
+		return err
+	}
 
 	return nil
 }
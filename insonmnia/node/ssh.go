@@ -0,0 +1,119 @@
+package node
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gliderlabs/ssh"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/util"
+	"go.uber.org/zap"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+type sshDialer interface {
+	DialContext(ctx context.Context, addr auth.Addr) (net.Conn, error)
+}
+
+// sshProxy is an SSH jump host forwarding connections to workers through
+// NPP, making SSH access to tasks possible even if workers are behind NAT.
+//
+// Destination hosts are specified as workers' Ethereum addresses, for
+// example: "ssh -J sonm@localhost:15032 <task_id>@<worker_address>".
+//
+// The proxy does not authenticate clients, because it is exposed on
+// loopback interfaces only, while workers authenticate SSH sessions using
+// keys specified for tasks.
+type sshProxy struct {
+	dialer sshDialer
+	server *ssh.Server
+	log    *zap.Logger
+}
+
+func newSSHProxy(dialer sshDialer, key *ecdsa.PrivateKey, log *zap.Logger) (*sshProxy, error) {
+	signer, err := util.NewSSHHostSigner(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive SSH host key: %v", err)
+	}
+
+	m := &sshProxy{
+		dialer: dialer,
+		log:    log,
+	}
+
+	m.server = &ssh.Server{
+		HostSigners: []ssh.Signer{signer},
+		ChannelHandlers: map[string]ssh.ChannelHandler{
+			"direct-tcpip": m.onDirectTCPIP,
+		},
+	}
+
+	return m, nil
+}
+
+func (m *sshProxy) Serve(listener net.Listener) error {
+	err := m.server.Serve(listener)
+	if err == ssh.ErrServerClosed {
+		return nil
+	}
+
+	return err
+}
+
+func (m *sshProxy) onDirectTCPIP(srv *ssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx ssh.Context) {
+	// See RFC4254, section 7.2.
+	request := struct {
+		DestAddr   string
+		DestPort   uint32
+		OriginAddr string
+		OriginPort uint32
+	}{}
+
+	if err := gossh.Unmarshal(newChan.ExtraData(), &request); err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "failed to parse forward data: "+err.Error())
+		return
+	}
+
+	if !common.IsHexAddress(request.DestAddr) {
+		newChan.Reject(gossh.Prohibited, "destination must be a worker's Ethereum address")
+		return
+	}
+
+	addr := common.HexToAddress(request.DestAddr)
+	log := m.log.With(zap.Stringer("worker", addr))
+
+	workerConn, err := m.dialer.DialContext(ctx, auth.NewAddrRaw(addr, ""))
+	if err != nil {
+		log.Warn("failed to connect to the worker", zap.Error(err))
+		newChan.Reject(gossh.ConnectionFailed, fmt.Sprintf("failed to connect to the worker: %v", err))
+		return
+	}
+
+	channel, requests, err := newChan.Accept()
+	if err != nil {
+		workerConn.Close()
+		return
+	}
+	go gossh.DiscardRequests(requests)
+
+	log.Info("forwarding ssh connection")
+
+	go func() {
+		defer channel.Close()
+		defer workerConn.Close()
+		io.Copy(channel, workerConn)
+	}()
+	go func() {
+		defer channel.Close()
+		defer workerConn.Close()
+		io.Copy(workerConn, channel)
+	}()
+}
+
+func (m *sshProxy) Close() error {
+	return m.server.Close()
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"strconv"
 
+	"github.com/sonm-io/core/insonmnia/auth"
 	pb "github.com/sonm-io/core/proto"
 	"go.uber.org/zap"
 	"golang.org/x/net/context"
@@ -220,7 +221,7 @@ func (t *tasksAPI) PushTask(clientStream pb.TaskManagement_PushTaskServer) error
 }
 
 func (t *tasksAPI) PullTask(req *pb.PullTaskRequest, srv pb.TaskManagement_PullTaskServer) error {
-	ctx := context.Background()
+	ctx := srv.Context()
 	worker, cc, err := t.remotes.getWorkerClientForDeal(ctx, req.GetDealId())
 	if err != nil {
 		return err
@@ -283,10 +284,18 @@ func (t *tasksAPI) extractStreamMeta(clientStream pb.TaskManagement_PushTaskServ
 		return nil, status.Errorf(codes.InvalidArgument, "`%s` required", "size")
 	}
 
-	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(map[string]string{
+	outgoing := map[string]string{
 		"deal": dealIDs[0],
 		"size": sizes[0],
-	}))
+	}
+
+	// The outgoing context is detached from the client stream, so the
+	// delegation token must be forwarded explicitly.
+	if token := auth.DelegationTokenFromContext(clientStream.Context()); len(token) != 0 {
+		outgoing[auth.DelegationTokenMetadataKey] = token
+	}
+
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.New(outgoing))
 
 	v, _ := strconv.ParseInt(sizes[0], 10, 64)
 
@@ -0,0 +1,114 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/util/xnet"
+	"go.uber.org/zap"
+)
+
+const (
+	udpProxyHandshakeTimeout = 30 * time.Second
+	udpProxyIdleTimeout      = 2 * time.Minute
+)
+
+type udpDialer interface {
+	DialUDPContext(ctx context.Context, addr auth.Addr) (net.Conn, error)
+}
+
+// udpProxy forwards UDP datagrams to published UDP ports of tasks through
+// UDP NPP, making them reachable even if workers are behind NAT.
+//
+// The first datagram of each local session must be
+// "<worker_address>/<task_id>/<port>", which is answered with either "OK" or
+// an error message. Like the SSH proxy it does not authenticate clients,
+// because it is exposed on loopback interfaces only.
+type udpProxy struct {
+	dialer udpDialer
+	log    *zap.Logger
+
+	mu     sync.Mutex
+	conns  []net.PacketConn
+	closed bool
+}
+
+func newUDPProxy(dialer udpDialer, log *zap.Logger) *udpProxy {
+	return &udpProxy{
+		dialer: dialer,
+		log:    log,
+	}
+}
+
+func (m *udpProxy) Serve(ctx context.Context, conn net.PacketConn) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		conn.Close()
+		return nil
+	}
+	m.conns = append(m.conns, conn)
+	m.mu.Unlock()
+
+	err := xnet.ServeDatagrams(conn, func(addr net.Addr, datagram []byte) (net.Conn, error) {
+		remote, err := m.dial(ctx, string(datagram))
+		xnet.ReplyDatagramHandshake(func(reply []byte) error {
+			_, err := conn.WriteTo(reply, addr)
+			return err
+		}, err)
+
+		if err != nil {
+			m.log.Warn("failed to forward UDP", zap.Stringer("local", addr), zap.Error(err))
+			return nil, err
+		}
+
+		m.log.Info("forwarding UDP", zap.Stringer("local", addr), zap.String("target", string(datagram)))
+		return remote, nil
+	}, udpProxyIdleTimeout)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil
+	}
+
+	return err
+}
+
+func (m *udpProxy) dial(ctx context.Context, request string) (net.Conn, error) {
+	parts := strings.SplitN(request, "/", 2)
+	if len(parts) != 2 || !common.IsHexAddress(parts[0]) {
+		return nil, fmt.Errorf("invalid UDP forwarding request: expected \"<worker_address>/<task_id>/<port>\"")
+	}
+
+	conn, err := m.dialer.DialUDPContext(ctx, auth.NewAddrRaw(common.HexToAddress(parts[0]), ""))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the worker: %v", err)
+	}
+
+	if err := xnet.DatagramHandshake(conn, parts[1], udpProxyHandshakeTimeout); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (m *udpProxy) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	for _, conn := range m.conns {
+		conn.Close()
+	}
+
+	return nil
+}
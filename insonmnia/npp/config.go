@@ -10,6 +10,7 @@ import (
 type Config struct {
 	Rendezvous         rendezvous.Config `yaml:"rendezvous"`
 	Relay              relay.Config      `yaml:"relay"`
+	Mux                MuxConfig         `yaml:"mux"`
 	Backlog            int               `yaml:"backlog" default:"128"`
 	MinBackoffInterval time.Duration     `yaml:"min_backoff_interval" default:"500ms"`
 	MaxBackoffInterval time.Duration     `yaml:"max_backoff_interval" default:"8000ms"`
@@ -0,0 +1,292 @@
+package npp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	StageDirect     = "direct"
+	StageRendezvous = "rendezvous"
+	StagePunch      = "punch"
+	StageRelay      = "relay"
+
+	diagnosticsTimeout = 5 * time.Second
+)
+
+// Diagnose runs each connection stage the dialer tries on its own, reporting
+// their results with timings.
+//
+// Unlike DialContext all of the stages are run regardless of previous
+// results. The connection established using the stage DialContext would
+// choose is returned along with the report, if any, while others are
+// closed. The caller is responsible for closing the returned connection.
+func (m *Dialer) Diagnose(ctx context.Context, addr auth.Addr) (*sonm.NPPReport, net.Conn) {
+	report := &sonm.NPPReport{}
+	var conns []net.Conn
+
+	appendStage := func(stage *sonm.NPPStageReport, conn net.Conn) {
+		report.Stages = append(report.Stages, stage)
+		if conn != nil {
+			conns = append(conns, conn)
+			if len(report.Path) == 0 {
+				report.Path = stage.Name
+			}
+		}
+	}
+
+	appendStage(m.diagnoseDirect(ctx, addr))
+
+	ethAddr, err := addr.ETH()
+	if err != nil {
+		return report, nil
+	}
+
+	rendezvousStage, punchStage, conn := m.diagnosePunch(ctx, ethAddr)
+	appendStage(rendezvousStage, nil)
+	appendStage(punchStage, conn)
+
+	appendStage(m.diagnoseRelay(ctx, ethAddr))
+
+	if len(conns) == 0 {
+		return report, nil
+	}
+
+	for _, conn := range conns[1:] {
+		conn.Close()
+	}
+
+	return report, conns[0]
+}
+
+func (m *Dialer) diagnoseDirect(ctx context.Context, addr auth.Addr) (*sonm.NPPStageReport, net.Conn) {
+	stage := &sonm.NPPStageReport{Name: StageDirect}
+
+	netAddr, err := addr.Addr()
+	if err != nil {
+		stage.Error = fmt.Sprintf("no direct address: %v", err)
+		return stage, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsTimeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	timer := startTimer()
+	conn, err := dialer.DialContext(ctx, "tcp", netAddr)
+	stage.Duration = timer.Elapsed()
+	stage.RemoteAddr = netAddr
+	if err != nil {
+		stage.Error = err.Error()
+		return stage, nil
+	}
+
+	return stage, conn
+}
+
+// diagnosePunch resolves the remote peer using the rendezvous and punches
+// each of its addresses, returning reports for both stages.
+func (m *Dialer) diagnosePunch(ctx context.Context, addr common.Address) (*sonm.NPPStageReport, *sonm.NPPStageReport, net.Conn) {
+	rendezvousStage := &sonm.NPPStageReport{Name: StageRendezvous}
+	punchStage := &sonm.NPPStageReport{Name: StagePunch}
+
+	if m.puncherNew == nil {
+		rendezvousStage.Error = "rendezvous is not configured"
+		punchStage.Error = rendezvousStage.Error
+		return rendezvousStage, punchStage, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsTimeout)
+	defer cancel()
+
+	timer := startTimer()
+	puncher, err := m.puncherNew(ctx)
+	if err != nil {
+		rendezvousStage.Duration = timer.Elapsed()
+		rendezvousStage.Error = fmt.Sprintf("failed to connect to the rendezvous: %v", err)
+		punchStage.Error = "rendezvous has failed"
+		return rendezvousStage, punchStage, nil
+	}
+	defer puncher.Close()
+
+	rendezvousStage.Server = puncher.RemoteAddr().String()
+
+	natPuncher, ok := puncher.(*natPuncher)
+	if !ok {
+		rendezvousStage.Error = fmt.Sprintf("diagnostics is not supported by %T puncher", puncher)
+		punchStage.Error = rendezvousStage.Error
+		return rendezvousStage, punchStage, nil
+	}
+
+	addrs, err := natPuncher.resolve(ctx, addr)
+	rendezvousStage.Duration = timer.Elapsed()
+	if err != nil {
+		rendezvousStage.Error = err.Error()
+		punchStage.Error = "rendezvous has failed"
+		return rendezvousStage, punchStage, nil
+	}
+
+	rendezvousStage.PublicAddr = addrs.PublicAddr
+	rendezvousStage.PrivateAddrs = addrs.PrivateAddrs
+
+	punchStage.Server = rendezvousStage.Server
+	conn := natPuncher.diagnosePunch(ctx, addrs, punchStage)
+
+	return rendezvousStage, punchStage, conn
+}
+
+// diagnosePunch punches all of the given addresses, recording each attempt
+// into the report.
+func (m *natPuncher) diagnosePunch(ctx context.Context, addrs *sonm.RendezvousReply, stage *sonm.NPPStageReport) net.Conn {
+	candidates := addrs.PrivateAddrs
+	if addrs.PublicAddr.IsValid() {
+		candidates = append([]*sonm.Addr{addrs.PublicAddr}, candidates...)
+	}
+
+	type attempt struct {
+		report *sonm.NPPPunchAttempt
+		conn   net.Conn
+	}
+
+	timer := startTimer()
+	pending := make(chan attempt, len(candidates))
+	wg := sync.WaitGroup{}
+	wg.Add(len(candidates))
+
+	for _, addr := range candidates {
+		go func(addr *sonm.Addr) {
+			defer wg.Done()
+
+			report := &sonm.NPPPunchAttempt{Addr: addr.String()}
+			if tcpAddr, err := addr.IntoTCP(); err == nil {
+				report.Addr = tcpAddr.String()
+			}
+
+			attemptTimer := startTimer()
+			conn, err := m.punchAddr(ctx, addr)
+			report.Duration = attemptTimer.Elapsed()
+			if err != nil {
+				report.Error = err.Error()
+			}
+
+			pending <- attempt{report: report, conn: conn}
+		}(addr)
+	}
+
+	go func() {
+		wg.Wait()
+		close(pending)
+	}()
+
+	var peer net.Conn
+	keep := func(conn net.Conn, remoteAddr string) {
+		if peer == nil {
+			peer = conn
+			stage.RemoteAddr = remoteAddr
+		} else {
+			conn.Close()
+		}
+	}
+
+	for pending != nil {
+		select {
+		case attempt, ok := <-pending:
+			if !ok {
+				pending = nil
+				continue
+			}
+
+			stage.Attempts = append(stage.Attempts, attempt.report)
+			if attempt.conn != nil {
+				keep(attempt.conn, attempt.report.Addr)
+			}
+		case conn := <-m.listenerChannel:
+			report := &sonm.NPPPunchAttempt{Duration: timer.Elapsed(), Incoming: true}
+			if conn.Error() != nil {
+				report.Error = conn.Error().Error()
+			} else {
+				report.Addr = conn.RemoteAddr().String()
+				keep(conn.conn, report.Addr)
+			}
+
+			stage.Attempts = append(stage.Attempts, report)
+		}
+	}
+
+	stage.Duration = timer.Elapsed()
+
+	if peer == nil {
+		stage.Error = "all attempts have failed"
+	}
+
+	m.log.Debug("finished NPP diagnostics", zap.Any("stage", *stage))
+
+	return peer
+}
+
+func (m *Dialer) diagnoseRelay(ctx context.Context, addr common.Address) (*sonm.NPPStageReport, net.Conn) {
+	stage := &sonm.NPPStageReport{Name: StageRelay}
+
+	if m.relayDialer == nil {
+		stage.Error = "relay is not configured"
+		return stage, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, diagnosticsTimeout)
+	defer cancel()
+
+	timer := startTimer()
+	channel := make(chan connTuple, 1)
+	go func() {
+		channel <- newConnTuple(m.relayDialer.Dial(addr))
+	}()
+
+	select {
+	case conn := <-channel:
+		stage.Duration = timer.Elapsed()
+		if conn.Error() != nil {
+			stage.Error = conn.Error().Error()
+			return stage, nil
+		}
+
+		// After the discovery the connection is established with the Relay
+		// member the meeting is on.
+		stage.Server = conn.RemoteAddr().String()
+		stage.RemoteAddr = addr.Hex()
+
+		return stage, conn.conn
+	case <-ctx.Done():
+		stage.Duration = timer.Elapsed()
+		stage.Error = ctx.Err().Error()
+
+		// The dialer will eventually give up, but its connection must be
+		// closed anyway.
+		go func() {
+			if conn := <-channel; conn.Error() == nil {
+				conn.Close()
+			}
+		}()
+
+		return stage, nil
+	}
+}
+
+type stopwatch struct {
+	startedAt time.Time
+}
+
+func startTimer() stopwatch {
+	return stopwatch{startedAt: time.Now()}
+}
+
+func (m stopwatch) Elapsed() *sonm.Duration {
+	return &sonm.Duration{Nanoseconds: int64(time.Since(m.startedAt))}
+}
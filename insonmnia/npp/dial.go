@@ -4,14 +4,26 @@ package npp
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/insonmnia/npp/relay"
+	"github.com/sonm-io/core/util/multierror"
 	"go.uber.org/zap"
 )
 
+const (
+	// directFallbackDelay specifies how long direct TCP connection is
+	// waited for before NPP is tried in parallel.
+	directFallbackDelay = 300 * time.Millisecond
+	// nppTimeout specifies how long NPP is waited for before Relay is tried
+	// in parallel.
+	nppTimeout = 5 * time.Second
+)
+
 // Dialer represents an NPP dialer.
 //
 // This structure acts like an usual dialer with an exception that the address
@@ -20,8 +32,10 @@ import (
 type Dialer struct {
 	log *zap.Logger
 
-	puncherNew  func(ctx context.Context) (NATPuncher, error)
-	relayDialer *relay.Dialer
+	puncherNew    func(ctx context.Context) (NATPuncher, error)
+	udpPuncherNew func(ctx context.Context) (*udpPuncher, error)
+	relayDialer   *relay.Dialer
+	pool          *sessionPool
 }
 
 // NewDialer constructs a new dialer that is aware of NAT Punching Protocol.
@@ -34,11 +48,18 @@ func NewDialer(options ...Option) (*Dialer, error) {
 		}
 	}
 
-	return &Dialer{
-		log:         opts.log,
-		puncherNew:  opts.puncherNew,
-		relayDialer: opts.relayDialer,
-	}, nil
+	m := &Dialer{
+		log:           opts.log,
+		puncherNew:    opts.puncherNew,
+		udpPuncherNew: opts.udpPuncherNew,
+		relayDialer:   opts.relayDialer,
+	}
+
+	if opts.mux != nil {
+		m.pool = newSessionPool(*opts.mux, m.dialContext, opts.log)
+	}
+
+	return m, nil
 }
 
 // Dial dials the given verified address using NPP.
@@ -53,105 +74,300 @@ func (m *Dialer) Dial(addr auth.Addr) (net.Conn, error) {
 // the connection is complete, an error is returned. Once successfully
 // connected, any expiration of the context will not affect the
 // connection.
+//
+// With multiplexing activated the returned connection is a stream over the
+// session shared with other connections to the same peer.
 func (m *Dialer) DialContext(ctx context.Context, addr auth.Addr) (net.Conn, error) {
+	if m.pool != nil {
+		return m.pool.DialContext(ctx, addr)
+	}
+
+	return m.dialContext(ctx, addr)
+}
+
+// dialStage describes a single connection establishment method.
+type dialStage struct {
+	name string
+	// fallbackDelay specifies how long to wait for the stage before starting
+	// the next one in parallel. The next stage is started immediately when
+	// this one fails.
+	fallbackDelay time.Duration
+	dial          func(ctx context.Context) (net.Conn, error)
+}
+
+// dialContext connects to the given address racing stages in the
+// Happy Eyeballs manner, see raceStages.
+func (m *Dialer) dialContext(ctx context.Context, addr auth.Addr) (net.Conn, error) {
 	log := m.log.With(zap.Stringer("remote_addr", addr))
 	log.Debug("connecting to remote peer")
 
-	if conn := m.dialDirect(ctx, addr); conn != nil {
-		return conn, nil
+	stages, err := m.dialStages(addr)
+	if err != nil {
+		return nil, err
 	}
 
-	ethAddr, err := addr.ETH()
+	conn, err := raceStages(ctx, stages, log)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to connect to %s: %v", addr.String(), err)
 	}
 
-	timeout := 5 * time.Second
-	log.Debug("connecting using NPP", zap.Duration("timeout", timeout))
+	return conn, nil
+}
 
-	if m.puncherNew != nil {
-		nppCtx, cancel := context.WithTimeout(ctx, timeout)
-		defer cancel()
+// raceStages establishes a connection racing the given stages.
+//
+// Stages are started in order of preference: direct TCP, NPP and Relay,
+// each after the previous one fails or its fallback delay expires. The first
+// established connection wins, while others are closed. This way a slow, but
+// still working direct path does not delay fallbacks much, while cheap
+// methods are still preferred over the expensive ones.
+func raceStages(ctx context.Context, stages []dialStage, log *zap.Logger) (net.Conn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		connTuple
+		stage dialStage
+	}
+
+	results := make(chan result, len(stages))
+	numPending := 0
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	startNext := func() {
+		if len(stages) == 0 {
+			return
+		}
 
-		nppChannel := make(chan connTuple)
+		stage := stages[0]
+		stages = stages[1:]
+		numPending++
 
+		log.Debug("connecting using "+stage.name, zap.Duration("fallback_delay", stage.fallbackDelay))
 		go func() {
-			puncher, err := m.puncherNew(nppCtx)
-			if err != nil {
-				nppChannel <- newConnTuple(nil, err)
-				return
+			results <- result{connTuple: newConnTuple(stage.dial(ctx)), stage: stage}
+		}()
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
 			}
-			defer puncher.Close()
+		}
+		timer.Reset(stage.fallbackDelay)
+	}
 
-			nppChannel <- newConnTuple(puncher.Dial(ethAddr))
-		}()
+	startNext()
 
+	errs := multierror.NewMultiError()
+	for numPending > 0 {
 		select {
-		case conn := <-nppChannel:
-			err := conn.Error()
-			if err == nil {
-				log.Debug("successfully connected using NPP", zap.Stringer("remote_peer", conn.RemoteAddr()))
-				return conn.unwrap()
+		case <-timer.C:
+			startNext()
+		case conn := <-results:
+			numPending--
+
+			if err := conn.Error(); err != nil {
+				log.Warn("failed to connect using "+conn.stage.name, zap.Error(err))
+				errs = multierror.AppendUnique(errs, fmt.Errorf("%s: %v", conn.stage.name, err))
+				startNext()
+				continue
 			}
 
-			log.Warn("failed to connect using NPP", zap.Error(err))
+			log.Debug("successfully connected using "+conn.stage.name, zap.Stringer("remote_peer", conn.RemoteAddr()))
 
-			if m.relayDialer == nil {
-				log.Debug("no relay configured - returning error", zap.Error(err))
-				return conn.unwrap()
-			}
-		case <-nppCtx.Done():
-			log.Warn("failed to connect using NPP", zap.Error(nppCtx.Err()))
+			// Late winners must be closed.
+			go func(numPending int) {
+				for id := 0; id < numPending; id++ {
+					if conn := <-results; conn.Error() == nil {
+						conn.Close()
+					}
+				}
+			}(numPending)
+
+			return conn.unwrap()
 		}
 	}
 
-	log.Debug("connecting using Relay")
-	channel := make(chan connTuple)
+	return nil, errs.ErrorOrNil()
+}
+
+func (m *Dialer) dialStages(addr auth.Addr) ([]dialStage, error) {
+	var stages []dialStage
+
+	if netAddr, err := addr.Addr(); err == nil {
+		stages = append(stages, dialStage{
+			name:          "direct TCP",
+			fallbackDelay: directFallbackDelay,
+			dial: func(ctx context.Context) (net.Conn, error) {
+				dialer := net.Dialer{}
+				return dialer.DialContext(ctx, "tcp", netAddr)
+			},
+		})
+	}
+
+	ethAddr, err := addr.ETH()
+	if err != nil {
+		if len(stages) == 0 {
+			return nil, err
+		}
+
+		return stages, nil
+	}
+
+	if m.puncherNew != nil {
+		stages = append(stages, dialStage{
+			name:          "NPP",
+			fallbackDelay: nppTimeout,
+			dial: func(ctx context.Context) (net.Conn, error) {
+				return m.dialPuncher(ctx, ethAddr)
+			},
+		})
+	}
+
+	if m.relayDialer != nil {
+		stages = append(stages, dialStage{
+			name: "Relay",
+			dial: func(ctx context.Context) (net.Conn, error) {
+				return m.dialRelay(ctx, ethAddr)
+			},
+		})
+	}
+
+	if len(stages) == 0 {
+		return nil, fmt.Errorf("neither direct address, nor rendezvous or relay is available")
+	}
+
+	return stages, nil
+}
+
+func (m *Dialer) dialPuncher(ctx context.Context, addr common.Address) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, nppTimeout)
+	defer cancel()
+
+	channel := make(chan connTuple, 1)
 	go func() {
-		channel <- newConnTuple(m.relayDialer.Dial(ethAddr))
+		puncher, err := m.puncherNew(ctx)
+		if err != nil {
+			channel <- newConnTuple(nil, err)
+			return
+		}
+		defer puncher.Close()
+
+		channel <- newConnTuple(puncher.DialContext(ctx, addr))
 	}()
 
 	select {
 	case conn := <-channel:
-		err := conn.Error()
-		if err == nil {
-			log.Debug("successfully connected using Relay", zap.Stringer("remote_peer", conn.RemoteAddr()))
-		} else {
-			log.Warn("failed to connect using Relay", zap.Error(err))
-		}
+		return conn.unwrap()
+	case <-ctx.Done():
+		go func() {
+			if conn := <-channel; conn.Error() == nil {
+				conn.Close()
+			}
+		}()
+
+		return nil, ctx.Err()
+	}
+}
 
+func (m *Dialer) dialRelay(ctx context.Context, addr common.Address) (net.Conn, error) {
+	channel := make(chan connTuple, 1)
+	go func() {
+		channel <- newConnTuple(m.relayDialer.Dial(addr))
+	}()
+
+	select {
+	case conn := <-channel:
 		return conn.unwrap()
 	case <-ctx.Done():
-		log.Warn("failed to connect using Relay", zap.Error(ctx.Err()))
+		go func() {
+			if conn := <-channel; conn.Error() == nil {
+				conn.Close()
+			}
+		}()
+
 		return nil, ctx.Err()
 	}
 }
 
-// Note, that this method acts as an optimization.
-func (m *Dialer) dialDirect(ctx context.Context, addr auth.Addr) net.Conn {
+// DialUDPContext connects to the given verified address using UDP hole
+// punching, falling back to the Relay if configured.
+//
+// The returned connection is datagram-oriented, i.e. each Write sends
+// exactly one datagram and each Read receives exactly one datagram. There is
+// no direct UDP connection attempt, because unlike TCP there is no way to
+// check whether the remote peer is actually listening.
+func (m *Dialer) DialUDPContext(ctx context.Context, addr auth.Addr) (net.Conn, error) {
 	log := m.log.With(zap.Stringer("remote_addr", addr))
-	log.Debug("connecting using direct TCP")
 
-	netAddr, err := addr.Addr()
+	ethAddr, err := addr.ETH()
 	if err != nil {
-		log.Debug("failed to connect using direct TCP", zap.Error(err))
-		return nil
+		return nil, err
 	}
 
-	dial := net.Dialer{}
-	conn, err := dial.DialContext(ctx, "tcp", netAddr)
+	if m.udpPuncherNew != nil {
+		timeout := 5 * time.Second
+		log.Debug("connecting using UDP NPP", zap.Duration("timeout", timeout))
+
+		conn, err := m.dialUDPPuncher(ctx, ethAddr, timeout)
+		if err == nil {
+			log.Debug("successfully connected using UDP NPP", zap.Stringer("remote_peer", conn.RemoteAddr()))
+			return conn, nil
+		}
+
+		log.Warn("failed to connect using UDP NPP", zap.Error(err))
+
+		if m.relayDialer == nil {
+			return nil, err
+		}
+	}
+
+	if m.relayDialer == nil {
+		return nil, fmt.Errorf("neither rendezvous nor relay configured")
+	}
+
+	log.Debug("connecting using UDP Relay")
+	channel := make(chan connTuple)
+	go func() {
+		channel <- newConnTuple(m.relayDialer.DialUDP(ethAddr))
+	}()
+
+	select {
+	case conn := <-channel:
+		if err := conn.Error(); err != nil {
+			log.Warn("failed to connect using UDP Relay", zap.Error(err))
+		}
+
+		return conn.unwrap()
+	case <-ctx.Done():
+		log.Warn("failed to connect using UDP Relay", zap.Error(ctx.Err()))
+		return nil, ctx.Err()
+	}
+}
+
+func (m *Dialer) dialUDPPuncher(ctx context.Context, addr common.Address, timeout time.Duration) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	puncher, err := m.udpPuncherNew(ctx)
 	if err != nil {
-		log.Debug("failed to connect using direct TCP", zap.Error(err))
-		return nil
+		return nil, err
 	}
+	defer puncher.Close()
 
-	log.Debug("successfully connected using direct TCP")
-	return conn
+	return puncher.DialContext(ctx, addr)
 }
 
 // Close closes the dialer.
 //
 // Any blocked operations will be unblocked and return errors.
 func (m *Dialer) Close() error {
+	if m.pool != nil {
+		return m.pool.Close()
+	}
+
 	return nil
 }
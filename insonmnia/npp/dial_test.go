@@ -0,0 +1,160 @@
+package npp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/npp/relay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// closeNotifyConn notifies when the connection is closed.
+type closeNotifyConn struct {
+	net.Conn
+	closed chan struct{}
+}
+
+func newCloseNotifyConn() *closeNotifyConn {
+	conn, _ := net.Pipe()
+	return &closeNotifyConn{
+		Conn:   conn,
+		closed: make(chan struct{}),
+	}
+}
+
+func (m *closeNotifyConn) Close() error {
+	close(m.closed)
+	return m.Conn.Close()
+}
+
+func newTestStage(name string, fallbackDelay time.Duration, dial func(ctx context.Context) (net.Conn, error)) dialStage {
+	return dialStage{
+		name:          name,
+		fallbackDelay: fallbackDelay,
+		dial:          dial,
+	}
+}
+
+func TestRaceStagesFallbackWins(t *testing.T) {
+	slowConn := newCloseNotifyConn()
+	fastConn := newCloseNotifyConn()
+	release := make(chan struct{})
+
+	stages := []dialStage{
+		// Ignores cancellation, succeeding after the fallback has won.
+		newTestStage("slow", 10*time.Millisecond, func(ctx context.Context) (net.Conn, error) {
+			<-release
+			return slowConn, nil
+		}),
+		newTestStage("fast", time.Hour, func(ctx context.Context) (net.Conn, error) {
+			return fastConn, nil
+		}),
+	}
+
+	conn, err := raceStages(context.Background(), stages, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, fastConn, conn)
+
+	close(release)
+
+	select {
+	case <-slowConn.closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the late winner has not been closed")
+	}
+
+	select {
+	case <-fastConn.closed:
+		t.Fatal("the winner must not be closed")
+	default:
+	}
+}
+
+func TestRaceStagesFailureStartsNextImmediately(t *testing.T) {
+	expected := newCloseNotifyConn()
+
+	stages := []dialStage{
+		newTestStage("failing", time.Hour, func(ctx context.Context) (net.Conn, error) {
+			return nil, errors.New("unreachable")
+		}),
+		newTestStage("working", time.Hour, func(ctx context.Context) (net.Conn, error) {
+			return expected, nil
+		}),
+	}
+
+	startedAt := time.Now()
+	conn, err := raceStages(context.Background(), stages, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, expected, conn)
+	assert.True(t, time.Since(startedAt) < time.Minute)
+}
+
+func TestRaceStagesPreferredStageWins(t *testing.T) {
+	preferred := newCloseNotifyConn()
+	started := make(chan struct{}, 1)
+
+	stages := []dialStage{
+		newTestStage("preferred", time.Hour, func(ctx context.Context) (net.Conn, error) {
+			return preferred, nil
+		}),
+		newTestStage("fallback", time.Hour, func(ctx context.Context) (net.Conn, error) {
+			started <- struct{}{}
+			return nil, errors.New("must not be called")
+		}),
+	}
+
+	conn, err := raceStages(context.Background(), stages, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, preferred, conn)
+	assert.Len(t, started, 0)
+}
+
+func TestRaceStagesAllFail(t *testing.T) {
+	stages := []dialStage{
+		newTestStage("first", time.Hour, func(ctx context.Context) (net.Conn, error) {
+			return nil, errors.New("first failed")
+		}),
+		newTestStage("second", 0, func(ctx context.Context) (net.Conn, error) {
+			return nil, errors.New("second failed")
+		}),
+	}
+
+	_, err := raceStages(context.Background(), stages, zap.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "first failed")
+	assert.Contains(t, err.Error(), "second failed")
+}
+
+func TestDialStagesOrder(t *testing.T) {
+	dialer := &Dialer{
+		log: zap.NewNop(),
+		puncherNew: func(ctx context.Context) (NATPuncher, error) {
+			return nil, errors.New("not implemented")
+		},
+		relayDialer: &relay.Dialer{},
+	}
+
+	addr, err := auth.NewAddr("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD@127.0.0.1:10000")
+	require.NoError(t, err)
+
+	stages, err := dialer.dialStages(*addr)
+	require.NoError(t, err)
+	require.Len(t, stages, 3)
+	assert.Equal(t, "direct TCP", stages[0].name)
+	assert.Equal(t, "NPP", stages[1].name)
+	assert.Equal(t, "Relay", stages[2].name)
+
+	addr, err = auth.NewAddr("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	require.NoError(t, err)
+
+	stages, err = dialer.dialStages(*addr)
+	require.NoError(t, err)
+	require.Len(t, stages, 2)
+	assert.Equal(t, "NPP", stages[0].name)
+}
@@ -9,10 +9,17 @@ import (
 	"net"
 	"time"
 
+	"github.com/sonm-io/core/insonmnia/npp/mux"
 	"github.com/sonm-io/core/insonmnia/npp/relay"
 	"go.uber.org/zap"
 )
 
+const (
+	// detectTimeout specifies how long to wait for the first bytes from the
+	// peer to detect whether it starts a multiplexed session.
+	detectTimeout = 30 * time.Second
+)
+
 type connSource int
 
 func (m connSource) String() string {
@@ -99,6 +106,10 @@ type Listener struct {
 	relayListener *relay.Listener
 	relayChannel  chan connTuple
 
+	// acceptedChannel contains connections ready to be accepted, i.e.
+	// either plain connections or streams of multiplexed sessions.
+	acceptedChannel chan connTuple
+
 	minBackoffInterval time.Duration
 	maxBackoffInterval time.Duration
 }
@@ -137,6 +148,8 @@ func NewListener(ctx context.Context, addr string, options ...Option) (*Listener
 		relayListener: opts.relayListener,
 		relayChannel:  make(chan connTuple, opts.nppBacklog),
 
+		acceptedChannel: make(chan connTuple, opts.nppBacklog),
+
 		minBackoffInterval: opts.nppMinBackoffInterval,
 		maxBackoffInterval: opts.nppMaxBackoffInterval,
 	}
@@ -144,6 +157,7 @@ func NewListener(ctx context.Context, addr string, options ...Option) (*Listener
 	go m.listen(ctx)
 	go m.listenPuncher(ctx)
 	go m.listenRelay(ctx)
+	go m.serve(ctx)
 
 	return m, nil
 }
@@ -249,31 +263,98 @@ func (m *Listener) listenRelay(ctx context.Context) error {
 // Simultaneously additional sockets are constructed after resolution to make
 // punching mechanism work. This can consume a meaningful amount of file
 // descriptors, so be prepared to enlarge your limits.
+//
+// Peers may start a multiplexed session over the connection established, in
+// this case each of its streams is accepted as a separate connection.
 func (m *Listener) Accept() (net.Conn, error) {
 	return m.AcceptContext(m.ctx)
 }
 
 func (m *Listener) AcceptContext(ctx context.Context) (net.Conn, error) {
-	conn, source, err := m.accept(ctx)
+	select {
+	case conn := <-m.acceptedChannel:
+		return conn.unwrap()
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// serve accepts peers, detecting whether they start multiplexed sessions.
+func (m *Listener) serve(ctx context.Context) {
+	for {
+		conn, source, err := m.accept(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			m.log.Warn("failed to accept peer", zap.Error(err))
+
+			select {
+			case m.acceptedChannel <- newConnTuple(nil, err):
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		m.log.Info("accepted peer", zap.Stringer("source", source), zap.Stringer("remote", conn.RemoteAddr()))
+
+		switch source {
+		case sourceDirectConnection:
+			m.metrics.NumConnectionsDirect.Inc()
+		case sourceNPPConnection:
+			m.metrics.NumConnectionsNAT.Inc()
+		case sourceRelayedConnection:
+			m.metrics.NumConnectionsRelay.Inc()
+		}
+
+		go m.detect(ctx, conn)
+	}
+}
+
+func (m *Listener) detect(ctx context.Context, conn net.Conn) {
+	log := m.log.With(zap.Stringer("remote", conn.RemoteAddr()))
+
+	session, plainConn, err := mux.Detect(conn, detectTimeout)
 	if err != nil {
-		m.log.Warn("failed to accept peer", zap.Error(err))
-		return nil, err
+		log.Warn("failed to detect connection protocol", zap.Error(err))
+		conn.Close()
+		return
 	}
 
-	m.log.Info("accepted peer", zap.Stringer("source", source), zap.Stringer("remote", conn.RemoteAddr()))
+	if session == nil {
+		m.push(ctx, plainConn)
+		return
+	}
 
-	switch source {
-	case sourceDirectConnection:
-		m.metrics.NumConnectionsDirect.Inc()
-	case sourceNPPConnection:
-		m.metrics.NumConnectionsNAT.Inc()
-	case sourceRelayedConnection:
-		m.metrics.NumConnectionsRelay.Inc()
-	default:
-		return nil, fmt.Errorf("unknown connection source")
+	log.Info("accepted multiplexed session")
+	defer log.Info("multiplexed session has been closed")
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.Close()
+		case <-session.Done():
+		}
+	}()
+
+	for {
+		stream, err := session.AcceptStream()
+		if err != nil {
+			return
+		}
+
+		m.push(ctx, stream)
 	}
+}
 
-	return conn, nil
+func (m *Listener) push(ctx context.Context, conn net.Conn) {
+	select {
+	case m.acceptedChannel <- newConnTuple(conn, nil):
+	case <-ctx.Done():
+		conn.Close()
+	}
 }
 
 // Note: this function only listens for multiple channels and transforms the
@@ -0,0 +1,187 @@
+package npp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/npp/relay"
+	"go.uber.org/zap"
+)
+
+// UDPListener accepts datagram-oriented connections from remote peers using
+// UDP hole punching, switching to the Relay when configured.
+//
+// Unlike TCP there is no plain listening socket, because it's impossible to
+// tell apart peers without punching anyway.
+type UDPListener struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    *zap.Logger
+
+	puncher    *udpPuncher
+	puncherNew func(ctx context.Context) (*udpPuncher, error)
+	nppChannel chan connTuple
+
+	relayListener *relay.Listener
+	relayChannel  chan connTuple
+
+	minBackoffInterval time.Duration
+	maxBackoffInterval time.Duration
+}
+
+// NewUDPListener constructs a new UDP NPP listener.
+//
+// At least either rendezvous or relay must be configured.
+func NewUDPListener(ctx context.Context, options ...Option) (*UDPListener, error) {
+	opts := newOptions()
+
+	for _, o := range options {
+		if err := o(opts); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.udpPuncherNew == nil && opts.relayListener == nil {
+		return nil, fmt.Errorf("neither rendezvous nor relay configured")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	m := &UDPListener{
+		ctx:        ctx,
+		cancel:     cancel,
+		log:        opts.log,
+		puncherNew: opts.udpPuncherNew,
+		nppChannel: make(chan connTuple, opts.nppBacklog),
+
+		relayListener: opts.relayListener,
+		relayChannel:  make(chan connTuple, opts.nppBacklog),
+
+		minBackoffInterval: opts.nppMinBackoffInterval,
+		maxBackoffInterval: opts.nppMaxBackoffInterval,
+	}
+
+	go m.listenPuncher(ctx)
+	go m.listenRelay(ctx)
+
+	return m, nil
+}
+
+func (m *UDPListener) listenPuncher(ctx context.Context) error {
+	if m.puncherNew == nil {
+		return nil
+	}
+
+	defer m.log.Info("finished listening UDP NPP")
+	defer func() {
+		if m.puncher != nil {
+			m.puncher.Close()
+		}
+	}()
+
+	timeout := m.minBackoffInterval
+	for {
+		timer := time.NewTimer(timeout)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if m.puncher == nil {
+			puncher, err := m.puncherNew(ctx)
+			if err != nil {
+				m.log.Warn("failed to construct a UDP puncher", zap.Error(err))
+				if timeout < m.maxBackoffInterval {
+					timeout = 2 * timeout
+				}
+				continue
+			}
+
+			m.puncher = puncher
+			timeout = m.minBackoffInterval
+		}
+
+		conn, err := m.puncher.AcceptContext(ctx)
+		if _, ok := err.(*rendezvousError); ok {
+			// In case of any rendezvous errors it's better to reconnect.
+			m.puncher.Close()
+			m.puncher = nil
+			continue
+		}
+
+		m.nppChannel <- newConnTuple(conn, err)
+	}
+}
+
+func (m *UDPListener) listenRelay(ctx context.Context) error {
+	if m.relayListener == nil {
+		return nil
+	}
+
+	defer m.log.Info("finished listening UDP Relay")
+
+	timeout := m.minBackoffInterval
+	for {
+		timer := time.NewTimer(timeout)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		conn, err := m.relayListener.AcceptUDP()
+		if err != nil {
+			m.log.Warn("failed to relay UDP", zap.Error(err))
+			if timeout < m.maxBackoffInterval {
+				timeout = 2 * timeout
+			}
+		} else {
+			timeout = m.minBackoffInterval
+		}
+
+		m.relayChannel <- newConnTuple(conn, newRelayError(err))
+	}
+}
+
+// Accept waits for and returns the next datagram-oriented connection.
+func (m *UDPListener) Accept() (net.Conn, error) {
+	return m.AcceptContext(m.ctx)
+}
+
+func (m *UDPListener) AcceptContext(ctx context.Context) (net.Conn, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case conn := <-m.nppChannel:
+			if conn.Error() != nil {
+				m.log.Warn("failed to accept UDP NPP peer", zap.Error(conn.Error()))
+				continue
+			}
+
+			m.log.Info("accepted UDP peer", zap.Stringer("source", sourceNPPConnection), zap.Stringer("remote", conn.RemoteAddr()))
+			return conn.unwrap()
+		case conn := <-m.relayChannel:
+			if conn.Error() != nil {
+				continue
+			}
+
+			m.log.Info("accepted UDP peer", zap.Stringer("source", sourceRelayedConnection), zap.Stringer("remote", conn.RemoteAddr()))
+			return conn.unwrap()
+		}
+	}
+}
+
+// Close closes the listener.
+//
+// Any blocked operations will be unblocked and return errors.
+func (m *UDPListener) Close() error {
+	m.cancel()
+	return nil
+}
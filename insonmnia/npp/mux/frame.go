@@ -0,0 +1,78 @@
+package mux
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	protocolVersion = 0
+	headerSize      = 12
+)
+
+type frameType uint8
+
+const (
+	// typeData carries stream payload in the frame body.
+	typeData frameType = iota
+	// typeWindowUpdate increases the sender's window by the length field.
+	typeWindowUpdate
+	// typePing carries an opaque ping identifier in the length field.
+	typePing
+	// typeGoAway notifies the remote side about the session termination.
+	typeGoAway
+)
+
+const (
+	flagSYN uint16 = 1 << iota
+	flagACK
+	flagFIN
+	flagRST
+)
+
+// header describes a single frame header.
+//
+// Wire format: version(1) | type(1) | flags(2) | stream ID(4) | length(4),
+// all integers are big-endian.
+type header struct {
+	Type     frameType
+	Flags    uint16
+	StreamID uint32
+	Length   uint32
+}
+
+func (m header) Has(flag uint16) bool {
+	return m.Flags&flag == flag
+}
+
+func (m header) encode(buf []byte) {
+	buf[0] = protocolVersion
+	buf[1] = uint8(m.Type)
+	binary.BigEndian.PutUint16(buf[2:4], m.Flags)
+	binary.BigEndian.PutUint32(buf[4:8], m.StreamID)
+	binary.BigEndian.PutUint32(buf[8:12], m.Length)
+}
+
+func readHeader(rd io.Reader, buf []byte) (header, error) {
+	if _, err := io.ReadFull(rd, buf[:headerSize]); err != nil {
+		return header{}, err
+	}
+
+	if buf[0] != protocolVersion {
+		return header{}, fmt.Errorf("unsupported protocol version: %d", buf[0])
+	}
+
+	hdr := header{
+		Type:     frameType(buf[1]),
+		Flags:    binary.BigEndian.Uint16(buf[2:4]),
+		StreamID: binary.BigEndian.Uint32(buf[4:8]),
+		Length:   binary.BigEndian.Uint32(buf[8:12]),
+	}
+
+	if hdr.Type > typeGoAway {
+		return header{}, fmt.Errorf("unknown frame type: %d", hdr.Type)
+	}
+
+	return hdr, nil
+}
@@ -0,0 +1,490 @@
+// Package mux implements stream multiplexing over a single reliable
+// connection.
+//
+// The protocol is similar to yamux: every frame has a fixed 12-byte header
+// followed by an optional body. Streams are opened implicitly by sending the
+// SYN flag and have credit-based flow control, so a single slow reader can
+// not block the entire session.
+//
+// This allows to establish a single NPP connection with the remote peer,
+// which is expensive in case of punching or relaying, and reuse it for
+// multiple independent logical connections.
+
+package mux
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	// initialWindowSize is the receive window size every stream starts with.
+	initialWindowSize = 256 * 1024
+	// maxFrameSize limits data frame body size to keep the session fair.
+	maxFrameSize = 32 * 1024
+	// acceptBacklog is the number of incoming streams pending acceptance.
+	acceptBacklog = 256
+
+	handshakeTimeout = 10 * time.Second
+)
+
+// magic is sent by the client right after the connection is established and
+// echoed back by the server to confirm that multiplexing is supported.
+//
+// Its first byte must not collide with neither TLS record types nor the
+// HTTP/2 preface, which allows servers to detect multiplexed sessions.
+var magic = []byte("SONMMUX\x01")
+
+var (
+	ErrUnsupported   = errors.New("remote peer does not support multiplexing")
+	ErrSessionClosed = errors.New("session is closed")
+	ErrStreamClosed  = errors.New("stream is closed")
+	ErrStreamReset   = errors.New("stream is reset by the remote peer")
+)
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+var errTimeout net.Error = timeoutError{}
+
+// Session represents a multiplexed connection.
+type Session struct {
+	conn net.Conn
+	rd   *bufio.Reader
+
+	mu           sync.Mutex
+	streams      map[uint32]*Stream
+	nextStreamID uint32
+	idleSince    time.Time
+	pings        map[uint32]chan struct{}
+	nextPingID   uint32
+
+	acceptChannel chan *Stream
+
+	writeMu sync.Mutex
+
+	closeErr error
+	closed   chan struct{}
+}
+
+// Client performs client-side handshake over the given connection and
+// constructs a new session.
+//
+// ErrUnsupported is returned if the remote peer does not acknowledge the
+// handshake. The connection is closed on any error.
+func Client(conn net.Conn) (*Session, error) {
+	if err := clientHandshake(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return newSession(conn, bufio.NewReader(conn), true), nil
+}
+
+func clientHandshake(conn net.Conn) error {
+	conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	if _, err := conn.Write(magic); err != nil {
+		return err
+	}
+
+	// Peers without multiplexing support either reply with garbage, close
+	// or reset the connection or just wait for more data, so any read
+	// failure is treated the same.
+	buf := make([]byte, len(magic))
+	if _, err := io.ReadFull(conn, buf); err != nil || string(buf) != string(magic) {
+		return ErrUnsupported
+	}
+
+	return nil
+}
+
+// Detect checks whether the given incoming connection starts a multiplexed
+// session, completing the handshake if so.
+//
+// Otherwise the returned connection must be used instead of the original
+// one, because some data may already be read from the latter.
+func Detect(conn net.Conn, timeout time.Duration) (*Session, net.Conn, error) {
+	rd := bufio.NewReader(conn)
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	// Peeking a single byte first is important, because non-multiplexed
+	// peers may send less than the magic length before waiting for reply.
+	head, err := rd.Peek(1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if head[0] != magic[0] {
+		return nil, &bufferedConn{Conn: conn, rd: rd}, nil
+	}
+
+	head, err = rd.Peek(len(magic))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if string(head) != string(magic) {
+		return nil, &bufferedConn{Conn: conn, rd: rd}, nil
+	}
+
+	rd.Discard(len(magic))
+
+	conn.SetWriteDeadline(time.Now().Add(timeout))
+	defer conn.SetWriteDeadline(time.Time{})
+
+	if _, err := conn.Write(magic); err != nil {
+		return nil, nil, err
+	}
+
+	return newSession(conn, rd, false), nil, nil
+}
+
+func newSession(conn net.Conn, rd *bufio.Reader, client bool) *Session {
+	m := &Session{
+		conn:          conn,
+		rd:            rd,
+		streams:       map[uint32]*Stream{},
+		idleSince:     time.Now(),
+		pings:         map[uint32]chan struct{}{},
+		acceptChannel: make(chan *Stream, acceptBacklog),
+		closed:        make(chan struct{}),
+	}
+
+	// Clients use odd stream IDs, while servers use even ones to avoid
+	// collisions.
+	if client {
+		m.nextStreamID = 1
+	} else {
+		m.nextStreamID = 2
+	}
+
+	go m.recvLoop()
+
+	return m
+}
+
+// OpenStream opens a new logical stream.
+func (m *Session) OpenStream() (*Stream, error) {
+	m.mu.Lock()
+	if m.IsClosed() {
+		m.mu.Unlock()
+		return nil, ErrSessionClosed
+	}
+
+	id := m.nextStreamID
+	m.nextStreamID += 2
+	stream := newStream(m, id)
+	m.streams[id] = stream
+	m.mu.Unlock()
+
+	if err := m.writeFrame(header{Type: typeWindowUpdate, Flags: flagSYN, StreamID: id}, nil); err != nil {
+		m.removeStream(id)
+		return nil, err
+	}
+
+	return stream, nil
+}
+
+// AcceptStream waits for and returns the next stream opened by the remote
+// peer.
+func (m *Session) AcceptStream() (*Stream, error) {
+	select {
+	case stream := <-m.acceptChannel:
+		return stream, nil
+	case <-m.closed:
+		return nil, m.err()
+	}
+}
+
+// Ping measures the round-trip time of the session.
+func (m *Session) Ping(ctx context.Context) (time.Duration, error) {
+	channel := make(chan struct{})
+
+	m.mu.Lock()
+	id := m.nextPingID
+	m.nextPingID++
+	m.pings[id] = channel
+	m.mu.Unlock()
+
+	defer func() {
+		m.mu.Lock()
+		delete(m.pings, id)
+		m.mu.Unlock()
+	}()
+
+	startedAt := time.Now()
+	if err := m.writeFrame(header{Type: typePing, Flags: flagSYN, Length: id}, nil); err != nil {
+		return 0, err
+	}
+
+	select {
+	case <-channel:
+		return time.Since(startedAt), nil
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-m.closed:
+		return 0, m.err()
+	}
+}
+
+// NumStreams returns the number of currently active streams.
+func (m *Session) NumStreams() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.streams)
+}
+
+// IdleSince returns the time the session has no active streams since. Zero
+// time is returned if there are active streams.
+func (m *Session) IdleSince() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.streams) != 0 {
+		return time.Time{}
+	}
+
+	return m.idleSince
+}
+
+func (m *Session) LocalAddr() net.Addr {
+	return m.conn.LocalAddr()
+}
+
+func (m *Session) RemoteAddr() net.Addr {
+	return m.conn.RemoteAddr()
+}
+
+// Done returns a channel that is closed when the session is closed.
+func (m *Session) Done() <-chan struct{} {
+	return m.closed
+}
+
+func (m *Session) IsClosed() bool {
+	select {
+	case <-m.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the session and all of its streams.
+func (m *Session) Close() error {
+	if m.IsClosed() {
+		return nil
+	}
+
+	m.writeFrame(header{Type: typeGoAway}, nil)
+
+	return m.closeWithError(ErrSessionClosed)
+}
+
+// CloseIfIdle atomically closes the session if it has no active streams
+// for at least the given duration, returning true if so.
+func (m *Session) CloseIfIdle(timeout time.Duration) bool {
+	m.mu.Lock()
+	idle := len(m.streams) == 0 && time.Since(m.idleSince) >= timeout
+	closed := idle && m.markClosed(ErrSessionClosed)
+	m.mu.Unlock()
+
+	if closed {
+		m.conn.Close()
+	}
+
+	return closed
+}
+
+func (m *Session) closeWithError(err error) error {
+	m.mu.Lock()
+	closed := m.markClosed(err)
+	m.mu.Unlock()
+
+	if !closed {
+		return nil
+	}
+
+	return m.conn.Close()
+}
+
+// markClosed marks the session as closed, returning false if it was already
+// closed.
+//
+// Must be called with the lock held.
+func (m *Session) markClosed(err error) bool {
+	if m.IsClosed() {
+		return false
+	}
+
+	m.closeErr = err
+	close(m.closed)
+
+	return true
+}
+
+func (m *Session) err() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.closeErr
+}
+
+func (m *Session) writeFrame(hdr header, body []byte) error {
+	buf := make([]byte, headerSize+len(body))
+	hdr.Length = hdr.Length + uint32(len(body))
+	hdr.encode(buf)
+	copy(buf[headerSize:], body)
+
+	m.writeMu.Lock()
+	defer m.writeMu.Unlock()
+
+	if m.IsClosed() {
+		return m.err()
+	}
+
+	if _, err := m.conn.Write(buf); err != nil {
+		m.closeWithError(err)
+		return err
+	}
+
+	return nil
+}
+
+func (m *Session) removeStream(id uint32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.streams[id]; !ok {
+		return
+	}
+
+	delete(m.streams, id)
+	if len(m.streams) == 0 {
+		m.idleSince = time.Now()
+	}
+}
+
+func (m *Session) recvLoop() {
+	buf := make([]byte, headerSize)
+
+	for {
+		hdr, err := readHeader(m.rd, buf)
+		if err != nil {
+			m.closeWithError(err)
+			return
+		}
+
+		switch hdr.Type {
+		case typeData, typeWindowUpdate:
+			err = m.handleStreamFrame(hdr)
+		case typePing:
+			err = m.handlePing(hdr)
+		case typeGoAway:
+			err = ErrSessionClosed
+		}
+
+		if err != nil {
+			m.closeWithError(err)
+			return
+		}
+	}
+}
+
+func (m *Session) handleStreamFrame(hdr header) error {
+	m.mu.Lock()
+	stream, ok := m.streams[hdr.StreamID]
+	m.mu.Unlock()
+
+	if hdr.Has(flagSYN) {
+		if ok {
+			return fmt.Errorf("duplicate stream %d", hdr.StreamID)
+		}
+
+		stream = newStream(m, hdr.StreamID)
+
+		m.mu.Lock()
+		m.streams[hdr.StreamID] = stream
+		m.mu.Unlock()
+
+		select {
+		case m.acceptChannel <- stream:
+		default:
+			m.removeStream(hdr.StreamID)
+			go m.writeFrame(header{Type: typeWindowUpdate, Flags: flagRST, StreamID: hdr.StreamID}, nil)
+			stream = nil
+		}
+	}
+
+	if stream == nil {
+		// The stream may be already closed locally, ignore its data.
+		if hdr.Type == typeData && hdr.Length > 0 {
+			if _, err := io.CopyN(ioutil.Discard, m.rd, int64(hdr.Length)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	switch hdr.Type {
+	case typeData:
+		if err := stream.recvData(hdr.Length, m.rd); err != nil {
+			return err
+		}
+	case typeWindowUpdate:
+		stream.recvWindowUpdate(hdr.Length)
+	}
+
+	if hdr.Has(flagRST) {
+		stream.recvReset()
+	}
+	if hdr.Has(flagFIN) {
+		stream.recvFIN()
+	}
+
+	return nil
+}
+
+func (m *Session) handlePing(hdr header) error {
+	if hdr.Has(flagSYN) {
+		go m.writeFrame(header{Type: typePing, Flags: flagACK, Length: hdr.Length}, nil)
+		return nil
+	}
+
+	m.mu.Lock()
+	channel, ok := m.pings[hdr.Length]
+	delete(m.pings, hdr.Length)
+	m.mu.Unlock()
+
+	if ok {
+		close(channel)
+	}
+
+	return nil
+}
+
+// bufferedConn is a connection with some data already read into the
+// buffer.
+type bufferedConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+func (m *bufferedConn) Read(b []byte) (int, error) {
+	return m.rd.Read(b)
+}
@@ -0,0 +1,169 @@
+package mux
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSessionPair(t *testing.T) (*Session, *Session) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	type result struct {
+		session *Session
+		err     error
+	}
+
+	channel := make(chan result, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			channel <- result{err: err}
+			return
+		}
+
+		session, _, err := Detect(conn, time.Second)
+		channel <- result{session: session, err: err}
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+
+	client, err := Client(conn)
+	require.NoError(t, err)
+
+	server := <-channel
+	require.NoError(t, server.err)
+	require.NotNil(t, server.session)
+
+	return client, server.session
+}
+
+func TestStreamsTransferMoreThanWindow(t *testing.T) {
+	client, server := newSessionPair(t)
+	defer client.Close()
+	defer server.Close()
+
+	payload := make([]byte, 4*initialWindowSize+17)
+	rand.Read(payload)
+
+	go func() {
+		stream, err := server.AcceptStream()
+		if err != nil {
+			return
+		}
+		defer stream.Close()
+
+		io.Copy(stream, stream)
+	}()
+
+	stream, err := client.OpenStream()
+	require.NoError(t, err)
+
+	go func() {
+		stream.Write(payload)
+		stream.Close()
+	}()
+
+	received, err := ioutil.ReadAll(stream)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(payload, received))
+
+	// Both sides have been closed, so the stream must be released.
+	for id := 0; id < 100 && client.NumStreams() != 0; id++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, 0, client.NumStreams())
+	assert.False(t, client.IdleSince().IsZero())
+}
+
+func TestConcurrentStreamsAreIndependent(t *testing.T) {
+	client, server := newSessionPair(t)
+	defer client.Close()
+	defer server.Close()
+
+	stalled, err := client.OpenStream()
+	require.NoError(t, err)
+
+	// Nobody reads the first stream, so its window is exhausted.
+	_, err = stalled.Write(make([]byte, initialWindowSize))
+	require.NoError(t, err)
+
+	_, err = server.AcceptStream()
+	require.NoError(t, err)
+
+	stream, err := client.OpenStream()
+	require.NoError(t, err)
+	_, err = stream.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	accepted, err := server.AcceptStream()
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(accepted, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	stalled.SetWriteDeadline(time.Now().Add(50 * time.Millisecond))
+	_, err = stalled.Write([]byte("x"))
+	require.Error(t, err)
+	assert.True(t, err.(net.Error).Timeout())
+}
+
+func TestPing(t *testing.T) {
+	client, server := newSessionPair(t)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := client.Ping(ctx)
+	require.NoError(t, err)
+
+	client.Close()
+	<-server.Done()
+
+	_, err = client.OpenStream()
+	assert.Equal(t, ErrSessionClosed, err)
+}
+
+func TestDetectPassesThroughOtherProtocols(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+
+	go clientConn.Write([]byte("PRI * HTTP/2.0"))
+
+	session, conn, err := Detect(serverConn, time.Second)
+	require.NoError(t, err)
+	require.Nil(t, session)
+	defer conn.Close()
+
+	buf := make([]byte, 14)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "PRI * HTTP/2.0", string(buf))
+}
+
+func TestClientHandshakeUnsupported(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+
+	go func() {
+		buf := make([]byte, len(magic))
+		io.ReadFull(serverConn, buf)
+		serverConn.Close()
+	}()
+
+	_, err := Client(clientConn)
+	assert.Equal(t, ErrUnsupported, err)
+}
@@ -0,0 +1,308 @@
+package mux
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// Stream is a logical connection within the session.
+//
+// It implements net.Conn interface with half-close semantics: Close only
+// shuts down the write side, while the stream is released when both sides
+// are closed or it is reset.
+type Stream struct {
+	id      uint32
+	session *Session
+
+	mu sync.Mutex
+	// recvBuf contains data received, but not read yet.
+	recvBuf bytes.Buffer
+	// recvWindow is the number of bytes the remote peer is allowed to send.
+	recvWindow uint32
+	// unacked is the number of bytes read, but not reported to the remote
+	// peer via window update yet.
+	unacked uint32
+	// sendWindow is the number of bytes we are allowed to send.
+	sendWindow    uint32
+	localClosed   bool
+	remoteClosed  bool
+	reset         bool
+	readDeadline  time.Time
+	writeDeadline time.Time
+
+	recvNotify chan struct{}
+	sendNotify chan struct{}
+}
+
+func newStream(session *Session, id uint32) *Stream {
+	return &Stream{
+		id:         id,
+		session:    session,
+		recvWindow: initialWindowSize,
+		sendWindow: initialWindowSize,
+		recvNotify: make(chan struct{}, 1),
+		sendNotify: make(chan struct{}, 1),
+	}
+}
+
+// ID returns the stream identifier, unique within the session.
+func (m *Stream) ID() uint32 {
+	return m.id
+}
+
+func (m *Stream) Read(b []byte) (int, error) {
+	for {
+		m.mu.Lock()
+		if m.recvBuf.Len() > 0 {
+			n, _ := m.recvBuf.Read(b)
+			update := m.consume(uint32(n))
+			m.mu.Unlock()
+
+			if update > 0 {
+				m.session.writeFrame(header{Type: typeWindowUpdate, StreamID: m.id, Length: update}, nil)
+			}
+
+			return n, nil
+		}
+
+		if m.reset {
+			m.mu.Unlock()
+			return 0, ErrStreamReset
+		}
+		if m.remoteClosed {
+			m.mu.Unlock()
+			return 0, io.EOF
+		}
+
+		deadline := m.readDeadline
+		m.mu.Unlock()
+
+		if err := m.wait(m.recvNotify, deadline); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// consume accounts read data, returning the window update size that should
+// be sent to the remote peer, if any.
+//
+// Updates are batched until at least half of the window is consumed to
+// avoid sending a frame for every read.
+//
+// Must be called with the lock held.
+func (m *Stream) consume(n uint32) uint32 {
+	m.unacked += n
+	if m.remoteClosed || m.unacked < initialWindowSize/2 {
+		return 0
+	}
+
+	update := m.unacked
+	m.recvWindow += update
+	m.unacked = 0
+
+	return update
+}
+
+func (m *Stream) Write(b []byte) (int, error) {
+	written := 0
+
+	for written < len(b) {
+		m.mu.Lock()
+		if m.reset {
+			m.mu.Unlock()
+			return written, ErrStreamReset
+		}
+		if m.localClosed {
+			m.mu.Unlock()
+			return written, ErrStreamClosed
+		}
+
+		if m.sendWindow == 0 {
+			deadline := m.writeDeadline
+			m.mu.Unlock()
+
+			if err := m.wait(m.sendNotify, deadline); err != nil {
+				return written, err
+			}
+
+			continue
+		}
+
+		size := uint32(len(b) - written)
+		if size > m.sendWindow {
+			size = m.sendWindow
+		}
+		if size > maxFrameSize {
+			size = maxFrameSize
+		}
+
+		m.sendWindow -= size
+		m.mu.Unlock()
+
+		if err := m.session.writeFrame(header{Type: typeData, StreamID: m.id}, b[written:written+int(size)]); err != nil {
+			return written, err
+		}
+
+		written += int(size)
+	}
+
+	return written, nil
+}
+
+// Close closes the write side of the stream.
+func (m *Stream) Close() error {
+	m.mu.Lock()
+	if m.localClosed || m.reset {
+		m.mu.Unlock()
+		return nil
+	}
+
+	m.localClosed = true
+	release := m.remoteClosed
+	m.mu.Unlock()
+
+	m.notify(m.sendNotify)
+
+	if release {
+		m.session.removeStream(m.id)
+	}
+
+	return m.session.writeFrame(header{Type: typeData, Flags: flagFIN, StreamID: m.id}, nil)
+}
+
+func (m *Stream) LocalAddr() net.Addr {
+	return m.session.LocalAddr()
+}
+
+func (m *Stream) RemoteAddr() net.Addr {
+	return m.session.RemoteAddr()
+}
+
+func (m *Stream) SetDeadline(t time.Time) error {
+	m.mu.Lock()
+	m.readDeadline = t
+	m.writeDeadline = t
+	m.mu.Unlock()
+
+	m.notify(m.recvNotify)
+	m.notify(m.sendNotify)
+	return nil
+}
+
+func (m *Stream) SetReadDeadline(t time.Time) error {
+	m.mu.Lock()
+	m.readDeadline = t
+	m.mu.Unlock()
+
+	m.notify(m.recvNotify)
+	return nil
+}
+
+func (m *Stream) SetWriteDeadline(t time.Time) error {
+	m.mu.Lock()
+	m.writeDeadline = t
+	m.mu.Unlock()
+
+	m.notify(m.sendNotify)
+	return nil
+}
+
+func (m *Stream) recvData(size uint32, rd io.Reader) error {
+	m.mu.Lock()
+	if size > m.recvWindow {
+		m.mu.Unlock()
+		return fmt.Errorf("stream %d has exceeded its receive window: %d > %d", m.id, size, m.recvWindow)
+	}
+
+	m.recvWindow -= size
+	m.mu.Unlock()
+
+	// Reading from the network is done without the lock to not block
+	// readers of already buffered data.
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(rd, buf); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	m.recvBuf.Write(buf)
+	m.mu.Unlock()
+
+	m.notify(m.recvNotify)
+	return nil
+}
+
+func (m *Stream) recvWindowUpdate(delta uint32) {
+	m.mu.Lock()
+	m.sendWindow += delta
+	m.mu.Unlock()
+
+	m.notify(m.sendNotify)
+}
+
+func (m *Stream) recvFIN() {
+	m.mu.Lock()
+	m.remoteClosed = true
+	release := m.localClosed
+	m.mu.Unlock()
+
+	m.notify(m.recvNotify)
+
+	if release {
+		m.session.removeStream(m.id)
+	}
+}
+
+func (m *Stream) recvReset() {
+	m.mu.Lock()
+	m.reset = true
+	m.mu.Unlock()
+
+	m.notify(m.recvNotify)
+	m.notify(m.sendNotify)
+
+	m.session.removeStream(m.id)
+}
+
+func (m *Stream) notify(channel chan struct{}) {
+	select {
+	case channel <- struct{}{}:
+	default:
+	}
+}
+
+func (m *Stream) wait(channel chan struct{}, deadline time.Time) error {
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		duration := time.Until(deadline)
+		if duration <= 0 {
+			return errTimeout
+		}
+
+		timer := time.NewTimer(duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-channel:
+		return nil
+	case <-timeout:
+		return errTimeout
+	case <-m.session.closed:
+		// Buffered data must still be readable after the session is closed.
+		m.mu.Lock()
+		pending := m.recvBuf.Len()
+		m.mu.Unlock()
+
+		if pending > 0 {
+			return nil
+		}
+
+		return m.session.err()
+	}
+}
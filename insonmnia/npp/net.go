@@ -2,12 +2,13 @@ package npp
 
 import (
 	"context"
-	"fmt"
 	"net"
+	"strconv"
 	"syscall"
 	"time"
 
 	"github.com/libp2p/go-reuseport"
+	"github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util/netutil"
 )
 
@@ -57,7 +58,7 @@ func privateAddrs(addr net.Addr) ([]net.Addr, error) {
 
 	var addrs []net.Addr
 	for _, ip := range ips {
-		addr, err := net.ResolveTCPAddr(protocol, fmt.Sprintf("%s:%d", ip, uint16(port)))
+		addr, err := net.ResolveTCPAddr(protocol, net.JoinHostPort(ip.String(), strconv.Itoa(int(port))))
 		if err != nil {
 			return nil, err
 		}
@@ -67,3 +68,60 @@ func privateAddrs(addr net.Addr) ([]net.Addr, error) {
 
 	return addrs, nil
 }
+
+// privateIPv6Addrs collects IPv6 addresses of network interfaces with the
+// given port.
+//
+// Unlike IPv4 most of IPv6 hosts have globally routable addresses, which
+// can be reached directly without any NAT penetration.
+func privateIPv6Addrs(port int) ([]net.Addr, error) {
+	ips, err := netutil.GetAvailableIPs()
+	if err != nil {
+		return nil, err
+	}
+
+	var addrs []net.Addr
+	for _, ip := range ips {
+		if sonm.NewAddrFamily(ip) != sonm.AddrFamily_FAMILY_IPV6 {
+			continue
+		}
+
+		addrs = append(addrs, &net.TCPAddr{IP: ip, Port: port})
+	}
+
+	return addrs, nil
+}
+
+// addrFamilies returns unique address families of the given addresses.
+func addrFamilies(addrs []*sonm.Addr) []sonm.AddrFamily {
+	var families []sonm.AddrFamily
+	seen := map[sonm.AddrFamily]bool{}
+	for _, addr := range addrs {
+		family := addr.IPFamily()
+		if family == sonm.AddrFamily_FAMILY_UNSPECIFIED || seen[family] {
+			continue
+		}
+
+		seen[family] = true
+		families = append(families, family)
+	}
+
+	return families
+}
+
+// netAddrFamily returns the address family of the given network address.
+func netAddrFamily(addr net.Addr) sonm.AddrFamily {
+	switch addr := addr.(type) {
+	case *net.TCPAddr:
+		return sonm.NewAddrFamily(addr.IP)
+	case *net.UDPAddr:
+		return sonm.NewAddrFamily(addr.IP)
+	default:
+		ip, _, err := netutil.SplitHostPort(addr.String())
+		if err != nil {
+			return sonm.AddrFamily_FAMILY_UNSPECIFIED
+		}
+
+		return sonm.NewAddrFamily(ip)
+	}
+}
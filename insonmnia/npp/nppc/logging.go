@@ -6,7 +6,7 @@
 // all datetime formatting is truncated, because it anyway be replaced with
 // Zap one.
 
-package relay
+package nppc
 
 import (
 	"io"
@@ -21,7 +21,9 @@ type logAdapter struct {
 	rx  *regexp.Regexp
 }
 
-func newLogAdapter(log *zap.Logger) io.Writer {
+// NewLogAdapter constructs a new writer that forwards MemberList logging
+// events into the given logger.
+func NewLogAdapter(log *zap.Logger) io.Writer {
 	return &logAdapter{
 		log: log.WithOptions(zap.AddCallerSkip(3)),
 		rx:  regexp.MustCompile(`\[(\w+)] \w+:(.*)`),
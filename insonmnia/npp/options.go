@@ -2,7 +2,6 @@ package npp
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/sonm-io/core/insonmnia/npp/relay"
@@ -18,11 +17,13 @@ type options struct {
 	log                   *zap.Logger
 	puncher               NATPuncher
 	puncherNew            func(ctx context.Context) (NATPuncher, error)
+	udpPuncherNew         func(ctx context.Context) (*udpPuncher, error)
 	nppBacklog            int
 	nppMinBackoffInterval time.Duration
 	nppMaxBackoffInterval time.Duration
 	relayListener         *relay.Listener
 	relayDialer           *relay.Dialer
+	mux                   *MuxConfig
 }
 
 func newOptions() *options {
@@ -36,6 +37,9 @@ func newOptions() *options {
 
 // WithRendezvous is an option that specifies Rendezvous client settings.
 //
+// When several endpoints are specified, they are tried in order, failing
+// over to the next one when the current becomes unavailable.
+//
 // Without this option no intermediate server will be used for obtaining
 // peer's endpoints and the entire connection establishment process will fall
 // back to the old good plain TCP connection.
@@ -45,15 +49,24 @@ func WithRendezvous(cfg rendezvous.Config, credentials credentials.TransportCred
 			return nil
 		}
 
+		pool := newRendezvousPool(cfg.Endpoints, credentials)
+
 		o.puncherNew = func(ctx context.Context) (NATPuncher, error) {
-			for _, addr := range cfg.Endpoints {
-				client, err := newRendezvousClient(ctx, addr, credentials)
-				if err == nil {
-					return newNATPuncher(ctx, cfg, client)
-				}
+			client, err := pool.Connect(ctx)
+			if err != nil {
+				return nil, err
 			}
 
-			return nil, fmt.Errorf("failed to connect to %+v", cfg.Endpoints)
+			return newNATPuncher(ctx, cfg, client)
+		}
+
+		o.udpPuncherNew = func(ctx context.Context) (*udpPuncher, error) {
+			client, err := pool.Connect(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return newUDPPuncher(ctx, cfg, client), nil
 		}
 
 		return nil
@@ -112,3 +125,21 @@ func WithRelayDialer(dialer *relay.Dialer) Option {
 		return nil
 	}
 }
+
+// WithMultiplexing is an option that activates multiplexed sessions pool on
+// a NPP dialer.
+//
+// Instead of establishing a new connection for every dial, a single
+// connection per remote peer is kept and shared between logical streams.
+// Peers that do not support multiplexing are dialed as usual.
+func WithMultiplexing(cfg MuxConfig) Option {
+	return func(o *options) error {
+		if cfg.Disabled {
+			o.mux = nil
+		} else {
+			o.mux = &cfg
+		}
+
+		return nil
+	}
+}
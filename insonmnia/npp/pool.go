@@ -0,0 +1,265 @@
+package npp
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/npp/mux"
+	"go.uber.org/zap"
+)
+
+const (
+	// unsupportedRetryInterval specifies how long peers without
+	// multiplexing support are dialed without trying it.
+	unsupportedRetryInterval = 10 * time.Minute
+
+	defaultIdleTimeout         = 5 * time.Minute
+	defaultHealthCheckInterval = 30 * time.Second
+)
+
+// MuxConfig describes multiplexed sessions pool settings.
+type MuxConfig struct {
+	// Disabled turns the pool off, establishing a new NPP connection for
+	// every dial.
+	Disabled bool `yaml:"disabled"`
+	// IdleTimeout specifies how long a session without active streams is
+	// kept open.
+	IdleTimeout time.Duration `yaml:"idle_timeout" default:"5m"`
+	// HealthCheckInterval specifies how often sessions are pinged. Sessions
+	// that fail to respond are closed.
+	HealthCheckInterval time.Duration `yaml:"health_check_interval" default:"30s"`
+}
+
+type dialFunc func(ctx context.Context, addr auth.Addr) (net.Conn, error)
+
+// pendingSession allows concurrent dials to the same peer to wait for a
+// single connection establishment instead of punching multiple times.
+type pendingSession struct {
+	done chan struct{}
+}
+
+// sessionPool keeps multiplexed sessions established with remote peers,
+// opening a new stream over an existing session for every dial.
+//
+// Peers that do not support multiplexing are dialed as usual.
+type sessionPool struct {
+	cfg  MuxConfig
+	dial dialFunc
+	log  *zap.Logger
+
+	mu          sync.Mutex
+	sessions    map[common.Address]*mux.Session
+	pending     map[common.Address]*pendingSession
+	unsupported map[common.Address]time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newSessionPool(cfg MuxConfig, dial dialFunc, log *zap.Logger) *sessionPool {
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = defaultIdleTimeout
+	}
+	if cfg.HealthCheckInterval == 0 {
+		cfg.HealthCheckInterval = defaultHealthCheckInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	m := &sessionPool{
+		cfg:         cfg,
+		dial:        dial,
+		log:         log,
+		sessions:    map[common.Address]*mux.Session{},
+		pending:     map[common.Address]*pendingSession{},
+		unsupported: map[common.Address]time.Time{},
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+
+	go m.watch(ctx)
+
+	return m
+}
+
+// DialContext opens a new stream to the given peer, establishing a new
+// multiplexed session if required.
+func (m *sessionPool) DialContext(ctx context.Context, addr auth.Addr) (net.Conn, error) {
+	ethAddr, err := addr.ETH()
+	if err != nil {
+		return m.dial(ctx, addr)
+	}
+
+	for {
+		m.mu.Lock()
+		if session, ok := m.sessions[ethAddr]; ok {
+			m.mu.Unlock()
+
+			stream, err := session.OpenStream()
+			if err == nil {
+				return stream, nil
+			}
+
+			m.log.Debug("failed to open stream, reconnecting", zap.Stringer("remote_addr", ethAddr), zap.Error(err))
+			m.remove(ethAddr, session)
+			continue
+		}
+
+		if retryAt, ok := m.unsupported[ethAddr]; ok && time.Now().Before(retryAt) {
+			m.mu.Unlock()
+			return m.dial(ctx, addr)
+		}
+
+		if pending, ok := m.pending[ethAddr]; ok {
+			m.mu.Unlock()
+
+			select {
+			case <-pending.done:
+				continue
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		pending := &pendingSession{done: make(chan struct{})}
+		m.pending[ethAddr] = pending
+		m.mu.Unlock()
+
+		session, err := m.connect(ctx, addr)
+
+		m.mu.Lock()
+		delete(m.pending, ethAddr)
+		switch err {
+		case nil:
+			m.sessions[ethAddr] = session
+		case mux.ErrUnsupported:
+			m.unsupported[ethAddr] = time.Now().Add(unsupportedRetryInterval)
+		}
+		m.mu.Unlock()
+		close(pending.done)
+
+		switch err {
+		case nil:
+			go m.release(ethAddr, session)
+		case mux.ErrUnsupported:
+			m.log.Info("remote peer does not support multiplexing", zap.Stringer("remote_addr", ethAddr))
+			return m.dial(ctx, addr)
+		default:
+			return nil, err
+		}
+	}
+}
+
+func (m *sessionPool) connect(ctx context.Context, addr auth.Addr) (*mux.Session, error) {
+	conn, err := m.dial(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := mux.Client(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	m.log.Debug("established multiplexed session", zap.Stringer("remote_addr", addr), zap.Stringer("remote_peer", session.RemoteAddr()))
+
+	return session, nil
+}
+
+// release removes the session from the pool once it is closed.
+func (m *sessionPool) release(addr common.Address, session *mux.Session) {
+	select {
+	case <-session.Done():
+		m.remove(addr, session)
+	case <-m.ctx.Done():
+	}
+}
+
+func (m *sessionPool) remove(addr common.Address, session *mux.Session) {
+	m.mu.Lock()
+	if m.sessions[addr] == session {
+		delete(m.sessions, addr)
+	}
+	m.mu.Unlock()
+
+	session.Close()
+}
+
+func (m *sessionPool) watch(ctx context.Context) {
+	timer := time.NewTicker(m.cfg.HealthCheckInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			m.check(ctx)
+		}
+	}
+}
+
+// check closes idle sessions and pings others, closing unhealthy ones.
+func (m *sessionPool) check(ctx context.Context) {
+	m.mu.Lock()
+	sessions := make(map[common.Address]*mux.Session, len(m.sessions))
+	for addr, session := range m.sessions {
+		sessions[addr] = session
+	}
+
+	now := time.Now()
+	for addr, retryAt := range m.unsupported {
+		if now.After(retryAt) {
+			delete(m.unsupported, addr)
+		}
+	}
+	m.mu.Unlock()
+
+	wg := sync.WaitGroup{}
+	for addr, session := range sessions {
+		if session.CloseIfIdle(m.cfg.IdleTimeout) {
+			m.log.Debug("closed idle session", zap.Stringer("remote_addr", addr))
+			m.remove(addr, session)
+			continue
+		}
+
+		wg.Add(1)
+		go func(addr common.Address, session *mux.Session) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, m.cfg.HealthCheckInterval)
+			defer cancel()
+
+			rtt, err := session.Ping(ctx)
+			if err != nil {
+				m.log.Warn("session has failed health check", zap.Stringer("remote_addr", addr), zap.Error(err))
+				m.remove(addr, session)
+				return
+			}
+
+			m.log.Debug("session is healthy", zap.Stringer("remote_addr", addr), zap.Duration("rtt", rtt))
+		}(addr, session)
+	}
+
+	wg.Wait()
+}
+
+// Close closes all sessions in the pool.
+func (m *sessionPool) Close() error {
+	m.cancel()
+
+	m.mu.Lock()
+	sessions := m.sessions
+	m.sessions = map[common.Address]*mux.Session{}
+	m.mu.Unlock()
+
+	for _, session := range sessions {
+		session.Close()
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package npp
+
+import (
+	"context"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/npp/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+// echoServer serves echo over both plain connections and multiplexed
+// sessions if enabled.
+//
+// Without multiplexing it closes connections starting with the handshake
+// like TLS servers do when receiving garbage.
+func echoServer(t *testing.T, multiplexing bool) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	echo := func(conn net.Conn) {
+		defer conn.Close()
+		io.Copy(conn, conn)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			if !multiplexing {
+				go func() {
+					buf := make([]byte, 1)
+					if _, err := io.ReadFull(conn, buf); err != nil || buf[0] == 'S' {
+						conn.Close()
+						return
+					}
+
+					conn.Write(buf)
+					echo(conn)
+				}()
+				continue
+			}
+
+			go func() {
+				session, plainConn, err := mux.Detect(conn, time.Second)
+				if err != nil {
+					return
+				}
+
+				if session == nil {
+					echo(plainConn)
+					return
+				}
+
+				for {
+					stream, err := session.AcceptStream()
+					if err != nil {
+						return
+					}
+
+					go echo(stream)
+				}
+			}()
+		}
+	}()
+
+	return listener
+}
+
+func newCountingDial(listener net.Listener) (dialFunc, *atomic.Uint32) {
+	counter := atomic.NewUint32(0)
+
+	return func(ctx context.Context, addr auth.Addr) (net.Conn, error) {
+		counter.Inc()
+		return net.Dial("tcp", listener.Addr().String())
+	}, counter
+}
+
+func assertEcho(t *testing.T, conn net.Conn) {
+	_, err := conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+}
+
+func TestSessionPoolReusesSession(t *testing.T) {
+	listener := echoServer(t, true)
+	defer listener.Close()
+
+	dial, numDials := newCountingDial(listener)
+	pool := newSessionPool(MuxConfig{}, dial, zap.NewNop())
+	defer pool.Close()
+
+	addr, err := auth.NewAddr("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	require.NoError(t, err)
+
+	wg := sync.WaitGroup{}
+	for id := 0; id < 8; id++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			conn, err := pool.DialContext(context.Background(), *addr)
+			require.NoError(t, err)
+			defer conn.Close()
+
+			assertEcho(t, conn)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint32(1), numDials.Load())
+}
+
+func TestSessionPoolFallsBackToPlainConnections(t *testing.T) {
+	listener := echoServer(t, false)
+	defer listener.Close()
+
+	dial, numDials := newCountingDial(listener)
+	pool := newSessionPool(MuxConfig{}, dial, zap.NewNop())
+	defer pool.Close()
+
+	addr, err := auth.NewAddr("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD")
+	require.NoError(t, err)
+
+	for id := 0; id < 2; id++ {
+		conn, err := pool.DialContext(context.Background(), *addr)
+		require.NoError(t, err)
+
+		assertEcho(t, conn)
+		conn.Close()
+	}
+
+	// The first dial is wasted for the handshake, while the next ones must
+	// not try to establish a session again.
+	assert.Equal(t, uint32(3), numDials.Load())
+}
+
+func TestListenerAcceptsMultiplexedStreams(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	listener, err := NewListener(ctx, "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	dialer, err := NewDialer(WithMultiplexing(MuxConfig{}))
+	require.NoError(t, err)
+	defer dialer.Close()
+
+	addr, err := auth.NewAddr("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD@" + listener.Addr().String())
+	require.NoError(t, err)
+
+	for id := 0; id < 3; id++ {
+		conn, err := dialer.DialContext(ctx, *addr)
+		require.NoError(t, err)
+
+		assertEcho(t, conn)
+		conn.Close()
+	}
+
+	assert.Equal(t, uint64(1), listener.Metrics().NumConnectionsDirect)
+}
@@ -49,8 +49,11 @@ type natPuncher struct {
 	ctx context.Context
 	log *zap.Logger
 
-	client          *rendezvousClient
-	listener        net.Listener
+	client   *rendezvousClient
+	listener net.Listener
+	// listener6 accepts IPv6 connections when the rendezvous is reached
+	// using IPv4. Can be nil if IPv6 is not available.
+	listener6       net.Listener
 	listenerChannel chan connTuple
 
 	maxAttempts int
@@ -78,6 +81,16 @@ func newNATPuncher(ctx context.Context, cfg rendezvous.Config, client *rendezvou
 		timeout:     cfg.Timeout,
 	}
 
+	if netAddrFamily(client.LocalAddr()) == sonm.AddrFamily_FAMILY_IPV4 {
+		listener6, err := reuseport.Listen("tcp6", "[::]:0")
+		if err != nil {
+			m.log.Debug("IPv6 is not available", zap.Error(err))
+		} else {
+			m.listener6 = listener6
+			go m.listen6()
+		}
+	}
+
 	go m.listen()
 
 	return m, nil
@@ -94,6 +107,19 @@ func (m *natPuncher) listen() error {
 	}
 }
 
+// listen6 accepts IPv6 connections. Unlike the primary listener errors are
+// not reported, because IPv6 is optional.
+func (m *natPuncher) listen6() {
+	for {
+		conn, err := m.listener6.Accept()
+		if err != nil {
+			return
+		}
+
+		m.listenerChannel <- newConnTuple(conn, nil)
+	}
+}
+
 func (m *natPuncher) Dial(addr common.Address) (net.Conn, error) {
 	ctx, cancel := context.WithTimeout(m.ctx, m.timeout)
 	defer cancel()
@@ -105,6 +131,7 @@ func (m *natPuncher) DialContext(ctx context.Context, addr common.Address) (net.
 	addrs, err := m.resolve(ctx, addr)
 	if err != nil {
 		m.log.Warn("failed to resolve remote peer using rendezvous", zap.Stringer("remote_addr", addr), zap.Error(err))
+		m.client.MaybeFailover(err)
 		return nil, err
 	}
 
@@ -127,6 +154,7 @@ func (m *natPuncher) AcceptContext(ctx context.Context) (net.Conn, error) {
 	addrs, err := m.publish(ctx)
 	if err != nil {
 		m.log.Warn("failed to publish itself on the rendezvous", zap.Error(err))
+		m.client.MaybeFailover(err)
 		return nil, newRendezvousError(err)
 	}
 
@@ -158,11 +186,13 @@ func (m *natPuncher) resolve(ctx context.Context, addr common.Address) (*sonm.Re
 		ID:           addr.Bytes(),
 	}
 
-	request.PrivateAddrs, err = convertAddrs(privateAddrs)
+	request.PrivateAddrs, err = convertAddrs(privateAddrs, protocol)
 	if err != nil {
 		return nil, err
 	}
 
+	request.Families = addrFamilies(request.PrivateAddrs)
+
 	return m.client.Resolve(ctx, request)
 }
 
@@ -176,15 +206,17 @@ func (m *natPuncher) publish(ctx context.Context) (*sonm.RendezvousReply, error)
 		PrivateAddrs: []*sonm.Addr{},
 	}
 
-	request.PrivateAddrs, err = convertAddrs(privateAddrs)
+	request.PrivateAddrs, err = convertAddrs(privateAddrs, protocol)
 	if err != nil {
 		return nil, err
 	}
 
+	request.Families = addrFamilies(request.PrivateAddrs)
+
 	return m.client.Publish(ctx, request)
 }
 
-func convertAddrs(addrs []net.Addr) ([]*sonm.Addr, error) {
+func convertAddrs(addrs []net.Addr, protocol string) ([]*sonm.Addr, error) {
 	var result []*sonm.Addr
 	for _, addr := range addrs {
 		host, port, err := netutil.SplitHostPort(addr.String())
@@ -198,6 +230,7 @@ func convertAddrs(addrs []net.Addr) ([]*sonm.Addr, error) {
 				Addr: host.String(),
 				Port: uint32(port),
 			},
+			Family: sonm.NewAddrFamily(host),
 		})
 	}
 
@@ -284,10 +317,12 @@ func (m *natPuncher) punchAddr(ctx context.Context, addr *sonm.Addr) (net.Conn,
 		return nil, err
 	}
 
+	localAddr := m.localAddr(addr.IPFamily())
+
 	var conn net.Conn
 	var errs = multierror.NewMultiError()
 	for i := 0; i < m.maxAttempts; i++ {
-		conn, err = DialContext(ctx, protocol, m.client.LocalAddr().String(), peerAddr.String())
+		conn, err = DialContext(ctx, protocol, localAddr, peerAddr.String())
 		if err == nil {
 			return conn, nil
 		}
@@ -298,6 +333,26 @@ func (m *natPuncher) punchAddr(ctx context.Context, addr *sonm.Addr) (net.Conn,
 	return nil, errs.ErrorOrNil()
 }
 
+// localAddr returns the local address to dial from for the given family.
+//
+// The rendezvous client local address is reused for successful NAT
+// penetration in case of cone NAT, but it can't be used to connect to
+// addresses of another family. In this case IPv6 listener's address is used
+// instead, which allows to pass through stateful IPv6 firewalls when both
+// peers connect simultaneously. Empty address means any.
+func (m *natPuncher) localAddr(family sonm.AddrFamily) string {
+	localAddr := m.client.LocalAddr()
+	if netAddrFamily(localAddr) == family {
+		return localAddr.String()
+	}
+
+	if family == sonm.AddrFamily_FAMILY_IPV6 && m.listener6 != nil {
+		return m.listener6.Addr().String()
+	}
+
+	return ""
+}
+
 func (m *natPuncher) RemoteAddr() net.Addr {
 	return m.client.RemoteAddr()
 }
@@ -308,6 +363,11 @@ func (m *natPuncher) Close() error {
 	if err := m.listener.Close(); err != nil {
 		errs = append(errs, err)
 	}
+	if m.listener6 != nil {
+		if err := m.listener6.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	if err := m.client.Close(); err != nil {
 		errs = append(errs, err)
 	}
@@ -322,5 +382,26 @@ func (m *natPuncher) Close() error {
 // PrivateAddrs collects and returns private addresses of a network interfaces
 // the listening socket bind on.
 func (m *natPuncher) privateAddrs() ([]net.Addr, error) {
-	return privateAddrs(m.listener.Addr())
+	addrs, err := privateAddrs(m.listener.Addr())
+	if err != nil {
+		return nil, err
+	}
+
+	if m.listener6 != nil {
+		_, port, err := netutil.SplitHostPort(m.listener6.Addr().String())
+		if err != nil {
+			return nil, err
+		}
+
+		// Failing to collect IPv6 addresses is not critical, because IPv4
+		// ones are still available.
+		addrs6, err := privateIPv6Addrs(int(port))
+		if err != nil {
+			m.log.Debug("failed to collect IPv6 addresses", zap.Error(err))
+		}
+
+		addrs = append(addrs, addrs6...)
+	}
+
+	return addrs, nil
 }
@@ -0,0 +1,165 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/proto"
+)
+
+const (
+	// DealsVerifier is the name of the verifier that allows only clients
+	// having an accepted deal with the server.
+	DealsVerifier = "deals"
+
+	// handshakeTimeWindow is the maximum difference between the client
+	// handshake timestamp and the Relay's clock.
+	handshakeTimeWindow = 5 * time.Minute
+)
+
+// ClientVerifier decides whether the client is allowed to connect to the
+// server that has referred to this verifier in its ACL.
+type ClientVerifier interface {
+	Verify(ctx context.Context, server, client common.Address) (bool, error)
+}
+
+type dealsVerifier struct {
+	dwh sonm.DWHClient
+}
+
+// NewDealsVerifier constructs a new client verifier that allows only clients
+// having an accepted deal with the server, i.e. its counterparties.
+func NewDealsVerifier(dwh sonm.DWHClient) ClientVerifier {
+	return &dealsVerifier{
+		dwh: dwh,
+	}
+}
+
+func (m *dealsVerifier) Verify(ctx context.Context, server, client common.Address) (bool, error) {
+	deals, err := m.dwh.GetDeals(ctx, &sonm.DealsRequest{
+		Status:     sonm.DealStatus_DEAL_ACCEPTED,
+		SupplierID: sonm.NewEthAddress(server),
+		ConsumerID: sonm.NewEthAddress(client),
+		Limit:      1,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch deals: %v", err)
+	}
+
+	return len(deals.GetDeals()) > 0, nil
+}
+
+// nonceCache remembers nonces of signed client handshakes within the time
+// window to prevent them from being replayed.
+type nonceCache struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+}
+
+func newNonceCache() *nonceCache {
+	return &nonceCache{
+		nonces: map[string]time.Time{},
+	}
+}
+
+// Insert remembers the given nonce, returning false if it has been already
+// seen.
+func (m *nonceCache) Insert(nonce []byte, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for key, expiresAt := range m.nonces {
+		if now.After(expiresAt) {
+			delete(m.nonces, key)
+		}
+	}
+
+	key := string(nonce)
+	if _, ok := m.nonces[key]; ok {
+		return false
+	}
+
+	// The handshake can't be replayed after its timestamp leaves the window,
+	// which is at most twice the window from now.
+	m.nonces[key] = now.Add(2 * handshakeTimeWindow)
+
+	return true
+}
+
+// authenticateClient verifies the signed client handshake freshness,
+// returning the client's ETH address. Unsigned handshakes result in nil
+// address.
+//
+// The signature itself is verified during the handshake validation.
+func (m *server) authenticateClient(handshake *sonm.HandshakeRequest) (*common.Address, error) {
+	if !handshake.IsSigned() {
+		return nil, nil
+	}
+
+	now := time.Now()
+	timestamp := time.Unix(0, handshake.Timestamp)
+	if timestamp.Before(now.Add(-handshakeTimeWindow)) || timestamp.After(now.Add(handshakeTimeWindow)) {
+		return nil, errInvalidHandshake(fmt.Errorf("timestamp is out of the allowed window"))
+	}
+
+	if !m.nonces.Insert(handshake.Nonce, now) {
+		return nil, errInvalidHandshake(fmt.Errorf("handshake has been replayed"))
+	}
+
+	addr := common.BytesToAddress(handshake.ClientAddr)
+	return &addr, nil
+}
+
+// verifyClient asks the given verifiers whether the client is allowed to
+// connect to the server, returning the set of verifiers that have allowed.
+func (m *server) verifyClient(ctx context.Context, server common.Address, client *common.Address, verifiers []string) map[string]bool {
+	allowed := map[string]bool{}
+	if client == nil {
+		return allowed
+	}
+
+	for _, name := range verifiers {
+		verifier, ok := m.verifiers[name]
+		if !ok {
+			m.log.Debugf("unknown client verifier `%s` referred by %s", name, server.Hex())
+			continue
+		}
+
+		ok, err := verifier.Verify(ctx, server, *client)
+		if err != nil {
+			m.log.Warnf("failed to verify client %s using `%s` verifier: %v", client.Hex(), name, err)
+			continue
+		}
+
+		allowed[name] = ok
+	}
+
+	return allowed
+}
+
+// isAllowed checks whether the client is allowed by the ACL using
+// precomputed verifier results.
+func isAllowed(acl *sonm.RelayACL, client *common.Address, verified map[string]bool) bool {
+	if acl.IsEmpty() {
+		return true
+	}
+
+	if client == nil {
+		return false
+	}
+
+	if acl.Contains(client.Bytes()) {
+		return true
+	}
+
+	for _, name := range acl.Verifiers {
+		if verified[name] {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package relay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignedClientHandshake(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	target := common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fd")
+
+	handshake, err := newSignedClientHandshake(key, target, "", sonm.DefaultNPPProtocol)
+	require.NoError(t, err)
+	require.NoError(t, handshake.Validate())
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey).Bytes(), handshake.ClientAddr)
+
+	// The signature must not be reusable for other targets, meetings or
+	// protocols.
+	modified := *handshake
+	modified.Addr = common.HexToAddress("0x1").Bytes()
+	assert.Error(t, modified.Validate())
+
+	modified = *handshake
+	modified.UUID = "uuid"
+	assert.Error(t, modified.Validate())
+
+	modified = *handshake
+	modified.Protocol = sonm.UDPNPPProtocol
+	assert.Error(t, modified.Validate())
+}
+
+func TestNonceCacheRejectsReplays(t *testing.T) {
+	cache := newNonceCache()
+	now := time.Now()
+
+	assert.True(t, cache.Insert([]byte("nonce"), now))
+	assert.False(t, cache.Insert([]byte("nonce"), now.Add(time.Minute)))
+	// Expired nonces are forgotten.
+	assert.True(t, cache.Insert([]byte("nonce"), now.Add(3*handshakeTimeWindow)))
+}
+
+func TestIsAllowed(t *testing.T) {
+	client := common.HexToAddress("0x1")
+	acl := &sonm.RelayACL{
+		Addrs:     [][]byte{common.HexToAddress("0x2").Bytes()},
+		Verifiers: []string{DealsVerifier},
+	}
+
+	assert.True(t, isAllowed(nil, nil, nil))
+	assert.True(t, isAllowed(&sonm.RelayACL{}, nil, nil))
+	assert.False(t, isAllowed(acl, nil, nil))
+	assert.False(t, isAllowed(acl, &client, map[string]bool{}))
+	assert.True(t, isAllowed(acl, &client, map[string]bool{DealsVerifier: true}))
+
+	other := common.HexToAddress("0x2")
+	assert.True(t, isAllowed(acl, &other, map[string]bool{}))
+}
+
+func TestConnPoolPopRespectsACL(t *testing.T) {
+	pool := newConnPool()
+	pool.put("restricted", nil, nil, &sonm.RelayACL{Verifiers: []string{DealsVerifier}})
+
+	denyAll := func(acl *sonm.RelayACL) bool { return acl.IsEmpty() }
+
+	candidate, denied := pool.popRandom(denyAll)
+	assert.Nil(t, candidate)
+	assert.True(t, denied)
+
+	candidate, denied = pool.pop("restricted", denyAll)
+	assert.Nil(t, candidate)
+	assert.True(t, denied)
+
+	pool.put("open", nil, nil, nil)
+	candidate, denied = pool.popRandom(denyAll)
+	require.NotNil(t, candidate)
+	assert.False(t, denied)
+	assert.Len(t, pool.candidates, 1)
+}
@@ -31,7 +31,11 @@ func Dial(addr net.Addr, targetAddr common.Address, uuid string) (net.Conn, erro
 
 // DialWithLog does the same as Dial, but with logging.
 func DialWithLog(addr net.Addr, targetAddr common.Address, uuid string, log *zap.Logger) (net.Conn, error) {
-	client, err := newClient(addr, log)
+	return dial(tcpTransport{}, addr.String(), targetAddr, uuid, sonm.DefaultNPPProtocol, nil, log)
+}
+
+func dial(transport transport, addr string, targetAddr common.Address, uuid string, protocol string, key *ecdsa.PrivateKey, log *zap.Logger) (net.Conn, error) {
+	client, err := newClient(transport, addr, log)
 	if err != nil {
 		return nil, err
 	}
@@ -42,14 +46,14 @@ func DialWithLog(addr net.Addr, targetAddr common.Address, uuid string, log *zap
 	log.Debug("discovering meeting point on the Continuum")
 
 	for numAttempts := 0; numAttempts < 2; numAttempts++ {
-		member, err := client.discover(targetAddr)
+		member, err := client.discover(targetAddr, protocol)
 		if err != nil {
 			log.Warn("failed to discover meeting point on the Continuum", zap.Error(err))
 			return nil, err
 		}
 
 		log.Debug("connecting to remote meeting point on the Continuum", zap.Stringer("remote_addr", member.conn.RemoteAddr()))
-		conn, err := member.dial(targetAddr, uuid)
+		conn, err := member.dial(targetAddr, uuid, protocol, key)
 		if err == nil {
 			return conn, nil
 		}
@@ -75,7 +79,11 @@ func Listen(addr net.Addr, publishAddr SignedETHAddr) (net.Conn, error) {
 
 // ListenWithLog does the same as Listen, but with logging.
 func ListenWithLog(addr net.Addr, publishAddr SignedETHAddr, log *zap.Logger) (net.Conn, error) {
-	client, err := newClient(addr, log)
+	return listen(tcpTransport{}, addr.String(), publishAddr, sonm.DefaultNPPProtocol, nil, log)
+}
+
+func listen(transport transport, addr string, publishAddr SignedETHAddr, protocol string, acl *sonm.RelayACL, log *zap.Logger) (net.Conn, error) {
+	client, err := newClient(transport, addr, log)
 	if err != nil {
 		return nil, err
 	}
@@ -84,14 +92,14 @@ func ListenWithLog(addr net.Addr, publishAddr SignedETHAddr, log *zap.Logger) (n
 	log = log.With(zap.Stringer("addr", publishAddr.Addr()))
 	log.Debug("discovering meeting point on the Continuum")
 
-	member, err := client.discover(publishAddr.addr)
+	member, err := client.discover(publishAddr.addr, protocol)
 	if err != nil {
 		log.Warn("failed to discover meeting point on the Continuum", zap.Error(err))
 		return nil, err
 	}
 
 	log.Debug("listening for connections on remote meeting point on the Continuum", zap.Stringer("remote_addr", member.conn.RemoteAddr()))
-	conn, err := member.accept(publishAddr)
+	conn, err := member.accept(publishAddr, protocol, acl)
 	if err != nil {
 		log.Warn("failed to accept connection on remote meeting point on the Continuum", zap.Error(err))
 		return nil, err
@@ -101,40 +109,32 @@ func ListenWithLog(addr net.Addr, publishAddr SignedETHAddr, log *zap.Logger) (n
 }
 
 type client struct {
-	conn net.Conn
-	log  *zap.Logger
+	conn      net.Conn
+	transport transport
+	log       *zap.Logger
 }
 
-func newClient(addr net.Addr, log *zap.Logger) (*client, error) {
-	log = log.With(zap.Stringer("remote_addr", addr))
+func newClient(transport transport, addr string, log *zap.Logger) (*client, error) {
+	log = log.With(zap.String("remote_addr", addr))
 	log.Debug("connecting to the Relay")
 
-	// Setting TCP keepalive is strictly suggested, because in case of mobile
-	// devices the network can be reconfigured multiple times between
-	// connection attempts.
-	// However if the network was silently changed or the connection was lost
-	// or the other side was kernel-panicked no FIN/RST will be delivered to
-	// us, which leads to infinite (well, 24-hour) hanging.
-	dialer := net.Dialer{
-		KeepAlive: tcpKeepAliveInterval,
-	}
-
-	conn, err := dialer.Dial("tcp", addr.String())
+	conn, err := transport.Dial(addr)
 	if err != nil {
 		log.Warn("failed to connect to the Relay", zap.Error(err))
 		return nil, err
 	}
 
 	m := &client{
-		conn: conn,
-		log:  log,
+		conn:      conn,
+		transport: transport,
+		log:       log,
 	}
 
 	return m, nil
 }
 
-func (m *client) discover(peer common.Address) (*client, error) {
-	if err := sendFrame(m.conn, newDiscover(peer)); err != nil {
+func (m *client) discover(peer common.Address, protocol string) (*client, error) {
+	if err := sendFrame(m.conn, newDiscover(peer, protocol)); err != nil {
 		return nil, err
 	}
 
@@ -143,16 +143,25 @@ func (m *client) discover(peer common.Address) (*client, error) {
 		return nil, err
 	}
 
-	addr, err := net.ResolveTCPAddr("tcp", response.Addr)
-	if err != nil {
+	if _, _, err := net.SplitHostPort(response.Addr); err != nil {
 		return nil, err
 	}
 
-	return newClient(addr, m.log)
+	return newClient(m.transport, response.Addr, m.log)
 }
 
-func (m *client) dial(targetAddr common.Address, uuid string) (net.Conn, error) {
-	if err := m.handshake(newClientHandshake(targetAddr, uuid)); err != nil {
+func (m *client) dial(targetAddr common.Address, uuid string, protocol string, key *ecdsa.PrivateKey) (net.Conn, error) {
+	handshake := newClientHandshake(targetAddr, uuid, protocol)
+	if key != nil {
+		var err error
+		handshake, err = newSignedClientHandshake(key, targetAddr, uuid, protocol)
+		if err != nil {
+			m.conn.Close()
+			return nil, err
+		}
+	}
+
+	if err := m.handshake(handshake); err != nil {
 		m.conn.Close()
 		return nil, err
 	}
@@ -160,8 +169,8 @@ func (m *client) dial(targetAddr common.Address, uuid string) (net.Conn, error)
 	return m.conn, nil
 }
 
-func (m *client) accept(publishAddr SignedETHAddr) (net.Conn, error) {
-	if err := m.handshake(newServerHandshake(publishAddr)); err != nil {
+func (m *client) accept(publishAddr SignedETHAddr, protocol string, acl *sonm.RelayACL) (net.Conn, error) {
+	if err := m.handshake(newServerHandshake(publishAddr, protocol, acl)); err != nil {
 		m.conn.Close()
 		return nil, err
 	}
@@ -180,7 +189,7 @@ func (m *client) handshake(message proto.Message) error {
 	}
 
 	if response.Error != 0 {
-		return fmt.Errorf("failed to perform handshake into relay: %s", response.Description)
+		return newProtocolError(response.Error, fmt.Errorf("failed to perform handshake into relay: %s", response.Description))
 	}
 
 	return nil
@@ -196,37 +205,48 @@ func (m *client) Close() error {
 // Hostname resolution is performed for each of them for environments with
 // dynamic DNS addition/removal. Thus, a single Relay endpoint as a hostname
 // should fit the best.
+// Addresses in "ws://host:port" or "wss://host:port" form are reached using
+// WebSocket transport, respecting HTTP proxy environment variables.
 type Dialer struct {
 	Addrs []string
-	Log   *zap.Logger
+	// Key is an optional private key used to sign client handshakes, which
+	// is required to connect to servers that have published an ACL.
+	Key *ecdsa.PrivateKey
+	Log *zap.Logger
 }
 
 // Dial mimics "net.Dial" and connects to a remote endpoint using Relay server.
 func (m *Dialer) Dial(target common.Address) (net.Conn, error) {
+	return m.dial(target, sonm.DefaultNPPProtocol)
+}
+
+// DialUDP connects to a remote UDP endpoint using Relay server.
+//
+// The returned connection preserves datagram boundaries.
+func (m *Dialer) DialUDP(target common.Address) (net.Conn, error) {
+	conn, err := m.dial(target, sonm.UDPNPPProtocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDatagramConn(conn), nil
+}
+
+func (m *Dialer) dial(target common.Address, protocol string) (net.Conn, error) {
 	m.initLog()
 	m.Log.Debug("connecting to remote Relay server")
 
 	errs := multierror.NewMultiError()
 
 	for _, addr := range m.Addrs {
-		m.Log.Debug("resolving Relay addr", zap.String("addr", addr))
-
-		addrs, err := netutil.LookupTCPHostPort(addr)
-		if err != nil {
-			errs = multierror.AppendUnique(errs, err)
-			continue
+		conn, err := connectEndpoint(addr, m.Log, func(transport transport, addr string) (net.Conn, error) {
+			return dial(transport, addr, target, "", protocol, m.Key, m.Log)
+		})
+		if err == nil {
+			return conn, nil
 		}
 
-		m.Log.Debug("successfully resolved Relay addr", zap.String("addr", addr), zap.Any("resolved", addrs))
-
-		for _, addr := range addrs {
-			conn, err := DialWithLog(addr, target, "", m.Log)
-			if err == nil {
-				return conn, nil
-			}
-
-			errs = multierror.AppendUnique(errs, err)
-		}
+		errs = multierror.AppendUnique(errs, err)
 	}
 
 	return nil, fmt.Errorf("failed to connect to %+v: %s", m.Addrs, errs.Error())
@@ -246,7 +266,9 @@ func (m *Dialer) initLog() {
 type Listener struct {
 	Addrs      []string
 	SignedAddr SignedETHAddr
-	Log        *zap.Logger
+	// ACL optionally restricts clients allowed to connect.
+	ACL *sonm.RelayACL
+	Log *zap.Logger
 }
 
 func NewListener(addrs []string, key *ecdsa.PrivateKey, log *zap.Logger) (*Listener, error) {
@@ -265,26 +287,76 @@ func NewListener(addrs []string, key *ecdsa.PrivateKey, log *zap.Logger) (*Liste
 }
 
 func (m *Listener) Accept() (net.Conn, error) {
+	return m.accept(sonm.DefaultNPPProtocol)
+}
+
+// AcceptUDP waits for a remote peer wanting to exchange UDP datagrams.
+//
+// The returned connection preserves datagram boundaries.
+func (m *Listener) AcceptUDP() (net.Conn, error) {
+	conn, err := m.accept(sonm.UDPNPPProtocol)
+	if err != nil {
+		return nil, err
+	}
+
+	return newDatagramConn(conn), nil
+}
+
+func (m *Listener) accept(protocol string) (net.Conn, error) {
 	m.Log.Debug("connecting to remote Relay server")
 
 	errs := multierror.NewMultiError()
 	for _, addr := range m.Addrs {
-		m.Log.Debug("resolving Relay addr", zap.String("addr", addr))
-		addrs, err := netutil.LookupTCPHostPort(addr)
+		conn, err := connectEndpoint(addr, m.Log, func(transport transport, addr string) (net.Conn, error) {
+			return listen(transport, addr, m.SignedAddr, protocol, m.ACL, m.Log)
+		})
+		if err == nil {
+			return conn, nil
+		}
+
+		errs = multierror.AppendUnique(errs, err)
+	}
+
+	return nil, fmt.Errorf("failed to connect to %+v: %s", m.Addrs, errs.Error())
+}
+
+// connectEndpoint tries to connect to the given Relay endpoint using the
+// suitable transport.
+//
+// TCP endpoints are resolved and tried one by one, while WebSocket endpoints
+// are dialed as is, because peers behind HTTP proxies may be unable to
+// resolve external hostnames.
+func connectEndpoint(endpoint string, log *zap.Logger, fn func(transport transport, addr string) (net.Conn, error)) (net.Conn, error) {
+	if isWebSocketEndpoint(endpoint) {
+		transport, err := newWebSocketTransport(endpoint)
 		if err != nil {
-			errs = multierror.AppendUnique(errs, err)
-			continue
+			return nil, err
 		}
 
-		m.Log.Debug("successfully resolved Relay addr", zap.String("addr", addr), zap.Any("resolved", addrs))
+		return fn(transport, transport.Addr())
+	}
+
+	log.Debug("resolving Relay addr", zap.String("addr", endpoint))
+	addrs, err := netutil.LookupTCPHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("successfully resolved Relay addr", zap.String("addr", endpoint), zap.Any("resolved", addrs))
 
-		for _, addr := range addrs {
-			conn, err := ListenWithLog(addr, m.SignedAddr, m.Log)
-			if err == nil {
-				return conn, nil
-			}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no addresses resolved for %s", endpoint)
+	}
+
+	errs := multierror.NewMultiError()
+	for _, addr := range addrs {
+		conn, err := fn(tcpTransport{}, addr.String())
+		if err == nil {
+			return conn, nil
 		}
+
+		errs = multierror.AppendUnique(errs, err)
 	}
 
-	return nil, fmt.Errorf("failed to connect to %+v: %s", m.Addrs, errs.Error())
+	return nil, errs.ErrorOrNil()
 }
@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/jinzhu/configor"
 	"github.com/pborman/uuid"
 	"github.com/sonm-io/core/accounts"
 	"github.com/sonm-io/core/insonmnia/logging"
+	sonm "github.com/sonm-io/core/proto"
 	"github.com/sonm-io/core/util/debug"
 	"github.com/sonm-io/core/util/netutil"
 )
@@ -22,6 +24,14 @@ type ClusterConfig struct {
 	Members   []string
 }
 
+// ACLConfig describes Relay-side client verifiers servers can refer to in
+// their ACLs.
+type ACLConfig struct {
+	// DWH is an optional DWH endpoint in "ethAddr@host:port" form, which
+	// enables "deals" verifier.
+	DWH string `yaml:"dwh"`
+}
+
 type MonitorConfig struct {
 	Endpoint   string
 	PrivateKey *ecdsa.PrivateKey `json:"-"`
@@ -33,20 +43,28 @@ type monitorConfig struct {
 }
 
 type serverConfig struct {
-	Addr    netutil.TCPAddr `yaml:"endpoint" required:"true"`
-	Cluster ClusterConfig   `yaml:"cluster"`
-	Logging logging.Config  `yaml:"logging"`
-	Monitor monitorConfig   `yaml:"monitoring"`
-	Debug   *debug.Config   `yaml:"debug"`
+	Addr      netutil.TCPAddr  `yaml:"endpoint" required:"true"`
+	Cluster   ClusterConfig    `yaml:"cluster"`
+	WebSocket *WebSocketConfig `yaml:"websocket"`
+	Limits    LimitsConfig     `yaml:"limits"`
+	ACL       ACLConfig        `yaml:"acl"`
+	Logging   logging.Config   `yaml:"logging"`
+	Monitor   monitorConfig    `yaml:"monitoring"`
+	Debug     *debug.Config    `yaml:"debug"`
 }
 
 // ServerConfig describes the complete relay server configuration.
 type ServerConfig struct {
 	Addr    netutil.TCPAddr
 	Cluster ClusterConfig
-	Logging logging.Config
-	Monitor MonitorConfig
-	Debug   *debug.Config
+	// WebSocket is an optional config of WebSocket transport, which is
+	// served in addition to the raw TCP.
+	WebSocket *WebSocketConfig
+	Limits    LimitsConfig
+	ACL       ACLConfig
+	Logging   logging.Config
+	Monitor   MonitorConfig
+	Debug     *debug.Config
 }
 
 // NewServerConfig loads a new Relay server config from a file.
@@ -72,9 +90,12 @@ func NewServerConfig(path string) (*ServerConfig, error) {
 	}
 
 	return &ServerConfig{
-		Addr:    cfg.Addr,
-		Cluster: cfg.Cluster,
-		Logging: cfg.Logging,
+		Addr:      cfg.Addr,
+		Cluster:   cfg.Cluster,
+		WebSocket: cfg.WebSocket,
+		Limits:    cfg.Limits,
+		ACL:       cfg.ACL,
+		Logging:   cfg.Logging,
 		Monitor: MonitorConfig{
 			Endpoint:   cfg.Monitor.Endpoint,
 			PrivateKey: privateKey,
@@ -88,4 +109,34 @@ func NewServerConfig(path string) (*ServerConfig, error) {
 // Used as a basic building block for high-level configurations.
 type Config struct {
 	Endpoints []string
+	// ACL optionally restricts clients allowed to connect to servers
+	// published on the Relay.
+	ACL *ListenerACLConfig `yaml:"acl"`
+}
+
+// ListenerACLConfig describes clients allowed to connect to a server
+// published on the Relay. It's enough for a client to match either of
+// the fields.
+type ListenerACLConfig struct {
+	// Addrs is a list of ETH addresses allowed to connect.
+	Addrs []common.Address `yaml:"addrs"`
+	// Verifiers is a list of Relay-side verifier names, for example "deals".
+	Verifiers []string `yaml:"verifiers"`
+}
+
+// ACL converts the config into the ACL published on the Relay. Returns nil
+// if no ACL is configured, allowing anyone to connect.
+func (m *ListenerACLConfig) ACL() *sonm.RelayACL {
+	if m == nil {
+		return nil
+	}
+
+	acl := &sonm.RelayACL{
+		Verifiers: m.Verifiers,
+	}
+	for _, addr := range m.Addrs {
+		acl.Addrs = append(acl.Addrs, addr.Bytes())
+	}
+
+	return acl
 }
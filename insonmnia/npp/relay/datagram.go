@@ -0,0 +1,81 @@
+package relay
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+)
+
+const (
+	// MaxDatagramSize is the maximum size of a single datagram that can be
+	// transported through the Relay.
+	MaxDatagramSize = 65507
+)
+
+// datagramConn transports UDP-like datagrams over the relayed stream by
+// prefixing each of them with its size, preserving message boundaries.
+//
+// Like UDP, reading into a buffer smaller than the datagram truncates it.
+type datagramConn struct {
+	net.Conn
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func newDatagramConn(conn net.Conn) *datagramConn {
+	return &datagramConn{
+		Conn: conn,
+		buf:  make([]byte, MaxDatagramSize),
+	}
+}
+
+func (m *datagramConn) Read(b []byte) (int, error) {
+	size, err := readDatagram(m.Conn, m.buf)
+	if err != nil {
+		return 0, err
+	}
+
+	return copy(b, m.buf[:size]), nil
+}
+
+func (m *datagramConn) Write(b []byte) (int, error) {
+	if len(b) > MaxDatagramSize {
+		return 0, fmt.Errorf("datagram too large: %d > %d", len(b), MaxDatagramSize)
+	}
+
+	frame := make([]byte, 2+len(b))
+	binary.BigEndian.PutUint16(frame, uint16(len(b)))
+	copy(frame[2:], b)
+
+	// Concurrent writes must not interleave, otherwise framing breaks.
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.Conn.Write(frame); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+// readDatagram reads a single size-prefixed datagram into the given buffer,
+// which must be large enough to hold any datagram.
+func readDatagram(rd io.Reader, buf []byte) (int, error) {
+	var size uint16
+	if err := binary.Read(rd, binary.BigEndian, &size); err != nil {
+		return 0, err
+	}
+
+	if int(size) > MaxDatagramSize || int(size) > len(buf) {
+		return 0, fmt.Errorf("datagram too large: %d", size)
+	}
+
+	if _, err := io.ReadFull(rd, buf[:size]); err != nil {
+		return 0, err
+	}
+
+	return int(size), nil
+}
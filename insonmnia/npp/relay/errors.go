@@ -13,6 +13,8 @@ const (
 	ErrNoPeer
 	ErrWrongNode
 	ErrEmptyContinuum
+	ErrQuotaExceeded
+	ErrUnauthorized
 )
 
 type protocolError struct {
@@ -54,3 +56,11 @@ func errWrongNode() error {
 func errEmptyContinuum() error {
 	return newProtocolError(ErrEmptyContinuum, fmt.Errorf("no nodes in the continuum"))
 }
+
+func errUnauthorized() error {
+	return newProtocolError(ErrUnauthorized, fmt.Errorf("client is not allowed to connect"))
+}
+
+func errQuotaExceeded() error {
+	return newProtocolError(ErrQuotaExceeded, fmt.Errorf("daily traffic quota exceeded"))
+}
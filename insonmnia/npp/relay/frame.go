@@ -2,9 +2,11 @@ package relay
 
 import (
 	"crypto/ecdsa"
+	"crypto/rand"
 	"encoding/binary"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/ethereum/go-ethereum/common"
@@ -41,10 +43,11 @@ func (m *SignedETHAddr) Addr() common.Address {
 	return m.addr
 }
 
-func newDiscover(addr common.Address) *sonm.HandshakeRequest {
+func newDiscover(addr common.Address, protocol string) *sonm.HandshakeRequest {
 	return &sonm.HandshakeRequest{
 		PeerType: sonm.PeerType_DISCOVER,
 		Addr:     addr.Bytes(),
+		Protocol: protocol,
 	}
 }
 
@@ -54,22 +57,48 @@ func newDiscoverResponse(addr string) *sonm.DiscoverResponse {
 	}
 }
 
-func newServerHandshake(addr SignedETHAddr) *sonm.HandshakeRequest {
+func newServerHandshake(addr SignedETHAddr, protocol string, acl *sonm.RelayACL) *sonm.HandshakeRequest {
 	return &sonm.HandshakeRequest{
 		PeerType: sonm.PeerType_SERVER,
 		Addr:     addr.addr.Bytes(),
 		Sign:     addr.sign,
+		Protocol: protocol,
+		ACL:      acl,
 	}
 }
 
-func newClientHandshake(addr common.Address, uuid string) *sonm.HandshakeRequest {
+func newClientHandshake(addr common.Address, uuid string, protocol string) *sonm.HandshakeRequest {
 	return &sonm.HandshakeRequest{
 		PeerType: sonm.PeerType_CLIENT,
 		Addr:     addr.Bytes(),
 		UUID:     uuid,
+		Protocol: protocol,
 	}
 }
 
+// newSignedClientHandshake constructs a client handshake signed with the
+// given key, allowing to connect to servers with ACL.
+func newSignedClientHandshake(key *ecdsa.PrivateKey, addr common.Address, uuid string, protocol string) (*sonm.HandshakeRequest, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	handshake := newClientHandshake(addr, uuid, protocol)
+	handshake.ClientAddr = crypto.PubkeyToAddress(key.PublicKey).Bytes()
+	handshake.Timestamp = time.Now().UnixNano()
+	handshake.Nonce = nonce
+
+	sign, err := crypto.Sign(handshake.ClientSignHash(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	handshake.Sign = sign
+
+	return handshake, nil
+}
+
 func sendFrame(wr io.Writer, message proto.Message) error {
 	frame, err := proto.Marshal(message)
 	if err != nil {
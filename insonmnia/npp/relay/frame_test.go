@@ -2,6 +2,7 @@ package relay
 
 import (
 	"bytes"
+	"net"
 	"testing"
 
 	"github.com/sonm-io/core/proto"
@@ -26,3 +27,29 @@ func TestFrameRoundTrip(t *testing.T) {
 
 	assert.Equal(t, message, messageBack)
 }
+
+func TestDatagramConnPreservesBoundaries(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	serverConn := newDatagramConn(server)
+	clientConn := newDatagramConn(client)
+
+	go func() {
+		clientConn.Write([]byte("first"))
+		clientConn.Write([]byte("second"))
+	}()
+
+	buf := make([]byte, 64)
+	n, err := serverConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(buf[:n]))
+
+	n, err = serverConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(buf[:n]))
+
+	_, err = clientConn.Write(make([]byte, MaxDatagramSize+1))
+	require.Error(t, err)
+}
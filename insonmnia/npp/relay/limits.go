@@ -0,0 +1,322 @@
+package relay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util/datasize"
+)
+
+const (
+	// limiterPruneInterval is the interval between removals of idle usage
+	// trackers.
+	limiterPruneInterval = 10 * time.Minute
+)
+
+// LimitsConfig describes per-ETH address traffic limits.
+//
+// Limits are accounted for the address of the server peer, i.e. the one that
+// has published itself on the Relay, because it's the party who benefits from
+// relaying. Both directions of all its connections are summed up.
+type LimitsConfig struct {
+	// Rate is the maximum bandwidth. Zero means unlimited.
+	Rate datasize.BitRate `yaml:"rate"`
+	// Burst is the maximum number of bytes that can be transmitted at once
+	// exceeding the rate. Defaults to one second of traffic.
+	Burst datasize.ByteSize `yaml:"burst"`
+	// DailyQuota is the maximum number of bytes that can be transmitted per
+	// day, starting from the UTC midnight. Zero means unlimited.
+	DailyQuota datasize.ByteSize `yaml:"daily_quota"`
+	// Privileged is a list of addresses exempt from all of the limits.
+	Privileged []common.Address `yaml:"privileged"`
+}
+
+func (m *LimitsConfig) rate() float64 {
+	return m.Rate.Bytes()
+}
+
+func (m *LimitsConfig) burst() float64 {
+	if m.Burst.Bytes() > 0 {
+		return float64(m.Burst.Bytes())
+	}
+
+	return m.rate()
+}
+
+func (m *LimitsConfig) dailyQuota() uint64 {
+	return m.DailyQuota.Bytes()
+}
+
+// tokenBucket implements the token bucket algorithm with bytes as tokens.
+//
+// Requests exceeding the bucket size are allowed, putting the bucket into
+// debt, which must be paid off by waiting before the next request.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate, burst float64, now time.Time) *tokenBucket {
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// Reserve takes the given number of tokens, returning the duration the
+// caller must wait before transmitting.
+func (m *tokenBucket) Reserve(n int, now time.Time) time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elapsed := now.Sub(m.last); elapsed > 0 {
+		m.tokens += elapsed.Seconds() * m.rate
+		if m.tokens > m.burst {
+			m.tokens = m.burst
+		}
+		m.last = now
+	}
+
+	m.tokens -= float64(n)
+	if m.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-m.tokens / m.rate * float64(time.Second))
+}
+
+// usage tracks and limits traffic of a single ETH address.
+type usage struct {
+	limiter    *limiter
+	bucket     *tokenBucket
+	privileged bool
+	// refs is the number of relayed connections using this tracker. Guarded
+	// by the limiter's lock.
+	refs int
+
+	mu         sync.Mutex
+	day        time.Time
+	bytesToday uint64
+	bytesTotal uint64
+}
+
+// Exceeded checks whether the daily quota is exhausted.
+func (m *usage) Exceeded() bool {
+	if m.privileged || m.limiter.cfg.dailyQuota() == 0 {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rotate(m.limiter.now())
+
+	return m.bytesToday >= m.limiter.cfg.dailyQuota()
+}
+
+// Consume accounts the given number of bytes, blocking until the rate limit
+// allows to transmit them.
+//
+// Returns an error when the daily quota is exhausted. The bytes are
+// accounted anyway, because we've already received them.
+func (m *usage) Consume(ctx context.Context, n int) error {
+	now := m.limiter.now()
+
+	m.mu.Lock()
+	m.rotate(now)
+	m.bytesToday += uint64(n)
+	m.bytesTotal += uint64(n)
+	bytesToday := m.bytesToday
+	m.mu.Unlock()
+
+	if m.privileged {
+		return nil
+	}
+
+	if quota := m.limiter.cfg.dailyQuota(); quota > 0 && bytesToday > quota {
+		return errQuotaExceeded()
+	}
+
+	if m.bucket == nil {
+		return nil
+	}
+
+	delay := m.bucket.Reserve(n, now)
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Release marks the tracker as no longer used by the relayed connection it
+// has been acquired for, allowing to prune it when idle.
+func (m *usage) Release() {
+	m.limiter.mu.Lock()
+	defer m.limiter.mu.Unlock()
+
+	m.refs--
+}
+
+// Idle checks whether the tracker holds no traffic accounted for the
+// current day, i.e. removing it doesn't reset the daily quota.
+func (m *usage) Idle() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rotate(m.limiter.now())
+
+	return m.bytesToday == 0
+}
+
+// rotate resets the daily counter when the day changes. Must be called with
+// the lock held.
+func (m *usage) rotate(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(m.day) {
+		m.day = day
+		m.bytesToday = 0
+	}
+}
+
+func (m *usage) Dump() *sonm.RelayUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rotate(m.limiter.now())
+
+	dump := &sonm.RelayUsage{
+		BytesToday: m.bytesToday,
+		BytesTotal: m.bytesTotal,
+		Privileged: m.privileged,
+	}
+
+	if !m.privileged {
+		dump.DailyQuota = m.limiter.cfg.dailyQuota()
+		dump.Rate = uint64(m.limiter.cfg.rate())
+	}
+
+	return dump
+}
+
+// limiter keeps track of traffic usage for all ETH addresses.
+type limiter struct {
+	cfg        LimitsConfig
+	privileged map[common.Address]bool
+	now        func() time.Time
+
+	mu    sync.Mutex
+	usage map[common.Address]*usage
+}
+
+func newLimiter(cfg LimitsConfig) *limiter {
+	privileged := map[common.Address]bool{}
+	for _, addr := range cfg.Privileged {
+		privileged[addr] = true
+	}
+
+	return &limiter{
+		cfg:        cfg,
+		privileged: privileged,
+		now:        time.Now,
+		usage:      map[common.Address]*usage{},
+	}
+}
+
+// Acquire returns the usage tracker for the given ETH address, creating it
+// if required. The tracker must be released after relaying is finished.
+//
+// Trackers must be acquired for authenticated peers only, otherwise anyone
+// would be able to bloat the limiter.
+func (m *limiter) Acquire(addr common.Address) *usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, ok := m.usage[addr]
+	if !ok {
+		u = &usage{
+			limiter:    m,
+			privileged: m.privileged[addr],
+		}
+
+		if m.cfg.rate() > 0 && !u.privileged {
+			u.bucket = newTokenBucket(m.cfg.rate(), m.cfg.burst(), m.now())
+		}
+
+		m.usage[addr] = u
+	}
+
+	u.refs++
+
+	return u
+}
+
+// Exceeded checks whether the daily quota of the given ETH address is
+// exhausted. Unlike Acquire it never creates trackers.
+func (m *limiter) Exceeded(addr common.Address) bool {
+	m.mu.Lock()
+	u, ok := m.usage[addr]
+	m.mu.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	return u.Exceeded()
+}
+
+// Prune removes trackers that are not used by any relayed connection and
+// have no traffic accounted for the current day.
+func (m *limiter) Prune() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for addr, u := range m.usage {
+		if u.refs == 0 && u.Idle() {
+			delete(m.usage, addr)
+		}
+	}
+}
+
+// Run periodically prunes idle trackers until the context is canceled.
+func (m *limiter) Run(ctx context.Context) error {
+	ticker := time.NewTicker(limiterPruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			m.Prune()
+		}
+	}
+}
+
+func (m *limiter) Dump() map[string]*sonm.RelayUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dump := map[string]*sonm.RelayUsage{}
+	for addr, u := range m.usage {
+		dump[addr.Hex()] = u.Dump()
+	}
+
+	return dump
+}
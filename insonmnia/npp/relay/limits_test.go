@@ -0,0 +1,108 @@
+package relay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimitsConfig(t *testing.T, rate, quota string, privileged ...common.Address) LimitsConfig {
+	cfg := LimitsConfig{Privileged: privileged}
+	require.NoError(t, cfg.Rate.UnmarshalText([]byte(rate)))
+	require.NoError(t, cfg.DailyQuota.UnmarshalText([]byte(quota)))
+
+	return cfg
+}
+
+func TestTokenBucketReserve(t *testing.T) {
+	now := time.Now()
+	bucket := newTokenBucket(1000, 1000, now)
+
+	assert.Equal(t, time.Duration(0), bucket.Reserve(1000, now))
+	assert.Equal(t, 500*time.Millisecond, bucket.Reserve(500, now))
+	// Half a second later the debt is paid off.
+	assert.Equal(t, time.Duration(0), bucket.Reserve(0, now.Add(500*time.Millisecond)))
+	// Tokens never exceed the burst.
+	assert.Equal(t, time.Second, bucket.Reserve(2000, now.Add(time.Hour)))
+}
+
+func TestUsageDailyQuota(t *testing.T) {
+	now := time.Date(2018, 6, 1, 23, 0, 0, 0, time.UTC)
+	limiter := newLimiter(newTestLimitsConfig(t, "0", "100B"))
+	limiter.now = func() time.Time { return now }
+
+	usage := limiter.Acquire(common.HexToAddress("0x1"))
+
+	require.NoError(t, usage.Consume(context.Background(), 100))
+	assert.True(t, usage.Exceeded())
+	assert.Error(t, usage.Consume(context.Background(), 1))
+
+	now = now.Add(2 * time.Hour)
+	assert.False(t, usage.Exceeded())
+	require.NoError(t, usage.Consume(context.Background(), 10))
+
+	dump := usage.Dump()
+	assert.Equal(t, uint64(10), dump.BytesToday)
+	assert.Equal(t, uint64(111), dump.BytesTotal)
+	assert.Equal(t, uint64(100), dump.DailyQuota)
+}
+
+func TestUsagePrivileged(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	limiter := newLimiter(newTestLimitsConfig(t, "8bit/s", "1B", addr))
+
+	usage := limiter.Acquire(addr)
+	require.NoError(t, usage.Consume(context.Background(), 1024))
+	assert.False(t, usage.Exceeded())
+	assert.True(t, usage.Dump().Privileged)
+}
+
+func TestUsageRateLimit(t *testing.T) {
+	cfg := newTestLimitsConfig(t, "8kbit/s", "0")
+	require.NoError(t, cfg.Burst.UnmarshalText([]byte("100B")))
+	assert.Equal(t, 1000.0, cfg.rate())
+
+	usage := newLimiter(cfg).Acquire(common.HexToAddress("0x1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, usage.Consume(ctx, 100))
+	// The next 1000 bytes require a second of waiting, which is longer than
+	// the deadline.
+	assert.Equal(t, context.DeadlineExceeded, usage.Consume(ctx, 1000))
+}
+
+func TestLimiterPrune(t *testing.T) {
+	now := time.Date(2018, 6, 1, 23, 0, 0, 0, time.UTC)
+	limiter := newLimiter(newTestLimitsConfig(t, "0", "100B"))
+	limiter.now = func() time.Time { return now }
+
+	addr := common.HexToAddress("0x1")
+	assert.False(t, limiter.Exceeded(addr))
+	assert.Empty(t, limiter.Dump())
+
+	usage := limiter.Acquire(addr)
+	require.NoError(t, usage.Consume(context.Background(), 100))
+	assert.True(t, limiter.Exceeded(addr))
+
+	// Trackers still in use must not be pruned.
+	limiter.Prune()
+	assert.Len(t, limiter.Dump(), 1)
+
+	// Neither must be ones with traffic accounted for today, otherwise the
+	// daily quota would be reset.
+	usage.Release()
+	limiter.Prune()
+	assert.Len(t, limiter.Dump(), 1)
+	assert.True(t, limiter.Exceeded(addr))
+
+	now = now.Add(2 * time.Hour)
+	limiter.Prune()
+	assert.Empty(t, limiter.Dump())
+	assert.False(t, limiter.Exceeded(addr))
+}
@@ -0,0 +1,68 @@
+package relay
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sonm-io/core/util/netutil"
+)
+
+// memberMeta describes the metadata published by each member of the
+// Relay cluster.
+type memberMeta struct {
+	// WebSocket is the announced public WebSocket endpoint of the member.
+	WebSocket string `json:"websocket,omitempty"`
+}
+
+func newMemberMeta(cfg *WebSocketConfig) (*memberMeta, error) {
+	m := &memberMeta{}
+
+	if cfg != nil && len(cfg.Announce) != 0 {
+		if _, _, err := netutil.SplitHostPort(cfg.Announce); err != nil {
+			return nil, fmt.Errorf("invalid WebSocket announce endpoint: %v", err)
+		}
+
+		m.WebSocket = cfg.Announce
+	}
+
+	return m, nil
+}
+
+func parseMemberMeta(data []byte) (*memberMeta, error) {
+	m := &memberMeta{}
+	if len(data) == 0 {
+		return m, nil
+	}
+
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// NodeMeta implements memberlist.Delegate.
+func (m *memberMeta) NodeMeta(limit int) []byte {
+	data, err := json.Marshal(m)
+	if err != nil || len(data) > limit {
+		return nil
+	}
+
+	return data
+}
+
+// NotifyMsg implements memberlist.Delegate.
+func (m *memberMeta) NotifyMsg([]byte) {}
+
+// GetBroadcasts implements memberlist.Delegate.
+func (m *memberMeta) GetBroadcasts(overhead, limit int) [][]byte {
+	return nil
+}
+
+// LocalState implements memberlist.Delegate.
+func (m *memberMeta) LocalState(join bool) []byte {
+	return nil
+}
+
+// MergeRemoteState implements memberlist.Delegate.
+func (m *memberMeta) MergeRemoteState(buf []byte, join bool) {}
@@ -0,0 +1,42 @@
+package relay
+
+import (
+	"testing"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestMemberMeta(t *testing.T) {
+	meta, err := newMemberMeta(&WebSocketConfig{Announce: "relay.example.com:443"})
+	require.NoError(t, err)
+
+	parsed, err := parseMemberMeta(meta.NodeMeta(memberlist.MetaMaxSize))
+	require.NoError(t, err)
+	assert.Equal(t, "relay.example.com:443", parsed.WebSocket)
+
+	_, err = newMemberMeta(&WebSocketConfig{Announce: "relay.example.com"})
+	assert.Error(t, err)
+}
+
+func TestServerTracksWebSocketEndpoints(t *testing.T) {
+	m := &server{
+		webSocketEndpoints: map[string]string{},
+		log:                zap.NewNop().Sugar(),
+	}
+
+	meta, err := newMemberMeta(&WebSocketConfig{Announce: "relay.example.com:443"})
+	require.NoError(t, err)
+
+	m.updateMember(&memberlist.Node{Name: "relay-1", Meta: meta.NodeMeta(memberlist.MetaMaxSize)})
+	m.updateMember(&memberlist.Node{Name: "relay-2"})
+
+	endpoint, ok := m.webSocketEndpoint("relay-1")
+	assert.True(t, ok)
+	assert.Equal(t, "relay.example.com:443", endpoint)
+
+	_, ok = m.webSocketEndpoint("relay-2")
+	assert.False(t, ok)
+}
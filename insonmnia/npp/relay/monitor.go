@@ -22,10 +22,11 @@ type monitor struct {
 	cluster *memberlist.Memberlist
 
 	metrics *metrics
+	limiter *limiter
 	log     *zap.Logger
 }
 
-func newMonitor(cfg MonitorConfig, cluster *memberlist.Memberlist, metrics *metrics, log *zap.Logger) (*monitor, error) {
+func newMonitor(cfg MonitorConfig, cluster *memberlist.Memberlist, metrics *metrics, limiter *limiter, log *zap.Logger) (*monitor, error) {
 	certificate, TLSConfig, err := util.NewHitlessCertRotator(context.Background(), cfg.PrivateKey)
 	if err != nil {
 		return nil, err
@@ -45,6 +46,7 @@ func newMonitor(cfg MonitorConfig, cluster *memberlist.Memberlist, metrics *metr
 		server:      server,
 		cluster:     cluster,
 		metrics:     metrics,
+		limiter:     limiter,
 		log:         log,
 	}
 
@@ -65,7 +67,10 @@ func (m *monitor) Cluster(ctx context.Context, request *sonm.Empty) (*sonm.Relay
 }
 
 func (m *monitor) Metrics(ctx context.Context, request *sonm.Empty) (*sonm.RelayMetrics, error) {
-	return m.metrics.Dump(), nil
+	metrics := m.metrics.Dump()
+	metrics.Usage = m.limiter.Dump()
+
+	return metrics, nil
 }
 
 func (m *monitor) Serve() error {
@@ -1,18 +1,24 @@
 package relay
 
-import "go.uber.org/zap"
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+)
 
 // Option is a function that configures the server.
 type Option func(options *options) error
 
 type options struct {
 	bufferSize int
+	verifiers  map[string]ClientVerifier
 	log        *zap.Logger
 }
 
 func newOptions() *options {
 	return &options{
 		bufferSize: 32 * 1024,
+		verifiers:  map[string]ClientVerifier{},
 		log:        zap.NewNop(),
 	}
 }
@@ -27,3 +33,16 @@ func WithLogger(log *zap.Logger) Option {
 		return nil
 	}
 }
+
+// WithClientVerifier is an option that registers a client verifier under the
+// given name, allowing servers to refer to it in their ACLs.
+func WithClientVerifier(name string, verifier ClientVerifier) Option {
+	return func(options *options) error {
+		if _, ok := options.verifiers[name]; ok {
+			return fmt.Errorf("client verifier `%s` is already registered", name)
+		}
+
+		options.verifiers[name] = verifier
+		return nil
+	}
+}
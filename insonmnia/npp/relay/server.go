@@ -50,6 +50,24 @@
 // optional message encryption and members authentication can be specified for
 // security reasons.
 //
+// Besides TCP, the Relay can forward UDP datagrams for peers that handshake
+// with "udp" protocol. Such datagrams are transported over the same TCP
+// connections, each prefixed with its size to preserve message boundaries.
+//
+// Clients may optionally sign their handshakes, proving their own ETH
+// address. Servers in their turn may publish an ACL, which restricts clients
+// allowed to connect to them either explicitly or using Relay-side
+// verifiers, for example allowing only current deal counterparties.
+//
+// Traffic can be limited per ETH address of the server peer using both
+// token-bucket rate limiting and daily quotas, except for privileged
+// addresses. When the quota is exhausted the Relay drops active connections
+// and rejects new handshakes until the next UTC day.
+//
+// Besides the raw TCP, the Relay can serve the same protocol tunnelled over
+// WebSocket, optionally on top of TLS, for peers in restrictive networks
+// where only outbound HTTP(S) is allowed, possibly through a proxy.
+//
 // Relay servers obviously require to be hosted on machines with public IP
 // address. However additionally an announce endpoint can be specified to host
 // Relay servers under the NAT, but with configured PMP or other stuff that
@@ -59,11 +77,13 @@ package relay
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"io"
 	"math/rand"
 	"net"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -83,8 +103,12 @@ import (
 type meeting struct {
 	conn net.Conn
 	tx   chan<- net.Conn
+	acl  *sonm.RelayACL
 }
 
+// allowFunc checks whether the server's ACL allows the current client.
+type allowFunc func(acl *sonm.RelayACL) bool
+
 type meetingRoom struct {
 	mu sync.Mutex
 	// Multiple servers can be registered for fault tolerance.
@@ -100,27 +124,62 @@ func newMeetingRoom(log *zap.Logger) *meetingRoom {
 	}
 }
 
-func (m *meetingRoom) PopRandomServer(addr nppc.ResourceID) *meeting {
+// PopRandomServer pops a random server allowing the client to connect,
+// returning whether there were servers that denied it.
+func (m *meetingRoom) PopRandomServer(addr nppc.ResourceID, allow allowFunc) (*meeting, bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if servers, ok := m.servers[addr]; ok {
-		return servers.popRandom()
+		return servers.popRandom(allow)
 	}
-	return nil
+	return nil, false
 }
 
-func (m *meetingRoom) PopServer(addr nppc.ResourceID, id ConnID) *meeting {
+// PopServer pops the specified server if it allows the client to connect,
+// returning whether it has denied it.
+func (m *meetingRoom) PopServer(addr nppc.ResourceID, id ConnID, allow allowFunc) (*meeting, bool) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
 	if servers, ok := m.servers[addr]; ok {
-		return servers.pop(id)
+		return servers.pop(id, allow)
 	}
-	return nil
+	return nil, false
 }
 
-func (m *meetingRoom) PutServer(addr nppc.ResourceID, id ConnID, conn net.Conn, tx chan<- net.Conn) {
+// Verifiers returns names of client verifiers referred by ACLs of the
+// waiting servers. When id is not empty only the specified server is
+// considered.
+func (m *meetingRoom) Verifiers(addr nppc.ResourceID, id ConnID) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	servers, ok := m.servers[addr]
+	if !ok {
+		return nil
+	}
+
+	unique := map[string]bool{}
+	for candidateID, candidate := range servers.candidates {
+		if len(id) != 0 && candidateID != id {
+			continue
+		}
+
+		for _, name := range candidate.acl.GetVerifiers() {
+			unique[name] = true
+		}
+	}
+
+	names := make([]string, 0, len(unique))
+	for name := range unique {
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func (m *meetingRoom) PutServer(addr nppc.ResourceID, id ConnID, conn net.Conn, tx chan<- net.Conn, acl *sonm.RelayACL) {
 	m.log.Debugf("putting %s server into the meeting map with %s id", addr.String(), id)
 
 	m.mu.Lock()
@@ -131,7 +190,7 @@ func (m *meetingRoom) PutServer(addr nppc.ResourceID, id ConnID, conn net.Conn,
 		servers = newConnPool()
 		m.servers[addr] = servers
 	}
-	servers.put(id, conn, tx)
+	servers.put(id, conn, tx, acl)
 }
 
 func (m *meetingRoom) DiscardConnections(addrs []nppc.ResourceID) {
@@ -163,41 +222,51 @@ func newConnPool() *connPool {
 	}
 }
 
-func (m *connPool) put(id ConnID, conn net.Conn, tx chan<- net.Conn) {
+func (m *connPool) put(id ConnID, conn net.Conn, tx chan<- net.Conn, acl *sonm.RelayACL) {
 	m.candidates[id] = &meeting{
 		conn: conn,
 		tx:   tx,
+		acl:  acl,
 	}
 }
 
-func (m *connPool) pop(id ConnID) *meeting {
+func (m *connPool) pop(id ConnID, allow allowFunc) (*meeting, bool) {
 	v, ok := m.candidates[id]
-	if ok {
-		delete(m.candidates, id)
-		return v
+	if !ok {
+		return nil, false
 	}
 
-	return nil
+	if !allow(v.acl) {
+		return nil, true
+	}
+
+	delete(m.candidates, id)
+	return v, false
 }
 
-func (m *connPool) popRandom() *meeting {
-	if len(m.candidates) == 0 {
-		return nil
-	}
+func (m *connPool) popRandom(allow allowFunc) (*meeting, bool) {
 	var keys []ConnID
-	for key := range m.candidates {
-		keys = append(keys, key)
+	for key, candidate := range m.candidates {
+		if allow(candidate.acl) {
+			keys = append(keys, key)
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, len(m.candidates) != 0
 	}
 
 	k := keys[rand.Intn(len(keys))]
 	v := m.candidates[k]
 	delete(m.candidates, k)
-	return v
+	return v, false
 }
 
 type meetingHandler struct {
+	protocol   string
 	bufferSize int
 	metrics    *netMetrics
+	usage      *usage
 	log        *zap.SugaredLogger
 }
 
@@ -205,24 +274,63 @@ func (m *meetingHandler) Relay(ctx context.Context, server, client net.Conn) err
 	log := m.log.With(zap.Stringer("server", server.RemoteAddr()), zap.Stringer("client", client.RemoteAddr()))
 	log.Info("ready for relaying")
 	defer log.Info("finished relaying")
+	defer m.usage.Release()
 
-	wg := errgroup.Group{}
+	transmit := m.transmitTCP
+	if m.protocol == sonm.UDPNPPProtocol {
+		transmit = m.transmitDatagrams
+	}
+
+	// Closing both connections when either direction fails, otherwise the
+	// other one may hang forever, for example when the quota is exceeded.
+	wg, ctx := errgroup.WithContext(ctx)
 	wg.Go(func() error {
-		return m.transmitTCP(server, client, m.metrics.TxBytes, log)
+		defer client.Close()
+		return transmit(ctx, server, client, m.metrics.TxBytes, log)
 	})
 	wg.Go(func() error {
-		return m.transmitTCP(client, server, m.metrics.RxBytes, log)
+		defer server.Close()
+		return transmit(ctx, client, server, m.metrics.RxBytes, log)
 	})
 
 	return wg.Wait()
 }
 
-func (m *meetingHandler) transmitTCP(from, to net.Conn, metrics *atomic.Uint64, log *zap.SugaredLogger) error {
+// transmitDatagrams forwards size-prefixed datagrams one by one, validating
+// their sizes, so a misbehaving peer can't break the other's framing.
+func (m *meetingHandler) transmitDatagrams(ctx context.Context, from, to net.Conn, metrics *atomic.Uint64, log *zap.SugaredLogger) error {
+	buf := make([]byte, 2+MaxDatagramSize)
+
+	for {
+		size, err := readDatagram(from, buf[2:])
+		if err != nil {
+			return err
+		}
+
+		if err := m.usage.Consume(ctx, size); err != nil {
+			return err
+		}
+
+		binary.BigEndian.PutUint16(buf, uint16(size))
+		if _, err := to.Write(buf[:2+size]); err != nil {
+			return err
+		}
+
+		metrics.Add(uint64(size))
+		log.Debugf("%d bytes datagram transmitted %s -> %s", size, from.RemoteAddr(), to.RemoteAddr())
+	}
+}
+
+func (m *meetingHandler) transmitTCP(ctx context.Context, from, to net.Conn, metrics *atomic.Uint64, log *zap.SugaredLogger) error {
 	buf := make([]byte, m.bufferSize)
 
 	for {
 		bytesRead, errRead := from.Read(buf[:])
 		if bytesRead > 0 {
+			if err := m.usage.Consume(ctx, bytesRead); err != nil {
+				return err
+			}
+
 			var bytesSent int
 			for bytesSent < bytesRead {
 				n, err := to.Write(buf[bytesSent:bytesRead])
@@ -246,10 +354,11 @@ func (m *meetingHandler) transmitTCP(from, to net.Conn, metrics *atomic.Uint64,
 type server struct {
 	cfg ServerConfig
 
-	port     netutil.Port
-	listener net.Listener
-	cluster  *memberlist.Memberlist
-	members  []string
+	port      netutil.Port
+	listener  net.Listener
+	webSocket *webSocketServer
+	cluster   *memberlist.Memberlist
+	members   []string
 
 	meetingRoom *meetingRoom
 
@@ -259,8 +368,17 @@ type server struct {
 	waitTimeout      time.Duration
 
 	metrics           *metrics
+	limiter           *limiter
 	newMeetingHandler func(addr nppc.ResourceID) *meetingHandler
 
+	verifiers map[string]ClientVerifier
+	nonces    *nonceCache
+
+	// webSocketEndpoints maps cluster member names to their announced
+	// WebSocket endpoints.
+	mu                 sync.Mutex
+	webSocketEndpoints map[string]string
+
 	monitoring *monitor
 
 	log *zap.SugaredLogger
@@ -290,12 +408,15 @@ func NewServer(cfg ServerConfig, options ...Option) (*server, error) {
 	}
 
 	metrics := newMetrics()
+	limiter := newLimiter(cfg.Limits)
 
 	newMeetingHandler := func(addr nppc.ResourceID) *meetingHandler {
 		return &meetingHandler{
+			protocol:   addr.Protocol,
 			bufferSize: opts.bufferSize,
 
 			metrics: metrics.NetMetrics(addr),
+			usage:   limiter.Acquire(addr.Addr),
 			log:     opts.log.Sugar(),
 		}
 	}
@@ -316,8 +437,14 @@ func NewServer(cfg ServerConfig, options ...Option) (*server, error) {
 		waitTimeout:      24 * time.Hour,
 
 		metrics:           metrics,
+		limiter:           limiter,
 		newMeetingHandler: newMeetingHandler,
 
+		verifiers: opts.verifiers,
+		nonces:    newNonceCache(),
+
+		webSocketEndpoints: map[string]string{},
+
 		log: opts.log.Sugar(),
 	}
 
@@ -325,7 +452,17 @@ func NewServer(cfg ServerConfig, options ...Option) (*server, error) {
 		return nil, err
 	}
 
-	m.monitoring, err = newMonitor(cfg.Monitor, m.cluster, metrics, opts.log)
+	if cfg.WebSocket != nil {
+		m.webSocket, err = newWebSocketServer(*cfg.WebSocket, func(ctx context.Context, conn net.Conn) {
+			m.log.Debugf("accepted WebSocket connection from %s", conn.RemoteAddr())
+			m.processConnection(ctx, conn, m.webSocket.port)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.monitoring, err = newMonitor(cfg.Monitor, m.cluster, metrics, limiter, opts.log)
 	if err != nil {
 		return nil, err
 	}
@@ -363,9 +500,15 @@ func (m *server) initCluster(cfg ClusterConfig) error {
 		config.AdvertiseAddr = announceAddr.String()
 		config.AdvertisePort = int(announcePort)
 	}
+	meta, err := newMemberMeta(m.cfg.WebSocket)
+	if err != nil {
+		return err
+	}
+
+	config.Delegate = meta
 	config.Events = m
 	config.Keyring = keyring
-	config.LogOutput = newLogAdapter(m.log.Desugar())
+	config.LogOutput = nppc.NewLogAdapter(m.log.Desugar())
 	config.ProbeInterval = time.Second
 
 	m.cluster, err = memberlist.Create(config)
@@ -382,11 +525,22 @@ func (m *server) Serve(ctx context.Context) error {
 	wg.Go(func() error {
 		return m.serveTCP(ctx)
 	})
+	if m.webSocket != nil {
+		wg.Go(func() error {
+			m.log.Infof("running WebSocket Relay server on %s", m.webSocket.Addr())
+			defer m.log.Info("WebSocket Relay server has been stopped")
+
+			return m.webSocket.Serve(ctx)
+		})
+	}
 	wg.Go(func() error {
 		// GRPC API doesn't allow to forward the context. Hence the server
 		// must be stopped explicitly.
 		return m.serveGRPC()
 	})
+	wg.Go(func() error {
+		return m.limiter.Run(ctx)
+	})
 	if m.cfg.Debug != nil {
 		wg.Go(func() error {
 			return debug.ServePProf(ctx, *m.cfg.Debug, m.log.Desugar())
@@ -421,7 +575,7 @@ func (m *server) serveTCP(ctx context.Context) error {
 		}
 
 		m.log.Debugf("accepted connection from %s", conn.RemoteAddr())
-		go m.processConnection(ctx, conn)
+		go m.processConnection(ctx, conn, m.port)
 	}
 }
 
@@ -429,13 +583,15 @@ func (m *server) serveGRPC() error {
 	return m.monitoring.Serve()
 }
 
-func (m *server) processConnection(ctx context.Context, conn net.Conn) {
+// processConnection processes the Relay protocol over the given
+// connection, which has been accepted on the specified port.
+func (m *server) processConnection(ctx context.Context, conn net.Conn, port netutil.Port) {
 	defer conn.Close()
 
 	m.metrics.ConnCurrent.Inc()
 	defer m.metrics.ConnCurrent.Dec()
 
-	if err := m.processConnectionBlocking(ctx, conn); err != nil {
+	if err := m.processConnectionBlocking(ctx, conn, port); err != nil {
 		if err != io.EOF {
 			m.log.Warnw("failed to process connection", zap.Error(err))
 		}
@@ -451,13 +607,16 @@ func (m *server) processConnection(ctx context.Context, conn net.Conn) {
 	}
 }
 
-func (m *server) processConnectionBlocking(ctx context.Context, conn net.Conn) error {
+func (m *server) processConnectionBlocking(ctx context.Context, conn net.Conn, port netutil.Port) error {
 	// Then we need to read a handshake message. Until this, we don't know
 	// whether the connection is a server or a client.
-	ctx, cancel := context.WithTimeout(ctx, m.handshakeTimeout)
+	//
+	// The timeout applies to the handshake only, while relaying lasts for
+	// the server lifetime, otherwise throttled transfers would be cut.
+	handshakeCtx, cancel := context.WithTimeout(ctx, m.handshakeTimeout)
 	defer cancel()
 
-	handshake, err := m.readHandshake(ctx, conn)
+	handshake, err := m.readHandshake(handshakeCtx, conn)
 	if err != nil {
 		return err
 	}
@@ -468,7 +627,7 @@ func (m *server) processConnectionBlocking(ctx context.Context, conn net.Conn) e
 			Protocol: handshake.Protocol,
 			Addr:     common.BytesToAddress(handshake.Addr),
 		}
-		return m.processDiscover(ctx, conn, addr)
+		return m.processDiscover(handshakeCtx, conn, addr, port)
 	case sonm.PeerType_SERVER, sonm.PeerType_CLIENT:
 		return m.processHandshake(ctx, conn, handshake)
 	default:
@@ -476,7 +635,7 @@ func (m *server) processConnectionBlocking(ctx context.Context, conn net.Conn) e
 	}
 }
 
-func (m *server) processDiscover(ctx context.Context, conn net.Conn, addr nppc.ResourceID) error {
+func (m *server) processDiscover(ctx context.Context, conn net.Conn, addr nppc.ResourceID, port netutil.Port) error {
 	m.log.Debugf("processing discover request %s", conn.RemoteAddr())
 
 	targetNode, err := m.continuum.GetNode(addr)
@@ -484,8 +643,21 @@ func (m *server) processDiscover(ctx context.Context, conn net.Conn, addr nppc.R
 		return err
 	}
 
-	m.log.Debugf("redirecting handshake for %s to %s", conn.RemoteAddr(), targetNode.String())
-	return sendFrame(conn, newDiscoverResponse(targetNode.Addr))
+	// The peer must be redirected to the same transport it came through.
+	host, _, err := net.SplitHostPort(targetNode.Addr)
+	if err != nil {
+		return err
+	}
+	targetAddr := net.JoinHostPort(host, strconv.Itoa(int(port)))
+
+	if m.webSocket != nil && port == m.webSocket.port {
+		if endpoint, ok := m.webSocketEndpoint(targetNode.Name); ok {
+			targetAddr = endpoint
+		}
+	}
+
+	m.log.Debugf("redirecting handshake for %s to %s", conn.RemoteAddr(), targetAddr)
+	return sendFrame(conn, newDiscoverResponse(targetAddr))
 }
 
 func (m *server) processHandshake(ctx context.Context, conn net.Conn, handshake *sonm.HandshakeRequest) error {
@@ -511,6 +683,10 @@ func (m *server) processHandshake(ctx context.Context, conn net.Conn, handshake
 		return errWrongNode()
 	}
 
+	if m.limiter.Exceeded(addr.Addr) {
+		return errQuotaExceeded()
+	}
+
 	// We support both multiple servers and clients.
 	switch handshake.PeerType {
 	case sonm.PeerType_SERVER:
@@ -520,7 +696,7 @@ func (m *server) processHandshake(ctx context.Context, conn net.Conn, handshake
 		defer timer.Stop()
 
 		m.continuum.Track(addr)
-		m.meetingRoom.PutServer(addr, id, conn, tx)
+		m.meetingRoom.PutServer(addr, id, conn, tx, handshake.ACL)
 
 		select {
 		case clientConn, ok := <-rx:
@@ -534,15 +710,35 @@ func (m *server) processHandshake(ctx context.Context, conn net.Conn, handshake
 				return m.newMeetingHandler(addr).Relay(ctx, conn, clientConn)
 			}
 		case <-timer.C:
-			m.meetingRoom.PopServer(addr, id)
+			m.meetingRoom.PopServer(addr, id, allowAll)
 			return errTimeout()
 		}
 	case sonm.PeerType_CLIENT:
+		client, err := m.authenticateClient(handshake)
+		if err != nil {
+			return err
+		}
+
+		// Verifiers may require network communication, so their results
+		// are obtained before touching the meeting room.
+		verifyCtx, cancel := context.WithTimeout(ctx, m.handshakeTimeout)
+		verified := m.verifyClient(verifyCtx, addr.Addr, client, m.meetingRoom.Verifiers(addr, ConnID(handshake.UUID)))
+		cancel()
+
+		allow := func(acl *sonm.RelayACL) bool {
+			return isAllowed(acl, client, verified)
+		}
+
 		var targetPeer *meeting
+		var denied bool
 		if handshake.HasUUID() {
-			targetPeer = m.meetingRoom.PopServer(addr, ConnID(handshake.UUID))
+			targetPeer, denied = m.meetingRoom.PopServer(addr, ConnID(handshake.UUID), allow)
 		} else {
-			targetPeer = m.meetingRoom.PopRandomServer(addr)
+			targetPeer, denied = m.meetingRoom.PopRandomServer(addr, allow)
+		}
+
+		if targetPeer == nil && denied {
+			return errUnauthorized()
 		}
 
 		if targetPeer != nil {
@@ -597,6 +793,9 @@ func (m *server) readHandshake(ctx context.Context, conn net.Conn) (*sonm.Handsh
 
 func (m *server) Close() error {
 	m.monitoring.Close()
+	if m.webSocket != nil {
+		m.webSocket.Close()
+	}
 	return m.listener.Close()
 }
 
@@ -609,6 +808,8 @@ func (m *server) NotifyJoin(node *memberlist.Node) {
 		return
 	}
 
+	m.updateMember(node)
+
 	discarded := m.continuum.Add(continuumNode.String(), 1)
 	m.meetingRoom.DiscardConnections(discarded)
 }
@@ -622,12 +823,44 @@ func (m *server) NotifyLeave(node *memberlist.Node) {
 		return
 	}
 
+	m.mu.Lock()
+	delete(m.webSocketEndpoints, node.Name)
+	m.mu.Unlock()
+
 	discarded := m.continuum.Remove(continuumNode.String())
 	m.meetingRoom.DiscardConnections(discarded)
 }
 
 func (m *server) NotifyUpdate(node *memberlist.Node) {
 	m.log.Infof("node `%s` has been updated", node.Name)
+
+	m.updateMember(node)
+}
+
+// updateMember remembers the metadata published by the cluster member.
+func (m *server) updateMember(node *memberlist.Node) {
+	meta, err := parseMemberMeta(node.Meta)
+	if err != nil {
+		m.log.Warnf("received malformed metadata of node `%s`: %v", node.Name, err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(meta.WebSocket) == 0 {
+		delete(m.webSocketEndpoints, node.Name)
+	} else {
+		m.webSocketEndpoints[node.Name] = meta.WebSocket
+	}
+}
+
+func (m *server) webSocketEndpoint(name string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endpoint, ok := m.webSocketEndpoints[name]
+	return endpoint, ok
 }
 
 func (m *server) formatEndpoint(ip net.IP) string {
@@ -639,6 +872,10 @@ func (m *server) formatEndpoint(ip net.IP) string {
 	return addr.String()
 }
 
+func allowAll(*sonm.RelayACL) bool {
+	return true
+}
+
 func mpsc() (chan<- net.Conn, <-chan net.Conn) {
 	txrx := make(chan net.Conn, 1)
 	return txrx, txrx
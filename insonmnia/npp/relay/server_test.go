@@ -0,0 +1,127 @@
+package relay
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/npp/nppc"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestServer(t *testing.T, limits LimitsConfig) *server {
+	log := zap.NewNop()
+	metrics := newMetrics()
+	limiter := newLimiter(limits)
+
+	m := &server{
+		cfg:              ServerConfig{Cluster: ClusterConfig{Name: "relay"}},
+		meetingRoom:      newMeetingRoom(log),
+		continuum:        newContinuum(),
+		handshakeTimeout: 100 * time.Millisecond,
+		waitTimeout:      time.Minute,
+		metrics:          metrics,
+		limiter:          limiter,
+		nonces:           newNonceCache(),
+		log:              log.Sugar(),
+	}
+	m.newMeetingHandler = func(addr nppc.ResourceID) *meetingHandler {
+		return &meetingHandler{
+			protocol:   addr.Protocol,
+			bufferSize: 64,
+			metrics:    metrics.NetMetrics(addr),
+			usage:      limiter.Acquire(addr.Addr),
+			log:        log.Sugar(),
+		}
+	}
+
+	m.continuum.Add("relay@127.0.0.1:12240", 1)
+
+	return m
+}
+
+func connectTestPeer(t *testing.T, ctx context.Context, m *server, handshake *sonm.HandshakeRequest) net.Conn {
+	conn, serverConn := net.Pipe()
+	go m.processConnection(ctx, serverConn, 12240)
+
+	require.NoError(t, sendFrame(conn, handshake))
+
+	return conn
+}
+
+// waitTestServer waits until a server with the given address is put into the
+// meeting room.
+func waitTestServer(t *testing.T, m *server, addr nppc.ResourceID) {
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		m.meetingRoom.mu.Lock()
+		servers, ok := m.meetingRoom.servers[addr]
+		waiting := ok && len(servers.candidates) != 0
+		m.meetingRoom.mu.Unlock()
+
+		if waiting {
+			return
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	t.Fatal("server has not been put into the meeting room")
+}
+
+func TestRelayThrottledTransferOutlivesHandshakeTimeout(t *testing.T) {
+	limits := newTestLimitsConfig(t, "8kbit/s", "0")
+	require.NoError(t, limits.Burst.UnmarshalText([]byte("100B")))
+
+	m := newTestServer(t, limits)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signedAddr, err := NewSignedAddr(key)
+	require.NoError(t, err)
+
+	serverConn := connectTestPeer(t, ctx, m, newServerHandshake(signedAddr, sonm.DefaultNPPProtocol, nil))
+	defer serverConn.Close()
+
+	waitTestServer(t, m, nppc.ResourceID{Protocol: sonm.DefaultNPPProtocol, Addr: signedAddr.Addr()})
+
+	clientConn := connectTestPeer(t, ctx, m, newClientHandshake(signedAddr.Addr(), "", sonm.DefaultNPPProtocol))
+	defer clientConn.Close()
+
+	// Pipes are synchronous, so replies are read concurrently.
+	errs := make(chan error, 2)
+	for _, conn := range []net.Conn{serverConn, clientConn} {
+		go func(conn net.Conn) {
+			errs <- recvFrame(conn, &sonm.HandshakeResponse{})
+		}(conn)
+	}
+	for range []net.Conn{serverConn, clientConn} {
+		require.NoError(t, <-errs)
+	}
+
+	// 600 bytes at 1000 B/s take about half a second, which is longer than
+	// the handshake timeout.
+	data := make([]byte, 600)
+	go func() {
+		serverConn.Write(data)
+		serverConn.Close()
+	}()
+
+	startedAt := time.Now()
+	received, err := ioutil.ReadAll(clientConn)
+	if err != io.ErrClosedPipe {
+		require.NoError(t, err)
+	}
+	assert.Equal(t, len(data), len(received))
+	assert.True(t, time.Since(startedAt) > m.handshakeTimeout)
+}
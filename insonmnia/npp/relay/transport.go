@@ -0,0 +1,206 @@
+// This module contains transports used to reach the Relay servers.
+//
+// Besides raw TCP the Relay protocol can be tunnelled over WebSocket,
+// optionally on top of TLS and through an HTTP CONNECT proxy, which helps
+// peers in restrictive networks where only outbound HTTP(S) is allowed.
+
+package relay
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// WebSocketPath is the HTTP path the Relay serves WebSocket transport on.
+	WebSocketPath = "/relay"
+)
+
+// transport establishes connections to the Relay servers.
+type transport interface {
+	// Dial connects to the Relay server at the given "host:port" address.
+	Dial(addr string) (net.Conn, error)
+}
+
+type tcpTransport struct{}
+
+func (tcpTransport) Dial(addr string) (net.Conn, error) {
+	// Setting TCP keepalive is strictly suggested, because in case of mobile
+	// devices the network can be reconfigured multiple times between
+	// connection attempts.
+	// However if the network was silently changed or the connection was lost
+	// or the other side was kernel-panicked no FIN/RST will be delivered to
+	// us, which leads to infinite (well, 24-hour) hanging.
+	dialer := net.Dialer{
+		KeepAlive: tcpKeepAliveInterval,
+	}
+
+	return dialer.Dial("tcp", addr)
+}
+
+// webSocketTransport tunnels the Relay protocol over WebSocket.
+//
+// All Relay servers in the cluster are reached using the same URL, i.e. the
+// host from the URL is used as both TLS server name and HTTP Host header
+// regardless of the actual address dialed. Thus, the certificate must be
+// valid for that host on all of the cluster members.
+type webSocketTransport struct {
+	url       *url.URL
+	tlsConfig *tls.Config
+}
+
+// isWebSocketEndpoint checks whether the given Relay endpoint should be
+// reached using WebSocket transport.
+func isWebSocketEndpoint(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "ws://") || strings.HasPrefix(endpoint, "wss://")
+}
+
+func newWebSocketTransport(endpoint string) (*webSocketTransport, error) {
+	location, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(location.Path) == 0 {
+		location.Path = WebSocketPath
+	}
+
+	if len(location.Port()) == 0 {
+		switch location.Scheme {
+		case "ws":
+			location.Host = net.JoinHostPort(location.Hostname(), "80")
+		case "wss":
+			location.Host = net.JoinHostPort(location.Hostname(), "443")
+		default:
+			return nil, fmt.Errorf("unsupported WebSocket scheme: %s", location.Scheme)
+		}
+	}
+
+	m := &webSocketTransport{
+		url: location,
+		tlsConfig: &tls.Config{
+			ServerName: location.Hostname(),
+		},
+	}
+
+	return m, nil
+}
+
+// Addr returns the address of the Relay endpoint itself.
+func (m *webSocketTransport) Addr() string {
+	return m.url.Host
+}
+
+func (m *webSocketTransport) Dial(addr string) (net.Conn, error) {
+	conn, err := dialProxy(addr, m.url.Scheme == "wss")
+	if err != nil {
+		return nil, err
+	}
+
+	if m.url.Scheme == "wss" {
+		tlsConn := tls.Client(conn, m.tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+
+		conn = tlsConn
+	}
+
+	origin := &url.URL{Scheme: "https", Host: m.url.Host}
+	if m.url.Scheme == "ws" {
+		origin.Scheme = "http"
+	}
+
+	cfg, err := websocket.NewConfig(m.url.String(), origin.String())
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to perform WebSocket handshake: %v", err)
+	}
+
+	ws.PayloadType = websocket.BinaryFrame
+
+	return ws, nil
+}
+
+// dialProxy connects to the given address, tunnelling through the HTTP
+// proxy specified in the environment using CONNECT method if required.
+func dialProxy(addr string, secure bool) (net.Conn, error) {
+	target := &url.URL{Scheme: "http", Host: addr}
+	if secure {
+		target.Scheme = "https"
+	}
+
+	proxyURL, err := http.ProxyFromEnvironment(&http.Request{URL: target})
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL == nil {
+		return tcpTransport{}.Dial(addr)
+	}
+
+	proxyAddr := proxyURL.Host
+	if len(proxyURL.Port()) == 0 {
+		proxyAddr = net.JoinHostPort(proxyURL.Hostname(), "80")
+	}
+
+	conn, err := tcpTransport{}.Dial(proxyAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy %s: %v", proxyAddr, err)
+	}
+
+	if err := connectProxy(conn, addr, proxyURL); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to tunnel through proxy %s: %v", proxyAddr, err)
+	}
+
+	return conn, nil
+}
+
+func connectProxy(conn net.Conn, addr string, proxyURL *url.URL) error {
+	request := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: http.Header{},
+	}
+
+	if user := proxyURL.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		request.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := request.Write(conn); err != nil {
+		return err
+	}
+
+	// Proxy must not send anything after the response until we speak, so
+	// there is no risk of losing buffered data.
+	response, err := http.ReadResponse(bufio.NewReader(conn), request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected proxy response: %s", response.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package relay
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebSocketTransportRoundTrip(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server, err := newWebSocketServer(WebSocketConfig{Endpoint: "127.0.0.1:0"}, func(ctx context.Context, conn net.Conn) {
+		defer conn.Close()
+
+		request := &sonm.HandshakeRequest{}
+		if err := recvFrame(conn, request); err != nil {
+			return
+		}
+
+		sendFrame(conn, newDiscoverResponse(conn.RemoteAddr().String()))
+		io.Copy(conn, conn)
+	})
+	require.NoError(t, err)
+	go server.Serve(ctx)
+
+	transport, err := newWebSocketTransport(fmt.Sprintf("ws://%s", server.Addr()))
+	require.NoError(t, err)
+
+	conn, err := transport.Dial(transport.Addr())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, sendFrame(conn, newDiscover([20]byte{}, sonm.DefaultNPPProtocol)))
+
+	response := &sonm.DiscoverResponse{}
+	require.NoError(t, recvFrame(conn, response))
+	// The server must see the real peer address instead of the origin.
+	host, _, err := net.SplitHostPort(response.Addr)
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1", host)
+
+	// Raw bytes are passed through after the handshake.
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+}
+
+func TestConnectProxy(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	requests := make(chan *http.Request, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		request, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		requests <- request
+
+		conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		io.Copy(conn, conn)
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	proxyURL := &url.URL{Scheme: "http", Host: listener.Addr().String(), User: url.UserPassword("user", "secret")}
+	require.NoError(t, connectProxy(conn, "relay.example.com:443", proxyURL))
+
+	request := <-requests
+	assert.Equal(t, http.MethodConnect, request.Method)
+	assert.Equal(t, "relay.example.com:443", request.Host)
+	assert.Equal(t, "Basic dXNlcjpzZWNyZXQ=", request.Header.Get("Proxy-Authorization"))
+
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+}
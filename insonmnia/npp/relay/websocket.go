@@ -0,0 +1,127 @@
+package relay
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+
+	"github.com/sonm-io/core/util/netutil"
+	"golang.org/x/net/websocket"
+)
+
+// WebSocketConfig describes the Relay WebSocket transport.
+type WebSocketConfig struct {
+	// Endpoint to listen on.
+	//
+	// Note that for the entire cluster it is meant that all of them will
+	// listen the same port, unless Announce is specified.
+	Endpoint string `yaml:"endpoint" required:"true"`
+	// Announce is an optional public "host:port" endpoint peers redirected
+	// to this member are told to connect to, for example the address of a
+	// TLS terminating load balancer in front of it. Defaults to the cluster
+	// address of the member with the listening port.
+	Announce string `yaml:"announce"`
+	// CertFile and KeyFile are paths to the TLS certificate and its private
+	// key. The certificate must be valid for the hostname clients use to
+	// reach the cluster. Plain WebSocket is served when omitted, for
+	// example behind a TLS terminating load balancer.
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+}
+
+// webSocketConn wraps server-side WebSocket connection, providing the real
+// remote address instead of the origin.
+type webSocketConn struct {
+	*websocket.Conn
+	remoteAddr net.Addr
+}
+
+func (m *webSocketConn) RemoteAddr() net.Addr {
+	return m.remoteAddr
+}
+
+type webSocketServer struct {
+	ctx      context.Context
+	port     netutil.Port
+	listener net.Listener
+	server   *http.Server
+}
+
+func newWebSocketServer(cfg WebSocketConfig, handler func(ctx context.Context, conn net.Conn)) (*webSocketServer, error) {
+	listener, err := net.Listen("tcp", cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	port, err := netutil.ExtractPort(listener.Addr().String())
+	if err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	if len(cfg.CertFile) != 0 {
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			listener.Close()
+			return nil, err
+		}
+
+		listener = tls.NewListener(listener, &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+		})
+	}
+
+	m := &webSocketServer{
+		ctx:      context.Background(),
+		port:     port,
+		listener: listener,
+	}
+
+	wsServer := websocket.Server{
+		// Any origin is allowed, because peers aren't browsers.
+		Handshake: func(cfg *websocket.Config, request *http.Request) error {
+			return nil
+		},
+		Handler: func(conn *websocket.Conn) {
+			conn.PayloadType = websocket.BinaryFrame
+
+			remoteAddr, err := net.ResolveTCPAddr("tcp", conn.Request().RemoteAddr)
+			if err != nil {
+				conn.Close()
+				return
+			}
+
+			handler(m.ctx, &webSocketConn{Conn: conn, remoteAddr: remoteAddr})
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(WebSocketPath, wsServer)
+
+	m.server = &http.Server{Handler: mux}
+
+	return m, nil
+}
+
+func (m *webSocketServer) Addr() net.Addr {
+	return m.listener.Addr()
+}
+
+// Serve serves WebSocket connections until the context is canceled.
+//
+// Must be called once.
+func (m *webSocketServer) Serve(ctx context.Context) error {
+	m.ctx = ctx
+
+	go func() {
+		<-ctx.Done()
+		m.server.Close()
+	}()
+
+	return m.server.Serve(m.listener)
+}
+
+func (m *webSocketServer) Close() error {
+	return m.server.Close()
+}
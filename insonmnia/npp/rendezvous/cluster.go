@@ -0,0 +1,450 @@
+// This module allows to unite several rendezvous servers into a single
+// cluster.
+//
+// Each member keeps its own meetings in memory, but gossips records of the
+// servers published on it to other members using SWIM protocol. When a client
+// wants to resolve a server published on another member, the member it is
+// connected to sends a MATCH message directly to the owner of the record,
+// which in its turn notifies the waiting server about the client.
+// Thus both peers are informed about each other regardless of which members
+// they're connected to, and losing a single member affects only peers
+// connected to it, which are able to reconnect to others.
+//
+// Records are eventually consistent. Besides broadcasting records on publish
+// and withdrawal, members periodically exchange their complete state, which
+// also cleans up records of members that left the cluster.
+
+package rendezvous
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/memberlist"
+	"github.com/sonm-io/core/insonmnia/npp/nppc"
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util/netutil"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
+)
+
+// ClusterConfig represents a rendezvous cluster membership config.
+type ClusterConfig struct {
+	// Name is an unique name of this member. Generated if empty.
+	Name string
+	// Endpoint to bind the membership protocol on.
+	Endpoint string
+	// Announce is an optional endpoint advertised to other members, for
+	// example when hosted under NAT with port forwarding configured.
+	Announce string
+	// SecretKey is an optional hex-encoded key used for encrypting the
+	// membership traffic.
+	SecretKey string `yaml:"secret_key" json:"-"`
+	// Members are endpoints of other members to join.
+	Members []string
+}
+
+type messageType uint8
+
+const (
+	messageAnnounce messageType = iota + 1
+	messageWithdraw
+	messageMatch
+)
+
+// serverRecord describes a server published on some cluster member.
+type serverRecord struct {
+	Node         string          `json:"node"`
+	ID           nppc.ResourceID `json:"id"`
+	PeerID       PeerID          `json:"peerID"`
+	PublicAddr   *sonm.Addr      `json:"publicAddr"`
+	PrivateAddrs []*sonm.Addr    `json:"privateAddrs"`
+}
+
+func newServerRecord(node string, id nppc.ResourceID, handle Peer) (*serverRecord, error) {
+	addr, err := sonm.NewAddr(handle.Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return &serverRecord{
+		Node:         node,
+		ID:           id,
+		PeerID:       handle.ID,
+		PublicAddr:   addr,
+		PrivateAddrs: handle.privateAddrs,
+	}, nil
+}
+
+// Peer converts the record back into a peer handle.
+func (m *serverRecord) Peer() (Peer, error) {
+	var addr net.Addr
+	var err error
+	switch m.PublicAddr.GetProtocol() {
+	case sonm.UDPNPPProtocol:
+		addr, err = m.PublicAddr.IntoUDP()
+	default:
+		addr, err = m.PublicAddr.IntoTCP()
+	}
+	if err != nil {
+		return Peer{}, err
+	}
+
+	handle := NewPeer(peer.Peer{Addr: addr}, m.PrivateAddrs)
+	handle.ID = m.PeerID
+
+	return handle, nil
+}
+
+type clusterMessage struct {
+	Type messageType `json:"type"`
+	// Record describes the server being announced, withdrawn or matched.
+	Record *serverRecord `json:"record"`
+	// Client describes the client for MATCH messages.
+	Client *serverRecord `json:"client,omitempty"`
+}
+
+type clusterState struct {
+	Node    string          `json:"node"`
+	Records []*serverRecord `json:"records"`
+}
+
+type broadcast struct {
+	id      PeerID
+	message []byte
+}
+
+func (m *broadcast) Invalidates(other memberlist.Broadcast) bool {
+	if other, ok := other.(*broadcast); ok {
+		return m.id == other.id
+	}
+
+	return false
+}
+
+func (m *broadcast) Message() []byte {
+	return m.message
+}
+
+func (m *broadcast) Finished() {}
+
+// clusterHandler is notified about cluster events.
+type clusterHandler interface {
+	// OnAnnounce is called when a server is published on another member.
+	OnAnnounce(record *serverRecord)
+	// OnMatch is called when a client connected to another member wants to
+	// meet the server published on this member.
+	OnMatch(record *serverRecord, client *serverRecord)
+}
+
+type cluster struct {
+	cfg     ClusterConfig
+	log     *zap.Logger
+	handler clusterHandler
+
+	memberlist *memberlist.Memberlist
+	broadcasts *memberlist.TransmitLimitedQueue
+
+	mu     sync.Mutex
+	local  map[PeerID]*serverRecord
+	remote map[string]map[PeerID]*serverRecord
+}
+
+func newCluster(cfg ClusterConfig, handler clusterHandler, log *zap.Logger) (*cluster, error) {
+	m := &cluster{
+		cfg:     cfg,
+		log:     log.With(zap.String("member", cfg.Name)),
+		handler: handler,
+		local:   map[PeerID]*serverRecord{},
+		remote:  map[string]map[PeerID]*serverRecord{},
+	}
+
+	config := memberlist.DefaultWANConfig()
+	config.Name = cfg.Name
+	config.Delegate = m
+	config.Events = m
+	config.LogOutput = nppc.NewLogAdapter(log)
+	config.ProbeInterval = time.Second
+
+	addr, port, err := netutil.SplitHostPort(cfg.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	config.BindAddr = addr.String()
+	config.BindPort = int(port)
+
+	if len(cfg.Announce) > 0 {
+		announceAddr, announcePort, err := netutil.SplitHostPort(cfg.Announce)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AdvertiseAddr = announceAddr.String()
+		config.AdvertisePort = int(announcePort)
+	}
+
+	if len(cfg.SecretKey) > 0 {
+		key, err := hex.DecodeString(cfg.SecretKey)
+		if err != nil {
+			return nil, err
+		}
+
+		config.Keyring, err = memberlist.NewKeyring([][]byte{}, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.memberlist, err = memberlist.Create(config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.broadcasts = &memberlist.TransmitLimitedQueue{
+		NumNodes:       m.memberlist.NumMembers,
+		RetransmitMult: config.RetransmitMult,
+	}
+
+	return m, nil
+}
+
+// Join joins the cluster using configured members, returning the number of
+// members successfully contacted.
+func (m *cluster) Join() (int, error) {
+	if len(m.cfg.Members) == 0 {
+		return 0, nil
+	}
+
+	return m.memberlist.Join(m.cfg.Members)
+}
+
+// Addr returns the membership protocol address of this member.
+func (m *cluster) Addr() string {
+	return m.memberlist.LocalNode().Address()
+}
+
+// Announce broadcasts the server published on this member, returning a
+// function that withdraws it.
+func (m *cluster) Announce(id nppc.ResourceID, handle Peer) (func(), error) {
+	record, err := newServerRecord(m.cfg.Name, id, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.local[record.PeerID] = record
+	m.mu.Unlock()
+
+	m.broadcast(messageAnnounce, record)
+
+	return func() {
+		m.mu.Lock()
+		delete(m.local, record.PeerID)
+		m.mu.Unlock()
+
+		m.broadcast(messageWithdraw, record)
+	}, nil
+}
+
+func (m *cluster) broadcast(ty messageType, record *serverRecord) {
+	message, err := json.Marshal(&clusterMessage{Type: ty, Record: record})
+	if err != nil {
+		m.log.Warn("failed to encode cluster message", zap.Error(err))
+		return
+	}
+
+	m.broadcasts.QueueBroadcast(&broadcast{id: record.PeerID, message: message})
+}
+
+// RandomServer returns a random server record published on other members
+// under the given ID, if any.
+func (m *cluster) RandomServer(id nppc.ResourceID) *serverRecord {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var candidates []*serverRecord
+	for _, records := range m.remote {
+		for _, record := range records {
+			if record.ID == id {
+				candidates = append(candidates, record)
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	return candidates[rand.Intn(len(candidates))]
+}
+
+// ServerIDs returns peer IDs of servers published on other members under
+// the given ETH address.
+func (m *cluster) ServerIDs(addr string) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ids []string
+	for _, records := range m.remote {
+		for _, record := range records {
+			if record.ID.Addr.Hex() == addr {
+				ids = append(ids, record.PeerID.String())
+			}
+		}
+	}
+
+	return ids
+}
+
+// Match notifies the member owning the given server record about the client
+// wanting to meet it. The record is forgotten, because the server is going
+// to be consumed by this meeting anyway.
+func (m *cluster) Match(record *serverRecord, client Peer) error {
+	m.forget(record)
+
+	clientRecord, err := newServerRecord(m.cfg.Name, record.ID, client)
+	if err != nil {
+		return err
+	}
+
+	message, err := json.Marshal(&clusterMessage{Type: messageMatch, Record: record, Client: clientRecord})
+	if err != nil {
+		return err
+	}
+
+	for _, node := range m.memberlist.Members() {
+		if node.Name == record.Node {
+			return m.memberlist.SendReliable(node, message)
+		}
+	}
+
+	return fmt.Errorf("member %s not found", record.Node)
+}
+
+func (m *cluster) forget(record *serverRecord) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if records, ok := m.remote[record.Node]; ok {
+		delete(records, record.PeerID)
+	}
+}
+
+// Close gracefully leaves the cluster.
+func (m *cluster) Close() error {
+	if err := m.memberlist.Leave(5 * time.Second); err != nil {
+		m.log.Warn("failed to leave the cluster", zap.Error(err))
+	}
+
+	return m.memberlist.Shutdown()
+}
+
+func (m *cluster) NodeMeta(limit int) []byte {
+	return nil
+}
+
+func (m *cluster) NotifyMsg(data []byte) {
+	message := &clusterMessage{}
+	if err := json.Unmarshal(data, message); err != nil {
+		m.log.Warn("received malformed cluster message", zap.Error(err))
+		return
+	}
+
+	record := message.Record
+	if record == nil || (record.Node == m.cfg.Name && message.Type != messageMatch) {
+		return
+	}
+
+	switch message.Type {
+	case messageAnnounce:
+		m.mu.Lock()
+		records, ok := m.remote[record.Node]
+		if !ok {
+			records = map[PeerID]*serverRecord{}
+			m.remote[record.Node] = records
+		}
+		records[record.PeerID] = record
+		m.mu.Unlock()
+
+		// Must not block the gossip receive loop.
+		go m.handler.OnAnnounce(record)
+	case messageWithdraw:
+		m.forget(record)
+	case messageMatch:
+		if message.Client == nil {
+			return
+		}
+
+		go m.handler.OnMatch(record, message.Client)
+	default:
+		m.log.Warn("received unknown cluster message", zap.Any("type", message.Type))
+	}
+}
+
+func (m *cluster) GetBroadcasts(overhead, limit int) [][]byte {
+	return m.broadcasts.GetBroadcasts(overhead, limit)
+}
+
+func (m *cluster) LocalState(join bool) []byte {
+	m.mu.Lock()
+	state := &clusterState{Node: m.cfg.Name}
+	for _, record := range m.local {
+		state.Records = append(state.Records, record)
+	}
+	m.mu.Unlock()
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		m.log.Warn("failed to encode cluster state", zap.Error(err))
+		return nil
+	}
+
+	return data
+}
+
+// MergeRemoteState replaces records of the remote member with its complete
+// state, which is the source of truth.
+func (m *cluster) MergeRemoteState(data []byte, join bool) {
+	state := &clusterState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		m.log.Warn("received malformed cluster state", zap.Error(err))
+		return
+	}
+
+	if state.Node == m.cfg.Name {
+		return
+	}
+
+	records := map[PeerID]*serverRecord{}
+	for _, record := range state.Records {
+		records[record.PeerID] = record
+	}
+
+	m.mu.Lock()
+	m.remote[state.Node] = records
+	m.mu.Unlock()
+
+	for _, record := range state.Records {
+		go m.handler.OnAnnounce(record)
+	}
+}
+
+func (m *cluster) NotifyJoin(node *memberlist.Node) {
+	m.log.Info("member has joined the cluster", zap.String("name", node.Name), zap.String("addr", node.Address()))
+}
+
+func (m *cluster) NotifyLeave(node *memberlist.Node) {
+	m.log.Info("member has left the cluster", zap.String("name", node.Name), zap.String("addr", node.Address()))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.remote, node.Name)
+}
+
+func (m *cluster) NotifyUpdate(node *memberlist.Node) {}
@@ -0,0 +1,164 @@
+package rendezvous
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/insonmnia/npp/nppc"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+type stubResolver struct{}
+
+func (stubResolver) PublicIP() (net.IP, error) {
+	return net.ParseIP("8.8.8.8"), nil
+}
+
+func newTestClusterServer(t *testing.T, name string, members ...string) *Server {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	server, err := NewServer(ServerConfig{
+		Addr:       &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1)},
+		PrivateKey: key,
+		Cluster: &ClusterConfig{
+			Name:     name,
+			Endpoint: "127.0.0.1:0",
+			Members:  members,
+		},
+	}, WithCredentials(credentials.NewTLS(&tls.Config{})))
+	require.NoError(t, err)
+
+	server.resolver = stubResolver{}
+
+	_, err = server.cluster.Join()
+	require.NoError(t, err)
+
+	return server
+}
+
+func newTestCluster(t *testing.T) (*Server, *Server) {
+	first := newTestClusterServer(t, "first")
+	second := newTestClusterServer(t, "second", first.cluster.Addr())
+
+	return first, second
+}
+
+func newPeerContext(ctx context.Context, addr string, wallet common.Address) context.Context {
+	tcpAddr, _ := net.ResolveTCPAddr("tcp", addr)
+	return peer.NewContext(ctx, &peer.Peer{
+		Addr:     tcpAddr,
+		AuthInfo: auth.EthAuthInfo{Wallet: wallet},
+	})
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); {
+		if condition() {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	t.Fatal("condition has not been met in time")
+}
+
+type replyTuple struct {
+	reply *sonm.RendezvousReply
+	err   error
+}
+
+func TestClusterResolvesServerPublishedOnAnotherMember(t *testing.T) {
+	first, second := newTestCluster(t)
+	defer first.Stop()
+	defer second.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	wallet := common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fd")
+	id := nppc.ResourceID{Protocol: sonm.DefaultNPPProtocol, Addr: wallet}
+
+	published := make(chan replyTuple, 1)
+	go func() {
+		reply, err := first.Publish(newPeerContext(ctx, "1.2.3.4:1000", wallet), &sonm.PublishRequest{
+			Protocol: sonm.DefaultNPPProtocol,
+		})
+		published <- replyTuple{reply, err}
+	}()
+
+	waitFor(t, func() bool { return second.cluster.RandomServer(id) != nil })
+
+	reply, err := second.Resolve(newPeerContext(ctx, "5.6.7.8:2000", common.Address{}), &sonm.ConnectRequest{
+		Protocol: sonm.DefaultNPPProtocol,
+		ID:       wallet.Bytes(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", reply.PublicAddr.Addr.Addr)
+	assert.Equal(t, uint32(1000), reply.PublicAddr.Addr.Port)
+
+	select {
+	case result := <-published:
+		require.NoError(t, result.err)
+		assert.Equal(t, "5.6.7.8", result.reply.PublicAddr.Addr.Addr)
+		assert.Equal(t, uint32(2000), result.reply.PublicAddr.Addr.Port)
+	case <-ctx.Done():
+		t.Fatal("server has not been matched")
+	}
+
+	// The server has been consumed, so its record must be gone eventually.
+	waitFor(t, func() bool { return second.cluster.RandomServer(id) == nil })
+}
+
+func TestClusterMatchesWaitingClientOnAnnounce(t *testing.T) {
+	first, second := newTestCluster(t)
+	defer first.Stop()
+	defer second.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	wallet := common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fd")
+	id := nppc.ResourceID{Protocol: sonm.DefaultNPPProtocol, Addr: wallet}
+
+	resolved := make(chan replyTuple, 1)
+	go func() {
+		reply, err := second.Resolve(newPeerContext(ctx, "5.6.7.8:2000", common.Address{}), &sonm.ConnectRequest{
+			Protocol: sonm.DefaultNPPProtocol,
+			ID:       wallet.Bytes(),
+		})
+		resolved <- replyTuple{reply, err}
+	}()
+
+	waitFor(t, func() bool {
+		second.mu.Lock()
+		defer second.mu.Unlock()
+
+		meeting, ok := second.rv[id]
+		return ok && len(meeting.clients) > 0
+	})
+
+	reply, err := first.Publish(newPeerContext(ctx, "1.2.3.4:1000", wallet), &sonm.PublishRequest{
+		Protocol: sonm.DefaultNPPProtocol,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "5.6.7.8", reply.PublicAddr.Addr.Addr)
+
+	select {
+	case result := <-resolved:
+		require.NoError(t, result.err)
+		assert.Equal(t, "1.2.3.4", result.reply.PublicAddr.Addr.Addr)
+	case <-ctx.Done():
+		t.Fatal("client has not been matched")
+	}
+}
@@ -2,10 +2,13 @@ package rendezvous
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"net"
+	"os"
 	"time"
 
 	"github.com/jinzhu/configor"
+	"github.com/pborman/uuid"
 	"github.com/sonm-io/core/accounts"
 	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/insonmnia/logging"
@@ -20,6 +23,9 @@ type ServerConfig struct {
 	PrivateKey *ecdsa.PrivateKey
 	Logging    logging.Config
 	Debug      *debug.Config
+	// Cluster is an optional cluster membership config. Without it the
+	// server runs standalone.
+	Cluster *ClusterConfig
 }
 
 type serverConfig struct {
@@ -27,6 +33,7 @@ type serverConfig struct {
 	Eth     accounts.EthConfig `yaml:"ethereum"`
 	Logging logging.Config     `yaml:"logging"`
 	Debug   *debug.Config      `yaml:"debug"`
+	Cluster *ClusterConfig     `yaml:"cluster"`
 }
 
 // NewServerConfig loads a new Rendezvous server config from a file.
@@ -42,11 +49,27 @@ func NewServerConfig(path string) (*ServerConfig, error) {
 		return nil, err
 	}
 
+	if cfg.Cluster != nil {
+		if len(cfg.Cluster.Endpoint) == 0 {
+			return nil, fmt.Errorf("cluster endpoint is required")
+		}
+
+		if len(cfg.Cluster.Name) == 0 {
+			hostname, err := os.Hostname()
+			if err != nil {
+				return nil, err
+			}
+
+			cfg.Cluster.Name = fmt.Sprintf("%s-%s", hostname, uuid.New())
+		}
+	}
+
 	return &ServerConfig{
 		Addr:       &cfg.Addr,
 		PrivateKey: privateKey,
 		Logging:    cfg.Logging,
 		Debug:      cfg.Debug,
+		Cluster:    cfg.Cluster,
 	}, nil
 }
 
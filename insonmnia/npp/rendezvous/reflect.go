@@ -0,0 +1,97 @@
+// UDP reflector allows peers to discover their public UDP endpoints.
+//
+// Unlike TCP, where the public address is observed from the gRPC connection
+// itself, UDP punching requires the address of the NAT mapping created for
+// the UDP socket that is going to be used for punching. To obtain it a peer
+// sends a tiny request datagram from that socket to the rendezvous server
+// and receives back the address the datagram came from.
+
+package rendezvous
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	reflectInterval = 250 * time.Millisecond
+)
+
+var (
+	reflectRequest = []byte("SNM-RV?")
+	reflectReply   = []byte("SNM-RV!")
+)
+
+// ReflectUDP asks the rendezvous server listening on the given address which
+// public address the provided packet connection is seen from.
+//
+// Requests are retransmitted periodically until either a reply arrives or
+// the context is canceled.
+func ReflectUDP(ctx context.Context, conn net.PacketConn, addr net.Addr) (*net.UDPAddr, error) {
+	defer conn.SetReadDeadline(time.Time{})
+
+	buf := make([]byte, 512)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if _, err := conn.WriteTo(reflectRequest, addr); err != nil {
+			return nil, err
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(reflectInterval)); err != nil {
+			return nil, err
+		}
+
+		for {
+			n, from, err := conn.ReadFrom(buf)
+			if err != nil {
+				if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+					break
+				}
+
+				return nil, err
+			}
+
+			if from.String() != addr.String() || !bytes.HasPrefix(buf[:n], reflectReply) {
+				continue
+			}
+
+			publicAddr, err := net.ResolveUDPAddr("udp", string(buf[len(reflectReply):n]))
+			if err != nil {
+				return nil, fmt.Errorf("malformed reflector reply: %v", err)
+			}
+
+			return publicAddr, nil
+		}
+	}
+}
+
+// serveReflector replies to reflection requests with the source address of
+// the request until the connection is closed.
+func serveReflector(conn net.PacketConn, log *zap.Logger) error {
+	log.Info("rendezvous UDP reflector is ready to serve", zap.Stringer("endpoint", conn.LocalAddr()))
+
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(buf[:n], reflectRequest) {
+			continue
+		}
+
+		reply := append(append([]byte{}, reflectReply...), addr.String()...)
+		if _, err := conn.WriteTo(reply, addr); err != nil {
+			log.Warn("failed to reply to reflection request", zap.Stringer("remote_addr", addr), zap.Error(err))
+		}
+	}
+}
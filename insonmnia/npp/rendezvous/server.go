@@ -26,8 +26,17 @@
 // they support.
 // Clients should specify the desired protocol and ID for resolution.
 //
-// Currently only TCP endpoints exchanging is supported.
-// TODO: When resolving it's necessary to track also IP version. For example to be able not to return IPv6 when connecting socket is IPv4.
+// Both TCP and UDP endpoints can be exchanged. For UDP the public address
+// observed from the gRPC connection is useless, because it belongs to another
+// NAT mapping. Instead, peers discover the public address of their UDP socket
+// using the UDP reflector, which listens on the same endpoint as the server,
+// and report it explicitly while publishing or resolving.
+//
+// Peers also report IP address families they are able to use, so only
+// addresses of these families are returned. For example an IPv4-only client
+// won't receive IPv6 addresses of a dual-stack server. Older peers that do
+// not report families are assumed to support families of the address they
+// are connected from and of their private addresses.
 
 package rendezvous
 
@@ -84,15 +93,17 @@ func (m *meeting) addClient(peer Peer, c chan<- Peer) {
 	m.clients[peer.ID] = peerCandidate{Peer: peer, C: c}
 }
 
-func (m *meeting) randomServer() *peerCandidate {
-	return randomPeerCandidate(m.servers)
+func (m *meeting) popRandomServer() *peerCandidate {
+	return popRandomPeerCandidate(m.servers)
 }
 
-func (m *meeting) randomClient() *peerCandidate {
-	return randomPeerCandidate(m.clients)
+func (m *meeting) popRandomClient() *peerCandidate {
+	return popRandomPeerCandidate(m.clients)
 }
 
-func randomPeerCandidate(candidates map[PeerID]peerCandidate) *peerCandidate {
+// PopRandomPeerCandidate removes and returns a random candidate, so it can't
+// be matched twice.
+func popRandomPeerCandidate(candidates map[PeerID]peerCandidate) *peerCandidate {
 	if len(candidates) == 0 {
 		return nil
 	}
@@ -101,7 +112,9 @@ func randomPeerCandidate(candidates map[PeerID]peerCandidate) *peerCandidate {
 		keys = append(keys, key)
 	}
 
-	v := candidates[keys[rand.Intn(len(keys))]]
+	k := keys[rand.Intn(len(keys))]
+	v := candidates[k]
+	delete(candidates, k)
 	return &v
 }
 
@@ -114,6 +127,7 @@ type Server struct {
 	log      *zap.Logger
 	server   *grpc.Server
 	resolver resolver
+	cluster  *cluster
 
 	mu sync.Mutex
 	rv map[nppc.ResourceID]*meeting
@@ -145,6 +159,15 @@ func NewServer(cfg ServerConfig, options ...Option) (*Server, error) {
 		rv:       map[nppc.ResourceID]*meeting{},
 	}
 
+	if cfg.Cluster != nil {
+		cluster, err := newCluster(*cfg.Cluster, server, opts.log)
+		if err != nil {
+			return nil, err
+		}
+
+		server.cluster = cluster
+	}
+
 	server.log.Debug("configured authentication settings",
 		zap.String("addr", crypto.PubkeyToAddress(cfg.PrivateKey.PublicKey).Hex()),
 		zap.Any("credentials", opts.credentials.Info()),
@@ -168,7 +191,21 @@ func (m *Server) Resolve(ctx context.Context, request *sonm.ConnectRequest) (*so
 	}
 	m.log.Info("resolving remote peer", zap.Stringer("id", id))
 
-	peerHandle := NewPeer(*peerInfo, request.PrivateAddrs)
+	peerHandle, err := m.newPeer(*peerInfo, request.PublicAddr, request.PrivateAddrs)
+	if err != nil {
+		return nil, err
+	}
+
+	families := requestFamilies(peerInfo.Addr, request.Families, request.PrivateAddrs)
+
+	if p, ok := m.matchRemoteServer(id, peerHandle); ok {
+		m.log.Info("providing remote server endpoint(s) published on another member",
+			zap.Stringer("id", id),
+			zap.Stringer("public_addr", p.Addr),
+			zap.Any("private_addrs", p.privateAddrs),
+		)
+		return m.newReply(p, families)
+	}
 
 	c, deleter := m.addServerWatch(id, peerHandle)
 	defer deleter()
@@ -182,7 +219,7 @@ func (m *Server) Resolve(ctx context.Context, request *sonm.ConnectRequest) (*so
 			zap.Stringer("public_addr", p.Addr),
 			zap.Any("private_addrs", p.privateAddrs),
 		)
-		return m.newReply(p)
+		return m.newReply(p, families)
 	}
 }
 
@@ -199,6 +236,10 @@ func (m *Server) ResolveAll(ctx context.Context, request *sonm.ID) (*sonm.Resolv
 		}
 	}
 
+	if m.cluster != nil {
+		ids = append(ids, m.cluster.ServerIDs(request.Id)...)
+	}
+
 	if len(ids) == 0 {
 		return nil, errPeerNotFound()
 	}
@@ -225,11 +266,25 @@ func (m *Server) Publish(ctx context.Context, request *sonm.PublishRequest) (*so
 	}
 	m.log.Info("publishing remote peer", zap.String("id", id.String()))
 
-	peerHandle := NewPeer(*peerInfo, request.PrivateAddrs)
+	peerHandle, err := m.newPeer(*peerInfo, request.PublicAddr, request.PrivateAddrs)
+	if err != nil {
+		return nil, err
+	}
+
+	families := requestFamilies(peerInfo.Addr, request.Families, request.PrivateAddrs)
 
-	c, deleter := m.newClientWatch(id, peerHandle)
+	c, waiting, deleter := m.newClientWatch(id, peerHandle)
 	defer deleter()
 
+	// Let clients connected to other members know about us.
+	if waiting && m.cluster != nil {
+		withdraw, err := m.cluster.Announce(id, peerHandle)
+		if err != nil {
+			return nil, err
+		}
+		defer withdraw()
+	}
+
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
@@ -239,10 +294,39 @@ func (m *Server) Publish(ctx context.Context, request *sonm.PublishRequest) (*so
 			zap.Stringer("public_addr", p.Addr),
 			zap.Any("private_addrs", p.privateAddrs),
 		)
-		return m.newReply(p)
+		return m.newReply(p, families)
 	}
 }
 
+// newPeer constructs a new peer handle, replacing the observed address with
+// the reported public one if provided.
+//
+// The reported address must share the IP with the observed one to prevent
+// peers from directing punching traffic to third-party hosts.
+func (m *Server) newPeer(peerInfo peer.Peer, publicAddr *sonm.Addr, privateAddrs []*sonm.Addr) (Peer, error) {
+	if !publicAddr.IsValid() {
+		return NewPeer(peerInfo, privateAddrs), nil
+	}
+
+	addr, err := publicAddr.IntoUDP()
+	if err != nil {
+		return Peer{}, status.Errorf(codes.InvalidArgument, "invalid public address: %v", err)
+	}
+
+	observedIP, _, err := net.SplitHostPort(peerInfo.Addr.String())
+	if err != nil {
+		return Peer{}, err
+	}
+
+	if !addr.IP.Equal(net.ParseIP(observedIP)) {
+		return Peer{}, status.Errorf(codes.InvalidArgument, "public address %s does not match the observed one %s", addr, peerInfo.Addr)
+	}
+
+	peerInfo.Addr = addr
+
+	return NewPeer(peerInfo, privateAddrs), nil
+}
+
 func (m *Server) addServerWatch(id nppc.ResourceID, peer Peer) (<-chan Peer, deleter) {
 	c := make(chan Peer, 1)
 
@@ -252,7 +336,7 @@ func (m *Server) addServerWatch(id nppc.ResourceID, peer Peer) (<-chan Peer, del
 	meeting, ok := m.rv[id]
 	if ok {
 		// Notify both sides immediately if there is match between candidates.
-		if server := meeting.randomServer(); server != nil {
+		if server := meeting.popRandomServer(); server != nil {
 			c <- server.Peer
 			server.C <- peer
 		} else {
@@ -267,17 +351,22 @@ func (m *Server) addServerWatch(id nppc.ResourceID, peer Peer) (<-chan Peer, del
 	return c, func() { m.removeServerWatch(id, peer) }
 }
 
-func (m *Server) newClientWatch(id nppc.ResourceID, peer Peer) (<-chan Peer, deleter) {
+// newClientWatch registers the server waiting for clients, returning
+// whether it has been added into the meeting, i.e. there was no client to
+// match immediately.
+func (m *Server) newClientWatch(id nppc.ResourceID, peer Peer) (<-chan Peer, bool, deleter) {
 	c := make(chan Peer, 1)
 
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	waiting := true
 	meeting, ok := m.rv[id]
 	if ok {
-		if client := meeting.randomClient(); client != nil {
+		if client := meeting.popRandomClient(); client != nil {
 			c <- client.Peer
 			client.C <- peer
+			waiting = false
 		} else {
 			meeting.addServer(peer, c)
 		}
@@ -287,7 +376,101 @@ func (m *Server) newClientWatch(id nppc.ResourceID, peer Peer) (<-chan Peer, del
 		m.rv[id] = meeting
 	}
 
-	return c, func() { m.removeClientWatch(id, peer) }
+	return c, waiting, func() { m.removeClientWatch(id, peer) }
+}
+
+// matchRemoteServer tries to match the client with a server published on
+// another cluster member, if there is no local one.
+func (m *Server) matchRemoteServer(id nppc.ResourceID, client Peer) (Peer, bool) {
+	if m.cluster == nil || m.hasLocalServer(id) {
+		return Peer{}, false
+	}
+
+	for {
+		record := m.cluster.RandomServer(id)
+		if record == nil {
+			return Peer{}, false
+		}
+
+		server, err := record.Peer()
+		if err != nil {
+			m.log.Warn("received malformed server record", zap.Error(err))
+			m.cluster.forget(record)
+			continue
+		}
+
+		if err := m.cluster.Match(record, client); err != nil {
+			m.log.Warn("failed to match server published on another member", zap.String("member", record.Node), zap.Error(err))
+			continue
+		}
+
+		return server, true
+	}
+}
+
+func (m *Server) hasLocalServer(id nppc.ResourceID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	meeting, ok := m.rv[id]
+	return ok && len(meeting.servers) > 0
+}
+
+// OnAnnounce matches the server published on another cluster member with
+// clients waiting for it on this member, if any.
+func (m *Server) OnAnnounce(record *serverRecord) {
+	m.mu.Lock()
+	var client *peerCandidate
+	if meeting, ok := m.rv[record.ID]; ok {
+		client = meeting.popRandomClient()
+	}
+	m.mu.Unlock()
+
+	if client == nil {
+		return
+	}
+
+	server, err := record.Peer()
+	if err == nil {
+		err = m.cluster.Match(record, client.Peer)
+	}
+
+	// The client has been already removed from the meeting, so it will retry
+	// after its deadline.
+	if err != nil {
+		m.log.Warn("failed to match server published on another member", zap.String("member", record.Node), zap.Error(err))
+		return
+	}
+
+	client.C <- server
+}
+
+// OnMatch notifies the server published on this member about the client
+// connected to another member.
+func (m *Server) OnMatch(record *serverRecord, clientRecord *serverRecord) {
+	client, err := clientRecord.Peer()
+	if err != nil {
+		m.log.Warn("received malformed client record", zap.Error(err))
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	meeting, ok := m.rv[record.ID]
+	if !ok {
+		m.log.Debug("server is no longer waiting", zap.Stringer("id", record.ID))
+		return
+	}
+
+	server, ok := meeting.servers[record.PeerID]
+	if !ok {
+		m.log.Debug("server is no longer waiting", zap.Stringer("id", record.ID))
+		return
+	}
+
+	delete(meeting.servers, record.PeerID)
+	server.C <- client
 }
 
 func (m *Server) removeClientWatch(id nppc.ResourceID, peer Peer) {
@@ -324,7 +507,32 @@ func (m *Server) maybeCleanMeeting(id nppc.ResourceID, candidates *meeting) {
 	}
 }
 
-func (m *Server) newReply(peer Peer) (*sonm.RendezvousReply, error) {
+// requestFamilies returns IP address families the requesting peer is able
+// to use, deriving them for peers that do not report families explicitly.
+func requestFamilies(observedAddr net.Addr, families []sonm.AddrFamily, privateAddrs []*sonm.Addr) []sonm.AddrFamily {
+	if len(families) != 0 {
+		return families
+	}
+
+	addrs := privateAddrs
+	if observed, err := sonm.NewAddr(observedAddr); err == nil {
+		addrs = append([]*sonm.Addr{observed}, addrs...)
+	}
+
+	seen := map[sonm.AddrFamily]bool{}
+	for _, addr := range addrs {
+		if family := addr.IPFamily(); !seen[family] {
+			seen[family] = true
+			families = append(families, family)
+		}
+	}
+
+	return families
+}
+
+// newReply constructs a reply with the given peer endpoints, leaving only
+// addresses of the specified families. Empty families mean no filtering.
+func (m *Server) newReply(peer Peer, families []sonm.AddrFamily) (*sonm.RendezvousReply, error) {
 	addr, err := sonm.NewAddr(peer.Addr)
 	if err != nil {
 		return nil, err
@@ -343,10 +551,18 @@ func (m *Server) newReply(peer Peer) (*sonm.RendezvousReply, error) {
 		addr.Addr.Addr = publicIP.String()
 	}
 
-	return &sonm.RendezvousReply{
-		PublicAddr:   addr,
-		PrivateAddrs: peer.privateAddrs,
-	}, nil
+	reply := &sonm.RendezvousReply{}
+	if sonm.ContainsFamily(families, addr.IPFamily()) {
+		reply.PublicAddr = addr
+	}
+
+	for _, privateAddr := range peer.privateAddrs {
+		if sonm.ContainsFamily(families, privateAddr.IPFamily()) {
+			reply.PrivateAddrs = append(reply.PrivateAddrs, privateAddr)
+		}
+	}
+
+	return reply, nil
 }
 
 func (m *Server) Info(ctx context.Context, request *sonm.Empty) (*sonm.RendezvousState, error) {
@@ -360,7 +576,7 @@ func (m *Server) Info(ctx context.Context, request *sonm.Empty) (*sonm.Rendezvou
 		servers := make(map[string]*sonm.RendezvousReply)
 
 		for clientID, candidate := range meeting.clients {
-			reply, err := m.newReply(candidate.Peer)
+			reply, err := m.newReply(candidate.Peer, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -369,7 +585,7 @@ func (m *Server) Info(ctx context.Context, request *sonm.Empty) (*sonm.Rendezvou
 		}
 
 		for serverID, candidate := range meeting.servers {
-			reply, err := m.newReply(candidate.Peer)
+			reply, err := m.newReply(candidate.Peer, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -393,6 +609,15 @@ func (m *Server) Info(ctx context.Context, request *sonm.Empty) (*sonm.Rendezvou
 //
 // Always returns non-nil error.
 func (m *Server) Run(ctx context.Context) error {
+	if m.cluster != nil {
+		members, err := m.cluster.Join()
+		if err != nil {
+			return err
+		}
+
+		m.log.Info("joined the rendezvous cluster", zap.String("addr", m.cluster.Addr()), zap.Int("members", members))
+	}
+
 	wg, ctx := errgroup.WithContext(ctx)
 	wg.Go(func() error {
 		listener, err := net.Listen(m.cfg.Addr.Network(), m.cfg.Addr.String())
@@ -403,6 +628,19 @@ func (m *Server) Run(ctx context.Context) error {
 		m.log.Info("rendezvous is ready to serve", zap.Stringer("endpoint", listener.Addr()))
 		return m.server.Serve(listener)
 	})
+	wg.Go(func() error {
+		conn, err := net.ListenPacket("udp", m.cfg.Addr.String())
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			<-ctx.Done()
+			conn.Close()
+		}()
+
+		return serveReflector(conn, m.log)
+	})
 
 	if m.cfg.Debug != nil {
 		wg.Go(func() error {
@@ -424,6 +662,12 @@ func (m *Server) Run(ctx context.Context) error {
 func (m *Server) Stop() {
 	m.log.Info("rendezvous is shutting down")
 	m.server.Stop()
+
+	if m.cluster != nil {
+		if err := m.cluster.Close(); err != nil {
+			m.log.Warn("failed to shutdown cluster membership", zap.Error(err))
+		}
+	}
 }
 
 func errNoPeerInfo() error {
@@ -0,0 +1,67 @@
+package rendezvous
+
+import (
+	"net"
+	"testing"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+)
+
+func newTestAddr(t *testing.T, endpoint string) *sonm.Addr {
+	addr, err := net.ResolveTCPAddr("tcp", endpoint)
+	require.NoError(t, err)
+
+	result, err := sonm.NewAddr(addr)
+	require.NoError(t, err)
+
+	return result
+}
+
+func TestRequestFamilies(t *testing.T) {
+	observed := &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 10000}
+
+	// Explicitly reported families are trusted.
+	families := requestFamilies(observed, []sonm.AddrFamily{sonm.AddrFamily_FAMILY_IPV6}, nil)
+	assert.Equal(t, []sonm.AddrFamily{sonm.AddrFamily_FAMILY_IPV6}, families)
+
+	// Older peers get families derived from their addresses.
+	families = requestFamilies(observed, nil, []*sonm.Addr{
+		newTestAddr(t, "192.168.0.1:10000"),
+		newTestAddr(t, "[fd00::1]:10000"),
+	})
+	assert.Equal(t, []sonm.AddrFamily{sonm.AddrFamily_FAMILY_IPV4, sonm.AddrFamily_FAMILY_IPV6}, families)
+
+	families = requestFamilies(observed, nil, nil)
+	assert.Equal(t, []sonm.AddrFamily{sonm.AddrFamily_FAMILY_IPV4}, families)
+}
+
+func TestNewReplyFiltersFamilies(t *testing.T) {
+	server := &Server{resolver: stubResolver{}}
+
+	candidate := NewPeer(peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 10000}}, []*sonm.Addr{
+		newTestAddr(t, "192.168.0.1:10000"),
+		newTestAddr(t, "[fd00::1]:10000"),
+	})
+
+	reply, err := server.newReply(candidate, []sonm.AddrFamily{sonm.AddrFamily_FAMILY_IPV4})
+	require.NoError(t, err)
+	assert.Nil(t, reply.PublicAddr)
+	require.Len(t, reply.PrivateAddrs, 1)
+	assert.Equal(t, "192.168.0.1", reply.PrivateAddrs[0].GetAddr().GetAddr())
+
+	reply, err = server.newReply(candidate, []sonm.AddrFamily{sonm.AddrFamily_FAMILY_IPV6})
+	require.NoError(t, err)
+	require.NotNil(t, reply.PublicAddr)
+	assert.Equal(t, "2001:db8::1", reply.PublicAddr.GetAddr().GetAddr())
+	require.Len(t, reply.PrivateAddrs, 1)
+	assert.Equal(t, "fd00::1", reply.PrivateAddrs[0].GetAddr().GetAddr())
+
+	// No families mean no filtering.
+	reply, err = server.newReply(candidate, nil)
+	require.NoError(t, err)
+	assert.NotNil(t, reply.PublicAddr)
+	assert.Len(t, reply.PrivateAddrs, 2)
+}
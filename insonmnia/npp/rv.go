@@ -2,13 +2,18 @@ package npp
 
 import (
 	"context"
+	"fmt"
 	"net"
+	"sync"
 
 	"github.com/libp2p/go-reuseport"
 	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/insonmnia/npp/rendezvous"
+	"github.com/sonm-io/core/util/multierror"
 	"github.com/sonm-io/core/util/xgrpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 // RendezvousClient is a tiny wrapper over the generated gRPC client allowing
@@ -20,6 +25,8 @@ type rendezvousClient struct {
 	// The underlying connection. Held here for information reasons, it is
 	// closed internally in the gRPC client.
 	conn net.Conn
+	// Failed is called when the rendezvous server becomes unavailable.
+	failed func()
 }
 
 func newRendezvousClient(ctx context.Context, addr auth.Addr, credentials credentials.TransportCredentials) (*rendezvousClient, error) {
@@ -47,7 +54,89 @@ func newRendezvousClient(ctx context.Context, addr auth.Addr, credentials creden
 		return nil, err
 	}
 
-	return &rendezvousClient{client, conn}, nil
+	return &rendezvousClient{Client: client, conn: conn, failed: func() {}}, nil
+}
+
+// MaybeFailover notifies the pool that the rendezvous server this client is
+// connected to is unavailable if the given error says so, making the next
+// connection attempt start from another endpoint.
+func (m *rendezvousClient) MaybeFailover(err error) {
+	if rendezvousErr, ok := err.(*rendezvousError); ok {
+		err = rendezvousErr.error
+	}
+
+	if status.Code(err) == codes.Unavailable {
+		m.failed()
+	}
+}
+
+// rendezvousPool connects to one of the configured rendezvous endpoints,
+// failing over to the next one when the current becomes unavailable.
+//
+// Since rendezvous servers can be united into a cluster, any of them is
+// suitable for meeting remote peers.
+type rendezvousPool struct {
+	endpoints   []auth.Addr
+	credentials credentials.TransportCredentials
+
+	mu      sync.Mutex
+	current int
+}
+
+func newRendezvousPool(endpoints []auth.Addr, credentials credentials.TransportCredentials) *rendezvousPool {
+	return &rendezvousPool{
+		endpoints:   endpoints,
+		credentials: credentials,
+	}
+}
+
+// Connect connects to the first available endpoint, starting from the
+// current one.
+func (m *rendezvousPool) Connect(ctx context.Context) (*rendezvousClient, error) {
+	start := m.currentIdx()
+
+	errs := multierror.NewMultiError()
+	for i := range m.endpoints {
+		idx := (start + i) % len(m.endpoints)
+
+		client, err := newRendezvousClient(ctx, m.endpoints[idx], m.credentials)
+		if err != nil {
+			errs = multierror.AppendUnique(errs, err)
+			continue
+		}
+
+		m.setCurrentIdx(idx)
+		client.failed = func() { m.failover(idx) }
+
+		return client, nil
+	}
+
+	return nil, fmt.Errorf("failed to connect to %+v: %s", m.endpoints, errs.Error())
+}
+
+func (m *rendezvousPool) currentIdx() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.current
+}
+
+func (m *rendezvousPool) setCurrentIdx(idx int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.current = idx
+}
+
+// Failover switches to the next endpoint if the failed one is still the
+// current.
+func (m *rendezvousPool) failover(idx int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.current == idx {
+		m.current = (idx + 1) % len(m.endpoints)
+	}
 }
 
 // LocalAddr returns the local network address.
@@ -59,3 +148,10 @@ func (m *rendezvousClient) LocalAddr() net.Addr {
 func (m *rendezvousClient) RemoteAddr() net.Addr {
 	return m.conn.RemoteAddr()
 }
+
+// UDPAddr returns the remote UDP reflector address, which shares the
+// endpoint with the rendezvous server.
+func (m *rendezvousClient) UDPAddr() net.Addr {
+	addr := m.conn.RemoteAddr().(*net.TCPAddr)
+	return &net.UDPAddr{IP: addr.IP, Port: addr.Port, Zone: addr.Zone}
+}
@@ -0,0 +1,350 @@
+package npp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/npp/rendezvous"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	udpPunchInterval   = 100 * time.Millisecond
+	udpMaxDatagramSize = 65507
+	// UDPMaxPayloadSize is the maximum size of a single datagram written to
+	// punched UDP connections, excluding the type tag.
+	udpMaxPayloadSize = udpMaxDatagramSize - 1
+)
+
+// Every datagram on punched connections starts with a type tag, so
+// application payloads never collide with control messages.
+const (
+	udpDataTag byte = iota
+	udpPunchTag
+	udpAckTag
+)
+
+var (
+	udpPunchMessage = []byte{udpPunchTag}
+	udpAckMessage   = []byte{udpAckTag}
+)
+
+// UDPPuncher performs UDP hole punching using the rendezvous server.
+//
+// Each connection gets its own UDP socket, which public address is
+// discovered using the rendezvous UDP reflector. Then both peers
+// simultaneously send punch datagrams to all of the endpoints of each other,
+// opening their NAT mappings, until some of them passes through.
+type udpPuncher struct {
+	log *zap.Logger
+
+	client       *rendezvousClient
+	listenPacket func() (net.PacketConn, error)
+
+	timeout time.Duration
+}
+
+func newUDPPuncher(ctx context.Context, cfg rendezvous.Config, client *rendezvousClient) *udpPuncher {
+	return &udpPuncher{
+		log:    ctxlog.G(ctx),
+		client: client,
+		listenPacket: func() (net.PacketConn, error) {
+			return net.ListenPacket(sonm.UDPNPPProtocol, ":0")
+		},
+		timeout: cfg.Timeout,
+	}
+}
+
+// DialContext connects to the given address using UDP hole punching.
+//
+// The returned connection is datagram-oriented, i.e. each Write sends
+// exactly one datagram.
+func (m *udpPuncher) DialContext(ctx context.Context, addr common.Address) (net.Conn, error) {
+	return m.punch(ctx, func(publicAddr *sonm.Addr, privateAddrs []*sonm.Addr) (*sonm.RendezvousReply, error) {
+		return m.client.Resolve(ctx, &sonm.ConnectRequest{
+			Protocol:     sonm.UDPNPPProtocol,
+			ID:           addr.Bytes(),
+			PublicAddr:   publicAddr,
+			PrivateAddrs: privateAddrs,
+			Families:     addrFamilies(append([]*sonm.Addr{publicAddr}, privateAddrs...)),
+		})
+	})
+}
+
+// AcceptContext publishes itself on the rendezvous server, waiting for a
+// remote peer, and then connects to it using UDP hole punching.
+func (m *udpPuncher) AcceptContext(ctx context.Context) (net.Conn, error) {
+	return m.punch(ctx, func(publicAddr *sonm.Addr, privateAddrs []*sonm.Addr) (*sonm.RendezvousReply, error) {
+		reply, err := m.client.Publish(ctx, &sonm.PublishRequest{
+			Protocol:     sonm.UDPNPPProtocol,
+			PublicAddr:   publicAddr,
+			PrivateAddrs: privateAddrs,
+			Families:     addrFamilies(append([]*sonm.Addr{publicAddr}, privateAddrs...)),
+		})
+		if err != nil {
+			return nil, newRendezvousError(err)
+		}
+
+		return reply, nil
+	})
+}
+
+func (m *udpPuncher) punch(ctx context.Context, exchange func(publicAddr *sonm.Addr, privateAddrs []*sonm.Addr) (*sonm.RendezvousReply, error)) (net.Conn, error) {
+	conn, err := m.listenPacket()
+	if err != nil {
+		return nil, err
+	}
+
+	peer, err := m.doPunch(ctx, conn, exchange)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return peer, nil
+}
+
+func (m *udpPuncher) doPunch(ctx context.Context, conn net.PacketConn, exchange func(publicAddr *sonm.Addr, privateAddrs []*sonm.Addr) (*sonm.RendezvousReply, error)) (net.Conn, error) {
+	reflectCtx, cancel := context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	publicAddr, err := rendezvous.ReflectUDP(reflectCtx, conn, m.client.UDPAddr())
+	if err != nil {
+		return nil, newRendezvousError(fmt.Errorf("failed to discover public UDP address: %v", err))
+	}
+
+	m.log.Debug("discovered public UDP address", zap.Stringer("public_addr", publicAddr))
+
+	public, err := sonm.NewAddr(publicAddr)
+	if err != nil {
+		return nil, err
+	}
+
+	privateAddrs, err := privateAddrs(conn.LocalAddr())
+	if err != nil {
+		return nil, err
+	}
+
+	private, err := convertAddrs(privateAddrs, sonm.UDPNPPProtocol)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := exchange(public, private)
+	if err != nil {
+		m.client.MaybeFailover(err)
+		return nil, err
+	}
+
+	m.log.Info("received remote peer UDP endpoints", zap.Any("addrs", *addrs))
+
+	ctx, cancel = context.WithTimeout(ctx, m.timeout)
+	defer cancel()
+
+	return punchUDP(ctx, conn, addrs)
+}
+
+// Close closes the puncher and the underlying rendezvous client.
+func (m *udpPuncher) Close() error {
+	return m.client.Close()
+}
+
+// PunchUDP punches the NAT by sending punch datagrams to all of the provided
+// remote endpoints, simultaneously waiting for the remote peer to do the
+// same.
+//
+// The first endpoint we've heard from wins, so the connection is bound to it.
+func punchUDP(ctx context.Context, conn net.PacketConn, addrs *sonm.RendezvousReply) (net.Conn, error) {
+	candidates, err := udpCandidates(addrs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no addresses resolved")
+	}
+
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	defer wg.Wait()
+	defer close(done)
+
+	go func() {
+		defer wg.Done()
+
+		timer := time.NewTicker(udpPunchInterval)
+		defer timer.Stop()
+
+		for {
+			for _, addr := range candidates {
+				// Errors are expected here, for example when some of
+				// private addresses are unreachable.
+				conn.WriteTo(udpPunchMessage, addr)
+			}
+
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+
+	defer conn.SetReadDeadline(time.Time{})
+
+	buf := make([]byte, udpMaxDatagramSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("failed to punch the network using UDP NPP: %v", err)
+		}
+
+		if err := conn.SetReadDeadline(time.Now().Add(udpPunchInterval)); err != nil {
+			return nil, err
+		}
+
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+				continue
+			}
+
+			return nil, err
+		}
+
+		if !isUDPCandidate(candidates, addr) {
+			continue
+		}
+
+		switch {
+		case bytes.Equal(buf[:n], udpPunchMessage):
+			// Notify the remote peer that its mapping is open, because our
+			// own punches may have been dropped by its NAT.
+			if _, err := conn.WriteTo(udpAckMessage, addr); err != nil {
+				return nil, err
+			}
+		case bytes.Equal(buf[:n], udpAckMessage):
+		default:
+			continue
+		}
+
+		return newUDPConn(conn, addr), nil
+	}
+}
+
+func udpCandidates(addrs *sonm.RendezvousReply) ([]*net.UDPAddr, error) {
+	var candidates []*net.UDPAddr
+
+	if addrs.PublicAddr.IsValid() {
+		addr, err := addrs.PublicAddr.IntoUDP()
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, addr)
+	}
+
+	for _, privateAddr := range addrs.PrivateAddrs {
+		addr, err := privateAddr.IntoUDP()
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, addr)
+	}
+
+	return candidates, nil
+}
+
+// IsUDPCandidate checks whether the datagram came from one of the remote
+// peer's endpoints. The port is not checked, because some NATs change it for
+// each destination.
+func isUDPCandidate(candidates []*net.UDPAddr, addr net.Addr) bool {
+	udpAddr, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+
+	for _, candidate := range candidates {
+		if candidate.IP.Equal(udpAddr.IP) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// udpConn is a packet connection bound to a single remote endpoint.
+//
+// Datagrams from other endpoints are dropped, as well as late punching
+// datagrams, which are acknowledged in case the remote peer has missed our
+// acknowledge.
+type udpConn struct {
+	net.PacketConn
+	remoteAddr net.Addr
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func newUDPConn(conn net.PacketConn, remoteAddr net.Addr) *udpConn {
+	return &udpConn{
+		PacketConn: conn,
+		remoteAddr: remoteAddr,
+		buf:        make([]byte, udpMaxDatagramSize),
+	}
+}
+
+// Read reads a single datagram, truncating it like UDP does if the buffer
+// is too small.
+func (m *udpConn) Read(b []byte) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for {
+		n, addr, err := m.PacketConn.ReadFrom(m.buf)
+		if err != nil {
+			return 0, err
+		}
+
+		if addr.String() != m.remoteAddr.String() || n == 0 {
+			continue
+		}
+
+		switch m.buf[0] {
+		case udpDataTag:
+			return copy(b, m.buf[1:n]), nil
+		case udpPunchTag:
+			m.PacketConn.WriteTo(udpAckMessage, m.remoteAddr)
+		}
+	}
+}
+
+func (m *udpConn) Write(b []byte) (int, error) {
+	if len(b) > udpMaxPayloadSize {
+		return 0, fmt.Errorf("datagram too large: %d > %d", len(b), udpMaxPayloadSize)
+	}
+
+	datagram := make([]byte, 1+len(b))
+	datagram[0] = udpDataTag
+	copy(datagram[1:], b)
+
+	if _, err := m.PacketConn.WriteTo(datagram, m.remoteAddr); err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
+func (m *udpConn) RemoteAddr() net.Addr {
+	return m.remoteAddr
+}
@@ -0,0 +1,240 @@
+package npp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+)
+
+type datagram struct {
+	data []byte
+	addr net.Addr
+}
+
+// natConn simulates a port-restricted cone NAT in front of a UDP socket.
+//
+// The underlying socket address acts as the public NAT mapping, while
+// inbound datagrams are allowed only from endpoints we've sent something to
+// before their arrival.
+type natConn struct {
+	net.PacketConn
+
+	mu       sync.Mutex
+	allowed  map[string]bool
+	deadline time.Time
+
+	rx      chan datagram
+	dropped atomic.Uint64
+}
+
+func newNATConn(t *testing.T) *natConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	m := &natConn{
+		PacketConn: conn,
+		allowed:    map[string]bool{},
+		rx:         make(chan datagram, 1024),
+	}
+
+	go m.pump()
+
+	return m
+}
+
+func (m *natConn) pump() {
+	defer close(m.rx)
+
+	for {
+		buf := make([]byte, udpMaxDatagramSize)
+		n, addr, err := m.PacketConn.ReadFrom(buf)
+		if err != nil {
+			return
+		}
+
+		m.mu.Lock()
+		allowed := m.allowed[addr.String()]
+		m.mu.Unlock()
+
+		if !allowed {
+			m.dropped.Inc()
+			continue
+		}
+
+		m.rx <- datagram{data: buf[:n], addr: addr}
+	}
+}
+
+func (m *natConn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	m.mu.Lock()
+	m.allowed[addr.String()] = true
+	m.mu.Unlock()
+
+	return m.PacketConn.WriteTo(b, addr)
+}
+
+func (m *natConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	m.mu.Lock()
+	deadline := m.deadline
+	m.mu.Unlock()
+
+	var timeout <-chan time.Time
+	if !deadline.IsZero() {
+		timer := time.NewTimer(time.Until(deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case datagram, ok := <-m.rx:
+		if !ok {
+			return 0, nil, errors.New("use of closed network connection")
+		}
+		return copy(b, datagram.data), datagram.addr, nil
+	case <-timeout:
+		return 0, nil, &net.OpError{Op: "read", Err: &timeoutError{}}
+	}
+}
+
+func (m *natConn) SetReadDeadline(t time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.deadline = t
+	return nil
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// newNATReply constructs rendezvous reply pointing to the peer behind the
+// given NAT, with an additional unreachable private address.
+func newNATReply(t *testing.T, conn *natConn) *sonm.RendezvousReply {
+	publicAddr, err := sonm.NewAddr(conn.LocalAddr())
+	require.NoError(t, err)
+
+	return &sonm.RendezvousReply{
+		PublicAddr: publicAddr,
+		PrivateAddrs: []*sonm.Addr{
+			{Protocol: "udp", Addr: &sonm.SocketAddr{Addr: "10.255.255.1", Port: 9}},
+		},
+	}
+}
+
+func TestPunchUDPThroughNATs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	server := newNATConn(t)
+	defer server.Close()
+	client := newNATConn(t)
+	defer client.Close()
+
+	serverReply := newNATReply(t, client)
+	clientReply := newNATReply(t, server)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	var serverConn net.Conn
+	var serverErr error
+	go func() {
+		defer wg.Done()
+		// Start a bit later, so the first punches of the other peer are
+		// dropped by our NAT.
+		time.Sleep(3 * udpPunchInterval)
+		serverConn, serverErr = punchUDP(ctx, server, serverReply)
+	}()
+
+	clientConn, err := punchUDP(ctx, client, clientReply)
+	require.NoError(t, err)
+
+	wg.Wait()
+	require.NoError(t, serverErr)
+
+	assert.True(t, server.dropped.Load() > 0, "expected the NAT to drop early punches")
+	assert.Equal(t, server.LocalAddr().String(), clientConn.RemoteAddr().String())
+	assert.Equal(t, client.LocalAddr().String(), serverConn.RemoteAddr().String())
+
+	_, err = clientConn.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 64)
+	n, err := serverConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf[:n]))
+
+	_, err = serverConn.Write([]byte("pong"))
+	require.NoError(t, err)
+
+	n, err = clientConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(buf[:n]))
+}
+
+func TestPunchUDPTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*udpPunchInterval)
+	defer cancel()
+
+	client := newNATConn(t)
+	defer client.Close()
+	// Nobody is punching from the other side.
+	silent := newNATConn(t)
+	defer silent.Close()
+
+	_, err := punchUDP(ctx, client, newNATReply(t, silent))
+	require.Error(t, err)
+}
+
+func TestUDPConnDropsForeignDatagrams(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	remote, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer remote.Close()
+
+	foreign, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer foreign.Close()
+
+	udpConn := newUDPConn(conn, remote.LocalAddr())
+
+	_, err = foreign.WriteTo([]byte("spoofed"), conn.LocalAddr())
+	require.NoError(t, err)
+	// Late punch must be acknowledged, but not delivered.
+	_, err = remote.WriteTo(udpPunchMessage, conn.LocalAddr())
+	require.NoError(t, err)
+	// Payloads equal to control messages must be delivered as is.
+	_, err = udpConn.Write(udpPunchMessage)
+	require.NoError(t, err)
+	_, err = remote.WriteTo(append([]byte{udpDataTag}, udpPunchMessage...), conn.LocalAddr())
+	require.NoError(t, err)
+
+	buf := make([]byte, 64)
+	n, err := udpConn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, udpPunchMessage, buf[:n])
+
+	require.NoError(t, remote.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err = remote.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, append([]byte{udpDataTag}, udpPunchMessage...), buf[:n])
+
+	require.NoError(t, remote.SetReadDeadline(time.Now().Add(time.Second)))
+	n, _, err = remote.ReadFrom(buf)
+	require.NoError(t, err)
+	assert.Equal(t, udpAckMessage, buf[:n])
+}
@@ -1,6 +1,7 @@
 package oracle
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/jinzhu/configor"
@@ -10,10 +11,25 @@ import (
 )
 
 type oracleConfig struct {
-	IsMaster             bool          `yaml:"is_master" default:"false"`
-	PriceUpdatePeriod    time.Duration `yaml:"price_update_period" default:"15s"`
-	ContractUpdatePeriod time.Duration `yaml:"contract_update_period" default:"15m"`
-	Percent              float64       `yaml:"deviation_percent" default:"1.0"`
+	IsMaster             bool                 `yaml:"is_master" default:"false"`
+	PriceUpdatePeriod    time.Duration        `yaml:"price_update_period" default:"15s"`
+	ContractUpdatePeriod time.Duration        `yaml:"contract_update_period" default:"15m"`
+	Percent              float64              `yaml:"deviation_percent" default:"1.0"`
+	Sources              []*PriceSourceConfig `yaml:"sources"`
+	Aggregation          aggregationConfig    `yaml:"aggregation"`
+}
+
+// aggregationConfig describes how prices from multiple sources are combined.
+type aggregationConfig struct {
+	// MinSources is the minimum number of fresh sources that must agree on
+	// price. The price is not updated otherwise.
+	MinSources uint `yaml:"min_sources" default:"1"`
+	// MaxDeviation is the maximum deviation from the median in percents,
+	// after which a price is considered as an outlier.
+	MaxDeviation float64 `yaml:"max_deviation_percent" default:"5.0"`
+	// MaxAge is the maximum age of prices. Older source prices are ignored
+	// while aggregating and older aggregated price is not submitted.
+	MaxAge time.Duration `yaml:"max_age" default:"5m"`
 }
 
 type Config struct {
@@ -30,5 +46,37 @@ func NewConfig(path string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if len(cfg.Oracle.Sources) == 0 {
+		cfg.Oracle.Sources = []*PriceSourceConfig{
+			{Name: "coinmarketcap", PriceSource: &tickerSource{URL: snmPriceTickerURL}},
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
 	return cfg, nil
 }
+
+func (c *Config) Validate() error {
+	names := map[string]struct{}{}
+	for _, source := range c.Oracle.Sources {
+		if _, ok := names[source.Name]; ok {
+			return fmt.Errorf("duplicate price source name: %s", source.Name)
+		}
+		names[source.Name] = struct{}{}
+	}
+
+	if c.Oracle.Aggregation.MinSources == 0 {
+		return fmt.Errorf("at least one price source must be required to agree")
+	}
+
+	if int(c.Oracle.Aggregation.MinSources) > len(c.Oracle.Sources) {
+		return fmt.Errorf("%d sources are required to agree, but only %d configured",
+			c.Oracle.Aggregation.MinSources, len(c.Oracle.Sources))
+	}
+
+	return nil
+}
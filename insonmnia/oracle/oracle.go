@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -24,7 +25,9 @@ type Oracle struct {
 	bch          blockchain.API
 	actualPrice  *big.Int
 	currentPrice *big.Int
-	mu           sync.Mutex
+	// priceUpdatedAt is the time when the actual price was aggregated.
+	priceUpdatedAt time.Time
+	mu             sync.Mutex
 }
 
 func NewOracle(ctx context.Context, key *ecdsa.PrivateKey, cfg *Config) (*Oracle, error) {
@@ -44,7 +47,7 @@ func NewOracle(ctx context.Context, key *ecdsa.PrivateKey, cfg *Config) (*Oracle
 }
 
 func (o *Oracle) watchPriceRoutine(ctx context.Context) error {
-	priceWatcher := NewPriceWatcher(o.cfg.Oracle.PriceUpdatePeriod)
+	priceWatcher := NewPriceWatcher(o.cfg.Oracle.PriceUpdatePeriod, o.cfg.Oracle.Sources, o.cfg.Oracle.Aggregation)
 	dw := priceWatcher.Start(ctx)
 	for {
 		select {
@@ -57,6 +60,7 @@ func (o *Oracle) watchPriceRoutine(ctx context.Context) error {
 			}
 			o.mu.Lock()
 			o.actualPrice = p.price
+			o.priceUpdatedAt = time.Now()
 			o.mu.Unlock()
 			o.logger.Debug("loaded new price", zap.String("price", p.price.String()))
 		}
@@ -118,7 +122,9 @@ func (o *Oracle) Serve(ctx context.Context) error {
 		zap.String("account", crypto.PubkeyToAddress(o.key.PublicKey).String()),
 		zap.String("price update period:", o.cfg.Oracle.PriceUpdatePeriod.String()),
 		zap.String("contract update period", o.cfg.Oracle.ContractUpdatePeriod.String()),
-		zap.Float64("deviation percent", o.cfg.Oracle.Percent))
+		zap.Float64("deviation percent", o.cfg.Oracle.Percent),
+		zap.Int("price sources", len(o.cfg.Oracle.Sources)),
+		zap.Uint("min agreed sources", o.cfg.Oracle.Aggregation.MinSources))
 
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -157,6 +163,10 @@ func (o *Oracle) getPriceForSubmit() (*big.Int, error) {
 		return nil, fmt.Errorf("actual price is not downloaded")
 	}
 
+	if o.isPriceStale() {
+		return nil, fmt.Errorf("actual price is stale, last updated at %s", o.priceUpdatedAt)
+	}
+
 	if o.actualPrice.Cmp(big.NewInt(params.Finney)) < 0 {
 		return nil, fmt.Errorf("oracle mustn't automaticly set price lower than 1e15")
 	}
@@ -180,12 +190,24 @@ func (o *Oracle) SetPrice(ctx context.Context) error {
 	return o.bch.OracleMultiSig().SubmitTransaction(ctx, o.key, o.bch.ContractRegistry().OracleUsdAddress(), big.NewInt(0), data)
 }
 
+// isPriceStale checks whether the actual price is too old to be trusted,
+// which happens when too few sources agree for a long time.
+// Must be called under the lock.
+func (o *Oracle) isPriceStale() bool {
+	maxAge := o.cfg.Oracle.Aggregation.MaxAge
+	return maxAge > 0 && time.Since(o.priceUpdatedAt) > maxAge
+}
+
 // checkPrice check that submitted price does not differ more than 1%
 // incapsulate ugly big math to separate function
 func (o *Oracle) checkPrice(price *big.Int) bool {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
+	if o.isPriceStale() {
+		return false
+	}
+
 	diff := big.NewInt(0).Set(o.actualPrice)
 	diff.Sub(diff, price)
 	diff.Abs(diff)
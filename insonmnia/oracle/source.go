@@ -0,0 +1,220 @@
+package oracle
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout = 30 * time.Second
+)
+
+// PriceSource provides the current SNM price in USD.
+type PriceSource interface {
+	Price(ctx context.Context) (*big.Float, error)
+}
+
+// PriceSourceConfig is a named price source, which type is determined by the
+// "type" field while decoding.
+type PriceSourceConfig struct {
+	Name string
+	PriceSource
+}
+
+func (m *PriceSourceConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	raw := struct {
+		Name string `yaml:"name"`
+		Type string `yaml:"type"`
+	}{}
+
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	var source PriceSource
+	switch raw.Type {
+	case "coinmarketcap":
+		source = &tickerSource{URL: snmPriceTickerURL}
+	case "json":
+		source = &jsonSource{}
+	case "static":
+		source = &staticSource{}
+	case "file":
+		source = &fileSource{}
+	default:
+		return fmt.Errorf("unknown price source type: %s", raw.Type)
+	}
+
+	if err := unmarshal(source); err != nil {
+		return err
+	}
+
+	m.Name = raw.Name
+	if m.Name == "" {
+		m.Name = raw.Type
+	}
+	m.PriceSource = source
+
+	return nil
+}
+
+// tickerSource loads the price from CoinMarketCap-like ticker, which is an
+// array of token descriptions.
+type tickerSource struct {
+	URL     string        `yaml:"url"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+func (m *tickerSource) Price(ctx context.Context) (*big.Float, error) {
+	body, err := httpGet(ctx, m.URL, m.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var tickerSnm []*tokenData
+	if err := json.Unmarshal(body, &tickerSnm); err != nil {
+		return nil, err
+	}
+
+	if len(tickerSnm) < 1 {
+		return nil, fmt.Errorf("loading ticker is abused")
+	}
+
+	return parsePrice(tickerSnm[0].PriceUsd)
+}
+
+// jsonSource loads the price from an arbitrary JSON document, using the
+// dot-separated path to the price field. Array elements are addressed by
+// their indexes, like "data.0.quote.USD.price".
+type jsonSource struct {
+	URL     string        `yaml:"url"`
+	Path    string        `yaml:"path"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+func (m *jsonSource) Price(ctx context.Context) (*big.Float, error) {
+	body, err := httpGet(ctx, m.URL, m.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+
+	value, err := lookupJSON(document, m.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := value.(type) {
+	case json.Number:
+		return parsePrice(value.String())
+	case string:
+		return parsePrice(value)
+	default:
+		return nil, fmt.Errorf("price at %s must be either a number or a string, got %T", m.Path, value)
+	}
+}
+
+func lookupJSON(document interface{}, path string) (interface{}, error) {
+	if path == "" {
+		return document, nil
+	}
+
+	current := document
+	for _, key := range strings.Split(path, ".") {
+		switch value := current.(type) {
+		case map[string]interface{}:
+			next, ok := value[key]
+			if !ok {
+				return nil, fmt.Errorf("field %s not found", key)
+			}
+			current = next
+		case []interface{}:
+			var idx int
+			if _, err := fmt.Sscanf(key, "%d", &idx); err != nil || idx < 0 || idx >= len(value) {
+				return nil, fmt.Errorf("invalid array index %s", key)
+			}
+			current = value[idx]
+		default:
+			return nil, fmt.Errorf("failed to lookup %s: %T is not an object or an array", key, current)
+		}
+	}
+
+	return current, nil
+}
+
+// staticSource always returns the configured price. Useful for tests and as
+// a manual override.
+type staticSource struct {
+	Value string `yaml:"price"`
+}
+
+func (m *staticSource) Price(ctx context.Context) (*big.Float, error) {
+	return parsePrice(m.Value)
+}
+
+// fileSource reads the price from the file on each request, allowing to
+// change it without restarting.
+type fileSource struct {
+	Path string `yaml:"path"`
+}
+
+func (m *fileSource) Price(ctx context.Context) (*big.Float, error) {
+	data, err := ioutil.ReadFile(m.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePrice(strings.TrimSpace(string(data)))
+}
+
+func parsePrice(value string) (*big.Float, error) {
+	price, _, err := new(big.Float).Parse(value, 10)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse price %q: %v", value, err)
+	}
+
+	if price.Sign() <= 0 {
+		return nil, fmt.Errorf("price must be positive, got %s", value)
+	}
+
+	return price, nil
+}
+
+func httpGet(ctx context.Context, url string, timeout time.Duration) ([]byte, error) {
+	if timeout == 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status: %s", resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
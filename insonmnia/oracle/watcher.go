@@ -2,11 +2,10 @@ package oracle
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"math/big"
-	"net/http"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/params"
@@ -31,15 +30,31 @@ type PriceData struct {
 	err   error
 }
 
+// sourcePrice is the last price successfully loaded from some source.
+type sourcePrice struct {
+	price     *big.Float
+	updatedAt time.Time
+}
+
 type PriceWatcher struct {
+	cfg         aggregationConfig
+	sources     []*PriceSourceConfig
 	parsePeriod time.Duration
 	data        chan *PriceData
+
+	mu     sync.Mutex
+	prices map[string]*sourcePrice
+	now    func() time.Time
 }
 
-func NewPriceWatcher(parsePeriod time.Duration) *PriceWatcher {
+func NewPriceWatcher(parsePeriod time.Duration, sources []*PriceSourceConfig, cfg aggregationConfig) *PriceWatcher {
 	return &PriceWatcher{
-		data:        make(chan *PriceData),
+		cfg:         cfg,
+		sources:     sources,
 		parsePeriod: parsePeriod,
+		data:        make(chan *PriceData),
+		prices:      map[string]*sourcePrice{},
+		now:         time.Now,
 	}
 }
 
@@ -54,10 +69,11 @@ func (p *PriceWatcher) Start(ctx context.Context) <-chan *PriceData {
 				return
 			case <-t.C:
 				price, err := p.loadCurrentPrice(ctx)
-				if err != nil {
-					p.data <- &PriceData{price: nil, err: err}
+				select {
+				case <-ctx.Done():
+					return
+				case p.data <- &PriceData{price: price, err: err}:
 				}
-				p.data <- &PriceData{price: price, err: nil}
 			}
 		}
 	}()
@@ -66,44 +82,113 @@ func (p *PriceWatcher) Start(ctx context.Context) <-chan *PriceData {
 
 func (p *PriceWatcher) loadCurrentPrice(ctx context.Context) (*big.Int, error) {
 	logger := ctxlog.GetLogger(ctx)
-	usdPrice, err := p.loadSNMPrice(snmPriceTickerURL)
+
+	p.loadSourcePrices(ctx)
+
+	usdPrice, err := p.aggregate()
 	if err != nil {
 		return nil, err
 	}
+
 	logger.Debug("Download new price", zap.String("price", usdPrice.String()))
 	return p.divideSNM(usdPrice), nil
 }
 
-func (p *PriceWatcher) divideSNM(price *big.Float) *big.Int {
-	snmInOneUsd := big.NewFloat(0).Quo(big.NewFloat(1), price)
-	snmInOneUsd.Mul(snmInOneUsd, big.NewFloat(params.Ether))
-	intPrice, _ := snmInOneUsd.Int(nil)
-	return intPrice
-}
+// loadSourcePrices concurrently loads prices from all sources, remembering
+// successful results. Failed sources keep their previous prices, which
+// are expired eventually.
+func (p *PriceWatcher) loadSourcePrices(ctx context.Context) {
+	logger := ctxlog.GetLogger(ctx)
 
-func (p *PriceWatcher) loadSNMPrice(url string) (*big.Float, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+	wg := sync.WaitGroup{}
+	wg.Add(len(p.sources))
+	for _, source := range p.sources {
+		go func(source *PriceSourceConfig) {
+			defer wg.Done()
+
+			price, err := source.Price(ctx)
+			if err != nil {
+				logger.Warn("failed to load price", zap.String("source", source.Name), zap.Error(err))
+				return
+			}
+
+			logger.Debug("loaded price", zap.String("source", source.Name), zap.String("price", price.String()))
+
+			p.mu.Lock()
+			defer p.mu.Unlock()
+			p.prices[source.Name] = &sourcePrice{price: price, updatedAt: p.now()}
+		}(source)
 	}
 
-	defer resp.Body.Close()
+	wg.Wait()
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+// aggregate calculates the median of fresh source prices after rejecting
+// outliers, i.e. prices that deviate from the median more than allowed.
+// Fails when less than the required number of sources agree.
+func (p *PriceWatcher) aggregate() (*big.Float, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var prices []*big.Float
+	for name, price := range p.prices {
+		if p.cfg.MaxAge > 0 && p.now().Sub(price.updatedAt) > p.cfg.MaxAge {
+			delete(p.prices, name)
+			continue
+		}
+
+		prices = append(prices, price.price)
 	}
-	var tickerSnm []*tokenData
-	err = json.Unmarshal(body, &tickerSnm)
-	if err != nil {
-		return nil, err
+
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no fresh prices available")
 	}
-	if len(tickerSnm) < 1 {
-		return nil, fmt.Errorf("loading ticker is abused")
+
+	median := medianPrice(prices)
+
+	var agreed []*big.Float
+	for _, price := range prices {
+		if p.cfg.MaxDeviation <= 0 || deviationPercent(price, median) <= p.cfg.MaxDeviation {
+			agreed = append(agreed, price)
+		}
 	}
-	f, _, err := new(big.Float).Parse(tickerSnm[0].PriceUsd, 10)
-	if err != nil {
-		return nil, err
+
+	if uint(len(agreed)) < p.cfg.MinSources {
+		return nil, fmt.Errorf("too few sources agree on price: %d/%d, while at least %d required", len(agreed), len(prices), p.cfg.MinSources)
+	}
+
+	return medianPrice(agreed), nil
+}
+
+func medianPrice(prices []*big.Float) *big.Float {
+	sorted := append([]*big.Float{}, prices...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Cmp(sorted[j]) < 0
+	})
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return new(big.Float).Set(sorted[mid])
 	}
-	return f, nil
+
+	median := new(big.Float).Add(sorted[mid-1], sorted[mid])
+	return median.Quo(median, big.NewFloat(2))
+}
+
+// deviationPercent returns how much the given price deviates from the
+// reference one in percents.
+func deviationPercent(price, reference *big.Float) float64 {
+	diff := new(big.Float).Sub(price, reference)
+	diff.Abs(diff)
+	diff.Quo(diff, reference)
+
+	value, _ := diff.Float64()
+	return 100.0 * value
+}
+
+func (p *PriceWatcher) divideSNM(price *big.Float) *big.Int {
+	snmInOneUsd := big.NewFloat(0).Quo(big.NewFloat(1), price)
+	snmInOneUsd.Mul(snmInOneUsd, big.NewFloat(params.Ether))
+	intPrice, _ := snmInOneUsd.Int(nil)
+	return intPrice
 }
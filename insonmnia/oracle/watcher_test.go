@@ -0,0 +1,211 @@
+package oracle
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v2"
+)
+
+func newTickerStub(price string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `[{"id": "sonm", "symbol": "SNM", "name": "SONM", "price_usd": "%s"}]`, price)
+	}))
+}
+
+func newJSONStub(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+}
+
+func newBrokenStub() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+}
+
+func requirePrice(t *testing.T, expected string, actual *big.Float) {
+	require.NotNil(t, actual)
+	expectedPrice, _, err := new(big.Float).Parse(expected, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, expectedPrice.Cmp(actual), "expected %s, actual %s", expected, actual.String())
+}
+
+func TestTickerSource(t *testing.T) {
+	server := newTickerStub("0.125")
+	defer server.Close()
+
+	price, err := (&tickerSource{URL: server.URL}).Price(context.Background())
+	require.NoError(t, err)
+	requirePrice(t, "0.125", price)
+}
+
+func TestJSONSource(t *testing.T) {
+	server := newJSONStub(`{"data": [{"quote": {"USD": {"price": 0.25}}}]}`)
+	defer server.Close()
+
+	price, err := (&jsonSource{URL: server.URL, Path: "data.0.quote.USD.price"}).Price(context.Background())
+	require.NoError(t, err)
+	requirePrice(t, "0.25", price)
+
+	_, err = (&jsonSource{URL: server.URL, Path: "data.1.quote"}).Price(context.Background())
+	require.Error(t, err)
+}
+
+func TestBrokenSource(t *testing.T) {
+	server := newBrokenStub()
+	defer server.Close()
+
+	_, err := (&tickerSource{URL: server.URL}).Price(context.Background())
+	require.Error(t, err)
+}
+
+func TestFileSource(t *testing.T) {
+	file, err := ioutil.TempFile("", "oracle-price")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	_, err = file.WriteString("0.5\n")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	price, err := (&fileSource{Path: file.Name()}).Price(context.Background())
+	require.NoError(t, err)
+	requirePrice(t, "0.5", price)
+}
+
+func TestPriceSourceConfigUnmarshal(t *testing.T) {
+	cfg := struct {
+		Sources []*PriceSourceConfig `yaml:"sources"`
+	}{}
+
+	err := yaml.Unmarshal([]byte(`
+sources:
+  - type: coinmarketcap
+  - name: exchange
+    type: json
+    url: http://localhost/ticker
+    path: price
+  - type: static
+    price: "0.1"
+`), &cfg)
+	require.NoError(t, err)
+	require.Len(t, cfg.Sources, 3)
+
+	assert.Equal(t, "coinmarketcap", cfg.Sources[0].Name)
+	assert.Equal(t, &tickerSource{URL: snmPriceTickerURL}, cfg.Sources[0].PriceSource)
+	assert.Equal(t, "exchange", cfg.Sources[1].Name)
+	assert.Equal(t, &jsonSource{URL: "http://localhost/ticker", Path: "price"}, cfg.Sources[1].PriceSource)
+	assert.Equal(t, &staticSource{Value: "0.1"}, cfg.Sources[2].PriceSource)
+
+	err = yaml.Unmarshal([]byte(`sources: [{type: unknown}]`), &cfg)
+	require.Error(t, err)
+}
+
+func TestPriceWatcherMedian(t *testing.T) {
+	first := newTickerStub("0.10")
+	defer first.Close()
+	second := newJSONStub(`{"price": "0.11"}`)
+	defer second.Close()
+	// Way too far from others, must be rejected.
+	outlier := newTickerStub("1.0")
+	defer outlier.Close()
+	broken := newBrokenStub()
+	defer broken.Close()
+
+	watcher := NewPriceWatcher(time.Second, []*PriceSourceConfig{
+		{Name: "first", PriceSource: &tickerSource{URL: first.URL}},
+		{Name: "second", PriceSource: &jsonSource{URL: second.URL, Path: "price"}},
+		{Name: "third", PriceSource: &staticSource{Value: "0.12"}},
+		{Name: "outlier", PriceSource: &tickerSource{URL: outlier.URL}},
+		{Name: "broken", PriceSource: &tickerSource{URL: broken.URL}},
+	}, aggregationConfig{
+		MinSources:   3,
+		MaxDeviation: 20.0,
+		MaxAge:       time.Minute,
+	})
+
+	watcher.loadSourcePrices(context.Background())
+
+	price, err := watcher.aggregate()
+	require.NoError(t, err)
+	requirePrice(t, "0.11", price)
+}
+
+func TestPriceWatcherTooFewSourcesAgree(t *testing.T) {
+	watcher := NewPriceWatcher(time.Second, []*PriceSourceConfig{
+		{Name: "first", PriceSource: &staticSource{Value: "0.1"}},
+		{Name: "second", PriceSource: &staticSource{Value: "0.2"}},
+		{Name: "third", PriceSource: &staticSource{Value: "0.4"}},
+	}, aggregationConfig{
+		MinSources:   2,
+		MaxDeviation: 10.0,
+		MaxAge:       time.Minute,
+	})
+
+	watcher.loadSourcePrices(context.Background())
+
+	_, err := watcher.aggregate()
+	require.Error(t, err)
+}
+
+func TestPriceWatcherStaleSources(t *testing.T) {
+	server := newTickerStub("0.1")
+
+	now := time.Now()
+	watcher := NewPriceWatcher(time.Second, []*PriceSourceConfig{
+		{Name: "first", PriceSource: &tickerSource{URL: server.URL}},
+		{Name: "second", PriceSource: &staticSource{Value: "0.1"}},
+	}, aggregationConfig{
+		MinSources:   2,
+		MaxDeviation: 10.0,
+		MaxAge:       time.Minute,
+	})
+	watcher.now = func() time.Time { return now }
+
+	watcher.loadSourcePrices(context.Background())
+	price, err := watcher.aggregate()
+	require.NoError(t, err)
+	requirePrice(t, "0.1", price)
+
+	// The first source goes down, but its last price is still fresh enough.
+	server.Close()
+	now = now.Add(30 * time.Second)
+	watcher.loadSourcePrices(context.Background())
+	_, err = watcher.aggregate()
+	require.NoError(t, err)
+
+	// Now it is too old.
+	now = now.Add(time.Minute)
+	watcher.loadSourcePrices(context.Background())
+	_, err = watcher.aggregate()
+	require.Error(t, err)
+}
+
+func TestPriceWatcherStart(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watcher := NewPriceWatcher(time.Hour, []*PriceSourceConfig{
+		{Name: "static", PriceSource: &staticSource{Value: "0.5"}},
+	}, aggregationConfig{MinSources: 1})
+
+	select {
+	case data := <-watcher.Start(ctx):
+		require.NoError(t, data.err)
+		// 1 USD costs 2 SNM.
+		assert.Equal(t, "2000000000000000000", data.price.String())
+	case <-time.After(5 * time.Second):
+		t.Fatal("no price received")
+	}
+}
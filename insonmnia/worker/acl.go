@@ -166,3 +166,23 @@ func (a *anyOfAuth) Authorize(ctx context.Context, request interface{}) error {
 func newAnyOfAuth(a ...auth.Authorization) auth.Authorization {
 	return &anyOfAuth{authorizers: a}
 }
+
+// pingAuthorization allows everyone to ping the worker, but requires the
+// given authorization for replies larger than the request payload.
+// Otherwise the worker could be used as a bandwidth amplifier.
+type pingAuthorization struct {
+	auth auth.Authorization
+}
+
+func newPingAuthorization(auth auth.Authorization) auth.Authorization {
+	return &pingAuthorization{auth: auth}
+}
+
+func (m *pingAuthorization) Authorize(ctx context.Context, request interface{}) error {
+	ping, ok := request.(*sonm.PingRequest)
+	if ok && ping.GetReplySize() <= uint64(len(ping.GetPayload())) {
+		return nil
+	}
+
+	return m.auth.Authorize(ctx, request)
+}
@@ -2,10 +2,14 @@ package worker
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	log "github.com/noxiouz/zapctx/ctxlog"
 	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/insonmnia/logging"
@@ -142,3 +146,228 @@ func TestMultiAuth(t *testing.T) {
 	err = mul.Authorize(context.Background(), nil)
 	assert.Error(t, err)
 }
+
+func TestPingAuthorization(t *testing.T) {
+	deny := newPingAuthorization(&magicAuthorizer{ok: false})
+
+	assert.NoError(t, deny.Authorize(context.Background(), &pb.PingRequest{}))
+	assert.NoError(t, deny.Authorize(context.Background(), &pb.PingRequest{Payload: make([]byte, 1024), ReplySize: 1024}))
+	// Replies larger than the request are amplification.
+	assert.Error(t, deny.Authorize(context.Background(), &pb.PingRequest{ReplySize: 1024}))
+
+	allow := newPingAuthorization(&magicAuthorizer{ok: true})
+	assert.NoError(t, allow.Authorize(context.Background(), &pb.PingRequest{ReplySize: 1024}))
+}
+
+type testDelegationStorage struct {
+	data []byte
+}
+
+func (m *testDelegationStorage) Save(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.data = data
+	return nil
+}
+
+func (m *testDelegationStorage) Load(value interface{}) (bool, error) {
+	if m.data == nil {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(m.data, value)
+}
+
+func newTestDelegationToken(t *testing.T, issuer *ecdsa.PrivateKey, delegate common.Address, methods ...string) (*pb.DelegationToken, context.Context) {
+	token := &pb.DelegationToken{
+		Id:        "token",
+		DealID:    pb.NewBigIntFromInt(66),
+		Delegate:  pb.NewEthAddress(delegate),
+		Methods:   methods,
+		ExpiresAt: &pb.Timestamp{Seconds: time.Now().Add(time.Hour).Unix()},
+	}
+	require.NoError(t, token.SignWith(issuer))
+
+	encoded, err := token.Encode()
+	require.NoError(t, err)
+
+	ctx := peer.NewContext(testCtx(), &peer.Peer{
+		AuthInfo: auth.EthAuthInfo{TLS: credentials.TLSInfo{}, Wallet: delegate},
+	})
+	ctx = metadata.NewIncomingContext(ctx, metadata.MD(map[string][]string{
+		auth.DelegationTokenMetadataKey: {encoded},
+	}))
+
+	return token, ctx
+}
+
+func TestDelegationAuthorization(t *testing.T) {
+	consumer, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	registry, err := newDelegationRegistry(&testDelegationStorage{})
+	require.NoError(t, err)
+
+	supplier := makeDealInfoSupplier(t, ethcrypto.PubkeyToAddress(consumer.PublicKey).Hex(), "66")
+	request := &sonm.StartTaskRequest{
+		DealID: pb.NewBigIntFromInt(66),
+	}
+
+	_, ctx := newTestDelegationToken(t, consumer, addr, "StartTask")
+
+	au := newDelegationAuthorization("StartTask", supplier, registry, startTaskDealExtractor())
+	require.NoError(t, au.Authorize(ctx, request))
+
+	tokens, err := registry.Tokens("66")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, "token", tokens[0].GetToken().GetId())
+	assert.False(t, tokens[0].GetRevoked())
+
+	require.NoError(t, registry.Revoke("66", "token"))
+	require.Error(t, au.Authorize(ctx, request))
+}
+
+func TestDelegationRegistryRevokeUnused(t *testing.T) {
+	consumer, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	storage := &testDelegationStorage{}
+	registry, err := newDelegationRegistry(storage)
+	require.NoError(t, err)
+
+	require.NoError(t, registry.Revoke("66", "token"))
+
+	// Revocation must survive restarts.
+	registry, err = newDelegationRegistry(storage)
+	require.NoError(t, err)
+
+	token, _ := newTestDelegationToken(t, consumer, addr, "StartTask")
+	assert.Equal(t, errTokenRevoked, registry.Use(token))
+
+	tokens, err := registry.Tokens("66")
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestDelegationRegistryPersistsLastUsedAt(t *testing.T) {
+	consumer, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	storage := &testDelegationStorage{}
+	registry, err := newDelegationRegistry(storage)
+	require.NoError(t, err)
+
+	token, _ := newTestDelegationToken(t, consumer, addr, "StartTask")
+	require.NoError(t, registry.Use(token))
+
+	lastUsedAt := time.Now().Add(-time.Hour)
+	record := registry.records[delegationRecordKey("66", "token")]
+	record.LastUsedAt = lastUsedAt
+	record.savedLastUsedAt = lastUsedAt
+	require.NoError(t, registry.Use(token))
+
+	registry, err = newDelegationRegistry(storage)
+	require.NoError(t, err)
+
+	tokens, err := registry.Tokens("66")
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.True(t, tokens[0].GetLastUsedAt().Unix().After(lastUsedAt))
+}
+
+func TestDelegationRegistryPersistsFrequentUsage(t *testing.T) {
+	consumer, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	storage := &testDelegationStorage{}
+	registry, err := newDelegationRegistry(storage)
+	require.NoError(t, err)
+
+	token, _ := newTestDelegationToken(t, consumer, addr, "StartTask")
+	require.NoError(t, registry.Use(token))
+
+	// The token is used more often than the precision, but its usage has
+	// been persisted long ago.
+	savedLastUsedAt := time.Now().Add(-delegationLastUsedPrecision * 3 / 2)
+	record := registry.records[delegationRecordKey("66", "token")]
+	record.LastUsedAt = time.Now().Add(-delegationLastUsedPrecision / 2)
+	record.savedLastUsedAt = savedLastUsedAt
+	require.NoError(t, registry.Use(token))
+
+	registry, err = newDelegationRegistry(storage)
+	require.NoError(t, err)
+
+	record = registry.records[delegationRecordKey("66", "token")]
+	assert.True(t, record.LastUsedAt.After(savedLastUsedAt.Add(delegationLastUsedPrecision)))
+}
+
+func TestDelegationRegistryPrunesTombstones(t *testing.T) {
+	consumer, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	registry, err := newDelegationRegistry(&testDelegationStorage{})
+	require.NoError(t, err)
+
+	require.NoError(t, registry.Revoke("66", "token"))
+	require.NoError(t, registry.Revoke("67", "unknown"))
+
+	// Tombstones learn the expiration time of presented tokens.
+	token, _ := newTestDelegationToken(t, consumer, addr, "StartTask")
+	assert.Equal(t, errTokenRevoked, registry.Use(token))
+
+	record := registry.records[delegationRecordKey("66", "token")]
+	assert.Equal(t, token.GetExpiresAt().GetSeconds(), record.ExpiresAt.Unix())
+
+	record.ExpiresAt = time.Now().Add(-time.Second)
+	require.NoError(t, registry.save())
+	assert.NotContains(t, registry.records, delegationRecordKey("66", "token"))
+
+	// Tombstones of never presented tokens are kept until their deals are
+	// finished.
+	assert.Contains(t, registry.records, delegationRecordKey("67", "unknown"))
+	require.NoError(t, registry.Forget("67"))
+	assert.Empty(t, registry.records)
+}
+
+func TestDelegationAuthorizationErrors(t *testing.T) {
+	consumer, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	stranger, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	registry, err := newDelegationRegistry(&testDelegationStorage{})
+	require.NoError(t, err)
+
+	supplier := makeDealInfoSupplier(t, ethcrypto.PubkeyToAddress(consumer.PublicKey).Hex(), "66")
+	request := &sonm.StartTaskRequest{
+		DealID: pb.NewBigIntFromInt(66),
+	}
+
+	au := newDelegationAuthorization("StartTask", supplier, registry, startTaskDealExtractor())
+
+	// No token at all.
+	require.Error(t, au.Authorize(testCtx(), request))
+
+	// The method is not delegated.
+	_, ctx := newTestDelegationToken(t, consumer, addr, "TaskLogs")
+	require.Error(t, au.Authorize(ctx, request))
+
+	// The token is not issued by the deal's consumer.
+	_, ctx = newTestDelegationToken(t, stranger, addr, "StartTask")
+	require.Error(t, au.Authorize(ctx, request))
+
+	// The caller is not the delegate.
+	_, ctx = newTestDelegationToken(t, consumer, common.HexToAddress("0x100500"), "StartTask")
+	ctx = peer.NewContext(ctx, &peer.Peer{
+		AuthInfo: auth.EthAuthInfo{TLS: credentials.TLSInfo{}, Wallet: addr},
+	})
+	require.Error(t, au.Authorize(ctx, request))
+
+	// The token is issued for another deal.
+	_, ctx = newTestDelegationToken(t, consumer, addr, "StartTask")
+	require.Error(t, au.Authorize(ctx, &sonm.StartTaskRequest{DealID: pb.NewBigIntFromInt(67)}))
+}
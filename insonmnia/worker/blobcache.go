@@ -0,0 +1,261 @@
+package worker
+
+import (
+	"container/list"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+// blobCache is a content-addressable on-disk storage limited by size, that
+// evicts the least recently used blobs when the limit is exceeded.
+//
+// Blobs are stored as "<dir>/<algorithm>/<hex>" files, which modification
+// times are used to restore the LRU order after restart.
+type blobCache struct {
+	mu      sync.Mutex
+	dir     string
+	maxSize uint64
+	size    uint64
+	// Entries maps digests to LRU list elements. The front of the list is
+	// the most recently used blob.
+	entries map[digest.Digest]*list.Element
+	lru     *list.List
+	// OnEvict is called for each evicted blob with its size.
+	onEvict func(size uint64)
+}
+
+type blobCacheEntry struct {
+	digest digest.Digest
+	size   uint64
+}
+
+func newBlobCache(dir string, maxSize uint64) (*blobCache, error) {
+	m := &blobCache{
+		dir:     dir,
+		maxSize: maxSize,
+		entries: map[digest.Digest]*list.Element{},
+		lru:     list.New(),
+		onEvict: func(uint64) {},
+	}
+
+	if err := os.MkdirAll(m.tmpDir(), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %v", err)
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func (m *blobCache) tmpDir() string {
+	return filepath.Join(m.dir, "tmp")
+}
+
+func (m *blobCache) path(dgst digest.Digest) string {
+	return filepath.Join(m.dir, dgst.Algorithm().String(), dgst.Hex())
+}
+
+// load restores the index from files, removing unfinished uploads.
+func (m *blobCache) load() error {
+	if err := os.RemoveAll(m.tmpDir()); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.tmpDir(), 0700); err != nil {
+		return err
+	}
+
+	type file struct {
+		digest  digest.Digest
+		size    uint64
+		modTime time.Time
+	}
+
+	var files []file
+	err := filepath.Walk(m.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		dgst := digest.NewDigestFromHex(filepath.Base(filepath.Dir(path)), info.Name())
+		if dgst.Validate() != nil || path != m.path(dgst) {
+			return nil
+		}
+
+		files = append(files, file{digest: dgst, size: uint64(info.Size()), modTime: info.ModTime()})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan cache directory: %v", err)
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, file := range files {
+		m.add(file.digest, file.size)
+	}
+	m.evict()
+
+	return nil
+}
+
+// Size returns the total size of cached blobs.
+func (m *blobCache) Size() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.size
+}
+
+// Contains checks whether the blob is cached without marking it as used.
+func (m *blobCache) Contains(dgst digest.Digest) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, ok := m.entries[dgst]
+	return ok
+}
+
+// Open opens the cached blob, marking it as the most recently used one.
+func (m *blobCache) Open(dgst digest.Digest) (*os.File, uint64, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[dgst]
+	if !ok {
+		return nil, 0, false
+	}
+
+	file, err := os.Open(m.path(dgst))
+	if err != nil {
+		m.remove(element)
+		return nil, 0, false
+	}
+
+	now := time.Now()
+	os.Chtimes(m.path(dgst), now, now)
+	m.lru.MoveToFront(element)
+
+	return file, element.Value.(*blobCacheEntry).size, true
+}
+
+// Create starts writing the blob with the given digest. The blob becomes
+// available only after the writer is committed.
+func (m *blobCache) Create(dgst digest.Digest) (*blobWriter, error) {
+	file, err := ioutil.TempFile(m.tmpDir(), "blob")
+	if err != nil {
+		return nil, err
+	}
+
+	return &blobWriter{
+		cache:    m,
+		digest:   dgst,
+		file:     file,
+		verifier: dgst.Verifier(),
+	}, nil
+}
+
+func (m *blobCache) commit(dgst digest.Digest, tmpPath string, size uint64) error {
+	if size > m.maxSize {
+		os.Remove(tmpPath)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.path(dgst)), 0700); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := os.Rename(tmpPath, m.path(dgst)); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+
+	if element, ok := m.entries[dgst]; ok {
+		m.lru.MoveToFront(element)
+		return nil
+	}
+
+	m.add(dgst, size)
+	m.evict()
+
+	return nil
+}
+
+func (m *blobCache) add(dgst digest.Digest, size uint64) {
+	m.entries[dgst] = m.lru.PushFront(&blobCacheEntry{digest: dgst, size: size})
+	m.size += size
+}
+
+func (m *blobCache) remove(element *list.Element) {
+	entry := m.lru.Remove(element).(*blobCacheEntry)
+	delete(m.entries, entry.digest)
+	m.size -= entry.size
+	os.Remove(m.path(entry.digest))
+}
+
+func (m *blobCache) evict() {
+	for m.size > m.maxSize && m.lru.Len() > 0 {
+		element := m.lru.Back()
+		size := element.Value.(*blobCacheEntry).size
+		m.remove(element)
+		m.onEvict(size)
+	}
+}
+
+// blobWriter writes the blob into a temporary file, verifying its digest.
+type blobWriter struct {
+	cache    *blobCache
+	digest   digest.Digest
+	file     *os.File
+	verifier digest.Verifier
+	size     uint64
+}
+
+func (m *blobWriter) Write(p []byte) (int, error) {
+	n, err := m.file.Write(p)
+	m.verifier.Write(p[:n])
+	m.size += uint64(n)
+
+	return n, err
+}
+
+// Commit places the blob into the cache if its content matches the digest.
+func (m *blobWriter) Commit() error {
+	if err := m.file.Close(); err != nil {
+		os.Remove(m.file.Name())
+		return err
+	}
+
+	if !m.verifier.Verified() {
+		os.Remove(m.file.Name())
+		return fmt.Errorf("blob content does not match digest %s", m.digest)
+	}
+
+	return m.cache.commit(m.digest, m.file.Name(), m.size)
+}
+
+// Abort discards the written data.
+func (m *blobWriter) Abort() {
+	m.file.Close()
+	os.Remove(m.file.Name())
+}
@@ -1,6 +1,8 @@
 package worker
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jinzhu/configor"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -13,12 +15,20 @@ import (
 	"github.com/sonm-io/core/insonmnia/state"
 	"github.com/sonm-io/core/insonmnia/worker/plugin"
 	"github.com/sonm-io/core/insonmnia/worker/salesman"
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util/datasize"
 	"github.com/sonm-io/core/util/debug"
 )
 
+// SSHConfig describes SSH access to tasks, which is always available
+// through NPP.
 type SSHConfig struct {
-	BindEndpoint   string `required:"true" yaml:"bind"`
-	PrivateKeyPath string `required:"true" yaml:"private_key_path"`
+	// BindEndpoint is an optional public endpoint SSH connections are also
+	// accepted on.
+	BindEndpoint string `yaml:"bind"`
+	// PrivateKeyPath is an optional path to the host key, which is derived
+	// from the worker's Ethereum key otherwise.
+	PrivateKeyPath string `yaml:"private_key_path"`
 }
 
 type ResourcesConfig struct {
@@ -33,30 +43,131 @@ type WhitelistConfig struct {
 	RefreshPeriod       uint     `yaml:"refresh_period" default:"60"`
 }
 
+type SignatureConfig struct {
+	// TrustedKeys are ETH addresses of publishers, which signatures are
+	// trusted.
+	TrustedKeys []common.Address `yaml:"trusted_keys"`
+	// TrustedIdentity additionally trusts signatures of publishers with at
+	// least the given identity level in the profile registry. Zero value
+	// disables this check.
+	TrustedIdentity sonm.IdentityLevel `yaml:"trusted_identity"`
+}
+
+type ImagePolicyConfig struct {
+	// Policy specifies how images are verified unless ask plans override
+	// it, defaulting to the whitelist only.
+	Policy    sonm.AskPlan_ImagePolicy `yaml:"policy"`
+	Signature SignatureConfig          `yaml:"signature"`
+}
+
+// RegistryCacheConfig describes the embedded pull-through registry cache,
+// that public images are pulled through.
+type RegistryCacheConfig struct {
+	// Endpoint must be a loopback address, because Docker pulls images
+	// from such registries without TLS.
+	Endpoint string `yaml:"endpoint" default:"127.0.0.1:15050"`
+	Path     string `yaml:"path" default:"/var/lib/sonm/registry_cache"`
+	// Size limits the total size of cached blobs, defaults to 20 GiB.
+	Size datasize.ByteSize `yaml:"size"`
+	// Prewarm is a list of images fetched into the cache in advance.
+	Prewarm       []string      `yaml:"prewarm"`
+	PrewarmPeriod time.Duration `yaml:"prewarm_period" default:"1h"`
+}
+
+// IsolationConfig describes how tasks are isolated from the host.
+type IsolationConfig struct {
+	// Runtime is the OCI runtime for consumers, which identity levels are
+	// lower than any level in Runtimes. Empty value means Docker's default
+	// one.
+	Runtime string `yaml:"runtime"`
+	// Runtimes maps consumer identity levels to OCI runtimes, for example
+	// "runsc" for anonymous consumers and "runc" for professional ones. A
+	// consumer gets the runtime of the highest configured level, that does
+	// not exceed its own. Ask plans can't replace a sandbox runtime chosen
+	// this way with a non-sandbox one.
+	Runtimes map[sonm.IdentityLevel]string `yaml:"runtimes"`
+	// SandboxRuntimes are the runtimes running tasks within a sandbox,
+	// defaults to gVisor and Kata Containers ones.
+	SandboxRuntimes []string `yaml:"sandbox_runtimes"`
+	// UserNamespaces requires Docker to be configured with user namespace
+	// remapping, so the worker refuses to start otherwise.
+	UserNamespaces bool `yaml:"user_namespaces"`
+	// SeccompProfile is the path to seccomp profile applied to tasks
+	// instead of Docker's default one.
+	SeccompProfile string `yaml:"seccomp_profile"`
+	// AppArmorProfile is the name of AppArmor profile applied to tasks.
+	AppArmorProfile string `yaml:"apparmor_profile"`
+	// CapDrop are Linux capabilities dropped from tasks, "ALL" is allowed.
+	CapDrop []string `yaml:"cap_drop"`
+	// NoNewPrivileges prevents tasks from gaining privileges with setuid
+	// binaries.
+	NoNewPrivileges bool `yaml:"no_new_privileges"`
+}
+
+// GatewayConfig describes how task ports are exposed on stable public ports
+// through the IPVS gateway.
+type GatewayConfig struct {
+	// Ports is the inclusive range of public ports allocated for tasks.
+	// Workers sharing a public IP must be configured with disjoint ranges.
+	Ports []uint16 `yaml:"ports" required:"true"`
+	// Host is the address virtual services are bound to, defaults to the
+	// address of the outbound interface.
+	Host string `yaml:"host"`
+}
+
+// IngressConfig describes HTTPS ingress routing requests to tasks by
+// hostname.
+type IngressConfig struct {
+	// Domain is the domain, which subdomains resolve to this worker. Tasks
+	// get "<task-id>.<domain>" hostnames.
+	Domain        string     `yaml:"domain" required:"true"`
+	HTTPEndpoint  string     `yaml:"http_endpoint" default:":80"`
+	HTTPSEndpoint string     `yaml:"https_endpoint" default:":443"`
+	ACME          ACMEConfig `yaml:"acme"`
+}
+
+// ACMEConfig describes how TLS certificates for the ingress are obtained.
+type ACMEConfig struct {
+	// DirectoryURL is the ACME server directory, defaults to Let's Encrypt.
+	DirectoryURL string `yaml:"directory_url"`
+	Email        string `yaml:"email"`
+	// CacheDir is where the account key and certificates are kept.
+	CacheDir string `yaml:"cache_dir" default:"/var/lib/sonm/acme"`
+	// CA is the path to PEM encoded certificates to trust the ACME server
+	// with in addition to system ones, which is useful for test servers.
+	CA string `yaml:"ca"`
+}
+
 type DevConfig struct {
 	DisableMasterApproval bool `yaml:"disable_master_approval"`
 }
 
 type Config struct {
-	Endpoint          string              `yaml:"endpoint" required:"true"`
-	Logging           logging.Config      `yaml:"logging"`
-	Resources         *ResourcesConfig    `yaml:"resources" required:"false" `
-	Blockchain        *blockchain.Config  `yaml:"blockchain"`
-	NPP               npp.Config          `yaml:"npp"`
-	SSH               *SSHConfig          `yaml:"ssh" required:"false" `
-	PublicIPs         []string            `yaml:"public_ip_addrs" required:"false" `
-	Plugins           plugin.Config       `yaml:"plugins"`
-	Storage           state.StorageConfig `yaml:"store"`
-	Benchmarks        benchmarks.Config   `yaml:"benchmarks"`
-	Whitelist         WhitelistConfig     `yaml:"whitelist"`
-	MetricsListenAddr string              `yaml:"metrics_listen_addr" default:"127.0.0.1:14000"`
-	DWH               dwh.YAMLConfig      `yaml:"dwh"`
-	Matcher           *matcher.YAMLConfig `yaml:"matcher"`
-	Salesman          salesman.YAMLConfig `yaml:"salesman"`
-	Master            common.Address      `yaml:"master" required:"true"`
-	Development       *DevConfig          `yaml:"development"`
-	Admin             *common.Address     `yaml:"admin"`
-	Debug             *debug.Config       `yaml:"debug"`
+	Endpoint          string               `yaml:"endpoint" required:"true"`
+	Logging           logging.Config       `yaml:"logging"`
+	Resources         *ResourcesConfig     `yaml:"resources" required:"false" `
+	Blockchain        *blockchain.Config   `yaml:"blockchain"`
+	NPP               npp.Config           `yaml:"npp"`
+	SSH               *SSHConfig           `yaml:"ssh" required:"false" `
+	PublicIPs         []string             `yaml:"public_ip_addrs" required:"false" `
+	Plugins           plugin.Config        `yaml:"plugins"`
+	Storage           state.StorageConfig  `yaml:"store"`
+	Benchmarks        benchmarks.Config    `yaml:"benchmarks"`
+	Whitelist         WhitelistConfig      `yaml:"whitelist"`
+	ImagePolicy       ImagePolicyConfig    `yaml:"image_policy"`
+	RegistryCache     *RegistryCacheConfig `yaml:"registry_cache"`
+	Isolation         IsolationConfig      `yaml:"isolation"`
+	Egress            *sonm.EgressPolicy   `yaml:"egress"`
+	Gateway           *GatewayConfig       `yaml:"gateway"`
+	Ingress           *IngressConfig       `yaml:"ingress"`
+	MetricsListenAddr string               `yaml:"metrics_listen_addr" default:"127.0.0.1:14000"`
+	DWH               dwh.YAMLConfig       `yaml:"dwh"`
+	Matcher           *matcher.YAMLConfig  `yaml:"matcher"`
+	Salesman          salesman.YAMLConfig  `yaml:"salesman"`
+	Master            common.Address       `yaml:"master" required:"true"`
+	Development       *DevConfig           `yaml:"development"`
+	Admin             *common.Address      `yaml:"admin"`
+	Debug             *debug.Config        `yaml:"debug"`
 }
 
 // NewConfig creates a new Worker config from the specified YAML file.
@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,6 +36,11 @@ npp:
   relay:
     endpoints:
       - 2.3.4.5:12345
+    acl:
+      addrs:
+        - "0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD"
+      verifiers:
+        - deals
 store:
   path: "/var/lib/sonm/worker.boltdb"
 benchmarks:
@@ -60,6 +66,9 @@ whitelist:
 
 	assert.Len(t, conf.NPP.Relay.Endpoints, 1)
 	assert.Contains(t, conf.NPP.Relay.Endpoints[0], "2.3.4.5:12345")
+	acl := conf.NPP.Relay.ACL.ACL()
+	assert.Equal(t, [][]byte{common.HexToAddress("0x8125721C2413d99a33E351e1F6Bb4e56b6b633FD").Bytes()}, acl.Addrs)
+	assert.Equal(t, []string{"deals"}, acl.Verifiers)
 
 	assert.Equal(t, "/var/lib/sonm/worker.boltdb", conf.Storage.Endpoint)
 	assert.Equal(t, "sonm", conf.Storage.Bucket)
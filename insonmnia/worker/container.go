@@ -38,6 +38,11 @@ func newContainer(ctx context.Context, dockerClient *client.Client, d Descriptio
 		description: d,
 	}
 
+	image := d.Reference
+	if d.mirrored != nil {
+		image = d.mirrored
+	}
+
 	exposedPorts, portBindings, err := d.Expose()
 	if err != nil {
 		log.G(ctx).Error("failed to parse `expose` section", zap.Error(err))
@@ -54,7 +59,7 @@ func newContainer(ctx context.Context, dockerClient *client.Client, d Descriptio
 
 		ExposedPorts: exposedPorts,
 
-		Image: d.Reference.String(),
+		Image: image.String(),
 		// TODO: set actual name
 		Labels:  map[string]string{overseerTag: "", dealIDTag: d.DealId},
 		Env:     d.FormatEnv(),
@@ -74,6 +79,7 @@ func newContainer(ctx context.Context, dockerClient *client.Client, d Descriptio
 		AutoRemove:      d.autoremove,
 		Resources:       d.Resources.ToHostConfigResources(d.CGroupParent),
 	}
+	d.isolation.Tune(&hostConfig)
 
 	networkingConfig := network.NetworkingConfig{}
 
@@ -0,0 +1,302 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	delegationTokensKey = "delegation_tokens"
+	// delegationLastUsedPrecision limits how often the last usage time is
+	// persisted, because tokens are used on every delegated call.
+	delegationLastUsedPrecision = time.Minute
+)
+
+var (
+	errNoDelegationToken = status.Error(codes.Unauthenticated, "no delegation token provided")
+	errTokenRevoked      = status.Error(codes.Unauthenticated, "delegation token has been revoked")
+	errTokenExpired      = status.Error(codes.Unauthenticated, "delegation token has expired")
+)
+
+type delegationStorage interface {
+	Save(value interface{}) error
+	Load(value interface{}) (bool, error)
+}
+
+type delegationRecord struct {
+	// Token is the encoded delegation token. Empty for tombstones of tokens
+	// revoked before they were ever used.
+	Token string
+	// ExpiresAt is the expiration time of the token. Zero for tombstones
+	// of tokens that have never been presented.
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	Revoked    bool
+	// savedLastUsedAt is the last usage time that has been persisted.
+	savedLastUsedAt time.Time
+}
+
+func (m *delegationRecord) isExpired(now time.Time) bool {
+	expiresAt := m.ExpiresAt
+	// Records saved before the expiration time was tracked.
+	if expiresAt.IsZero() && len(m.Token) != 0 {
+		token, err := sonm.DecodeDelegationToken(m.Token)
+		if err != nil {
+			return true
+		}
+
+		expiresAt = time.Unix(token.GetExpiresAt().GetSeconds(), 0)
+	}
+
+	return !expiresAt.IsZero() && !now.Before(expiresAt)
+}
+
+// delegationRegistry keeps delegation tokens that were used to access deals,
+// allowing consumers to list and revoke them.
+//
+// Tokens are keyed by both deal and token IDs, because token IDs are chosen
+// by issuers and are unique only within a deal.
+type delegationRegistry struct {
+	mu      sync.Mutex
+	storage delegationStorage
+	records map[string]*delegationRecord
+}
+
+func newDelegationRegistry(storage delegationStorage) (*delegationRegistry, error) {
+	records := map[string]*delegationRecord{}
+	if _, err := storage.Load(&records); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		record.savedLastUsedAt = record.LastUsedAt
+	}
+
+	return &delegationRegistry{
+		storage: storage,
+		records: records,
+	}, nil
+}
+
+func delegationRecordKey(dealID string, id string) string {
+	return dealID + "/" + id
+}
+
+// Use marks the given token as used, returning an error if it has been
+// revoked.
+func (m *delegationRegistry) Use(token *sonm.DelegationToken) error {
+	encoded, err := token.Encode()
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	key := delegationRecordKey(token.GetDealID().Unwrap().String(), token.GetId())
+	record, ok := m.records[key]
+	if ok {
+		if record.Revoked {
+			// The tombstone's expiration becomes known, allowing to prune it
+			// eventually.
+			if record.ExpiresAt.IsZero() {
+				record.ExpiresAt = time.Unix(token.GetExpiresAt().GetSeconds(), 0)
+				m.save()
+			}
+
+			return errTokenRevoked
+		}
+
+		record.LastUsedAt = now
+		if now.Sub(record.savedLastUsedAt) < delegationLastUsedPrecision {
+			return nil
+		}
+
+		return m.save()
+	}
+
+	m.records[key] = &delegationRecord{
+		Token:      encoded,
+		ExpiresAt:  time.Unix(token.GetExpiresAt().GetSeconds(), 0),
+		LastUsedAt: now,
+	}
+
+	return m.save()
+}
+
+// Tokens returns tokens seen for the specified deal sorted by their
+// expiration time.
+func (m *delegationRegistry) Tokens(dealID string) ([]*sonm.DelegationTokenStatus, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var tokens []*sonm.DelegationTokenStatus
+	for _, record := range m.records {
+		if len(record.Token) == 0 {
+			continue
+		}
+
+		token, err := sonm.DecodeDelegationToken(record.Token)
+		if err != nil {
+			return nil, err
+		}
+
+		if token.GetDealID().Unwrap().String() != dealID {
+			continue
+		}
+
+		tokens = append(tokens, &sonm.DelegationTokenStatus{
+			Token:      token,
+			LastUsedAt: &sonm.Timestamp{Seconds: record.LastUsedAt.Unix()},
+			Revoked:    record.Revoked,
+		})
+	}
+
+	sort.Slice(tokens, func(i, j int) bool {
+		return tokens[i].GetToken().GetExpiresAt().GetSeconds() < tokens[j].GetToken().GetExpiresAt().GetSeconds()
+	})
+
+	return tokens, nil
+}
+
+// Revoke revokes the token with the given ID. Tokens that have never been
+// used are remembered as tombstones, which reject them once presented.
+func (m *delegationRegistry) Revoke(dealID string, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := delegationRecordKey(dealID, id)
+	record, ok := m.records[key]
+	if !ok {
+		record = &delegationRecord{}
+		m.records[key] = record
+	}
+
+	record.Revoked = true
+
+	return m.save()
+}
+
+// Forget drops records of tokens issued for the given deal, which is
+// finished, including tombstones of tokens that have never been presented.
+func (m *delegationRegistry) Forget(dealID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	forgotten := false
+	for key := range m.records {
+		if strings.HasPrefix(key, dealID+"/") {
+			delete(m.records, key)
+			forgotten = true
+		}
+	}
+
+	if !forgotten {
+		return nil
+	}
+
+	return m.save()
+}
+
+// save persists records, dropping expired ones including tombstones,
+// because they are rejected anyway. Tombstones of tokens that have never
+// been presented are kept until their deals are finished, because their
+// expiration is unknown.
+func (m *delegationRegistry) save() error {
+	now := time.Now()
+	for key, record := range m.records {
+		if record.isExpired(now) {
+			delete(m.records, key)
+		}
+	}
+
+	if err := m.storage.Save(m.records); err != nil {
+		return err
+	}
+
+	for _, record := range m.records {
+		record.savedLastUsedAt = record.LastUsedAt
+	}
+
+	return nil
+}
+
+// delegationAuthorization authorizes calls of the specified method made by
+// delegates using delegation tokens issued by the deal's consumer.
+type delegationAuthorization struct {
+	method    string
+	extractor DealExtractor
+	supplier  DealInfoSupplier
+	registry  *delegationRegistry
+}
+
+func newDelegationAuthorization(method string, supplier DealInfoSupplier, registry *delegationRegistry, extractor DealExtractor) auth.Authorization {
+	return &delegationAuthorization{
+		method:    method,
+		extractor: extractor,
+		supplier:  supplier,
+		registry:  registry,
+	}
+}
+
+func (m *delegationAuthorization) Authorize(ctx context.Context, request interface{}) error {
+	encoded := auth.DelegationTokenFromContext(ctx)
+	if len(encoded) == 0 {
+		return errNoDelegationToken
+	}
+
+	token, err := sonm.DecodeDelegationToken(encoded)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err := token.Validate(); err != nil {
+		return status.Errorf(codes.Unauthenticated, "invalid delegation token: %v", err)
+	}
+
+	if token.IsExpired(time.Now()) {
+		return errTokenExpired
+	}
+
+	if !token.Allows(m.method) {
+		return status.Errorf(codes.Unauthenticated, "method %s is not delegated", m.method)
+	}
+
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *wallet != token.GetDelegate().Unwrap() {
+		return status.Errorf(codes.Unauthenticated, "delegate wallet mismatch: %s", wallet.Hex())
+	}
+
+	dealID, err := m.extractor(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	if dealID.String() != token.GetDealID().Unwrap().String() {
+		return status.Errorf(codes.Unauthenticated, "delegation token is issued for another deal")
+	}
+
+	meta, err := m.supplier.GetDealInfo(ctx, &sonm.ID{Id: dealID.String()})
+	if err != nil {
+		return err
+	}
+
+	if meta.GetDeal().GetConsumerID().Unwrap() != token.GetIssuer().Unwrap() {
+		return status.Errorf(codes.Unauthenticated, "delegation token is not issued by the deal's consumer")
+	}
+
+	return m.registry.Use(token)
+}
@@ -0,0 +1,267 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/util/netutil"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+// ingress routes HTTPS requests to tasks by hostname, terminating TLS with
+// certificates obtained from the configured ACME server on demand.
+//
+// The lowest exposed TCP port of a task is reachable as
+// "<task-id>.<domain>", while other ones are reachable as
+// "<task-id>-<port>.<domain>". Plain HTTP requests are redirected to HTTPS.
+type ingress struct {
+	cfg     *IngressConfig
+	manager *autocert.Manager
+	// Port is the HTTPS port published for exposed tasks.
+	port uint16
+
+	mu     sync.RWMutex
+	routes map[string]http.Handler
+	// Hosts map task IDs to their hostnames.
+	hosts map[string][]string
+}
+
+func newIngress(cfg *IngressConfig) (*ingress, error) {
+	if len(cfg.Domain) == 0 {
+		return nil, errors.New("ingress domain is required")
+	}
+
+	port, err := netutil.ExtractPort(cfg.HTTPSEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ingress HTTPS endpoint: %v", err)
+	}
+	if port == 0 {
+		return nil, errors.New("ingress HTTPS endpoint must have an explicit port")
+	}
+
+	client := &acme.Client{
+		DirectoryURL: cfg.ACME.DirectoryURL,
+	}
+
+	if len(cfg.ACME.CA) != 0 {
+		data, err := ioutil.ReadFile(cfg.ACME.CA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ACME server CA: %v", err)
+		}
+
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", cfg.ACME.CA)
+		}
+
+		client.HTTPClient = &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{RootCAs: roots},
+			},
+		}
+	}
+
+	m := &ingress{
+		cfg:    cfg,
+		port:   uint16(port),
+		routes: map[string]http.Handler{},
+		hosts:  map[string][]string{},
+	}
+
+	m.manager = &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		Cache:      autocert.DirCache(cfg.ACME.CacheDir),
+		HostPolicy: m.hostPolicy,
+		Client:     client,
+		Email:      cfg.ACME.Email,
+	}
+
+	return m, nil
+}
+
+// Serve accepts HTTP and HTTPS connections until the context is canceled.
+func (m *ingress) Serve(ctx context.Context) error {
+	httpListener, err := net.Listen("tcp", m.cfg.HTTPEndpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen for ingress HTTP: %v", err)
+	}
+
+	httpsListener, err := net.Listen("tcp", m.cfg.HTTPSEndpoint)
+	if err != nil {
+		httpListener.Close()
+		return fmt.Errorf("failed to listen for ingress HTTPS: %v", err)
+	}
+
+	// HTTP server answers ACME challenges and redirects everything else.
+	httpServer := &http.Server{Handler: m.manager.HTTPHandler(http.HandlerFunc(m.redirectHTTPS))}
+	httpsServer := &http.Server{Handler: m, TLSConfig: m.manager.TLSConfig()}
+
+	go func() {
+		<-ctx.Done()
+		httpServer.Close()
+		httpsServer.Close()
+	}()
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- httpServer.Serve(httpListener)
+	}()
+	go func() {
+		errs <- httpsServer.ServeTLS(httpsListener, "", "")
+	}()
+
+	log.G(ctx).Info("serving ingress", zap.String("domain", m.cfg.Domain),
+		zap.Stringer("http", httpListener.Addr()), zap.Stringer("https", httpsListener.Addr()))
+
+	err = <-errs
+	httpServer.Close()
+	httpsServer.Close()
+
+	if ctx.Err() == nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+// redirectHTTPS redirects plain HTTP requests to the configured HTTPS port,
+// which is not necessarily the default one.
+func (m *ingress) redirectHTTPS(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(rw, "Use HTTPS", http.StatusBadRequest)
+		return
+	}
+
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if m.port != 443 {
+		host = net.JoinHostPort(host, strconv.Itoa(int(m.port)))
+	}
+
+	http.Redirect(rw, r, "https://"+host+r.URL.RequestURI(), http.StatusFound)
+}
+
+// Port returns the HTTPS port tasks are reachable on.
+func (m *ingress) Port() uint16 {
+	return m.port
+}
+
+func (m *ingress) hostPolicy(ctx context.Context, host string) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if _, ok := m.routes[host]; !ok {
+		return fmt.Errorf("host %s is not routed to any task", host)
+	}
+
+	return nil
+}
+
+// Expose routes hostnames of the given task to published ports of its
+// container, returning hostnames by container ports.
+func (m *ingress) Expose(ctx context.Context, taskID string, ports nat.PortMap) (map[nat.Port]string, error) {
+	var containerPorts []nat.Port
+	for containerPort, bindings := range ports {
+		if containerPort.Proto() == "tcp" && len(bindings) != 0 {
+			containerPorts = append(containerPorts, containerPort)
+		}
+	}
+
+	sort.Slice(containerPorts, func(i, j int) bool {
+		return containerPorts[i].Int() < containerPorts[j].Int()
+	})
+
+	routes := map[string]http.Handler{}
+	result := map[nat.Port]string{}
+	for id, containerPort := range containerPorts {
+		hostPort, err := nat.ParsePort(ports[containerPort][0].HostPort)
+		if err != nil {
+			return nil, err
+		}
+
+		host := taskID + "." + m.cfg.Domain
+		if id != 0 {
+			host = fmt.Sprintf("%s-%d.%s", taskID, containerPort.Int(), m.cfg.Domain)
+		}
+
+		routes[host] = newIngressProxy(fmt.Sprintf("127.0.0.1:%d", hostPort))
+		result[containerPort] = host
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for host, handler := range routes {
+		m.routes[host] = handler
+		m.hosts[taskID] = append(m.hosts[taskID], host)
+	}
+
+	log.G(ctx).Info("exposed task through ingress", zap.String("task_id", taskID), zap.Any("hosts", result))
+
+	return result, nil
+}
+
+// Unexpose removes routes of the given task.
+func (m *ingress) Unexpose(taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, host := range m.hosts[taskID] {
+		delete(m.routes, host)
+	}
+
+	delete(m.hosts, taskID)
+}
+
+func (m *ingress) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	host := strings.ToLower(r.Host)
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+
+	m.mu.RLock()
+	handler, ok := m.routes[host]
+	m.mu.RUnlock()
+
+	if !ok {
+		http.Error(rw, "no such task", http.StatusNotFound)
+		return
+	}
+
+	handler.ServeHTTP(rw, r)
+}
+
+func newIngressProxy(addr string) http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: addr})
+	director := proxy.Director
+	proxy.Director = func(r *http.Request) {
+		director(r)
+		if r.TLS != nil {
+			r.Header.Set("X-Forwarded-Proto", "https")
+		}
+	}
+
+	return proxy
+}
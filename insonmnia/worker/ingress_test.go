@@ -0,0 +1,188 @@
+package worker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBackend(t *testing.T, name string) (*httptest.Server, string) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(rw, "%s %s", name, r.URL.Path)
+	}))
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	return server, u.Port()
+}
+
+func ingressGet(m *ingress, host string) (int, string) {
+	rw := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "https://"+host+"/index.html", nil)
+	m.ServeHTTP(rw, r)
+
+	body, _ := ioutil.ReadAll(rw.Body)
+	return rw.Code, string(body)
+}
+
+func TestIngressRouting(t *testing.T) {
+	web, webPort := newTestBackend(t, "web")
+	defer web.Close()
+	api, apiPort := newTestBackend(t, "api")
+	defer api.Close()
+
+	m, err := newIngress(&IngressConfig{Domain: "worker.example.com", HTTPSEndpoint: ":8443"})
+	require.NoError(t, err)
+	assert.Equal(t, uint16(8443), m.Port())
+
+	hosts, err := m.Expose(context.Background(), "task", nat.PortMap{
+		"80/tcp":   {{HostIP: "0.0.0.0", HostPort: webPort}},
+		"8080/tcp": {{HostIP: "0.0.0.0", HostPort: apiPort}},
+		"53/udp":   {{HostIP: "0.0.0.0", HostPort: "10053"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[nat.Port]string{
+		"80/tcp":   "task.worker.example.com",
+		"8080/tcp": "task-8080.worker.example.com",
+	}, hosts)
+
+	code, body := ingressGet(m, "task.worker.example.com")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "web /index.html", body)
+
+	code, body = ingressGet(m, "TASK-8080.worker.example.com:443")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "api /index.html", body)
+
+	code, _ = ingressGet(m, "other.worker.example.com")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	assert.NoError(t, m.hostPolicy(context.Background(), "task.worker.example.com"))
+	assert.Error(t, m.hostPolicy(context.Background(), "other.worker.example.com"))
+
+	m.Unexpose("task")
+
+	code, _ = ingressGet(m, "task.worker.example.com")
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Error(t, m.hostPolicy(context.Background(), "task.worker.example.com"))
+}
+
+func TestIngressRedirectHTTPS(t *testing.T) {
+	redirect := func(m *ingress, method, target string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		m.redirectHTTPS(rw, httptest.NewRequest(method, target, nil))
+		return rw
+	}
+
+	m, err := newIngress(&IngressConfig{Domain: "worker.example.com", HTTPSEndpoint: ":8443"})
+	require.NoError(t, err)
+
+	rw := redirect(m, http.MethodGet, "http://task.worker.example.com:8080/index.html?q=1")
+	assert.Equal(t, http.StatusFound, rw.Code)
+	assert.Equal(t, "https://task.worker.example.com:8443/index.html?q=1", rw.Header().Get("Location"))
+
+	rw = redirect(m, http.MethodPost, "http://task.worker.example.com/")
+	assert.Equal(t, http.StatusBadRequest, rw.Code)
+
+	m, err = newIngress(&IngressConfig{Domain: "worker.example.com", HTTPSEndpoint: ":443"})
+	require.NoError(t, err)
+
+	rw = redirect(m, http.MethodGet, "http://task.worker.example.com/")
+	assert.Equal(t, "https://task.worker.example.com/", rw.Header().Get("Location"))
+}
+
+func TestIngressInvalidHTTPSEndpoint(t *testing.T) {
+	_, err := newIngress(&IngressConfig{Domain: "worker.example.com", HTTPSEndpoint: "localhost"})
+	assert.Error(t, err)
+
+	_, err = newIngress(&IngressConfig{Domain: "worker.example.com", HTTPSEndpoint: ":0"})
+	assert.Error(t, err)
+}
+
+// TestIngressACMEPebble obtains a certificate for an exposed task from a
+// Pebble ACME server through the HTTP-01 challenge.
+//
+// Pebble must be started separately, resolving task hostnames to this host,
+// for example using "pebble-challtestsrv -defaultIPv4 127.0.0.1" as its DNS
+// server. PEBBLE_DIRECTORY_URL specifies its directory, e.g.
+// "https://localhost:14000/dir", and PEBBLE_CA the certificate its API is
+// served with. PEBBLE_HTTP_PORT and PEBBLE_DOMAIN optionally override the
+// port HTTP-01 challenges are validated on and the ingress domain. The test is
+// skipped when Pebble is not available.
+func TestIngressACMEPebble(t *testing.T) {
+	directoryURL := os.Getenv("PEBBLE_DIRECTORY_URL")
+	if len(directoryURL) == 0 {
+		t.Skip("PEBBLE_DIRECTORY_URL is not specified")
+	}
+
+	httpPort := os.Getenv("PEBBLE_HTTP_PORT")
+	if len(httpPort) == 0 {
+		httpPort = "5002"
+	}
+
+	domain := os.Getenv("PEBBLE_DOMAIN")
+	if len(domain) == 0 {
+		domain = "worker.example.com"
+	}
+
+	cacheDir, err := ioutil.TempDir("", "sonm-acme")
+	require.NoError(t, err)
+	defer os.RemoveAll(cacheDir)
+
+	m, err := newIngress(&IngressConfig{
+		Domain:        domain,
+		HTTPSEndpoint: ":443",
+		ACME: ACMEConfig{
+			DirectoryURL: directoryURL,
+			CacheDir:     cacheDir,
+			CA:           os.Getenv("PEBBLE_CA"),
+		},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if _, err := m.manager.Client.Discover(ctx); err != nil {
+		t.Skipf("Pebble is not available: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", ":"+httpPort)
+	require.NoError(t, err)
+
+	// Registers HTTP-01 support with the manager as the ingress does.
+	server := &http.Server{Handler: m.manager.HTTPHandler(nil)}
+	go server.Serve(listener)
+	defer server.Close()
+
+	web, webPort := newTestBackend(t, "web")
+	defer web.Close()
+
+	hosts, err := m.Expose(ctx, "task", nat.PortMap{
+		"80/tcp": {{HostIP: "0.0.0.0", HostPort: webPort}},
+	})
+	require.NoError(t, err)
+	host := hosts["80/tcp"]
+
+	cert, err := m.manager.GetCertificate(&tls.ClientHelloInfo{ServerName: host})
+	require.NoError(t, err)
+	require.NotNil(t, cert.Leaf)
+	assert.Equal(t, []string{host}, cert.Leaf.DNSNames)
+
+	// Hosts not routed to tasks are rejected by the host policy.
+	_, err = m.manager.GetCertificate(&tls.ClientHelloInfo{ServerName: "other." + domain})
+	assert.Error(t, err)
+}
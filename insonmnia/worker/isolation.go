@@ -0,0 +1,221 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/sonm-io/core/proto"
+)
+
+var (
+	defaultSandboxRuntimes = []string{"runsc", "kata-runtime", "kata"}
+)
+
+// isolationProfile describes how a single task is isolated from the host.
+type isolationProfile struct {
+	Runtime     string
+	SecurityOpt []string
+	CapDrop     []string
+}
+
+func (m *isolationProfile) Tune(hostConfig *container.HostConfig) {
+	if m == nil {
+		return
+	}
+
+	hostConfig.Runtime = m.Runtime
+	hostConfig.SecurityOpt = append(hostConfig.SecurityOpt, m.SecurityOpt...)
+	hostConfig.CapDrop = append(hostConfig.CapDrop, m.CapDrop...)
+}
+
+type dockerInfoClient interface {
+	Info(ctx context.Context) (types.Info, error)
+}
+
+// isolation selects OCI runtimes and security options for tasks depending
+// on consumers and ask plans, taking into account what Docker daemon is
+// capable of.
+type isolation struct {
+	cfg             *IsolationConfig
+	runtimes        map[string]struct{}
+	sandboxRuntimes []string
+	userNamespaces  bool
+	securityOpt     []string
+}
+
+func newIsolation(ctx context.Context, cfg *IsolationConfig, client dockerInfoClient) (*isolation, error) {
+	info, err := client.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Docker info: %v", err)
+	}
+
+	m := &isolation{
+		cfg:             cfg,
+		runtimes:        map[string]struct{}{},
+		sandboxRuntimes: cfg.SandboxRuntimes,
+	}
+
+	if len(m.sandboxRuntimes) == 0 {
+		m.sandboxRuntimes = defaultSandboxRuntimes
+	}
+
+	for name := range info.Runtimes {
+		m.runtimes[name] = struct{}{}
+	}
+
+	securityOptions, err := types.DecodeSecurityOptions(info.SecurityOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Docker security options: %v", err)
+	}
+	for _, option := range securityOptions {
+		if option.Name == "userns" {
+			m.userNamespaces = true
+		}
+	}
+
+	if cfg.UserNamespaces && !m.userNamespaces {
+		return nil, fmt.Errorf("user namespaces are required, but Docker daemon has no user namespace remapping configured")
+	}
+
+	runtimes := []string{cfg.Runtime}
+	for _, runtime := range cfg.Runtimes {
+		runtimes = append(runtimes, runtime)
+	}
+	for _, runtime := range runtimes {
+		if len(runtime) != 0 && !m.hasRuntime(runtime) {
+			return nil, fmt.Errorf("OCI runtime %s is not configured in Docker daemon", runtime)
+		}
+	}
+
+	if len(cfg.SeccompProfile) != 0 {
+		data, err := ioutil.ReadFile(cfg.SeccompProfile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seccomp profile: %v", err)
+		}
+
+		profile := &bytes.Buffer{}
+		if err := json.Compact(profile, data); err != nil {
+			return nil, fmt.Errorf("failed to parse seccomp profile: %v", err)
+		}
+
+		m.securityOpt = append(m.securityOpt, "seccomp="+profile.String())
+	}
+
+	if len(cfg.AppArmorProfile) != 0 {
+		m.securityOpt = append(m.securityOpt, "apparmor="+cfg.AppArmorProfile)
+	}
+
+	if cfg.NoNewPrivileges {
+		m.securityOpt = append(m.securityOpt, "no-new-privileges")
+	}
+
+	return m, nil
+}
+
+func (m *isolation) hasRuntime(runtime string) bool {
+	_, ok := m.runtimes[runtime]
+	return ok
+}
+
+func (m *isolation) isSandbox(runtime string) bool {
+	for _, sandbox := range m.sandboxRuntimes {
+		if runtime == sandbox {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sandboxRuntime returns the first sandbox runtime Docker daemon has.
+func (m *isolation) sandboxRuntime() (string, bool) {
+	for _, runtime := range m.sandboxRuntimes {
+		if m.hasRuntime(runtime) {
+			return runtime, true
+		}
+	}
+
+	return "", false
+}
+
+// Level returns the strongest isolation level tasks can be run with.
+func (m *isolation) Level() sonm.IsolationLevel {
+	if _, ok := m.sandboxRuntime(); ok {
+		return sonm.IsolationLevel_SANDBOX
+	}
+	if m.userNamespaces {
+		return sonm.IsolationLevel_USER_NAMESPACE
+	}
+
+	return sonm.IsolationLevel_CONTAINER
+}
+
+// ValidateAskPlan checks that tasks of the given ask plan can be run with
+// the runtime it specifies.
+func (m *isolation) ValidateAskPlan(ask *sonm.AskPlan) error {
+	runtime := ask.GetRuntime()
+	if len(runtime) == 0 {
+		return nil
+	}
+
+	if !m.hasRuntime(runtime) {
+		return fmt.Errorf("OCI runtime %s is not configured in Docker daemon", runtime)
+	}
+
+	if ask.GetResources().GetNetwork().GetNetFlags().GetSandbox() && !m.isSandbox(runtime) {
+		return fmt.Errorf("sandbox is required, but OCI runtime %s does not provide it", runtime)
+	}
+
+	return nil
+}
+
+// Profile returns the isolation profile for tasks of the given ask plan's
+// deal with a consumer having the given identity level.
+func (m *isolation) Profile(ask *sonm.AskPlan, level sonm.IdentityLevel) (*isolationProfile, error) {
+	runtime := m.runtime(ask, level)
+
+	if ask.GetResources().GetNetwork().GetNetFlags().GetSandbox() && !m.isSandbox(runtime) {
+		sandbox, ok := m.sandboxRuntime()
+		if !ok {
+			return nil, fmt.Errorf("sandbox is required, but no sandbox OCI runtime is configured in Docker daemon")
+		}
+
+		runtime = sandbox
+	}
+
+	return &isolationProfile{
+		Runtime:     runtime,
+		SecurityOpt: m.securityOpt,
+		CapDrop:     m.cfg.CapDrop,
+	}, nil
+}
+
+// runtime selects the runtime for the given ask plan and identity level.
+//
+// The runtime configured for the identity level is the minimum: the ask
+// plan may override it unless it would drop the sandbox the identity level
+// requires.
+func (m *isolation) runtime(ask *sonm.AskPlan, level sonm.IdentityLevel) string {
+	runtime := m.cfg.Runtime
+	closest := sonm.IdentityLevel(-1)
+	for configured, configuredRuntime := range m.cfg.Runtimes {
+		if configured <= level && configured > closest {
+			closest, runtime = configured, configuredRuntime
+		}
+	}
+
+	if len(ask.GetRuntime()) == 0 {
+		return runtime
+	}
+
+	if m.isSandbox(runtime) && !m.isSandbox(ask.GetRuntime()) {
+		return runtime
+	}
+
+	return ask.GetRuntime()
+}
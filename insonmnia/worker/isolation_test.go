@@ -0,0 +1,136 @@
+package worker
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDockerInfo types.Info
+
+func (m testDockerInfo) Info(ctx context.Context) (types.Info, error) {
+	return types.Info(m), nil
+}
+
+func newTestDockerInfo(userns bool, runtimes ...string) testDockerInfo {
+	info := types.Info{Runtimes: map[string]types.Runtime{}, SecurityOptions: []string{"name=seccomp,profile=default"}}
+	for _, runtime := range runtimes {
+		info.Runtimes[runtime] = types.Runtime{Path: runtime}
+	}
+	if userns {
+		info.SecurityOptions = append(info.SecurityOptions, "name=userns")
+	}
+
+	return testDockerInfo(info)
+}
+
+func sandboxAskPlan(runtime string) *sonm.AskPlan {
+	return &sonm.AskPlan{
+		Runtime: runtime,
+		Resources: &sonm.AskPlanResources{
+			Network: &sonm.AskPlanNetwork{NetFlags: (&sonm.NetFlags{}).SetSandbox(true)},
+		},
+	}
+}
+
+func TestIsolationRuntimeByIdentity(t *testing.T) {
+	cfg := &IsolationConfig{
+		Runtimes: map[sonm.IdentityLevel]string{
+			sonm.IdentityLevel_ANONYMOUS:    "runsc",
+			sonm.IdentityLevel_PROFESSIONAL: "runc",
+		},
+		CapDrop: []string{"NET_RAW"},
+	}
+
+	isolation, err := newIsolation(context.Background(), cfg, newTestDockerInfo(false, "runc", "runsc"))
+	require.NoError(t, err)
+	assert.Equal(t, sonm.IsolationLevel_SANDBOX, isolation.Level())
+
+	tests := []struct {
+		level   sonm.IdentityLevel
+		runtime string
+	}{
+		{sonm.IdentityLevel_UNKNOWN, ""},
+		{sonm.IdentityLevel_ANONYMOUS, "runsc"},
+		{sonm.IdentityLevel_IDENTIFIED, "runsc"},
+		{sonm.IdentityLevel_PROFESSIONAL, "runc"},
+	}
+
+	for _, test := range tests {
+		profile, err := isolation.Profile(&sonm.AskPlan{}, test.level)
+		require.NoError(t, err)
+		assert.Equal(t, test.runtime, profile.Runtime, test.level.String())
+		assert.Equal(t, []string{"NET_RAW"}, profile.CapDrop)
+	}
+
+	// Ask plans can't weaken the isolation required for the identity level.
+	profile, err := isolation.Profile(&sonm.AskPlan{Runtime: "runc"}, sonm.IdentityLevel_ANONYMOUS)
+	require.NoError(t, err)
+	assert.Equal(t, "runsc", profile.Runtime)
+
+	// But can strengthen it.
+	profile, err = isolation.Profile(&sonm.AskPlan{Runtime: "runsc"}, sonm.IdentityLevel_PROFESSIONAL)
+	require.NoError(t, err)
+	assert.Equal(t, "runsc", profile.Runtime)
+
+	profile, err = isolation.Profile(&sonm.AskPlan{Runtime: "runc"}, sonm.IdentityLevel_PROFESSIONAL)
+	require.NoError(t, err)
+	assert.Equal(t, "runc", profile.Runtime)
+
+	profile, err = isolation.Profile(sandboxAskPlan(""), sonm.IdentityLevel_PROFESSIONAL)
+	require.NoError(t, err)
+	assert.Equal(t, "runsc", profile.Runtime)
+}
+
+func TestIsolationValidateAskPlan(t *testing.T) {
+	isolation, err := newIsolation(context.Background(), &IsolationConfig{}, newTestDockerInfo(true, "runc", "kata-runtime"))
+	require.NoError(t, err)
+
+	assert.NoError(t, isolation.ValidateAskPlan(&sonm.AskPlan{}))
+	assert.NoError(t, isolation.ValidateAskPlan(sandboxAskPlan("kata-runtime")))
+	assert.Error(t, isolation.ValidateAskPlan(&sonm.AskPlan{Runtime: "runsc"}))
+	assert.Error(t, isolation.ValidateAskPlan(sandboxAskPlan("runc")))
+}
+
+func TestIsolationWithoutSandbox(t *testing.T) {
+	isolation, err := newIsolation(context.Background(), &IsolationConfig{UserNamespaces: true}, newTestDockerInfo(true, "runc"))
+	require.NoError(t, err)
+	assert.Equal(t, sonm.IsolationLevel_USER_NAMESPACE, isolation.Level())
+
+	_, err = isolation.Profile(sandboxAskPlan(""), sonm.IdentityLevel_ANONYMOUS)
+	assert.Error(t, err)
+
+	_, err = newIsolation(context.Background(), &IsolationConfig{UserNamespaces: true}, newTestDockerInfo(false, "runc"))
+	assert.Error(t, err)
+
+	_, err = newIsolation(context.Background(), &IsolationConfig{Runtime: "runsc"}, newTestDockerInfo(false, "runc"))
+	assert.Error(t, err)
+}
+
+func TestIsolationSecurityOptions(t *testing.T) {
+	file, err := ioutil.TempFile("", "seccomp")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+
+	file.WriteString("{\n  \"defaultAction\": \"SCMP_ACT_ERRNO\"\n}\n")
+	file.Close()
+
+	cfg := &IsolationConfig{
+		SeccompProfile:  file.Name(),
+		AppArmorProfile: "sonm-default",
+		NoNewPrivileges: true,
+	}
+
+	isolation, err := newIsolation(context.Background(), cfg, newTestDockerInfo(false, "runc"))
+	require.NoError(t, err)
+
+	profile, err := isolation.Profile(&sonm.AskPlan{}, sonm.IdentityLevel_ANONYMOUS)
+	require.NoError(t, err)
+	assert.Equal(t, []string{`seccomp={"defaultAction":"SCMP_ACT_ERRNO"}`, "apparmor=sonm-default", "no-new-privileges"}, profile.SecurityOpt)
+}
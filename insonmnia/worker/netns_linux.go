@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+
+	"golang.org/x/sys/unix"
+)
+
+type dialResult struct {
+	conn net.Conn
+	err  error
+}
+
+// dialNetns connects to the given address from the network namespace of
+// the process with the given PID.
+func dialNetns(ctx context.Context, pid int, network, addr string) (net.Conn, error) {
+	result := make(chan dialResult, 1)
+
+	go func() {
+		// The thread is never unlocked, so the runtime destroys it when the
+		// goroutine exits instead of reusing it in the foreign namespace.
+		runtime.LockOSThread()
+
+		conn, err := dialNetnsLocked(ctx, pid, network, addr)
+		result <- dialResult{conn: conn, err: err}
+	}()
+
+	r := <-result
+	return r.conn, r.err
+}
+
+func dialNetnsLocked(ctx context.Context, pid int, network, addr string) (net.Conn, error) {
+	netns, err := os.Open(fmt.Sprintf("/proc/%d/ns/net", pid))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open network namespace: %v", err)
+	}
+	defer netns.Close()
+
+	if err := unix.Setns(int(netns.Fd()), unix.CLONE_NEWNET); err != nil {
+		return nil, fmt.Errorf("failed to enter network namespace: %v", err)
+	}
+
+	dialer := net.Dialer{}
+	return dialer.DialContext(ctx, network, addr)
+}
@@ -0,0 +1,13 @@
+// +build !linux
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"net"
+)
+
+func dialNetns(ctx context.Context, pid int, network, addr string) (net.Conn, error) {
+	return nil, errors.New("dialing into container network namespaces is supported only on Linux")
+}
@@ -0,0 +1,352 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/client"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	egressNetworkPrefix = "sonm-egress-"
+	egressChainPrefix   = "SONM-EGRESS-"
+	// Linux limits interface names to 15 characters.
+	egressBridgePrefix = "sonm-eg"
+	egressBridgeOption = "com.docker.network.bridge.name"
+	// dockerUserChain is the chain Docker reserves for user rules, which
+	// is jumped to before any of its own forwarding rules.
+	dockerUserChain = "DOCKER-USER"
+)
+
+var (
+	// privateNetworks are networks denied by "DenyPrivate" policy.
+	privateNetworks = []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"198.18.0.0/15",
+	}
+)
+
+// Iptables executes iptables commands.
+type Iptables interface {
+	Run(args ...string) (string, error)
+}
+
+type iptablesCmd struct{}
+
+func (iptablesCmd) Run(args ...string) (string, error) {
+	output, err := exec.Command("iptables", append([]string{"-w"}, args...)...).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("`iptables %s` failed: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+
+	return string(output), nil
+}
+
+// EgressTuner restricts outgoing connections of containers according to
+// egress policies.
+//
+// Each container is attached to its own bridge network, which traffic is
+// passed through the dedicated iptables chain both when forwarded and when
+// destined to the host itself. Forwarded traffic is hooked via the
+// DOCKER-USER chain, because Docker reorders the FORWARD chain on restarts.
+// Packets dropped by the chain are counted as policy violations.
+type EgressTuner struct {
+	client   *client.Client
+	iptables Iptables
+
+	mu     sync.Mutex
+	chains map[string]string
+}
+
+func NewEgressTuner() (*EgressTuner, error) {
+	cli, err := client.NewEnvClient()
+	if err != nil {
+		return nil, err
+	}
+
+	args := filters.NewArgs()
+	args.Add("name", egressNetworkPrefix)
+	networks, err := cli.NetworkList(context.Background(), types.NetworkListOptions{Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list egress networks: %v", err)
+	}
+
+	var ids []string
+	for _, network := range networks {
+		if strings.HasPrefix(network.Name, egressNetworkPrefix) {
+			ids = append(ids, strings.TrimPrefix(network.Name, egressNetworkPrefix))
+		}
+	}
+
+	tuner := newEgressTuner(cli, iptablesCmd{})
+	if err := tuner.reconcile(ids); err != nil {
+		return nil, err
+	}
+
+	return tuner, nil
+}
+
+func newEgressTuner(client *client.Client, iptables Iptables) *EgressTuner {
+	return &EgressTuner{
+		client:   client,
+		iptables: iptables,
+		chains:   map[string]string{},
+	}
+}
+
+// Tune creates the network with the given policy applied for the container
+// with the given ID.
+func (m *EgressTuner) Tune(ctx context.Context, id string, policy *sonm.EgressPolicy, hostConfig *container.HostConfig) (Cleanup, error) {
+	bridge, chain := egressNames(id)
+	name := egressNetworkPrefix + id
+
+	response, err := m.client.NetworkCreate(ctx, name, types.NetworkCreate{
+		CheckDuplicate: true,
+		Driver:         "bridge",
+		Options:        map[string]string{egressBridgeOption: bridge},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create egress network: %v", err)
+	}
+
+	cleanup := &egressCleanup{
+		ctx:       ctx,
+		tuner:     m,
+		id:        id,
+		networkID: response.ID,
+		bridge:    bridge,
+		chain:     chain,
+	}
+
+	if err := m.setupChain(chain, bridge, policy); err != nil {
+		cleanup.Close()
+		return nil, err
+	}
+
+	m.mu.Lock()
+	m.chains[id] = chain
+	m.mu.Unlock()
+
+	log.G(ctx).Info("applied egress policy", zap.String("network", name), zap.String("chain", chain), zap.Any("policy", policy))
+	hostConfig.NetworkMode = container.NetworkMode(name)
+
+	return cleanup, nil
+}
+
+// egressNames returns names of the bridge and the iptables chain of the
+// container with the given ID.
+func egressNames(id string) (string, string) {
+	hash := sha256.Sum256([]byte(id))
+	suffix := hex.EncodeToString(hash[:])
+
+	bridge := egressBridgePrefix + suffix[:15-len(egressBridgePrefix)]
+	chain := egressChainPrefix + strings.ToUpper(suffix[:12])
+
+	return bridge, chain
+}
+
+// reconcile takes over egress chains left by the previous run. Chains of
+// containers with the given IDs, whose networks are still alive, are reused
+// and hooked via DOCKER-USER. Other chains are removed.
+func (m *EgressTuner) reconcile(ids []string) error {
+	alive := map[string]string{}
+	for _, id := range ids {
+		_, chain := egressNames(id)
+		alive[chain] = id
+	}
+
+	output, err := m.iptables.Run("-S")
+	if err != nil {
+		return fmt.Errorf("failed to list egress chains: %v", err)
+	}
+
+	var chains []string
+	jumps := map[string][][]string{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch {
+		case len(fields) == 2 && fields[0] == "-N" && strings.HasPrefix(fields[1], egressChainPrefix):
+			chains = append(chains, fields[1])
+		case len(fields) > 3 && fields[0] == "-A" && fields[len(fields)-2] == "-j" &&
+			strings.HasPrefix(fields[len(fields)-1], egressChainPrefix):
+			chain := fields[len(fields)-1]
+			jumps[chain] = append(jumps[chain], fields[1:])
+		}
+	}
+
+	for _, chain := range chains {
+		id, ok := alive[chain]
+		if !ok {
+			m.removeChain(chain, jumps[chain])
+			continue
+		}
+
+		bridge, _ := egressNames(id)
+		hooked := map[string]bool{}
+		for _, jump := range jumps[chain] {
+			if jump[0] == "FORWARD" {
+				m.iptables.Run(append([]string{"-D"}, jump...)...)
+				continue
+			}
+			hooked[jump[0]] = true
+		}
+
+		if err := m.hookChain(chain, bridge, hooked); err != nil {
+			return err
+		}
+
+		m.mu.Lock()
+		m.chains[id] = chain
+		m.mu.Unlock()
+	}
+
+	return nil
+}
+
+// removeChain removes the given chain together with the given rules
+// jumping to it. Errors are ignored, because the chain may be partially set
+// up.
+func (m *EgressTuner) removeChain(chain string, jumps [][]string) {
+	for _, jump := range jumps {
+		m.iptables.Run(append([]string{"-D"}, jump...)...)
+	}
+	m.iptables.Run("-F", chain)
+	m.iptables.Run("-X", chain)
+}
+
+// hookChain directs traffic from the given bridge to the given chain,
+// skipping parent chains that are already hooked.
+func (m *EgressTuner) hookChain(chain, bridge string, hooked map[string]bool) error {
+	for _, parent := range []string{dockerUserChain, "INPUT"} {
+		if hooked[parent] {
+			continue
+		}
+
+		if _, err := m.iptables.Run("-I", parent, "-i", bridge, "-j", chain); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *EgressTuner) setupChain(chain, bridge string, policy *sonm.EgressPolicy) error {
+	rules := [][]string{
+		{"-m", "conntrack", "--ctstate", "RELATED,ESTABLISHED", "-j", "RETURN"},
+	}
+
+	if rate := policy.GetMaxConnectionRate(); rate != 0 {
+		limit := strconv.FormatUint(uint64(rate), 10)
+		rules = append(rules, []string{"-m", "conntrack", "--ctstate", "NEW", "-m", "hashlimit",
+			"--hashlimit-name", bridge, "--hashlimit-above", limit + "/sec", "--hashlimit-burst", limit, "-j", "DROP"})
+	}
+
+	for _, port := range policy.GetDenyPorts() {
+		for _, protocol := range []string{"tcp", "udp"} {
+			rules = append(rules, []string{"-p", protocol, "--dport", strconv.FormatUint(uint64(port), 10), "-j", "DROP"})
+		}
+	}
+
+	for _, cidr := range policy.GetAllowCIDRs() {
+		rules = append(rules, []string{"-d", cidr, "-j", "RETURN"})
+	}
+
+	switch {
+	case len(policy.GetAllowCIDRs()) != 0:
+		rules = append(rules, []string{"-j", "DROP"})
+	case policy.GetDenyPrivate():
+		for _, cidr := range privateNetworks {
+			rules = append(rules, []string{"-d", cidr, "-j", "DROP"})
+		}
+	}
+
+	if _, err := m.iptables.Run("-N", chain); err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if _, err := m.iptables.Run(append([]string{"-A", chain}, rule...)...); err != nil {
+			return err
+		}
+	}
+
+	return m.hookChain(chain, bridge, nil)
+}
+
+// Violations returns the number of packets dropped by the policy of the
+// container with the given ID, which is zero for containers without one.
+func (m *EgressTuner) Violations(id string) (uint64, error) {
+	m.mu.Lock()
+	chain, ok := m.chains[id]
+	m.mu.Unlock()
+
+	if !ok {
+		return 0, nil
+	}
+
+	output, err := m.iptables.Run("-L", chain, "-v", "-x", "-n")
+	if err != nil {
+		return 0, err
+	}
+
+	violations := uint64(0)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[2] != "DROP" {
+			continue
+		}
+
+		packets, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		violations += packets
+	}
+
+	return violations, nil
+}
+
+type egressCleanup struct {
+	ctx       context.Context
+	tuner     *EgressTuner
+	id        string
+	networkID string
+	bridge    string
+	chain     string
+}
+
+func (m *egressCleanup) Close() error {
+	m.tuner.mu.Lock()
+	delete(m.tuner.chains, m.id)
+	m.tuner.mu.Unlock()
+
+	var jumps [][]string
+	for _, parent := range []string{dockerUserChain, "INPUT"} {
+		jumps = append(jumps, []string{parent, "-i", m.bridge, "-j", m.chain})
+	}
+	m.tuner.removeChain(m.chain, jumps)
+
+	return removeNetwork(m.ctx, m.tuner.client, m.networkID)
+}
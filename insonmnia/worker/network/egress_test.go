@@ -0,0 +1,123 @@
+package network
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testIptables struct {
+	commands []string
+	output   string
+}
+
+func (m *testIptables) Run(args ...string) (string, error) {
+	m.commands = append(m.commands, strings.Join(args, " "))
+	return m.output, nil
+}
+
+func TestEgressChain(t *testing.T) {
+	iptables := &testIptables{}
+	tuner := newEgressTuner(nil, iptables)
+
+	policy := &sonm.EgressPolicy{
+		DenyPrivate:       true,
+		DenyPorts:         []uint32{25},
+		MaxConnectionRate: 10,
+	}
+	require.NoError(t, tuner.setupChain("SONM-EGRESS-0", "sonm-eg0", policy))
+
+	expected := []string{
+		"-N SONM-EGRESS-0",
+		"-A SONM-EGRESS-0 -m conntrack --ctstate RELATED,ESTABLISHED -j RETURN",
+		"-A SONM-EGRESS-0 -m conntrack --ctstate NEW -m hashlimit --hashlimit-name sonm-eg0 --hashlimit-above 10/sec --hashlimit-burst 10 -j DROP",
+		"-A SONM-EGRESS-0 -p tcp --dport 25 -j DROP",
+		"-A SONM-EGRESS-0 -p udp --dport 25 -j DROP",
+	}
+	for _, cidr := range privateNetworks {
+		expected = append(expected, "-A SONM-EGRESS-0 -d "+cidr+" -j DROP")
+	}
+	expected = append(expected, "-I DOCKER-USER -i sonm-eg0 -j SONM-EGRESS-0", "-I INPUT -i sonm-eg0 -j SONM-EGRESS-0")
+
+	assert.Equal(t, expected, iptables.commands)
+}
+
+func TestEgressChainAllowList(t *testing.T) {
+	iptables := &testIptables{}
+	tuner := newEgressTuner(nil, iptables)
+
+	policy := &sonm.EgressPolicy{
+		DenyPrivate: true,
+		AllowCIDRs:  []string{"8.8.8.8/32"},
+	}
+	require.NoError(t, tuner.setupChain("SONM-EGRESS-0", "sonm-eg0", policy))
+
+	assert.Equal(t, []string{
+		"-N SONM-EGRESS-0",
+		"-A SONM-EGRESS-0 -m conntrack --ctstate RELATED,ESTABLISHED -j RETURN",
+		"-A SONM-EGRESS-0 -d 8.8.8.8/32 -j RETURN",
+		"-A SONM-EGRESS-0 -j DROP",
+		"-I DOCKER-USER -i sonm-eg0 -j SONM-EGRESS-0",
+		"-I INPUT -i sonm-eg0 -j SONM-EGRESS-0",
+	}, iptables.commands)
+}
+
+func TestEgressViolations(t *testing.T) {
+	iptables := &testIptables{output: `Chain SONM-EGRESS-0 (2 references)
+    pkts      bytes target     prot opt in     out     source               destination
+     120    10080 RETURN     all  --  *      *       0.0.0.0/0            0.0.0.0/0            ctstate RELATED,ESTABLISHED
+       3      180 DROP       tcp  --  *      *       0.0.0.0/0            0.0.0.0/0            tcp dpt:25
+       0        0 DROP       udp  --  *      *       0.0.0.0/0            0.0.0.0/0            udp dpt:25
+       4      240 DROP       all  --  *      *       0.0.0.0/0            10.0.0.0/8
+`}
+	tuner := newEgressTuner(nil, iptables)
+
+	violations, err := tuner.Violations("task")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), violations)
+	assert.Empty(t, iptables.commands)
+
+	tuner.chains["task"] = "SONM-EGRESS-0"
+	violations, err = tuner.Violations("task")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(7), violations)
+}
+
+func TestEgressReconcile(t *testing.T) {
+	bridge, chain := egressNames("alive")
+	migratedBridge, migrated := egressNames("migrated")
+
+	iptables := &testIptables{output: `-P INPUT ACCEPT
+-P FORWARD DROP
+-N DOCKER-USER
+-N ` + chain + `
+-N ` + migrated + `
+-N SONM-EGRESS-DEAD
+-A INPUT -i sonm-egdead -j SONM-EGRESS-DEAD
+-A INPUT -i ` + bridge + ` -j ` + chain + `
+-A FORWARD -j DOCKER-USER
+-A FORWARD -i ` + migratedBridge + ` -j ` + migrated + `
+-A DOCKER-USER -i sonm-egdead -j SONM-EGRESS-DEAD
+-A DOCKER-USER -i ` + bridge + ` -j ` + chain + `
+-A DOCKER-USER -j RETURN
+-A SONM-EGRESS-DEAD -j DROP
+`}
+	tuner := newEgressTuner(nil, iptables)
+
+	require.NoError(t, tuner.reconcile([]string{"alive", "migrated", "gone"}))
+
+	assert.Equal(t, []string{
+		"-S",
+		"-D FORWARD -i " + migratedBridge + " -j " + migrated,
+		"-I DOCKER-USER -i " + migratedBridge + " -j " + migrated,
+		"-I INPUT -i " + migratedBridge + " -j " + migrated,
+		"-D INPUT -i sonm-egdead -j SONM-EGRESS-DEAD",
+		"-D DOCKER-USER -i sonm-egdead -j SONM-EGRESS-DEAD",
+		"-F SONM-EGRESS-DEAD",
+		"-X SONM-EGRESS-DEAD",
+	}, iptables.commands)
+	assert.Equal(t, map[string]string{"alive": chain, "migrated": migrated}, tuner.chains)
+}
@@ -152,14 +152,20 @@ func (t *TincTuner) GenerateInvitation(ID string) (*structs.NetworkSpec, error)
 	return t.netDriver.GenerateInvitation(ID)
 }
 
-func (t *TincCleaner) Close() (err error) {
+func (t *TincCleaner) Close() error {
+	return removeNetwork(t.ctx, t.client, t.networkID)
+}
+
+// removeNetwork removes the network, retrying while containers are still
+// being detached from it.
+func removeNetwork(ctx context.Context, client *client.Client, networkID string) (err error) {
 	timeout := time.Millisecond * 100
 	for i := 0; i < 10; i++ {
-		err = t.client.NetworkRemove(t.ctx, t.networkID)
+		err = client.NetworkRemove(ctx, networkID)
 		if err == nil {
 			return
 		}
-		log.S(t.ctx).Warnf("failed to remove network, retrying after %s", timeout)
+		log.S(ctx).Warnf("failed to remove network, retrying after %s", timeout)
 		timeout = timeout * 2
 		if timeout > time.Second*2 {
 			timeout = time.Second * 2
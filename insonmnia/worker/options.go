@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/noxiouz/zapctx/ctxlog"
 	"github.com/sonm-io/core/blockchain"
 	"github.com/sonm-io/core/insonmnia/benchmarks"
+	"github.com/sonm-io/core/insonmnia/gateway"
 	"github.com/sonm-io/core/insonmnia/matcher"
 	"github.com/sonm-io/core/insonmnia/state"
 	"github.com/sonm-io/core/insonmnia/worker/plugin"
@@ -19,6 +21,7 @@ import (
 	"github.com/sonm-io/core/util/multierror"
 	"github.com/sonm-io/core/util/netutil"
 	"github.com/sonm-io/core/util/xgrpc"
+	"go.uber.org/zap"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc/credentials"
 )
@@ -44,7 +47,13 @@ type options struct {
 	certRotator util.HitlessCertRotator
 	plugins     *plugin.Repository
 	whitelist   Whitelist
+	signatures  Whitelist
 	matcher     matcher.Matcher
+	mirror      ImageMirror
+	isolation   *isolation
+	gateway     *gateway.Gateway
+	ports       *publicPorts
+	ingress     *ingress
 }
 
 func (m *options) validate() error {
@@ -106,7 +115,19 @@ func (m *options) SetupDefaults() error {
 		return err
 	}
 
-	if err := m.setupSSH(); err != nil {
+	if err := m.setupGateway(); err != nil {
+		return err
+	}
+
+	if err := m.setupIngress(); err != nil {
+		return err
+	}
+
+	if err := m.setupIsolation(); err != nil {
+		return err
+	}
+
+	if err := m.setupRegistryCache(); err != nil {
 		return err
 	}
 
@@ -206,18 +227,25 @@ func (m *options) setupDWH() error {
 }
 
 func (m *options) setupWhitelist() error {
+	privilegedAddresses := m.cfg.Whitelist.PrivilegedAddresses
+	if len(privilegedAddresses) == 0 {
+		privilegedAddresses = append(privilegedAddresses, crypto.PubkeyToAddress(m.key.PublicKey).Hex())
+		privilegedAddresses = append(privilegedAddresses, m.cfg.Master.Hex())
+		if m.cfg.Admin != nil {
+			privilegedAddresses = append(privilegedAddresses, m.cfg.Admin.Hex())
+		}
+	}
+
 	if m.whitelist == nil {
 		cfg := m.cfg.Whitelist
-		if len(cfg.PrivilegedAddresses) == 0 {
-			cfg.PrivilegedAddresses = append(cfg.PrivilegedAddresses, crypto.PubkeyToAddress(m.key.PublicKey).Hex())
-			cfg.PrivilegedAddresses = append(cfg.PrivilegedAddresses, m.cfg.Master.Hex())
-			if m.cfg.Admin != nil {
-				cfg.PrivilegedAddresses = append(cfg.PrivilegedAddresses, m.cfg.Admin.Hex())
-			}
-		}
+		cfg.PrivilegedAddresses = privilegedAddresses
 
 		m.whitelist = NewWhitelist(m.ctx, &cfg)
 	}
+
+	if m.signatures == nil {
+		m.signatures = NewSignatureWhitelist(&m.cfg.ImagePolicy.Signature, privilegedAddresses, m.eth.ProfileRegistry())
+	}
 	return nil
 }
 
@@ -279,16 +307,85 @@ func (m *options) setupNetworkOptions() error {
 	return errors.New("failed to get public IPs")
 }
 
-func (m *options) setupSSH() error {
-	if m.ssh == nil {
-		m.ssh = nilSSH{}
+func (m *options) setupGateway() error {
+	if m.ports == nil && m.cfg.Gateway != nil {
+		if !gateway.PlatformSupportIPVS {
+			return errors.New("gateway requires IPVS, which is not supported on this platform")
+		}
+
+		gate, err := gateway.NewGateway(m.ctx)
+		if err != nil {
+			return fmt.Errorf("cannot setup gateway: %v", err)
+		}
+
+		ports, err := newPublicPorts(m.ctx, m.cfg.Gateway, gate, state.NewKeyedStorage(publicPortsKey, m.storage))
+		if err != nil {
+			gate.Close()
+			return fmt.Errorf("cannot setup public ports: %v", err)
+		}
+
+		m.gateway = gate
+		m.ports = ports
+	}
+	return nil
+}
+
+func (m *options) setupIngress() error {
+	if m.ingress == nil && m.cfg.Ingress != nil {
+		ingress, err := newIngress(m.cfg.Ingress)
+		if err != nil {
+			return fmt.Errorf("cannot setup ingress: %v", err)
+		}
+
+		go func() {
+			if err := ingress.Serve(m.ctx); err != nil && err != context.Canceled {
+				ctxlog.G(m.ctx).Error("ingress has failed", zap.Error(err))
+			}
+		}()
+
+		m.ingress = ingress
+	}
+	return nil
+}
+
+func (m *options) setupIsolation() error {
+	if m.isolation == nil {
+		dockerClient, err := client.NewEnvClient()
+		if err != nil {
+			return err
+		}
+		defer dockerClient.Close()
+
+		isolation, err := newIsolation(m.ctx, &m.cfg.Isolation, dockerClient)
+		if err != nil {
+			return fmt.Errorf("cannot setup task isolation: %v", err)
+		}
+		m.isolation = isolation
+	}
+	return nil
+}
+
+func (m *options) setupRegistryCache() error {
+	if m.mirror == nil && m.cfg.RegistryCache != nil {
+		cache, err := newRegistryCache(m.cfg.RegistryCache)
+		if err != nil {
+			return fmt.Errorf("cannot create registry cache: %v", err)
+		}
+
+		go func() {
+			if err := cache.Serve(m.ctx); err != nil && err != context.Canceled {
+				ctxlog.G(m.ctx).Error("registry cache has failed", zap.Error(err))
+			}
+		}()
+
+		m.mirror = cache
 	}
 	return nil
 }
 
 func (m *options) setupOverseer() error {
 	if m.ovs == nil {
-		ovs, err := NewOverseer(m.ctx, m.plugins)
+		ovs, err := NewOverseer(m.ctx, m.plugins, m.mirror)
 		if err != nil {
 			return err
 		}
@@ -384,6 +481,22 @@ func WithWhitelist(whitelist Whitelist) Option {
 	}
 }
 
+// WithSignatureWhitelist specifies the whitelist that verifies image
+// signatures.
+func WithSignatureWhitelist(whitelist Whitelist) Option {
+	return func(o *options) {
+		o.signatures = whitelist
+	}
+}
+
+// WithImageMirror specifies the mirror images are pulled through instead of
+// the embedded registry cache.
+func WithImageMirror(mirror ImageMirror) Option {
+	return func(o *options) {
+		o.mirror = mirror
+	}
+}
+
 func WithMatcher(matcher matcher.Matcher) Option {
 	return func(o *options) {
 		o.matcher = matcher
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"strconv"
 	"sync"
 	"time"
@@ -41,12 +42,18 @@ type Description struct {
 	TaskId       string
 	DealId       string
 	autoremove   bool
+	// mirrored is the reference the image was pulled with through the
+	// image mirror, if any.
+	mirrored reference.Reference
 
 	GPUDevices []gpu.GPUID
 
 	mounts []volume.Mount
 
 	networks []*structs.NetworkSpec
+
+	isolation *isolationProfile
+	egress    *pb.EgressPolicy
 }
 
 func (d *Description) ID() string {
@@ -73,6 +80,10 @@ func (d *Description) Networks() []*structs.NetworkSpec {
 	return d.networks
 }
 
+func (d *Description) EgressPolicy() *pb.EgressPolicy {
+	return d.egress
+}
+
 func (d *Description) FormatEnv() []string {
 	vars := make([]string, 0, len(d.Env))
 	for k, v := range d.Env {
@@ -86,13 +97,21 @@ func (d *Description) Expose() (nat.PortSet, nat.PortMap, error) {
 	return nat.ParsePortSpecs(d.Container.Expose)
 }
 
-// ContainerInfo is a brief information about containers
+// ContainerInfo is a brief information about containers.
+//
+// Ports are the container's ports published by Docker on the host, while
+// PublicPorts are endpoints they are reachable at from the outside, which
+// are reported to clients. IngressHosts are hostnames container ports are
+// additionally reachable at through the worker's ingress on IngressPort.
 type ContainerInfo struct {
 	status       pb.TaskStatusReply_Status
 	ID           string
 	ImageName    string
 	StartAt      time.Time
 	Ports        nat.PortMap
+	PublicPorts  nat.PortMap
+	IngressHosts map[nat.Port]string
+	IngressPort  uint16
 	PublicKey    ssh.PublicKey
 	Cgroup       string
 	CgroupParent string
@@ -104,7 +123,7 @@ type ContainerInfo struct {
 
 func (c *ContainerInfo) IntoProto(ctx context.Context) *pb.TaskStatusReply {
 	ports := make(map[string]*pb.Endpoints)
-	for hostPort, binding := range c.Ports {
+	for hostPort, binding := range c.PublicPorts {
 		addrs := make([]*pb.SocketAddr, len(binding))
 		for i, bind := range binding {
 			port, err := strconv.ParseUint(bind.HostPort, 10, 16)
@@ -119,6 +138,16 @@ func (c *ContainerInfo) IntoProto(ctx context.Context) *pb.TaskStatusReply {
 		ports[string(hostPort)] = &pb.Endpoints{Endpoints: addrs}
 	}
 
+	for containerPort, host := range c.IngressHosts {
+		endpoints, ok := ports[string(containerPort)]
+		if !ok {
+			endpoints = &pb.Endpoints{}
+			ports[string(containerPort)] = endpoints
+		}
+
+		endpoints.Endpoints = append(endpoints.Endpoints, &pb.SocketAddr{Addr: host, Port: uint32(c.IngressPort)})
+	}
+
 	return &pb.TaskStatusReply{
 		Status:             c.status,
 		ImageName:          c.ImageName,
@@ -135,6 +164,8 @@ type ContainerMetrics struct {
 	cpu types.CPUStats
 	mem types.MemoryStats
 	net map[string]types.NetworkStats
+
+	egressViolations uint64
 }
 
 func (m *ContainerMetrics) Marshal() *pb.ResourceUsage {
@@ -157,7 +188,8 @@ func (m *ContainerMetrics) Marshal() *pb.ResourceUsage {
 		Memory: &pb.MemoryUsage{
 			MaxUsage: m.mem.MaxUsage,
 		},
-		Network: network,
+		Network:          network,
+		EgressViolations: m.egressViolations,
 	}
 }
 
@@ -185,6 +217,10 @@ type Overseer interface {
 	// Exec a given command in running container
 	Exec(ctx context.Context, Id string, cmd []string, env []string, isTty bool, wCh <-chan ssh.Window) (types.HijackedResponse, error)
 
+	// Dial connects to the address from the network namespace of a running
+	// container.
+	Dial(ctx context.Context, containerID, network, addr string) (net.Conn, error)
+
 	// Stop terminates the container.
 	Stop(ctx context.Context, containerID string) error
 
@@ -213,10 +249,16 @@ type overseer struct {
 
 	registryAuth map[string]string
 
+	// Mirror is an optional image mirror, public images are pulled through.
+	mirror ImageMirror
+
 	// protects containers map
 	mu         sync.Mutex
 	containers map[string]*containerDescriptor
 	statuses   map[string]chan pb.TaskStatusReply_Status
+	// Mirrored maps image references to the ones they were pulled with
+	// through the mirror.
+	mirrored map[string]reference.Reference
 }
 
 func (o *overseer) supportGPU() bool {
@@ -224,7 +266,7 @@ func (o *overseer) supportGPU() bool {
 }
 
 // NewOverseer creates new overseer
-func NewOverseer(ctx context.Context, plugins *plugin.Repository) (Overseer, error) {
+func NewOverseer(ctx context.Context, plugins *plugin.Repository, mirror ImageMirror) (Overseer, error) {
 	dockerClient, err := client.NewEnvClient()
 	if err != nil {
 		return nil, err
@@ -236,8 +278,10 @@ func NewOverseer(ctx context.Context, plugins *plugin.Repository) (Overseer, err
 		cancel:     cancel,
 		plugins:    plugins,
 		client:     dockerClient,
+		mirror:     mirror,
 		containers: make(map[string]*containerDescriptor),
 		statuses:   make(map[string]chan pb.TaskStatusReply_Status),
+		mirrored:   make(map[string]reference.Reference),
 	}
 
 	go ovr.collectStats()
@@ -248,6 +292,7 @@ func NewOverseer(ctx context.Context, plugins *plugin.Repository) (Overseer, err
 
 func (o *overseer) Info(ctx context.Context) (map[string]ContainerMetrics, error) {
 	info := make(map[string]ContainerMetrics)
+	taskIDs := make(map[string]string)
 
 	o.mu.Lock()
 	for _, container := range o.containers {
@@ -258,9 +303,21 @@ func (o *overseer) Info(ctx context.Context) (map[string]ContainerMetrics, error
 		}
 
 		info[container.ID] = metrics
+		taskIDs[container.ID] = container.description.TaskId
 	}
 	o.mu.Unlock()
 
+	for id, metrics := range info {
+		violations, err := o.plugins.EgressViolations(taskIDs[id])
+		if err != nil {
+			log.G(ctx).Warn("failed to count egress violations", zap.String("id", id), zap.Error(err))
+			continue
+		}
+
+		metrics.egressViolations = violations
+		info[id] = metrics
+	}
+
 	return info, nil
 }
 
@@ -457,6 +514,10 @@ func (o *overseer) Spool(ctx context.Context, d Description) error {
 		RegistryAuth: d.Auth,
 	}
 
+	if o.spoolMirrored(ctx, d) {
+		return nil
+	}
+
 	body, err := o.client.ImagePull(ctx, refStr, options)
 	if err != nil {
 		log.G(ctx).Error("ImagePull failed", zap.String("ref", refStr), zap.Error(err))
@@ -471,12 +532,51 @@ func (o *overseer) Spool(ctx context.Context, d Description) error {
 	return nil
 }
 
+// spoolMirrored pulls the public image through the mirror, returning false
+// if the image should be pulled directly.
+func (o *overseer) spoolMirrored(ctx context.Context, d Description) bool {
+	refStr := d.Reference.String()
+
+	o.mu.Lock()
+	delete(o.mirrored, refStr)
+	o.mu.Unlock()
+
+	if o.mirror == nil || len(d.Auth) != 0 {
+		return false
+	}
+
+	mirrored, ok := o.mirror.Mirror(d.Reference)
+	if !ok {
+		return false
+	}
+
+	body, err := o.client.ImagePull(ctx, mirrored.String(), types.ImagePullOptions{})
+	if err == nil {
+		err = decodeImagePull(body)
+	}
+	if err != nil {
+		log.G(ctx).Warn("failed to pull an image through the mirror, pulling directly",
+			zap.String("ref", refStr), zap.Stringer("mirror", mirrored), zap.Error(err))
+		return false
+	}
+
+	o.mu.Lock()
+	o.mirrored[refStr] = mirrored
+	o.mu.Unlock()
+
+	return true
+}
+
 func (o *overseer) Start(ctx context.Context, description Description) (status chan pb.TaskStatusReply_Status, cinfo ContainerInfo, err error) {
 	if description.IsGPURequired() && !o.supportGPU() {
 		err = fmt.Errorf("GPU required but not supported or disabled")
 		return
 	}
 
+	o.mu.Lock()
+	description.mirrored = o.mirrored[description.Reference.String()]
+	o.mu.Unlock()
+
 	// TODO: Well, we should refactor those dozens of arguments.
 	// Note: maybe will be better to make the "newContainer()" func as part of the overseer struct
 	// ( in that case we can access docker client and plugins repo from the Ovs instance. )
@@ -536,6 +636,19 @@ func (o *overseer) Exec(ctx context.Context, id string, cmd []string, env []stri
 	return
 }
 
+func (o *overseer) Dial(ctx context.Context, containerID, network, addr string) (net.Conn, error) {
+	info, err := o.client.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.State == nil || !info.State.Running || info.State.Pid == 0 {
+		return nil, fmt.Errorf("container %s is not running", containerID)
+	}
+
+	return dialNetns(ctx, info.State.Pid, network, addr)
+}
+
 func (o *overseer) Stop(ctx context.Context, containerid string) error {
 	o.mu.Lock()
 
@@ -21,7 +21,7 @@ import (
 
 func TestOvsSpool(t *testing.T) {
 	ctx := context.Background()
-	ovs, err := NewOverseer(ctx, plugin.EmptyRepository())
+	ovs, err := NewOverseer(ctx, plugin.EmptyRepository(), nil)
 	defer ovs.Close()
 	require.NoError(t, err, "failed to create Overseer")
 
@@ -108,7 +108,7 @@ func TestOvsSpawn(t *testing.T) {
 	assrt.NoError(err)
 	defer cl.Close()
 	ctx := context.Background()
-	ovs, err := NewOverseer(ctx, plugin.EmptyRepository())
+	ovs, err := NewOverseer(ctx, plugin.EmptyRepository(), nil)
 	require.NoError(t, err)
 	ref, err := reference.ParseNormalizedNamed("worker")
 	require.NoError(t, err)
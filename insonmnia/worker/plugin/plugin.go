@@ -28,6 +28,7 @@ type Provider interface {
 	GPUProvider
 	VolumeProvider
 	NetworkProvider
+	EgressProvider
 }
 
 // GPUProvider describes an interface for applying GPU settings to the
@@ -52,11 +53,22 @@ type NetworkProvider interface {
 	Networks() []*structs.NetworkSpec
 }
 
+// EgressProvider describes an interface for restricting outgoing
+// connections of the container.
+type EgressProvider interface {
+	// ID returns a unique identifier of the container.
+	ID() string
+	// EgressPolicy returns the policy to be applied, nil means no
+	// restrictions.
+	EgressPolicy() *sonm.EgressPolicy
+}
+
 // Repository describes a place where all SONM plugins for Docker live.
 type Repository struct {
 	volumes       map[string]volume.VolumeDriver
 	gpuTuners     map[sonm.GPUVendorType]gpu.Tuner
 	networkTuners map[string]minet.Tuner
+	egressTuner   *minet.EgressTuner
 }
 
 // NewRepository constructs a new repository for SONM plugins from the
@@ -103,6 +115,12 @@ func NewRepository(ctx context.Context, cfg Config) (*Repository, error) {
 		r.gpuTuners[typeID] = tuner
 	}
 
+	egressTuner, err := minet.NewEgressTuner()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize egress tuner - %v", err)
+	}
+	r.egressTuner = egressTuner
+
 	if cfg.Overlay.Drivers.Tinc != nil {
 		tincTuner, err := minet.NewTincTuner(ctx, cfg.Overlay.Drivers.Tinc)
 		if err != nil {
@@ -148,6 +166,13 @@ func (r *Repository) Tune(ctx context.Context, provider Provider, hostCfg *conta
 	}
 	cleanup.Add(c)
 
+	c, err = r.TuneEgress(ctx, provider, hostCfg)
+	if err != nil {
+		cleanup.Close()
+		return nil, err
+	}
+	cleanup.Add(c)
+
 	c, err = r.TuneNetworks(ctx, provider, hostCfg, netCfg)
 	if err != nil {
 		cleanup.Close()
@@ -249,6 +274,38 @@ func (r *Repository) TuneVolumes(ctx context.Context, provider VolumeProvider, c
 	return &cleanup, nil
 }
 
+// TuneEgress applies the egress policy of the given provider, if any.
+func (r *Repository) TuneEgress(ctx context.Context, provider EgressProvider, hostCfg *container.HostConfig) (Cleanup, error) {
+	cleanup := newNestedCleanup()
+
+	policy := provider.EgressPolicy()
+	if policy.IsEmpty() {
+		return &cleanup, nil
+	}
+
+	if r.egressTuner == nil {
+		return nil, fmt.Errorf("egress policies are not supported")
+	}
+
+	c, err := r.egressTuner.Tune(ctx, provider.ID(), policy, hostCfg)
+	if err != nil {
+		return nil, err
+	}
+	cleanup.Add(c)
+
+	return &cleanup, nil
+}
+
+// EgressViolations returns the number of packets dropped by the egress
+// policy of the container with the given ID.
+func (r *Repository) EgressViolations(id string) (uint64, error) {
+	if r.egressTuner == nil {
+		return 0, nil
+	}
+
+	return r.egressTuner.Violations(id)
+}
+
 func (r *Repository) TuneNetworks(ctx context.Context, provider NetworkProvider, hostCfg *container.HostConfig, netCfg *network.NetworkingConfig) (Cleanup, error) {
 	log.G(ctx).Info("tuning networks")
 	cleanup := newNestedCleanup()
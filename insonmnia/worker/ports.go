@@ -0,0 +1,262 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/docker/go-connections/nat"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/insonmnia/gateway"
+	"go.uber.org/zap"
+)
+
+const (
+	publicPortsKey = "public_ports"
+)
+
+type publicPortsStorage interface {
+	Save(value interface{}) error
+	Load(value interface{}) (bool, error)
+}
+
+type publicPortsGateway interface {
+	CreateService(vsID string, options *gateway.ServiceOptions) error
+	CreateBackend(vsID, rsID string, options *gateway.RealOptions) error
+	RemoveService(vsID string) (*gateway.ServiceOptions, error)
+	RemoveStaleServices(host string, from, to uint16) error
+}
+
+// publicPorts allocates stable public ports for exposed task ports from the
+// configured range and routes them to containers through IPVS virtual
+// services.
+//
+// Ports are allocated per deal and container port, so tasks restarted
+// within the same deal keep their public ports. Allocations survive worker
+// restarts and are released only when deals are finished.
+type publicPorts struct {
+	mu      sync.Mutex
+	host    string
+	pool    *gateway.PortPool
+	gateway publicPortsGateway
+	storage publicPortsStorage
+	// Allocations map "<dealID>/<port>/<protocol>" keys to public ports.
+	allocations map[string]uint16
+	// Services map task IDs to virtual service IDs.
+	services map[string][]string
+}
+
+func newPublicPorts(ctx context.Context, cfg *GatewayConfig, gate publicPortsGateway, storage publicPortsStorage) (*publicPorts, error) {
+	if len(cfg.Ports) != 2 || cfg.Ports[0] == 0 || cfg.Ports[0] > cfg.Ports[1] {
+		return nil, fmt.Errorf("public port range must be specified as [from, to], got %v", cfg.Ports)
+	}
+
+	host := cfg.Host
+	if len(host) == 0 {
+		ip, err := gateway.GetOutboundIP()
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect outbound IP: %v", err)
+		}
+		host = ip.String()
+	}
+
+	// Routes of a previous worker instance are not known to the gateway and
+	// would point to containers that are no longer tracked, because tasks
+	// are not restored after restarts. Allocations are kept though, so tasks
+	// started again within the same deals get the same public ports, while
+	// ones of deals finished meanwhile are freed by "Reconcile".
+	if err := gate.RemoveStaleServices(host, cfg.Ports[0], cfg.Ports[1]); err != nil {
+		return nil, fmt.Errorf("failed to remove stale virtual services: %v", err)
+	}
+
+	allocations := map[string]uint16{}
+	if _, err := storage.Load(&allocations); err != nil {
+		return nil, fmt.Errorf("failed to load public port allocations: %v", err)
+	}
+
+	m := &publicPorts{
+		host:        host,
+		pool:        gateway.NewPortPool(cfg.Ports[0], cfg.Ports[1]-cfg.Ports[0]+1),
+		gateway:     gate,
+		storage:     storage,
+		allocations: map[string]uint16{},
+		services:    map[string][]string{},
+	}
+
+	for key, port := range allocations {
+		if err := m.pool.Reserve(key, port); err != nil {
+			log.G(ctx).Warn("dropping public port allocation", zap.String("key", key), zap.Uint16("port", port), zap.Error(err))
+			continue
+		}
+
+		m.allocations[key] = port
+	}
+
+	if len(m.allocations) != len(allocations) {
+		if err := m.storage.Save(m.allocations); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+func publicPortKey(dealID string, port nat.Port) string {
+	return dealID + "/" + string(port)
+}
+
+func publicPortDealID(key string) string {
+	return strings.SplitN(key, "/", 2)[0]
+}
+
+// Expose routes the given published ports of the task to public ports,
+// which are allocated for the deal unless already done.
+func (m *publicPorts) Expose(ctx context.Context, dealID, taskID string, ports nat.PortMap) (map[nat.Port]uint16, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := map[nat.Port]uint16{}
+	for containerPort, bindings := range ports {
+		if len(bindings) == 0 {
+			continue
+		}
+
+		hostPort, err := nat.ParsePort(bindings[0].HostPort)
+		if err != nil {
+			m.unexpose(ctx, taskID)
+			return nil, err
+		}
+
+		port, err := m.allocate(publicPortKey(dealID, containerPort))
+		if err != nil {
+			m.unexpose(ctx, taskID)
+			return nil, err
+		}
+
+		vsID := taskID + "/" + string(containerPort)
+		if err := m.route(vsID, containerPort.Proto(), port, uint16(hostPort)); err != nil {
+			m.unexpose(ctx, taskID)
+			return nil, fmt.Errorf("failed to route public port %d to %s: %v", port, containerPort, err)
+		}
+		m.services[taskID] = append(m.services[taskID], vsID)
+
+		result[containerPort] = port
+	}
+
+	return result, nil
+}
+
+func (m *publicPorts) allocate(key string) (uint16, error) {
+	if port, ok := m.allocations[key]; ok {
+		return port, nil
+	}
+
+	port, err := m.pool.Assign(key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to allocate public port: %v", err)
+	}
+
+	m.allocations[key] = port
+	if err := m.storage.Save(m.allocations); err != nil {
+		delete(m.allocations, key)
+		m.pool.Retain(key)
+		return 0, fmt.Errorf("failed to save public port allocation: %v", err)
+	}
+
+	return port, nil
+}
+
+func (m *publicPorts) route(vsID, protocol string, port, hostPort uint16) error {
+	serviceOptions, err := gateway.NewServiceOptions(m.host, port, protocol)
+	if err != nil {
+		return err
+	}
+
+	realOptions, err := gateway.NewRealOptions(m.host, hostPort, 0, vsID)
+	if err != nil {
+		return err
+	}
+
+	if err := m.gateway.CreateService(vsID, serviceOptions); err != nil {
+		return err
+	}
+
+	if err := m.gateway.CreateBackend(vsID, vsID, realOptions); err != nil {
+		m.gateway.RemoveService(vsID)
+		return err
+	}
+
+	return nil
+}
+
+// Unexpose removes routes of the given task, keeping its public ports
+// allocated for the deal.
+func (m *publicPorts) Unexpose(ctx context.Context, taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unexpose(ctx, taskID)
+}
+
+func (m *publicPorts) unexpose(ctx context.Context, taskID string) {
+	for _, vsID := range m.services[taskID] {
+		if _, err := m.gateway.RemoveService(vsID); err != nil {
+			log.G(ctx).Warn("failed to remove virtual service", zap.String("service", vsID), zap.Error(err))
+		}
+	}
+
+	delete(m.services, taskID)
+}
+
+// Release frees public ports allocated for the given deal.
+func (m *publicPorts) Release(dealID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	released := false
+	for key := range m.allocations {
+		if strings.HasPrefix(key, dealID+"/") {
+			m.pool.Retain(key)
+			delete(m.allocations, key)
+			released = true
+		}
+	}
+
+	if !released {
+		return nil
+	}
+
+	return m.storage.Save(m.allocations)
+}
+
+// Reconcile frees public ports allocated for deals other than the given
+// active ones, which may happen when deals are finished while the worker is
+// down.
+func (m *publicPorts) Reconcile(ctx context.Context, activeDealIDs []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := map[string]bool{}
+	for _, dealID := range activeDealIDs {
+		active[dealID] = true
+	}
+
+	released := false
+	for key, port := range m.allocations {
+		if active[publicPortDealID(key)] {
+			continue
+		}
+
+		log.G(ctx).Info("releasing public port of inactive deal", zap.String("key", key), zap.Uint16("port", port))
+		m.pool.Retain(key)
+		delete(m.allocations, key)
+		released = true
+	}
+
+	if !released {
+		return nil
+	}
+
+	return m.storage.Save(m.allocations)
+}
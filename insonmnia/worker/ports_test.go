@@ -0,0 +1,167 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/sonm-io/core/insonmnia/gateway"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPortsStorage struct {
+	data []byte
+}
+
+func (m *testPortsStorage) Save(value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.data = data
+	return nil
+}
+
+func (m *testPortsStorage) Load(value interface{}) (bool, error) {
+	if m.data == nil {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(m.data, value)
+}
+
+type testPortsGateway struct {
+	services map[string]uint16
+	backends map[string]uint16
+}
+
+func newTestPortsGateway() *testPortsGateway {
+	return &testPortsGateway{
+		services: map[string]uint16{},
+		backends: map[string]uint16{},
+	}
+}
+
+func (m *testPortsGateway) CreateService(vsID string, options *gateway.ServiceOptions) error {
+	m.services[vsID] = options.Port
+	return nil
+}
+
+func (m *testPortsGateway) CreateBackend(vsID, rsID string, options *gateway.RealOptions) error {
+	m.backends[vsID] = options.Port
+	return nil
+}
+
+func (m *testPortsGateway) RemoveService(vsID string) (*gateway.ServiceOptions, error) {
+	delete(m.services, vsID)
+	delete(m.backends, vsID)
+	return nil, nil
+}
+
+func (m *testPortsGateway) RemoveStaleServices(host string, from, to uint16) error {
+	return nil
+}
+
+func testPublishedPorts(ports ...string) nat.PortMap {
+	portMap := nat.PortMap{}
+	for id, port := range ports {
+		portMap[nat.Port(port)] = []nat.PortBinding{{HostIP: "0.0.0.0", HostPort: strconv.Itoa(10000 * (id + 1))}}
+	}
+
+	return portMap
+}
+
+func TestPublicPortsStableWithinDeal(t *testing.T) {
+	cfg := &GatewayConfig{Ports: []uint16{30000, 30009}, Host: "127.0.0.1"}
+	gate := newTestPortsGateway()
+
+	ports, err := newPublicPorts(context.Background(), cfg, gate, &testPortsStorage{})
+	require.NoError(t, err)
+
+	exposed, err := ports.Expose(context.Background(), "1", "task-1", testPublishedPorts("80/tcp", "53/udp"))
+	require.NoError(t, err)
+	require.Len(t, exposed, 2)
+	assert.Equal(t, exposed["80/tcp"], gate.services["task-1/80/tcp"])
+	assert.Equal(t, uint16(10000), gate.backends["task-1/80/tcp"])
+	assert.Equal(t, uint16(20000), gate.backends["task-1/53/udp"])
+
+	ports.Unexpose(context.Background(), "task-1")
+	assert.Empty(t, gate.services)
+
+	restarted, err := ports.Expose(context.Background(), "1", "task-2", testPublishedPorts("80/tcp", "53/udp"))
+	require.NoError(t, err)
+	assert.Equal(t, exposed, restarted)
+
+	other, err := ports.Expose(context.Background(), "2", "task-3", testPublishedPorts("80/tcp"))
+	require.NoError(t, err)
+	assert.NotEqual(t, exposed["80/tcp"], other["80/tcp"])
+}
+
+func TestPublicPortsPersisted(t *testing.T) {
+	cfg := &GatewayConfig{Ports: []uint16{30000, 30001}, Host: "127.0.0.1"}
+	storage := &testPortsStorage{}
+
+	ports, err := newPublicPorts(context.Background(), cfg, newTestPortsGateway(), storage)
+	require.NoError(t, err)
+
+	exposed, err := ports.Expose(context.Background(), "1", "task-1", testPublishedPorts("80/tcp"))
+	require.NoError(t, err)
+
+	ports, err = newPublicPorts(context.Background(), cfg, newTestPortsGateway(), storage)
+	require.NoError(t, err)
+
+	restored, err := ports.Expose(context.Background(), "1", "task-2", testPublishedPorts("80/tcp"))
+	require.NoError(t, err)
+	assert.Equal(t, exposed, restored)
+
+	_, err = ports.Expose(context.Background(), "2", "task-3", testPublishedPorts("80/tcp"))
+	require.NoError(t, err)
+	_, err = ports.Expose(context.Background(), "3", "task-4", testPublishedPorts("80/tcp"))
+	require.Error(t, err)
+
+	require.NoError(t, ports.Release("1"))
+	released, err := ports.Expose(context.Background(), "3", "task-4", testPublishedPorts("80/tcp"))
+	require.NoError(t, err)
+	assert.Equal(t, exposed, released)
+}
+
+func TestPublicPortsInvalidRange(t *testing.T) {
+	_, err := newPublicPorts(context.Background(), &GatewayConfig{Ports: []uint16{30001, 30000}, Host: "127.0.0.1"}, newTestPortsGateway(), &testPortsStorage{})
+	assert.Error(t, err)
+}
+
+func TestPublicPortsReconcile(t *testing.T) {
+	cfg := &GatewayConfig{Ports: []uint16{30000, 30009}, Host: "127.0.0.1"}
+	storage := &testPortsStorage{}
+
+	ports, err := newPublicPorts(context.Background(), cfg, newTestPortsGateway(), storage)
+	require.NoError(t, err)
+
+	active, err := ports.Expose(context.Background(), "1", "task-1", testPublishedPorts("80/tcp"))
+	require.NoError(t, err)
+	_, err = ports.Expose(context.Background(), "2", "task-2", testPublishedPorts("80/tcp", "53/udp"))
+	require.NoError(t, err)
+
+	// Deal "2" has been finished while the worker was down.
+	ports, err = newPublicPorts(context.Background(), cfg, newTestPortsGateway(), storage)
+	require.NoError(t, err)
+	require.NoError(t, ports.Reconcile(context.Background(), []string{"1"}))
+	assert.Len(t, ports.allocations, 1)
+
+	ports, err = newPublicPorts(context.Background(), cfg, newTestPortsGateway(), storage)
+	require.NoError(t, err)
+	assert.Len(t, ports.allocations, 1)
+
+	exposed, err := ports.Expose(context.Background(), "1", "task-1", testPublishedPorts("80/tcp"))
+	require.NoError(t, err)
+	assert.Equal(t, active, exposed)
+
+	// Released ports are available again.
+	exposed, err = ports.Expose(context.Background(), "3", "task-3", testPublishedPorts("80/tcp", "53/udp"))
+	require.NoError(t, err)
+	assert.Len(t, exposed, 2)
+}
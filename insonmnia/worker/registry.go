@@ -0,0 +1,283 @@
+package worker
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	defaultRegistryDomain   = "docker.io"
+	defaultRegistryEndpoint = "registry-1.docker.io"
+
+	mediaTypeDockerManifest = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeDockerList     = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+var (
+	errRegistryNotFound = errors.New("not found in the registry")
+)
+
+// registryClient is a minimal Docker Registry HTTP API V2 client, that is
+// able to fetch manifests using either basic or token authentication.
+type registryClient struct {
+	client *http.Client
+}
+
+func newRegistryClient() *registryClient {
+	return &registryClient{
+		client: http.DefaultClient,
+	}
+}
+
+// ManifestDigest resolves the digest of the manifest the given reference
+// points to.
+func (m *registryClient) ManifestDigest(ctx context.Context, ref reference.Named, authority string) (digest.Digest, error) {
+	tag := "latest"
+	if tagged, ok := ref.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+
+	response, err := m.manifest(ctx, http.MethodHead, ref, tag, authority)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	return digest.Parse(response.Header.Get("Docker-Content-Digest"))
+}
+
+// Manifest fetches the OCI or Docker V2 manifest of the given repository
+// by its tag or digest.
+func (m *registryClient) Manifest(ctx context.Context, ref reference.Named, tagOrDigest string, authority string) (*ocispec.Manifest, error) {
+	response, err := m.manifest(ctx, http.MethodGet, ref, tagOrDigest, authority)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	manifest := &ocispec.Manifest{}
+	if err := json.NewDecoder(response.Body).Decode(manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest: %v", err)
+	}
+
+	return manifest, nil
+}
+
+func (m *registryClient) manifest(ctx context.Context, method string, ref reference.Named, tagOrDigest string, authority string) (*http.Response, error) {
+	accept := strings.Join([]string{ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex, mediaTypeDockerManifest, mediaTypeDockerList}, ", ")
+	return m.fetch(ctx, method, ref, "manifests", tagOrDigest, accept, authority)
+}
+
+// Blob fetches the blob with the given digest. The caller is responsible
+// for closing the response body.
+func (m *registryClient) Blob(ctx context.Context, ref reference.Named, digest digest.Digest, authority string) (*http.Response, error) {
+	return m.fetch(ctx, http.MethodGet, ref, "blobs", digest.String(), "", authority)
+}
+
+// fetch requests either a manifest or a blob of the given repository,
+// returning the response only if it succeeds.
+func (m *registryClient) fetch(ctx context.Context, method string, ref reference.Named, kind string, tagOrDigest string, accept string, authority string) (*http.Response, error) {
+	domain := reference.Domain(ref)
+	if domain == defaultRegistryDomain {
+		domain = defaultRegistryEndpoint
+	}
+
+	request, err := http.NewRequest(method, fmt.Sprintf("https://%s/v2/%s/%s/%s", domain, reference.Path(ref), kind, tagOrDigest), nil)
+	if err != nil {
+		return nil, err
+	}
+	request = request.WithContext(ctx)
+	if len(accept) != 0 {
+		request.Header.Set("Accept", accept)
+	}
+
+	response, err := m.do(request, authority)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return response, nil
+	case http.StatusNotFound:
+		drainBody(response.Body)
+		return nil, errRegistryNotFound
+	default:
+		drainBody(response.Body)
+		return nil, fmt.Errorf("failed to fetch %s %s of %s: %s", strings.TrimSuffix(kind, "s"), tagOrDigest, ref.Name(), response.Status)
+	}
+}
+
+// do performs the request, authenticating it when the registry asks so.
+func (m *registryClient) do(request *http.Request, authority string) (*http.Response, error) {
+	response, err := m.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusUnauthorized {
+		return response, nil
+	}
+
+	challenge := response.Header.Get("WWW-Authenticate")
+	drainBody(response.Body)
+
+	credentials, err := decodeRegistryAuth(authority)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		request.SetBasicAuth(credentials.Username, credentials.Password)
+	case "bearer":
+		token, err := m.token(request.Context(), params, credentials)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set("Authorization", "Bearer "+token)
+	default:
+		return nil, fmt.Errorf("unsupported registry authentication scheme: %s", scheme)
+	}
+
+	return m.client.Do(request)
+}
+
+// token obtains the bearer token from the authorization service specified
+// in the challenge.
+func (m *registryClient) token(ctx context.Context, params map[string]string, credentials types.AuthConfig) (string, error) {
+	realm, err := url.Parse(params["realm"])
+	if err != nil || len(realm.Host) == 0 {
+		return "", fmt.Errorf("invalid registry authentication realm: %s", params["realm"])
+	}
+
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if value, ok := params[key]; ok {
+			query.Set(key, value)
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	request, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	request = request.WithContext(ctx)
+
+	if len(credentials.Username) != 0 {
+		request.SetBasicAuth(credentials.Username, credentials.Password)
+	}
+
+	response, err := m.client.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to obtain registry token: %s", response.Status)
+	}
+
+	reply := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&reply); err != nil {
+		return "", fmt.Errorf("failed to decode registry token: %v", err)
+	}
+
+	if len(reply.Token) != 0 {
+		return reply.Token, nil
+	}
+	if len(reply.AccessToken) != 0 {
+		return reply.AccessToken, nil
+	}
+
+	return "", errors.New("registry token is empty")
+}
+
+// decodeRegistryAuth decodes the authority in the format Docker API uses,
+// i.e. base64-encoded JSON.
+func decodeRegistryAuth(authority string) (types.AuthConfig, error) {
+	credentials := types.AuthConfig{}
+	if len(authority) == 0 {
+		return credentials, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(authority)
+	if err != nil {
+		data, err = base64.URLEncoding.DecodeString(authority)
+		if err != nil {
+			return credentials, fmt.Errorf("failed to decode registry auth: %v", err)
+		}
+	}
+
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return credentials, fmt.Errorf("failed to decode registry auth: %v", err)
+	}
+
+	return credentials, nil
+}
+
+// parseAuthChallenge parses "WWW-Authenticate" header value, like
+// `Bearer realm="https://auth.docker.io/token",service="registry.docker.io"`.
+func parseAuthChallenge(challenge string) (string, map[string]string) {
+	params := map[string]string{}
+
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	if len(parts) != 2 {
+		return parts[0], params
+	}
+
+	rest := parts[1]
+	for len(rest) != 0 {
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			break
+		}
+
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = strings.TrimSpace(rest[eq+1:])
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.Index(rest, ",")
+			if end < 0 {
+				end = len(rest)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+
+		params[key] = value
+		rest = strings.TrimPrefix(strings.TrimSpace(rest), ",")
+	}
+
+	return parts[0], params
+}
+
+func drainBody(body io.ReadCloser) {
+	io.Copy(ioutil.Discard, body)
+	body.Close()
+}
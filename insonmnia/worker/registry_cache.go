@@ -0,0 +1,582 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/docker/distribution/reference"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sonm-io/core/util"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultRegistryCacheSize = 20 << 30
+	maxManifestSize          = 4 << 20
+)
+
+var (
+	registryCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sonm_registry_cache_requests_total",
+		Help: "Number of requests served by the registry cache by kind (blob or manifest) and result (hit, miss or stale)",
+	}, []string{"kind", "result"})
+	registryCacheSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "sonm_registry_cache_size_bytes",
+		Help: "Total size of blobs stored in the registry cache",
+	})
+	registryCacheEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sonm_registry_cache_evictions_total",
+		Help: "Number of blobs evicted from the registry cache",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(registryCacheRequests, registryCacheSize, registryCacheEvictions)
+}
+
+// ImageMirror rewrites image references to pull them through a mirror.
+type ImageMirror interface {
+	// Mirror returns the reference pointing to the mirrored image, if the
+	// image can be mirrored.
+	Mirror(ref reference.Reference) (reference.Reference, bool)
+}
+
+// registryCacheIndex keeps manifests metadata, because manifests are
+// stored as regular blobs.
+type registryCacheIndex struct {
+	// Tags maps "<name>:<tag>" to the digest of the last manifest seen for
+	// the tag, which is served when the upstream registry is unavailable.
+	Tags map[string]digest.Digest `json:"tags"`
+	// MediaTypes maps manifest digests to their media types.
+	MediaTypes map[digest.Digest]string `json:"media_types"`
+}
+
+// registryCache is a pull-through cache implementing the read-only part of
+// the Docker Registry HTTP API V2.
+//
+// Images are mirrored with the upstream registry domain being the first
+// component of the repository name, i.e. "docker.io/library/nginx" is
+// available as "<endpoint>/docker.io/library/nginx". Only public images are
+// cached, because the cache does not forward credentials.
+//
+// The cache must listen on a loopback address, which Docker trusts without
+// TLS.
+type registryCache struct {
+	cfg      *RegistryCacheConfig
+	blobs    *blobCache
+	upstream *registryClient
+
+	mu    sync.Mutex
+	index registryCacheIndex
+}
+
+func newRegistryCache(cfg *RegistryCacheConfig) (*registryCache, error) {
+	size := cfg.Size.Bytes()
+	if size == 0 {
+		size = defaultRegistryCacheSize
+	}
+
+	blobs, err := newBlobCache(filepath.Join(cfg.Path, "blobs"), size)
+	if err != nil {
+		return nil, err
+	}
+	blobs.onEvict = func(uint64) { registryCacheEvictions.Inc() }
+	registryCacheSize.Set(float64(blobs.Size()))
+
+	m := &registryCache{
+		cfg:      cfg,
+		blobs:    blobs,
+		upstream: newRegistryClient(),
+		index: registryCacheIndex{
+			Tags:       map[string]digest.Digest{},
+			MediaTypes: map[digest.Digest]string{},
+		},
+	}
+
+	data, err := ioutil.ReadFile(m.indexPath())
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &m.index); err != nil {
+			return nil, fmt.Errorf("failed to decode registry cache index: %v", err)
+		}
+	case os.IsNotExist(err):
+	default:
+		return nil, fmt.Errorf("failed to read registry cache index: %v", err)
+	}
+
+	return m, nil
+}
+
+func (m *registryCache) indexPath() string {
+	return filepath.Join(m.cfg.Path, "index.json")
+}
+
+// Serve serves the cache and periodically pre-warms configured images until
+// the context is canceled.
+func (m *registryCache) Serve(ctx context.Context) error {
+	listener, err := net.Listen("tcp", m.cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen for registry cache: %v", err)
+	}
+
+	server := &http.Server{Handler: m}
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	go m.prewarmRoutine(ctx)
+
+	log.G(ctx).Info("serving registry cache", zap.Stringer("address", listener.Addr()))
+	if err := server.Serve(listener); err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+func (m *registryCache) Mirror(ref reference.Reference) (reference.Reference, bool) {
+	named, ok := ref.(reference.Named)
+	if !ok {
+		return nil, false
+	}
+
+	// Domains with ports are not valid repository name components.
+	domain := reference.Domain(named)
+	if strings.Contains(domain, ":") {
+		return nil, false
+	}
+
+	name := m.cfg.Endpoint + "/" + domain + "/" + reference.Path(named)
+	switch ref := ref.(type) {
+	case reference.Digested:
+		name += "@" + ref.Digest().String()
+	case reference.Tagged:
+		name += ":" + ref.Tag()
+	}
+
+	mirrored, err := reference.ParseAnyReference(name)
+	if err != nil {
+		return nil, false
+	}
+
+	return mirrored, true
+}
+
+func (m *registryCache) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Docker-Distribution-API-Version", "registry/2.0")
+
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if r.URL.Path == "/v2/" || r.URL.Path == "/v2" {
+		rw.WriteHeader(http.StatusOK)
+		return
+	}
+
+	for _, kind := range []string{"/manifests/", "/blobs/"} {
+		idx := strings.LastIndex(r.URL.Path, kind)
+		if idx < 0 || !strings.HasPrefix(r.URL.Path, "/v2/") {
+			continue
+		}
+
+		upstream, err := reference.ParseNamed(r.URL.Path[len("/v2/"):idx])
+		if err != nil {
+			http.Error(rw, fmt.Sprintf("invalid repository name: %v", err), http.StatusNotFound)
+			return
+		}
+
+		tagOrDigest := r.URL.Path[idx+len(kind):]
+		if kind == "/blobs/" {
+			m.serveBlob(rw, r, upstream, tagOrDigest)
+		} else {
+			m.serveManifest(rw, r, upstream, tagOrDigest)
+		}
+		return
+	}
+
+	rw.WriteHeader(http.StatusNotFound)
+}
+
+func (m *registryCache) serveManifest(rw http.ResponseWriter, r *http.Request, upstream reference.Named, tagOrDigest string) {
+	ctx := r.Context()
+	accept := strings.Join(r.Header["Accept"], ", ")
+
+	data, mediaType, dgst, err := m.manifest(ctx, upstream, tagOrDigest, accept, true)
+	if err != nil {
+		log.G(ctx).Warn("failed to serve manifest", zap.String("name", upstream.Name()), zap.String("reference", tagOrDigest), zap.Error(err))
+		writeRegistryError(rw, err)
+		return
+	}
+
+	rw.Header().Set("Content-Type", mediaType)
+	rw.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	rw.Header().Set("Docker-Content-Digest", dgst.String())
+	rw.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		rw.Write(data)
+	}
+}
+
+// manifest returns the manifest by its digest or tag. Manifests requested
+// by digest are served from the cache when possible, while tags are always
+// resolved using the upstream registry, falling back to the last known
+// digest if it is unavailable.
+func (m *registryCache) manifest(ctx context.Context, upstream reference.Named, tagOrDigest string, accept string, account bool) ([]byte, string, digest.Digest, error) {
+	dgst, err := digest.Parse(tagOrDigest)
+	isDigest := err == nil
+
+	if isDigest {
+		if data, mediaType, ok := m.cachedManifest(dgst); ok {
+			m.account(account, "manifest", "hit")
+			return data, mediaType, dgst, nil
+		}
+	}
+
+	data, mediaType, fetchErr := m.fetchManifest(ctx, upstream, tagOrDigest, accept)
+	if fetchErr == nil {
+		m.account(account, "manifest", "miss")
+
+		actual := digest.FromBytes(data)
+		if isDigest && actual != dgst {
+			return nil, "", "", fmt.Errorf("manifest content does not match digest %s", dgst)
+		}
+
+		if err := m.storeManifest(upstream, tagOrDigest, actual, data, mediaType, !isDigest); err != nil {
+			log.G(ctx).Warn("failed to cache manifest", zap.Error(err))
+		}
+
+		return data, mediaType, actual, nil
+	}
+
+	if isDigest || fetchErr == errRegistryNotFound {
+		return nil, "", "", fetchErr
+	}
+
+	m.mu.Lock()
+	dgst, ok := m.index.Tags[upstream.Name()+":"+tagOrDigest]
+	m.mu.Unlock()
+	if ok {
+		if data, mediaType, ok := m.cachedManifest(dgst); ok {
+			log.G(ctx).Warn("upstream registry is unavailable, serving the last known manifest",
+				zap.String("name", upstream.Name()), zap.String("tag", tagOrDigest), zap.Error(fetchErr))
+			m.account(account, "manifest", "stale")
+			return data, mediaType, dgst, nil
+		}
+	}
+
+	return nil, "", "", fetchErr
+}
+
+func (m *registryCache) account(account bool, kind, result string) {
+	if account {
+		registryCacheRequests.WithLabelValues(kind, result).Inc()
+	}
+}
+
+func (m *registryCache) cachedManifest(dgst digest.Digest) ([]byte, string, bool) {
+	m.mu.Lock()
+	mediaType, ok := m.index.MediaTypes[dgst]
+	m.mu.Unlock()
+	if !ok {
+		return nil, "", false
+	}
+
+	file, _, ok := m.blobs.Open(dgst)
+	if !ok {
+		return nil, "", false
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(file)
+	if err != nil {
+		return nil, "", false
+	}
+
+	return data, mediaType, true
+}
+
+func (m *registryCache) fetchManifest(ctx context.Context, upstream reference.Named, tagOrDigest string, accept string) ([]byte, string, error) {
+	if len(accept) == 0 {
+		accept = strings.Join([]string{ocispec.MediaTypeImageManifest, ocispec.MediaTypeImageIndex, mediaTypeDockerManifest, mediaTypeDockerList}, ", ")
+	}
+
+	response, err := m.upstream.fetch(ctx, http.MethodGet, upstream, "manifests", tagOrDigest, accept, "")
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	if response.ContentLength > maxManifestSize {
+		return nil, "", fmt.Errorf("manifest size %d exceeds %d bytes limit", response.ContentLength, maxManifestSize)
+	}
+
+	// Read one byte more to tell too large manifests apart from ones of
+	// exactly the maximum size.
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, maxManifestSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(data) > maxManifestSize {
+		return nil, "", fmt.Errorf("manifest size exceeds %d bytes limit", maxManifestSize)
+	}
+
+	if response.ContentLength >= 0 && int64(len(data)) != response.ContentLength {
+		return nil, "", fmt.Errorf("manifest size %d does not match Content-Length %d", len(data), response.ContentLength)
+	}
+
+	// Manifests fetched by tag can not be verified by the requested digest,
+	// so the one reported by the upstream is checked instead, if any.
+	if header := response.Header.Get("Docker-Content-Digest"); len(header) != 0 {
+		dgst, err := digest.Parse(header)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid manifest digest %q: %v", header, err)
+		}
+
+		if dgst.Algorithm().FromBytes(data) != dgst {
+			return nil, "", fmt.Errorf("manifest content does not match digest %s", dgst)
+		}
+	}
+
+	return data, response.Header.Get("Content-Type"), nil
+}
+
+func (m *registryCache) storeManifest(upstream reference.Named, tag string, dgst digest.Digest, data []byte, mediaType string, tagged bool) error {
+	writer, err := m.blobs.Create(dgst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := writer.Write(data); err != nil {
+		writer.Abort()
+		return err
+	}
+
+	if err := writer.Commit(); err != nil {
+		return err
+	}
+	registryCacheSize.Set(float64(m.blobs.Size()))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.index.MediaTypes[dgst] = mediaType
+	if tagged {
+		m.index.Tags[upstream.Name()+":"+tag] = dgst
+	}
+
+	for dgst := range m.index.MediaTypes {
+		if !m.blobs.Contains(dgst) {
+			delete(m.index.MediaTypes, dgst)
+		}
+	}
+
+	data, err = json.Marshal(m.index)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(m.indexPath(), data, 0600)
+}
+
+func (m *registryCache) serveBlob(rw http.ResponseWriter, r *http.Request, upstream reference.Named, rawDigest string) {
+	ctx := r.Context()
+
+	dgst, err := digest.Parse(rawDigest)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("invalid digest: %v", err), http.StatusNotFound)
+		return
+	}
+
+	rw.Header().Set("Content-Type", "application/octet-stream")
+	rw.Header().Set("Docker-Content-Digest", dgst.String())
+
+	if file, size, ok := m.blobs.Open(dgst); ok {
+		defer file.Close()
+
+		registryCacheRequests.WithLabelValues("blob", "hit").Inc()
+		rw.Header().Set("Content-Length", strconv.FormatUint(size, 10))
+		rw.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			io.Copy(rw, file)
+		}
+		return
+	}
+
+	registryCacheRequests.WithLabelValues("blob", "miss").Inc()
+
+	response, err := m.upstream.Blob(ctx, upstream, dgst, "")
+	if err != nil {
+		log.G(ctx).Warn("failed to fetch blob", zap.String("name", upstream.Name()), zap.Stringer("digest", dgst), zap.Error(err))
+		writeRegistryError(rw, err)
+		return
+	}
+	defer response.Body.Close()
+
+	writer, err := m.blobs.Create(dgst)
+	if err != nil {
+		http.Error(rw, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if response.ContentLength >= 0 {
+		rw.Header().Set("Content-Length", strconv.FormatInt(response.ContentLength, 10))
+	}
+	rw.WriteHeader(http.StatusOK)
+
+	var dst io.Writer = writer
+	if r.Method == http.MethodGet {
+		dst = io.MultiWriter(writer, rw)
+	}
+
+	if _, err := io.Copy(dst, response.Body); err != nil {
+		writer.Abort()
+		log.G(ctx).Warn("failed to transfer blob", zap.Stringer("digest", dgst), zap.Error(err))
+		return
+	}
+
+	if err := writer.Commit(); err != nil {
+		log.G(ctx).Warn("failed to cache blob", zap.Stringer("digest", dgst), zap.Error(err))
+	}
+	registryCacheSize.Set(float64(m.blobs.Size()))
+}
+
+// fetchBlob downloads the blob into the cache unless it is already there.
+func (m *registryCache) fetchBlob(ctx context.Context, upstream reference.Named, dgst digest.Digest) error {
+	if m.blobs.Contains(dgst) {
+		return nil
+	}
+
+	response, err := m.upstream.Blob(ctx, upstream, dgst, "")
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	writer, err := m.blobs.Create(dgst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(writer, response.Body); err != nil {
+		writer.Abort()
+		return err
+	}
+
+	if err := writer.Commit(); err != nil {
+		return err
+	}
+	registryCacheSize.Set(float64(m.blobs.Size()))
+
+	return nil
+}
+
+func (m *registryCache) prewarmRoutine(ctx context.Context) {
+	if len(m.cfg.Prewarm) == 0 {
+		return
+	}
+
+	ticker := util.NewImmediateTicker(m.cfg.PrewarmPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, image := range m.cfg.Prewarm {
+				if err := m.prewarm(ctx, image); err != nil {
+					log.G(ctx).Warn("failed to pre-warm image", zap.String("image", image), zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+// prewarm fetches the image for the current platform into the cache.
+func (m *registryCache) prewarm(ctx context.Context, image string) error {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return err
+	}
+
+	tagOrDigest := "latest"
+	switch ref := named.(type) {
+	case reference.Digested:
+		tagOrDigest = ref.Digest().String()
+	case reference.Tagged:
+		tagOrDigest = ref.Tag()
+	}
+
+	upstream := reference.TrimNamed(named)
+	data, _, _, err := m.manifest(ctx, upstream, tagOrDigest, "", false)
+	if err != nil {
+		return err
+	}
+
+	manifest := struct {
+		ocispec.Manifest
+		Manifests []ocispec.Descriptor `json:"manifests"`
+	}{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return fmt.Errorf("failed to decode manifest: %v", err)
+	}
+
+	if len(manifest.Manifests) != 0 {
+		var platformDigest digest.Digest
+		for _, descriptor := range manifest.Manifests {
+			if descriptor.Platform != nil && descriptor.Platform.OS == "linux" && descriptor.Platform.Architecture == runtime.GOARCH {
+				platformDigest = descriptor.Digest
+				break
+			}
+		}
+
+		if len(platformDigest) == 0 {
+			return fmt.Errorf("no manifest found for linux/%s", runtime.GOARCH)
+		}
+
+		data, _, _, err = m.manifest(ctx, upstream, platformDigest.String(), "", false)
+		if err != nil {
+			return err
+		}
+
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			return fmt.Errorf("failed to decode manifest: %v", err)
+		}
+	}
+
+	for _, descriptor := range append([]ocispec.Descriptor{manifest.Config}, manifest.Layers...) {
+		if err := m.fetchBlob(ctx, upstream, descriptor.Digest); err != nil {
+			return fmt.Errorf("failed to fetch blob %s: %v", descriptor.Digest, err)
+		}
+	}
+
+	log.G(ctx).Info("pre-warmed image", zap.String("image", image))
+	return nil
+}
+
+func writeRegistryError(rw http.ResponseWriter, err error) {
+	if err == errRegistryNotFound {
+		http.Error(rw, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	http.Error(rw, err.Error(), http.StatusBadGateway)
+}
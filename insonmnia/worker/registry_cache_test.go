@@ -0,0 +1,268 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testUpstream is a public registry stand-in serving a single
+// "sonm/app:latest" image.
+type testUpstream struct {
+	*httptest.Server
+	manifest []byte
+	// Digest overrides the digest reported for the manifest.
+	digest   digest.Digest
+	blobs    map[digest.Digest][]byte
+	requests int
+	down     bool
+}
+
+func newTestUpstream(layers ...string) *testUpstream {
+	m := &testUpstream{blobs: map[digest.Digest][]byte{}}
+
+	manifest := ocispec.Manifest{Config: m.addBlob("{}")}
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers, m.addBlob(layer))
+	}
+	m.manifest, _ = json.Marshal(manifest)
+
+	m.Server = httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		m.requests++
+		if m.down {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		const prefix = "/v2/sonm/app/"
+		switch {
+		case r.URL.Path == prefix+"manifests/latest" || r.URL.Path == prefix+"manifests/"+digest.FromBytes(m.manifest).String():
+			dgst := m.digest
+			if len(dgst) == 0 {
+				dgst = digest.FromBytes(m.manifest)
+			}
+			rw.Header().Set("Content-Type", ocispec.MediaTypeImageManifest)
+			rw.Header().Set("Docker-Content-Digest", dgst.String())
+			rw.Write(m.manifest)
+		case strings.HasPrefix(r.URL.Path, prefix+"blobs/"):
+			data, ok := m.blobs[digest.Digest(strings.TrimPrefix(r.URL.Path, prefix+"blobs/"))]
+			if !ok {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+			rw.Write(data)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return m
+}
+
+func (m *testUpstream) addBlob(data string) ocispec.Descriptor {
+	dgst := digest.FromString(data)
+	m.blobs[dgst] = []byte(data)
+
+	return ocispec.Descriptor{Digest: dgst, Size: int64(len(data))}
+}
+
+func (m *testUpstream) name() string {
+	return strings.TrimPrefix(m.URL, "https://") + "/sonm/app"
+}
+
+func newTestRegistryCache(t *testing.T, upstream *testUpstream, cfg RegistryCacheConfig) *registryCache {
+	dir, err := ioutil.TempDir("", "registry_cache")
+	require.NoError(t, err)
+
+	cfg.Endpoint = "127.0.0.1:15050"
+	cfg.Path = dir
+
+	cache, err := newRegistryCache(&cfg)
+	require.NoError(t, err)
+	cache.upstream.client = upstream.Client()
+
+	return cache
+}
+
+func get(t *testing.T, handler http.Handler, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return recorder
+}
+
+func TestRegistryCacheBlob(t *testing.T) {
+	upstream := newTestUpstream("layer")
+	defer upstream.Close()
+
+	cache := newTestRegistryCache(t, upstream, RegistryCacheConfig{})
+	defer os.RemoveAll(cache.cfg.Path)
+
+	dgst := digest.FromString("layer")
+	path := "/v2/" + upstream.name() + "/blobs/" + dgst.String()
+
+	response := get(t, cache, path)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "layer", response.Body.String())
+	assert.True(t, cache.blobs.Contains(dgst))
+
+	upstream.down = true
+	requests := upstream.requests
+
+	response = get(t, cache, path)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, "layer", response.Body.String())
+	assert.Equal(t, requests, upstream.requests)
+
+	response = get(t, cache, "/v2/"+upstream.name()+"/blobs/"+digest.FromString("unknown").String())
+	assert.Equal(t, http.StatusBadGateway, response.Code)
+}
+
+func TestRegistryCacheStaleTag(t *testing.T) {
+	upstream := newTestUpstream("layer")
+	defer upstream.Close()
+
+	cache := newTestRegistryCache(t, upstream, RegistryCacheConfig{})
+	defer os.RemoveAll(cache.cfg.Path)
+
+	path := "/v2/" + upstream.name() + "/manifests/latest"
+
+	response := get(t, cache, path)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, upstream.manifest, response.Body.Bytes())
+	assert.Equal(t, digest.FromBytes(upstream.manifest).String(), response.Header().Get("Docker-Content-Digest"))
+
+	upstream.down = true
+
+	// The index must survive restarts.
+	cache, err := newRegistryCache(cache.cfg)
+	require.NoError(t, err)
+	cache.upstream.client = upstream.Client()
+
+	response = get(t, cache, path)
+	require.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, upstream.manifest, response.Body.Bytes())
+	assert.Equal(t, ocispec.MediaTypeImageManifest, response.Header().Get("Content-Type"))
+}
+
+func TestRegistryCacheRejectsInvalidManifests(t *testing.T) {
+	upstream := newTestUpstream("layer")
+	defer upstream.Close()
+
+	cache := newTestRegistryCache(t, upstream, RegistryCacheConfig{})
+	defer os.RemoveAll(cache.cfg.Path)
+
+	path := "/v2/" + upstream.name() + "/manifests/latest"
+
+	upstream.digest = digest.FromString("tampered")
+	response := get(t, cache, path)
+	assert.Equal(t, http.StatusBadGateway, response.Code)
+
+	upstream.digest = ""
+	upstream.manifest = bytes.Repeat([]byte(" "), maxManifestSize+1)
+	response = get(t, cache, path)
+	assert.Equal(t, http.StatusBadGateway, response.Code)
+
+	// Nothing must be cached.
+	assert.Empty(t, cache.index.Tags)
+	assert.Empty(t, cache.index.MediaTypes)
+
+	// Manifests of exactly the maximum size are fine.
+	upstream.manifest = upstream.manifest[:maxManifestSize]
+	response = get(t, cache, path)
+	assert.Equal(t, http.StatusOK, response.Code)
+}
+
+func TestRegistryCachePrewarm(t *testing.T) {
+	upstream := newTestUpstream("layer0", "layer1")
+	defer upstream.Close()
+
+	cache := newTestRegistryCache(t, upstream, RegistryCacheConfig{})
+	defer os.RemoveAll(cache.cfg.Path)
+
+	require.NoError(t, cache.prewarm(context.Background(), upstream.name()))
+
+	for dgst := range upstream.blobs {
+		assert.True(t, cache.blobs.Contains(dgst))
+	}
+}
+
+func TestRegistryCacheEviction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "blob_cache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	blobs, err := newBlobCache(dir, 10)
+	require.NoError(t, err)
+
+	put := func(data string) digest.Digest {
+		dgst := digest.FromString(data)
+		writer, err := blobs.Create(dgst)
+		require.NoError(t, err)
+		writer.Write([]byte(data))
+		require.NoError(t, writer.Commit())
+		return dgst
+	}
+
+	first := put("1111")
+	second := put("2222")
+
+	file, _, ok := blobs.Open(first)
+	require.True(t, ok)
+	file.Close()
+
+	third := put("3333")
+	assert.True(t, blobs.Contains(first))
+	assert.False(t, blobs.Contains(second))
+	assert.True(t, blobs.Contains(third))
+	assert.Equal(t, uint64(8), blobs.Size())
+
+	writer, err := blobs.Create(digest.FromString("other"))
+	require.NoError(t, err)
+	writer.Write([]byte("data"))
+	assert.Error(t, writer.Commit())
+
+	blobs, err = newBlobCache(dir, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(8), blobs.Size())
+}
+
+func TestRegistryCacheMirror(t *testing.T) {
+	cache := &registryCache{cfg: &RegistryCacheConfig{Endpoint: "127.0.0.1:15050"}}
+
+	tests := []struct {
+		image    string
+		mirrored string
+	}{
+		{"nginx", "127.0.0.1:15050/docker.io/library/nginx"},
+		{"sonm/app:v1", "127.0.0.1:15050/docker.io/sonm/app:v1"},
+		{"quay.io/sonm/app@" + testImageDigest.String(), "127.0.0.1:15050/quay.io/sonm/app@" + testImageDigest.String()},
+		{"localhost:5000/sonm/app", ""},
+	}
+
+	for _, test := range tests {
+		ref, err := reference.ParseNormalizedNamed(test.image)
+		require.NoError(t, err)
+
+		mirrored, ok := cache.Mirror(ref)
+		if len(test.mirrored) == 0 {
+			assert.False(t, ok, test.image)
+			continue
+		}
+
+		require.True(t, ok, test.image)
+		assert.Equal(t, test.mirrored, mirrored.String())
+	}
+}
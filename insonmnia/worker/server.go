@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"reflect"
 	"strconv"
 	"sync"
@@ -40,6 +41,7 @@ import (
 	bm "github.com/sonm-io/core/insonmnia/benchmarks"
 	"github.com/sonm-io/core/insonmnia/hardware"
 	"github.com/sonm-io/core/insonmnia/resource"
+	"github.com/sonm-io/core/insonmnia/state"
 	"github.com/sonm-io/core/insonmnia/structs"
 	"github.com/sonm-io/core/insonmnia/worker/volume"
 	pb "github.com/sonm-io/core/proto"
@@ -54,6 +56,8 @@ import (
 const (
 	workerAPIPrefix = "/sonm.WorkerManagement/"
 	taskAPIPrefix   = "/sonm.Worker/"
+
+	maxPingReplySize = 1 << 20
 )
 
 var (
@@ -83,6 +87,7 @@ type Worker struct {
 	salesman  *salesman.Salesman
 
 	eventAuthorization *auth.AuthRouter
+	delegations        *delegationRegistry
 
 	// Maps StartRequest's IDs to containers' IDs
 	// TODO: It's doubtful that we should keep this map here instead in the Overseer.
@@ -146,11 +151,21 @@ func NewWorker(opts ...Option) (m *Worker, err error) {
 		return nil, err
 	}
 
+	if err := m.reconcilePublicPorts(); err != nil {
+		m.Close()
+		return nil, err
+	}
+
 	if err := m.setupServer(); err != nil {
 		m.Close()
 		return nil, err
 	}
 
+	if err := m.setupSSH(); err != nil {
+		m.Close()
+		return nil, err
+	}
+
 	return m, nil
 }
 
@@ -167,6 +182,7 @@ func (m *Worker) Serve() error {
 	if err != nil {
 		return err
 	}
+	relayListener.ACL = m.cfg.NPP.Relay.ACL.ACL()
 
 	listener, err := npp.NewListener(m.ctx, m.cfg.Endpoint,
 		npp.WithNPPBacklog(m.cfg.NPP.Backlog),
@@ -180,9 +196,42 @@ func (m *Worker) Serve() error {
 		return err
 	}
 	m.listener = listener
+	defer listener.Close()
+
+	udpListener, err := npp.NewUDPListener(m.ctx,
+		npp.WithNPPBacklog(m.cfg.NPP.Backlog),
+		npp.WithNPPBackoff(m.cfg.NPP.MinBackoffInterval, m.cfg.NPP.MaxBackoffInterval),
+		npp.WithRendezvous(m.cfg.NPP.Rendezvous, m.creds),
+		npp.WithRelayListener(relayListener),
+		npp.WithLogger(log.G(m.ctx)),
+	)
+	if err != nil {
+		log.G(m.ctx).Warn("UDP forwarding is disabled", zap.Error(err))
+	} else {
+		defer udpListener.Close()
+
+		go func() {
+			if err := newUDPForwarder(udpListener, m, m.ovs, log.G(m.ctx)).Serve(m.ctx); err != nil && err != context.Canceled {
+				log.G(m.ctx).Error("UDP forwarder has failed", zap.Error(err))
+			}
+		}()
+	}
 
-	log.G(m.ctx).Info("listening for gRPC API connections", zap.Stringer("address", listener.Addr()))
-	err = m.externalGrpc.Serve(listener)
+	// SSH sessions are tunnelled through the same listener as the gRPC API.
+	sshMux := newSSHMux(listener, log.G(m.ctx))
+	go sshMux.Serve()
+	go func() {
+		// Otherwise incoming SSH connections would block forever once the
+		// server stops accepting them.
+		defer sshMux.SSH().Close()
+
+		if err := m.ssh.Serve(sshMux.SSH()); err != nil {
+			log.G(m.ctx).Error("ssh server has failed", zap.Error(err))
+		}
+	}()
+
+	log.G(m.ctx).Info("listening for gRPC API and SSH connections", zap.Stringer("address", listener.Addr()))
+	err = m.externalGrpc.Serve(sshMux.Other())
 
 	return err
 }
@@ -254,6 +303,22 @@ func (m *Worker) setupAuthorization() error {
 
 	managementAuth := newAnyOfAuth(managementAuthOptions...)
 
+	delegations, err := newDelegationRegistry(state.NewKeyedStorage(delegationTokensKey, m.storage))
+	if err != nil {
+		return fmt.Errorf("failed to load delegation tokens: %v", err)
+	}
+
+	m.delegations = delegations
+
+	// Deal methods can be called either by the deal's consumer or by
+	// delegates, who have the consumer's delegation token.
+	dealAuth := func(method string, extractor DealExtractor) auth.Authorization {
+		return newAnyOfAuth(
+			newDealAuthorization(m.ctx, m, extractor),
+			newDelegationAuthorization(method, m, delegations, extractor),
+		)
+	}
+
 	authorization := auth.NewEventAuthorization(m.ctx,
 		auth.WithLog(log.G(m.ctx)),
 		// Note: need to refactor auth router to support multiple prefixes for methods.
@@ -261,23 +326,36 @@ func (m *Worker) setupAuthorization() error {
 		auth.Allow(workerManagementMethods...).With(managementAuth),
 		// everyone can get worker's status
 		auth.Allow(workerAPIPrefix+"Status").With(auth.NewNilAuthorization()),
+		// Throughput measurement replies are allowed only for the deal's
+		// consumer, otherwise anyone would be able to amplify traffic.
+		auth.Allow(workerAPIPrefix+"Ping").With(newPingAuthorization(newAnyOfAuth(
+			managementAuth,
+			dealAuth("Ping", newContextDealExtractor()),
+		))),
 		auth.Allow(taskAPIPrefix+"TaskStatus").With(newAnyOfAuth(
 			managementAuth,
-			newDealAuthorization(m.ctx, m, newFromTaskDealExtractor(m)),
+			dealAuth("TaskStatus", newFromTaskDealExtractor(m)),
 		)),
-		auth.Allow(taskAPIPrefix+"StopTask").With(newDealAuthorization(m.ctx, m, newFromTaskDealExtractor(m))),
-		auth.Allow(taskAPIPrefix+"JoinNetwork").With(newDealAuthorization(m.ctx, m, newFromNamedTaskDealExtractor(m, "TaskID"))),
-		auth.Allow(taskAPIPrefix+"StartTask").With(newDealAuthorization(m.ctx, m, newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
+		auth.Allow(taskAPIPrefix+"StopTask").With(dealAuth("StopTask", newFromTaskDealExtractor(m))),
+		auth.Allow(taskAPIPrefix+"JoinNetwork").With(dealAuth("JoinNetwork", newFromNamedTaskDealExtractor(m, "TaskID"))),
+		auth.Allow(taskAPIPrefix+"StartTask").With(dealAuth("StartTask", newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
 			return structs.DealID(request.(*pb.StartTaskRequest).GetDealID().Unwrap().String()), nil
 		}))),
-		auth.Allow(taskAPIPrefix+"TaskLogs").With(newDealAuthorization(m.ctx, m, newFromTaskDealExtractor(m))),
-		auth.Allow(taskAPIPrefix+"PushTask").With(newDealAuthorization(m.ctx, m, newContextDealExtractor())),
-		auth.Allow(taskAPIPrefix+"PullTask").With(newDealAuthorization(m.ctx, m, newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
+		auth.Allow(taskAPIPrefix+"TaskLogs").With(dealAuth("TaskLogs", newFromTaskDealExtractor(m))),
+		auth.Allow(taskAPIPrefix+"PushTask").With(dealAuth("PushTask", newContextDealExtractor())),
+		auth.Allow(taskAPIPrefix+"PullTask").With(dealAuth("PullTask", newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
 			return structs.DealID(request.(*pb.PullTaskRequest).DealId), nil
 		}))),
-		auth.Allow(taskAPIPrefix+"GetDealInfo").With(newDealAuthorization(m.ctx, m, newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
+		auth.Allow(taskAPIPrefix+"GetDealInfo").With(dealAuth("GetDealInfo", newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
 			return structs.DealID(request.(*pb.ID).GetId()), nil
 		}))),
+		// Delegation tokens are managed by the deal's consumer only.
+		auth.Allow(taskAPIPrefix+"DelegationTokens").With(newDealAuthorization(m.ctx, m, newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
+			return structs.DealID(request.(*pb.ID).GetId()), nil
+		}))),
+		auth.Allow(taskAPIPrefix+"RevokeDelegationToken").With(newDealAuthorization(m.ctx, m, newRequestDealExtractor(func(request interface{}) (structs.DealID, error) {
+			return structs.DealID(request.(*pb.RevokeDelegationTokenRequest).GetDealID().Unwrap().String()), nil
+		}))),
 		auth.WithFallback(auth.NewDenyAuthorization()),
 	)
 
@@ -321,6 +399,7 @@ func (m *Worker) setupHardware() error {
 	hardwareInfo.SetNetworkIncoming(m.publicIPs)
 	//TODO: configurable?
 	hardwareInfo.Network.NetFlags.SetOutbound(true)
+	hardwareInfo.Network.NetFlags.SetSandbox(m.isolation.Level() == pb.IsolationLevel_SANDBOX)
 	m.hardware = hardwareInfo
 	return nil
 }
@@ -362,6 +441,16 @@ func (m *Worker) cancelDealTasks(deal *pb.Deal) error {
 			result = multierror.Append(result, err)
 		}
 	}
+	if m.ports != nil {
+		if err := m.ports.Release(dealID); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	if m.delegations != nil {
+		if err := m.delegations.Forget(dealID); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
 	return result.ErrorOrNil()
 }
 
@@ -391,7 +480,10 @@ func (m *Worker) getContainerIdByTaskId(id string) (string, bool) {
 }
 
 func (m *Worker) Devices(ctx context.Context, request *pb.Empty) (*pb.DevicesReply, error) {
-	return m.hardware.IntoProto(), nil
+	reply := m.hardware.IntoProto()
+	reply.Isolation = m.isolation.Level()
+
+	return reply, nil
 }
 
 // Status returns internal worker statistic
@@ -429,7 +521,10 @@ func (m *Worker) FreeDevices(ctx context.Context, request *pb.Empty) (*pb.Device
 		return nil, err
 	}
 
-	return hardware.IntoProto(), nil
+	reply := hardware.IntoProto()
+	reply.Isolation = m.isolation.Level()
+
+	return reply, nil
 }
 
 func (m *Worker) setStatus(status *pb.TaskStatusReply, id string) {
@@ -444,6 +539,12 @@ func (m *Worker) setStatus(status *pb.TaskStatusReply, id string) {
 	m.containers[id].status = status.GetStatus()
 	if status.Status == pb.TaskStatusReply_BROKEN || status.Status == pb.TaskStatusReply_FINISHED {
 		m.resources.ReleaseTask(id)
+		if m.ports != nil {
+			m.ports.Unexpose(m.ctx, id)
+		}
+		if m.ingress != nil {
+			m.ingress.Unexpose(id)
+		}
 	}
 }
 
@@ -535,30 +636,53 @@ func (m *Worker) PullTask(request *pb.PullTaskRequest, stream pb.Worker_PullTask
 	return nil
 }
 
-func (m *Worker) taskAllowed(ctx context.Context, request *pb.StartTaskRequest) (bool, reference.Reference, error) {
+// egressPolicy returns the egress policy for tasks of the given ask plan,
+// which can only tighten the worker's one.
+func (m *Worker) egressPolicy(ask *pb.AskPlan) *pb.EgressPolicy {
+	return m.cfg.Egress.Merge(ask.GetEgress())
+}
+
+// consumerLevel returns the identity level of the deal's consumer.
+func (m *Worker) consumerLevel(ctx context.Context, dealID *pb.BigInt) (pb.IdentityLevel, error) {
+	deal, err := m.salesman.Deal(dealID)
+	if err != nil {
+		return pb.IdentityLevel_UNKNOWN, err
+	}
+
+	return m.eth.ProfileRegistry().GetProfileLevel(ctx, deal.GetConsumerID().Unwrap())
+}
+
+func (m *Worker) taskAllowed(ctx context.Context, request *pb.StartTaskRequest, level pb.IdentityLevel) (bool, reference.Reference, error) {
 	spec := request.GetSpec()
 	reference, err := reference.ParseAnyReference(spec.GetContainer().GetImage())
 	if err != nil {
 		return false, nil, fmt.Errorf("failed to parse reference: %s", err)
 	}
 
-	deal, err := m.salesman.Deal(request.GetDealID())
-	if err != nil {
-		return false, nil, err
-	}
-	level, err := m.eth.ProfileRegistry().GetProfileLevel(ctx, deal.GetConsumerID().Unwrap())
-	if err != nil {
-		return false, nil, err
-	}
 	if level <= pb.IdentityLevel_REGISTERED {
-		return m.whitelist.Allowed(ctx, reference, spec.GetRegistry().Auth())
+		ask, err := m.salesman.AskPlanByDeal(request.GetDealID())
+		if err != nil {
+			return false, nil, err
+		}
+
+		policy := ask.GetImagePolicy()
+		if policy == pb.AskPlan_DEFAULT {
+			policy = m.cfg.ImagePolicy.Policy
+		}
+
+		return newImagePolicyWhitelist(policy, m.whitelist, m.signatures).Allowed(ctx, reference, spec.GetRegistry().Auth())
 	}
 
 	return true, reference, nil
 }
 
 func (m *Worker) StartTask(ctx context.Context, request *pb.StartTaskRequest) (*pb.StartTaskReply, error) {
-	allowed, reference, err := m.taskAllowed(ctx, request)
+	level, err := m.consumerLevel(ctx, request.GetDealID())
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, reference, err := m.taskAllowed(ctx, request, level)
 	if err != nil {
 		return nil, err
 	}
@@ -580,6 +704,11 @@ func (m *Worker) StartTask(ctx context.Context, request *pb.StartTaskRequest) (*
 		return nil, err
 	}
 
+	isolation, err := m.isolation.Profile(ask, level)
+	if err != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "failed to isolate task: %v", err)
+	}
+
 	spec := request.GetSpec()
 	publicKey, err := parsePublicKey(spec.GetContainer().GetSshKey())
 	if err != nil {
@@ -649,6 +778,8 @@ func (m *Worker) StartTask(ctx context.Context, request *pb.StartTaskRequest) (*
 		GPUDevices:   gpuids,
 		mounts:       mounts,
 		networks:     networks,
+		isolation:    isolation,
+		egress:       m.egressPolicy(ask),
 	}
 
 	// TODO: Detect whether it's the first time allocation. If so - release resources on error.
@@ -680,36 +811,71 @@ func (m *Worker) StartTask(ctx context.Context, request *pb.StartTaskRequest) (*
 		PortMap:    make(map[string]*pb.Endpoints, 0),
 		NetworkIDs: containerInfo.NetworkIDs,
 	}
+	containerInfo.PublicPorts = nat.PortMap{}
 
-	for internalPort, portBindings := range containerInfo.Ports {
-		if len(portBindings) < 1 {
-			continue
+	var hosts map[nat.Port]string
+	if m.ingress != nil {
+		hosts, err = m.ingress.Expose(ctx, taskID, containerInfo.Ports)
+		if err != nil {
+			log.G(ctx).Error("failed to expose task through ingress", zap.Error(err))
+			m.ovs.Stop(ctx, containerInfo.ID)
+			m.setStatus(&pb.TaskStatusReply{Status: pb.TaskStatusReply_BROKEN}, taskID)
+			return nil, status.Errorf(codes.Internal, "failed to expose task through ingress: %v", err)
 		}
+	}
 
-		var socketAddrs []*pb.SocketAddr
-		var pubPortBindings []nat.PortBinding
-
-		for _, portBinding := range portBindings {
-			hostPort := portBinding.HostPort
-			hostPortInt, err := nat.ParsePort(hostPort)
-			if err != nil {
-				m.resources.ReleaseTask(taskID)
-				return nil, err
+	if m.ports != nil {
+		if err := m.exposePublicPorts(ctx, taskID, &containerInfo, &reply); err != nil {
+			log.G(ctx).Error("failed to expose public ports", zap.Error(err))
+			m.ovs.Stop(ctx, containerInfo.ID)
+			m.setStatus(&pb.TaskStatusReply{Status: pb.TaskStatusReply_BROKEN}, taskID)
+			return nil, status.Errorf(codes.Internal, "failed to expose public ports: %v", err)
+		}
+	} else {
+		for internalPort, portBindings := range containerInfo.Ports {
+			if len(portBindings) < 1 {
+				continue
 			}
 
-			for _, publicIP := range m.publicIPs {
-				socketAddrs = append(socketAddrs, &pb.SocketAddr{
-					Addr: publicIP,
-					Port: uint32(hostPortInt),
-				})
+			var socketAddrs []*pb.SocketAddr
+			var pubPortBindings []nat.PortBinding
+
+			for _, portBinding := range portBindings {
+				hostPort := portBinding.HostPort
+				hostPortInt, err := nat.ParsePort(hostPort)
+				if err != nil {
+					m.resources.ReleaseTask(taskID)
+					return nil, err
+				}
+
+				for _, publicIP := range m.publicIPs {
+					socketAddrs = append(socketAddrs, &pb.SocketAddr{
+						Addr: publicIP,
+						Port: uint32(hostPortInt),
+					})
 
-				pubPortBindings = append(pubPortBindings, nat.PortBinding{HostIP: publicIP, HostPort: hostPort})
+					pubPortBindings = append(pubPortBindings, nat.PortBinding{HostIP: publicIP, HostPort: hostPort})
+				}
 			}
+
+			containerInfo.PublicPorts[internalPort] = pubPortBindings
+
+			reply.PortMap[string(internalPort)] = &pb.Endpoints{Endpoints: socketAddrs}
 		}
+	}
 
-		containerInfo.Ports[internalPort] = pubPortBindings
+	if m.ingress != nil {
+		containerInfo.IngressHosts = hosts
+		containerInfo.IngressPort = m.ingress.Port()
+	}
 
-		reply.PortMap[string(internalPort)] = &pb.Endpoints{Endpoints: socketAddrs}
+	for internalPort, host := range hosts {
+		endpoints := reply.PortMap[string(internalPort)]
+		if endpoints == nil {
+			endpoints = &pb.Endpoints{}
+			reply.PortMap[string(internalPort)] = endpoints
+		}
+		endpoints.Endpoints = append(endpoints.Endpoints, &pb.SocketAddr{Addr: host, Port: uint32(m.ingress.Port())})
 	}
 
 	m.saveContainerInfo(taskID, containerInfo)
@@ -719,6 +885,36 @@ func (m *Worker) StartTask(ctx context.Context, request *pb.StartTaskRequest) (*
 	return &reply, nil
 }
 
+// exposePublicPorts routes the container's published ports through the
+// gateway, reporting stable public ports instead of randomly chosen host
+// ones.
+func (m *Worker) exposePublicPorts(ctx context.Context, taskID string, containerInfo *ContainerInfo, reply *pb.StartTaskReply) error {
+	ports, err := m.ports.Expose(ctx, containerInfo.DealID, taskID, containerInfo.Ports)
+	if err != nil {
+		return err
+	}
+
+	for internalPort, publicPort := range ports {
+		var socketAddrs []*pb.SocketAddr
+		var pubPortBindings []nat.PortBinding
+
+		for _, publicIP := range m.publicIPs {
+			socketAddrs = append(socketAddrs, &pb.SocketAddr{
+				Addr: publicIP,
+				Port: uint32(publicPort),
+			})
+
+			pubPortBindings = append(pubPortBindings, nat.PortBinding{HostIP: publicIP, HostPort: strconv.Itoa(int(publicPort))})
+		}
+
+		containerInfo.PublicPorts[internalPort] = pubPortBindings
+
+		reply.PortMap[string(internalPort)] = &pb.Endpoints{Endpoints: socketAddrs}
+	}
+
+	return nil
+}
+
 // Stop request forces to kill container
 func (m *Worker) StopTask(ctx context.Context, request *pb.ID) (*pb.Empty, error) {
 	m.mu.Lock()
@@ -846,10 +1042,6 @@ func (m *Worker) TaskStatus(ctx context.Context, req *pb.ID) (*pb.TaskStatusRepl
 	return reply, nil
 }
 
-func (m *Worker) RunSSH() error {
-	return m.ssh.Run()
-}
-
 // RunBenchmarks perform benchmarking of Worker's resources.
 func (m *Worker) runBenchmarks() error {
 	requiredBenchmarks := m.benchmarks.ByID()
@@ -893,6 +1085,27 @@ func (m *Worker) setupSalesman() error {
 	return nil
 }
 
+// reconcilePublicPorts releases public ports of deals, which are no longer
+// assigned to any ask plan.
+func (m *Worker) reconcilePublicPorts() error {
+	if m.ports == nil {
+		return nil
+	}
+
+	var dealIDs []string
+	for _, plan := range m.salesman.AskPlans() {
+		if !plan.GetDealID().IsZero() {
+			dealIDs = append(dealIDs, plan.GetDealID().Unwrap().String())
+		}
+	}
+
+	if err := m.ports.Reconcile(m.ctx, dealIDs); err != nil {
+		return fmt.Errorf("failed to reconcile public ports: %v", err)
+	}
+
+	return nil
+}
+
 func (m *Worker) setupServer() error {
 	logger := log.GetLogger(m.ctx)
 	grpcServer := xgrpc.NewServer(logger,
@@ -915,6 +1128,33 @@ func (m *Worker) setupServer() error {
 	return nil
 }
 
+func (m *Worker) setupSSH() error {
+	if m.ssh == nil {
+		ssh, err := NewSSH(m, m.cfg.SSH, m.key)
+		if err != nil {
+			return fmt.Errorf("cannot setup ssh server: %v", err)
+		}
+		m.ssh = ssh
+	}
+
+	if m.cfg.SSH != nil && len(m.cfg.SSH.BindEndpoint) != 0 {
+		listener, err := net.Listen("tcp", m.cfg.SSH.BindEndpoint)
+		if err != nil {
+			return fmt.Errorf("failed to listen for ssh: %v", err)
+		}
+
+		log.G(m.ctx).Info("listening for ssh connections", zap.Stringer("address", listener.Addr()))
+
+		go func() {
+			if err := m.ssh.Serve(listener); err != nil {
+				log.G(m.ctx).Error("ssh server has failed", zap.Error(err))
+			}
+		}()
+	}
+
+	return nil
+}
+
 type BenchmarkHasher interface {
 	// Hash of the hardware, empty string means that we need to rebenchmark everytime
 	HardwareHash() string
@@ -1212,6 +1452,13 @@ func (m *Worker) CreateAskPlan(ctx context.Context, request *pb.AskPlan) (*pb.ID
 	if request.GetCreateTime().Unix().UnixNano() != 0 || request.GetLastOrderPlacedTime().Unix().UnixNano() != 0 {
 		return nil, errors.New("creating ask plans with predefined timestamps is not supported")
 	}
+	if err := m.isolation.ValidateAskPlan(request); err != nil {
+		return nil, err
+	}
+	if err := request.GetEgress().Validate(); err != nil {
+		return nil, err
+	}
+
 	id, err := m.salesman.CreateAskPlan(request)
 	if err != nil {
 		return nil, err
@@ -1272,6 +1519,32 @@ func (m *Worker) GetDealInfo(ctx context.Context, id *pb.ID) (*pb.DealInfoReply,
 	return m.getDealInfo(dealID)
 }
 
+func (m *Worker) DelegationTokens(ctx context.Context, id *pb.ID) (*pb.DelegationTokensReply, error) {
+	dealID, err := pb.NewBigIntFromString(id.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := m.delegations.Tokens(dealID.Unwrap().String())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DelegationTokensReply{Tokens: tokens}, nil
+}
+
+func (m *Worker) RevokeDelegationToken(ctx context.Context, request *pb.RevokeDelegationTokenRequest) (*pb.Empty, error) {
+	if request.GetDealID().IsZero() {
+		return nil, status.Error(codes.InvalidArgument, "deal ID is required")
+	}
+
+	if err := m.delegations.Revoke(request.GetDealID().Unwrap().String(), request.GetId()); err != nil {
+		return nil, err
+	}
+
+	return &pb.Empty{}, nil
+}
+
 func (m *Worker) RemoveBenchmark(ctx context.Context, id *pb.NumericID) (*pb.Empty, error) {
 	err := m.dropCachedValue(id.Id)
 	if err != nil {
@@ -1291,6 +1564,14 @@ func (m *Worker) PurgeBenchmarks(ctx context.Context, _ *pb.Empty) (*pb.Empty, e
 	return &pb.Empty{}, multi.ErrorOrNil()
 }
 
+func (m *Worker) Ping(ctx context.Context, request *pb.PingRequest) (*pb.PingReply, error) {
+	if request.GetReplySize() > maxPingReplySize {
+		return nil, status.Errorf(codes.InvalidArgument, "reply size must not exceed %d bytes", maxPingReplySize)
+	}
+
+	return &pb.PingReply{Payload: make([]byte, request.GetReplySize())}, nil
+}
+
 func (m *Worker) getDealInfo(dealID *pb.BigInt) (*pb.DealInfoReply, error) {
 	deal, err := m.salesman.Deal(dealID)
 	if err != nil {
@@ -1366,4 +1647,7 @@ func (m *Worker) Close() {
 	if m.certRotator != nil {
 		m.certRotator.Close()
 	}
+	if m.gateway != nil {
+		m.gateway.Close()
+	}
 }
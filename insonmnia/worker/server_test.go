@@ -9,9 +9,11 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/docker/go-connections/nat"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	pb "github.com/sonm-io/core/proto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -82,3 +84,22 @@ func TestCollectTasksStatuses(t *testing.T) {
 	result4 := m.CollectTasksStatuses(pb.TaskStatusReply_RUNNING, pb.TaskStatusReply_SPOOLING, pb.TaskStatusReply_BROKEN)
 	assert.Equal(t, 5, len(result4))
 }
+
+func TestContainerInfoReportsPublicPorts(t *testing.T) {
+	info := &ContainerInfo{
+		Ports:        nat.PortMap{"80/tcp": {{HostIP: "0.0.0.0", HostPort: "32768"}}},
+		PublicPorts:  nat.PortMap{"80/tcp": {{HostIP: "1.2.3.4", HostPort: "40000"}}},
+		IngressHosts: map[nat.Port]string{"80/tcp": "task.worker.example.com"},
+		IngressPort:  8443,
+	}
+
+	reply := info.IntoProto(context.Background())
+	require.Contains(t, reply.GetPortMap(), "80/tcp")
+	assert.Equal(t, []*pb.SocketAddr{
+		{Addr: "1.2.3.4", Port: 40000},
+		{Addr: "task.worker.example.com", Port: 8443},
+	}, reply.GetPortMap()["80/tcp"].GetEndpoints())
+
+	// Ports published by Docker are kept intact.
+	assert.Equal(t, "32768", info.Ports["80/tcp"][0].HostPort)
+}
@@ -0,0 +1,176 @@
+package worker
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/docker/distribution/reference"
+	"github.com/ethereum/go-ethereum/common"
+	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/opencontainers/go-digest"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+type ProfileLevelSupplier interface {
+	GetProfileLevel(ctx context.Context, owner common.Address) (sonm.IdentityLevel, error)
+}
+
+// signatureWhitelist allows images signed by trusted publishers.
+type signatureWhitelist struct {
+	superusers      map[common.Address]struct{}
+	trustedKeys     map[common.Address]struct{}
+	trustedIdentity sonm.IdentityLevel
+	profiles        ProfileLevelSupplier
+	registry        *registryClient
+}
+
+func NewSignatureWhitelist(config *SignatureConfig, privilegedAddresses []string, profiles ProfileLevelSupplier) Whitelist {
+	m := &signatureWhitelist{
+		superusers:      map[common.Address]struct{}{},
+		trustedKeys:     map[common.Address]struct{}{},
+		trustedIdentity: config.TrustedIdentity,
+		profiles:        profiles,
+		registry:        newRegistryClient(),
+	}
+
+	for _, su := range privilegedAddresses {
+		m.superusers[common.HexToAddress(su)] = struct{}{}
+	}
+
+	for _, key := range config.TrustedKeys {
+		m.trustedKeys[key] = struct{}{}
+	}
+
+	return m
+}
+
+func (m *signatureWhitelist) Allowed(ctx context.Context, ref reference.Reference, authority string) (bool, reference.Reference, error) {
+	wallet, err := auth.ExtractWalletFromContext(ctx)
+	if err != nil {
+		log.G(ctx).Warn("could not extract wallet from context", zap.Error(err))
+		return false, nil, err
+	}
+
+	if _, ok := m.superusers[*wallet]; ok {
+		return true, ref, nil
+	}
+
+	named, ok := ref.(reference.Named)
+	if !ok {
+		return false, nil, errors.New("can not verify signature for unnamed reference")
+	}
+
+	var imageDigest digest.Digest
+	if digested, ok := ref.(reference.Digested); ok {
+		imageDigest = digested.Digest()
+	} else {
+		imageDigest, err = m.registry.ManifestDigest(ctx, named, authority)
+		if err != nil {
+			return false, nil, fmt.Errorf("could not resolve digest for %s: %v", ref.String(), err)
+		}
+
+		ref, err = reference.WithDigest(named, imageDigest)
+		if err != nil {
+			return false, nil, err
+		}
+	}
+
+	manifest, err := m.registry.Manifest(ctx, named, auth.ImageSignatureTag(imageDigest.String()), authority)
+	if err == errRegistryNotFound {
+		return false, ref, nil
+	}
+	if err != nil {
+		return false, nil, fmt.Errorf("could not fetch signatures for %s: %v", ref.String(), err)
+	}
+
+	for _, layer := range manifest.Layers {
+		sign, err := hex.DecodeString(layer.Annotations[auth.ImageSignatureAnnotation])
+		if err != nil || len(sign) == 0 {
+			continue
+		}
+
+		signer, err := auth.RecoverImageSigner(imageDigest.String(), sign)
+		if err != nil {
+			log.G(ctx).Debug("skipping invalid image signature", zap.Error(err))
+			continue
+		}
+
+		trusted, err := m.trusted(ctx, signer)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if trusted {
+			log.G(ctx).Info("image signature verified", zap.Stringer("image", ref), zap.Stringer("publisher", signer))
+			return true, ref, nil
+		}
+	}
+
+	return false, ref, nil
+}
+
+func (m *signatureWhitelist) trusted(ctx context.Context, publisher common.Address) (bool, error) {
+	if _, ok := m.trustedKeys[publisher]; ok {
+		return true, nil
+	}
+
+	if m.trustedIdentity == sonm.IdentityLevel_UNKNOWN || m.profiles == nil {
+		return false, nil
+	}
+
+	level, err := m.profiles.GetProfileLevel(ctx, publisher)
+	if err != nil {
+		return false, fmt.Errorf("could not get profile level of publisher %s: %v", publisher.Hex(), err)
+	}
+
+	return level >= m.trustedIdentity, nil
+}
+
+// imagePolicyWhitelist combines the digest whitelist and signatures
+// according to the policy.
+type imagePolicyWhitelist struct {
+	policy     sonm.AskPlan_ImagePolicy
+	whitelist  Whitelist
+	signatures Whitelist
+}
+
+func newImagePolicyWhitelist(policy sonm.AskPlan_ImagePolicy, whitelist, signatures Whitelist) Whitelist {
+	return &imagePolicyWhitelist{
+		policy:     policy,
+		whitelist:  whitelist,
+		signatures: signatures,
+	}
+}
+
+func (m *imagePolicyWhitelist) Allowed(ctx context.Context, ref reference.Reference, authority string) (bool, reference.Reference, error) {
+	switch m.policy {
+	case sonm.AskPlan_DEFAULT, sonm.AskPlan_WHITELIST:
+		return m.whitelist.Allowed(ctx, ref, authority)
+	case sonm.AskPlan_SIGNATURE:
+		return m.signatures.Allowed(ctx, ref, authority)
+	case sonm.AskPlan_WHITELIST_OR_SIGNATURE:
+		allowed, digestedRef, err := m.whitelist.Allowed(ctx, ref, authority)
+		if err == nil && allowed {
+			return true, digestedRef, nil
+		}
+		if err != nil {
+			log.G(ctx).Debug("whitelist check failed, falling back to signatures", zap.Error(err))
+		}
+
+		return m.signatures.Allowed(ctx, ref, authority)
+	case sonm.AskPlan_WHITELIST_AND_SIGNATURE:
+		allowed, digestedRef, err := m.whitelist.Allowed(ctx, ref, authority)
+		if err != nil || !allowed {
+			return false, digestedRef, err
+		}
+
+		// Verify exactly the same digest the whitelist has allowed.
+		return m.signatures.Allowed(ctx, digestedRef, authority)
+	default:
+		return false, nil, fmt.Errorf("unknown image policy: %s", m.policy)
+	}
+}
@@ -0,0 +1,195 @@
+package worker
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution/reference"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testImageDigest = digest.Digest("sha256:b5f9a9e47fa319607ed339789ef6692d4937ae5910b86e0ab929d035849e491e")
+)
+
+// testRegistry is a local registry stand-in serving a single "sonm/app"
+// repository protected by token authentication.
+type testRegistry struct {
+	*httptest.Server
+	signatures []string
+}
+
+func newTestRegistry(t *testing.T) *testRegistry {
+	m := &testRegistry{}
+	m.Server = httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			username, password, ok := r.BasicAuth()
+			if !ok || username != "user" || password != "password" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			assert.Equal(t, "repository:sonm/app:pull", r.URL.Query().Get("scope"))
+			json.NewEncoder(rw).Encode(map[string]string{"token": "secret"})
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			rw.Header().Set("WWW-Authenticate", `Bearer realm="`+m.URL+`/token",service="registry",scope="repository:sonm/app:pull"`)
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v2/sonm/app/manifests/latest":
+			rw.Header().Set("Docker-Content-Digest", testImageDigest.String())
+			rw.WriteHeader(http.StatusOK)
+		case "/v2/sonm/app/manifests/" + auth.ImageSignatureTag(testImageDigest.String()):
+			if len(m.signatures) == 0 {
+				rw.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			manifest := ocispec.Manifest{}
+			for _, signature := range m.signatures {
+				manifest.Layers = append(manifest.Layers, ocispec.Descriptor{
+					Annotations: map[string]string{auth.ImageSignatureAnnotation: signature},
+				})
+			}
+			json.NewEncoder(rw).Encode(manifest)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	return m
+}
+
+func (m *testRegistry) sign(t *testing.T) common.Address {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	sign, err := auth.SignImage(key, testImageDigest.String())
+	require.NoError(t, err)
+
+	m.signatures = append(m.signatures, hex.EncodeToString(sign))
+	return crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func (m *testRegistry) ref(t *testing.T) reference.Reference {
+	ref, err := reference.ParseAnyReference(strings.TrimPrefix(m.URL, "https://") + "/sonm/app:latest")
+	require.NoError(t, err)
+
+	return ref
+}
+
+func (m *testRegistry) authority() string {
+	return (&sonm.Registry{Username: "user", Password: "password"}).Auth()
+}
+
+func newTestSignatureWhitelist(registry *testRegistry, config *SignatureConfig, profiles ProfileLevelSupplier) *signatureWhitelist {
+	whitelist := NewSignatureWhitelist(config, nil, profiles).(*signatureWhitelist)
+	whitelist.registry.client = registry.Client()
+
+	return whitelist
+}
+
+type testProfiles map[common.Address]sonm.IdentityLevel
+
+func (m testProfiles) GetProfileLevel(ctx context.Context, owner common.Address) (sonm.IdentityLevel, error) {
+	return m[owner], nil
+}
+
+func TestSignatureWhitelistTrustedKey(t *testing.T) {
+	registry := newTestRegistry(t)
+	defer registry.Close()
+
+	registry.sign(t)
+	publisher := registry.sign(t)
+
+	whitelist := newTestSignatureWhitelist(registry, &SignatureConfig{TrustedKeys: []common.Address{publisher}}, nil)
+
+	allowed, ref, err := whitelist.Allowed(walletCtx(common.Address{}), registry.ref(t), registry.authority())
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, testImageDigest, ref.(reference.Digested).Digest())
+}
+
+func TestSignatureWhitelistTrustedIdentity(t *testing.T) {
+	registry := newTestRegistry(t)
+	defer registry.Close()
+
+	publisher := registry.sign(t)
+	profiles := testProfiles{publisher: sonm.IdentityLevel_REGISTERED}
+	config := &SignatureConfig{TrustedIdentity: sonm.IdentityLevel_IDENTIFIED}
+
+	allowed, _, err := newTestSignatureWhitelist(registry, config, profiles).Allowed(walletCtx(common.Address{}), registry.ref(t), registry.authority())
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	profiles[publisher] = sonm.IdentityLevel_IDENTIFIED
+	allowed, _, err = newTestSignatureWhitelist(registry, config, profiles).Allowed(walletCtx(common.Address{}), registry.ref(t), registry.authority())
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
+
+func TestSignatureWhitelistUnsigned(t *testing.T) {
+	registry := newTestRegistry(t)
+	defer registry.Close()
+
+	whitelist := newTestSignatureWhitelist(registry, &SignatureConfig{TrustedKeys: []common.Address{addr}}, nil)
+
+	allowed, _, err := whitelist.Allowed(walletCtx(common.Address{}), registry.ref(t), registry.authority())
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	_, _, err = whitelist.Allowed(walletCtx(common.Address{}), registry.ref(t), "")
+	require.Error(t, err)
+}
+
+type staticWhitelist bool
+
+func (m staticWhitelist) Allowed(ctx context.Context, ref reference.Reference, authority string) (bool, reference.Reference, error) {
+	return bool(m), ref, nil
+}
+
+func TestImagePolicyWhitelist(t *testing.T) {
+	ref, err := reference.ParseAnyReference("sonm/app")
+	require.NoError(t, err)
+
+	tests := []struct {
+		policy     sonm.AskPlan_ImagePolicy
+		whitelist  bool
+		signatures bool
+		allowed    bool
+	}{
+		{sonm.AskPlan_DEFAULT, true, false, true},
+		{sonm.AskPlan_WHITELIST, false, true, false},
+		{sonm.AskPlan_SIGNATURE, false, true, true},
+		{sonm.AskPlan_SIGNATURE, true, false, false},
+		{sonm.AskPlan_WHITELIST_OR_SIGNATURE, false, true, true},
+		{sonm.AskPlan_WHITELIST_OR_SIGNATURE, false, false, false},
+		{sonm.AskPlan_WHITELIST_AND_SIGNATURE, true, false, false},
+		{sonm.AskPlan_WHITELIST_AND_SIGNATURE, true, true, true},
+	}
+
+	for _, test := range tests {
+		policy := newImagePolicyWhitelist(test.policy, staticWhitelist(test.whitelist), staticWhitelist(test.signatures))
+
+		allowed, _, err := policy.Allowed(context.Background(), ref, "")
+		require.NoError(t, err)
+		assert.Equal(t, test.allowed, allowed, "%s: whitelist %v, signatures %v", test.policy, test.whitelist, test.signatures)
+	}
+}
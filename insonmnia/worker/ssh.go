@@ -1,68 +1,98 @@
 package worker
 
 import (
+	"crypto/ecdsa"
+	"fmt"
 	"io"
 	"io/ioutil"
 	"net"
+	"strconv"
 
 	"github.com/docker/docker/pkg/stdcopy"
 	"github.com/gliderlabs/ssh"
 	log "github.com/noxiouz/zapctx/ctxlog"
+	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
 	gossh "golang.org/x/crypto/ssh"
 )
 
+const (
+	// sftpServerCommand starts the SFTP server shipped with the task image,
+	// looking for it in locations used by popular distributions.
+	sftpServerCommand = `for path in /usr/lib/openssh/sftp-server /usr/lib/ssh/sftp-server /usr/libexec/openssh/sftp-server /usr/libexec/sftp-server; do
+	if [ -x "$path" ]; then exec "$path"; fi
+done
+echo "sftp-server is not found in the task image" >&2
+exit 127`
+)
+
+// SSH provides SSH access to tasks.
+//
+// Users are authenticated as task IDs using keys specified when starting
+// the tasks.
 type SSH interface {
-	Run() error
+	// Serve accepts SSH connections on the given listener until it is
+	// closed.
+	Serve(listener net.Listener) error
 	Close()
 }
 
-type nilSSH struct{}
-
-func (nilSSH) Run() error {
-	return nil
-}
-
-func (nilSSH) Close() {}
-
 type sshServer struct {
-	worker         *Worker
-	laddr          string
-	privateKeyPath string
-	listener       net.Listener
-	server         *ssh.Server
+	worker *Worker
+	server *ssh.Server
 }
 
-func NewSSH(worker *Worker, config *SSHConfig) (SSH, error) {
-	ret := sshServer{
-		laddr:          config.BindEndpoint,
-		privateKeyPath: config.PrivateKeyPath,
-		worker:         worker,
-	}
-	return &ret, nil
-}
+// NewSSH constructs a new SSH server for tasks of the given worker.
+//
+// The host key is read from the configured path if any, otherwise it is
+// derived from the worker's Ethereum key.
+func NewSSH(worker *Worker, cfg *SSHConfig, key *ecdsa.PrivateKey) (SSH, error) {
+	var signer gossh.Signer
+	if cfg != nil && len(cfg.PrivateKeyPath) != 0 {
+		data, err := ioutil.ReadFile(cfg.PrivateKeyPath)
+		if err != nil {
+			return nil, err
+		}
 
-func (s *sshServer) Run() error {
-	l, err := net.Listen("tcp", s.laddr)
-	if err != nil {
-		return err
-	}
-	s.listener = l
-	s.server = &ssh.Server{}
-	if len(s.privateKeyPath) != 0 {
-		pkeyData, err := ioutil.ReadFile(s.privateKeyPath)
+		signer, err = gossh.ParsePrivateKey(data)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		pkey, err := gossh.ParsePrivateKey(pkeyData)
+	} else {
+		var err error
+		signer, err = util.NewSSHHostSigner(key)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("failed to derive SSH host key: %v", err)
 		}
-		s.server.HostSigners = append(s.server.HostSigners, pkey)
 	}
-	s.server.Handle(s.onSession)
-	s.server.PublicKeyHandler = s.verify
-	return s.server.Serve(s.listener)
+
+	m := &sshServer{
+		worker: worker,
+	}
+
+	m.server = &ssh.Server{
+		Handler:          m.onSession,
+		PublicKeyHandler: m.verify,
+		HostSigners:      []ssh.Signer{signer},
+		ChannelHandlers: map[string]ssh.ChannelHandler{
+			"session":      ssh.DefaultSessionHandler,
+			"direct-tcpip": m.onDirectTCPIP,
+		},
+		SubsystemHandlers: map[string]ssh.SubsystemHandler{
+			"sftp": m.onSFTP,
+		},
+	}
+
+	return m, nil
+}
+
+func (s *sshServer) Serve(listener net.Listener) error {
+	err := s.server.Serve(listener)
+	if err == ssh.ErrServerClosed {
+		return nil
+	}
+
+	return err
 }
 
 func (s *sshServer) verify(ctx ssh.Context, key ssh.PublicKey) bool {
@@ -70,24 +100,32 @@ func (s *sshServer) verify(ctx ssh.Context, key ssh.PublicKey) bool {
 	if !ok {
 		return false
 	}
-	log.G(s.worker.ctx).Info("verifying public key")
+	log.G(s.worker.ctx).Info("verifying public key", zap.String("task_id", ctx.User()))
 	return ssh.KeysEqual(cinfo.PublicKey, key)
 }
 
 func (s *sshServer) onSession(session ssh.Session) {
-	status := s.process(session)
+	cmd := session.Command()
+	if len(cmd) == 0 {
+		cmd = append(cmd, "login", "-f", "root")
+	}
+
+	s.exit(session, s.process(session, cmd))
+}
+
+func (s *sshServer) onSFTP(session ssh.Session) {
+	s.exit(session, s.process(session, []string{"sh", "-c", sftpServerCommand}))
+}
+
+func (s *sshServer) exit(session ssh.Session, status int) {
 	session.Exit(status)
-	log.G(s.worker.ctx).Info("finished processing ssh session", zap.Int("status", status))
+	log.G(s.worker.ctx).Info("finished processing ssh session", zap.String("task_id", session.User()), zap.Int("status", status))
 }
 
-func (s *sshServer) process(session ssh.Session) (status int) {
+func (s *sshServer) process(session ssh.Session, cmd []string) (status int) {
 	status = 255
 	_, wCh, isTty := session.Pty()
 
-	cmd := session.Command()
-	if len(cmd) == 0 {
-		cmd = append(cmd, "login", "-f", "root")
-	}
 	cid, ok := s.worker.getContainerIdByTaskId(session.User())
 	if !ok {
 		msg := "could not find container by task " + string(session.User()+"\n")
@@ -119,7 +157,7 @@ func (s *sshServer) process(session ssh.Session) (status int) {
 	}()
 
 	err = <-outputErr
-	if err != nil {
+	if err == nil {
 		status = 0
 	} else {
 		log.G(s.worker.ctx).Warn("io error during ssh session:", zap.Error(err))
@@ -127,11 +165,70 @@ func (s *sshServer) process(session ssh.Session) (status int) {
 	return
 }
 
-func (s *sshServer) Close() {
-	if s.server != nil {
-		log.G(s.worker.ctx).Info("closing ssh server")
-		s.server.Close()
+// onDirectTCPIP forwards connections to ports bound on the loopback
+// interface of the task container, making "ssh -L" work.
+func (s *sshServer) onDirectTCPIP(srv *ssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx ssh.Context) {
+	// See RFC4254, section 7.2.
+	request := struct {
+		DestAddr   string
+		DestPort   uint32
+		OriginAddr string
+		OriginPort uint32
+	}{}
+
+	if err := gossh.Unmarshal(newChan.ExtraData(), &request); err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "failed to parse forward data: "+err.Error())
+		return
+	}
+
+	var host string
+	switch request.DestAddr {
+	case "", "localhost", "127.0.0.1":
+		host = "127.0.0.1"
+	case "::1":
+		host = "::1"
+	default:
+		newChan.Reject(gossh.Prohibited, "only forwarding to the task's loopback interface is allowed")
+		return
+	}
+
+	cid, ok := s.worker.getContainerIdByTaskId(ctx.User())
+	if !ok {
+		newChan.Reject(gossh.ConnectionFailed, "could not find container by task "+ctx.User())
+		return
+	}
+
+	addr := net.JoinHostPort(host, strconv.FormatUint(uint64(request.DestPort), 10))
+	dstConn, err := s.worker.ovs.Dial(ctx, cid, "tcp", addr)
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, err.Error())
+		return
+	}
+
+	channel, requests, err := newChan.Accept()
+	if err != nil {
+		dstConn.Close()
+		return
 	}
+	go gossh.DiscardRequests(requests)
+
+	log.G(s.worker.ctx).Info("forwarding ssh connection", zap.String("task_id", ctx.User()), zap.String("address", addr))
+
+	go func() {
+		defer channel.Close()
+		defer dstConn.Close()
+		io.Copy(channel, dstConn)
+	}()
+	go func() {
+		defer channel.Close()
+		defer dstConn.Close()
+		io.Copy(dstConn, channel)
+	}()
+}
+
+func (s *sshServer) Close() {
+	log.G(s.worker.ctx).Info("closing ssh server")
+	s.server.Close()
 }
 
 func parsePublicKey(key string) (ssh.PublicKey, error) {
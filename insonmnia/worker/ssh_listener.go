@@ -0,0 +1,165 @@
+package worker
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	sshDetectTimeout = 30 * time.Second
+)
+
+var (
+	errListenerClosed = errors.New("listener has been closed")
+	sshBanner         = []byte("SSH-")
+)
+
+// sshMux splits connections accepted by the listener into SSH and other
+// ones by peeking for the SSH protocol banner, allowing SSH sessions to be
+// tunnelled through NPP along with the gRPC API.
+//
+// This works because SSH clients send their banner first, while gRPC
+// clients start with the TLS handshake.
+type sshMux struct {
+	listener net.Listener
+	log      *zap.Logger
+	ssh      *chanListener
+	other    *chanListener
+}
+
+func newSSHMux(listener net.Listener, log *zap.Logger) *sshMux {
+	return &sshMux{
+		listener: listener,
+		log:      log,
+		ssh:      newChanListener(listener.Addr()),
+		other:    newChanListener(listener.Addr()),
+	}
+}
+
+// SSH returns the listener SSH connections are accepted from.
+func (m *sshMux) SSH() net.Listener {
+	return m.ssh
+}
+
+// Other returns the listener all other connections are accepted from.
+func (m *sshMux) Other() net.Listener {
+	return m.other
+}
+
+// Serve accepts connections from the underlying listener until it fails,
+// closing both resulting listeners after.
+func (m *sshMux) Serve() error {
+	defer m.ssh.Close()
+	defer m.other.Close()
+
+	for {
+		conn, err := m.listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go m.detect(conn)
+	}
+}
+
+func (m *sshMux) detect(conn net.Conn) {
+	isSSH, bufConn, err := detectSSH(conn, sshDetectTimeout)
+	if err != nil {
+		m.log.Warn("failed to detect connection protocol", zap.Stringer("remote", conn.RemoteAddr()), zap.Error(err))
+		conn.Close()
+		return
+	}
+
+	if isSSH {
+		m.ssh.push(bufConn)
+	} else {
+		m.other.push(bufConn)
+	}
+}
+
+func detectSSH(conn net.Conn, timeout time.Duration) (bool, net.Conn, error) {
+	rd := bufio.NewReader(conn)
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+	defer conn.SetReadDeadline(time.Time{})
+
+	// Peeking a single byte first is important, because other peers may
+	// send less than the banner length before waiting for reply.
+	head, err := rd.Peek(1)
+	if err != nil {
+		return false, nil, err
+	}
+
+	bufConn := &bufferedConn{Conn: conn, rd: rd}
+	if head[0] != sshBanner[0] {
+		return false, bufConn, nil
+	}
+
+	head, err = rd.Peek(len(sshBanner))
+	if err != nil {
+		return false, nil, err
+	}
+
+	return string(head) == string(sshBanner), bufConn, nil
+}
+
+// bufferedConn is a connection with some data already read into the
+// buffer.
+type bufferedConn struct {
+	net.Conn
+	rd *bufio.Reader
+}
+
+func (m *bufferedConn) Read(b []byte) (int, error) {
+	return m.rd.Read(b)
+}
+
+// chanListener is a listener accepting connections pushed into it.
+type chanListener struct {
+	addr      net.Addr
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newChanListener(addr net.Addr) *chanListener {
+	return &chanListener{
+		addr:   addr,
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+func (m *chanListener) push(conn net.Conn) {
+	select {
+	case m.conns <- conn:
+	case <-m.closed:
+		conn.Close()
+	}
+}
+
+func (m *chanListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-m.conns:
+		return conn, nil
+	case <-m.closed:
+		return nil, errListenerClosed
+	}
+}
+
+func (m *chanListener) Close() error {
+	m.closeOnce.Do(func() {
+		close(m.closed)
+	})
+
+	return nil
+}
+
+func (m *chanListener) Addr() net.Addr {
+	return m.addr
+}
@@ -0,0 +1,98 @@
+package worker
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func acceptData(t *testing.T, listener net.Listener) string {
+	conn, err := listener.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	data, err := ioutil.ReadAll(conn)
+	require.NoError(t, err)
+
+	return string(data)
+}
+
+func TestSSHMux(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	mux := newSSHMux(listener, zap.NewNop())
+	go mux.Serve()
+
+	send := func(data string) {
+		conn, err := net.Dial("tcp", listener.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte(data))
+		require.NoError(t, err)
+	}
+
+	send("SSH-2.0-OpenSSH_7.6\r\n")
+	assert.Equal(t, "SSH-2.0-OpenSSH_7.6\r\n", acceptData(t, mux.SSH()))
+
+	send("\x16\x03\x01")
+	assert.Equal(t, "\x16\x03\x01", acceptData(t, mux.Other()))
+
+	send("SSL3")
+	assert.Equal(t, "SSL3", acceptData(t, mux.Other()))
+
+	listener.Close()
+
+	_, err = mux.SSH().Accept()
+	assert.Error(t, err)
+	_, err = mux.Other().Accept()
+	assert.Error(t, err)
+}
+
+func TestSSHMuxClosedSSHListener(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	mux := newSSHMux(listener, zap.NewNop())
+	go mux.Serve()
+
+	mux.SSH().Close()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	_, err = conn.Write([]byte("SSH-2.0-OpenSSH_7.6\r\n"))
+	require.NoError(t, err)
+
+	// Connections pushed into the closed listener are dropped.
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	require.Error(t, err)
+	if netErr, ok := err.(net.Error); ok {
+		assert.False(t, netErr.Timeout())
+	}
+
+	// Other connections are still accepted.
+	other, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	_, err = other.Write([]byte("\x16\x03\x01"))
+	require.NoError(t, err)
+	other.Close()
+	assert.Equal(t, "\x16\x03\x01", acceptData(t, mux.Other()))
+}
+
+func TestDetectSSHTimeout(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	_, _, err := detectSSH(server, 10*time.Millisecond)
+	assert.Error(t, err)
+}
@@ -0,0 +1,131 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/sonm-io/core/util/xnet"
+	"go.uber.org/zap"
+)
+
+const (
+	udpForwardHandshakeTimeout = 30 * time.Second
+	udpForwardIdleTimeout      = 2 * time.Minute
+)
+
+type udpForwardListener interface {
+	Accept() (net.Conn, error)
+}
+
+type udpForwardTasks interface {
+	GetContainerInfo(id string) (*ContainerInfo, bool)
+}
+
+type udpForwardDialer interface {
+	Dial(ctx context.Context, containerID, network, addr string) (net.Conn, error)
+}
+
+// udpForwarder forwards datagrams of peers connected using UDP NPP to
+// published UDP ports of tasks, making them reachable even if the worker is
+// behind NAT.
+//
+// The first datagram of each connection must be "<task_id>/<port>", which
+// is answered with either "OK" or an error message. Peers are not
+// authenticated, because published ports are meant to be reachable by
+// anyone.
+type udpForwarder struct {
+	listener udpForwardListener
+	tasks    udpForwardTasks
+	dialer   udpForwardDialer
+	log      *zap.Logger
+}
+
+func newUDPForwarder(listener udpForwardListener, tasks udpForwardTasks, dialer udpForwardDialer, log *zap.Logger) *udpForwarder {
+	return &udpForwarder{
+		listener: listener,
+		tasks:    tasks,
+		dialer:   dialer,
+		log:      log,
+	}
+}
+
+// Serve accepts connections until the listener fails.
+func (m *udpForwarder) Serve(ctx context.Context) error {
+	for {
+		conn, err := m.listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go m.forward(ctx, conn)
+	}
+}
+
+func (m *udpForwarder) forward(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	log := m.log.With(zap.Stringer("remote", conn.RemoteAddr()))
+
+	if err := conn.SetReadDeadline(time.Now().Add(udpForwardHandshakeTimeout)); err != nil {
+		return
+	}
+
+	buf := make([]byte, xnet.MaxDatagramSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		log.Warn("failed to read UDP forwarding request", zap.Error(err))
+		return
+	}
+
+	target, err := m.dial(ctx, string(buf[:n]))
+	xnet.ReplyDatagramHandshake(func(reply []byte) error {
+		_, err := conn.Write(reply)
+		return err
+	}, err)
+	if err != nil {
+		log.Warn("failed to forward UDP", zap.Error(err))
+		return
+	}
+
+	log.Info("forwarding UDP", zap.Stringer("target", target.RemoteAddr()))
+	defer log.Info("finished forwarding UDP")
+
+	xnet.PipeDatagrams(conn, target, udpForwardIdleTimeout)
+}
+
+func (m *udpForwarder) dial(ctx context.Context, request string) (net.Conn, error) {
+	taskID, port, err := parseUDPForwardRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	info, ok := m.tasks.GetContainerInfo(taskID)
+	if !ok {
+		return nil, fmt.Errorf("task %s not found", taskID)
+	}
+
+	if _, ok := info.Ports[port]; !ok {
+		return nil, fmt.Errorf("port %s is not published by task %s", port, taskID)
+	}
+
+	return m.dialer.Dial(ctx, info.ID, "udp", net.JoinHostPort("127.0.0.1", port.Port()))
+}
+
+func parseUDPForwardRequest(request string) (string, nat.Port, error) {
+	parts := strings.Split(request, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return "", "", fmt.Errorf("invalid UDP forwarding request: expected \"<task_id>/<port>\"")
+	}
+
+	port, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil || port == 0 {
+		return "", "", fmt.Errorf("invalid UDP port: %s", parts[1])
+	}
+
+	return parts[0], nat.Port(fmt.Sprintf("%d/udp", port)), nil
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/docker/go-connections/nat"
+	"github.com/sonm-io/core/util/xnet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testUDPForwardTasks map[string]*ContainerInfo
+
+func (m testUDPForwardTasks) GetContainerInfo(id string) (*ContainerInfo, bool) {
+	info, ok := m[id]
+	return info, ok
+}
+
+type testUDPForwardDialer struct {
+	addr string
+}
+
+func (m *testUDPForwardDialer) Dial(ctx context.Context, containerID, network, addr string) (net.Conn, error) {
+	return net.Dial(network, m.addr)
+}
+
+func TestParseUDPForwardRequest(t *testing.T) {
+	taskID, port, err := parseUDPForwardRequest("task/27015")
+	require.NoError(t, err)
+	assert.Equal(t, "task", taskID)
+	assert.Equal(t, nat.Port("27015/udp"), port)
+
+	for _, request := range []string{"task", "task/0", "task/70000", "/27015", "task/27015/udp"} {
+		_, _, err := parseUDPForwardRequest(request)
+		assert.Error(t, err, request)
+	}
+}
+
+func TestUDPForwarder(t *testing.T) {
+	echo, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer echo.Close()
+
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, addr, err := echo.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			echo.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	tasks := testUDPForwardTasks{
+		"task": {ID: "container", Ports: nat.PortMap{"27015/udp": {{HostIP: "0.0.0.0", HostPort: "32000"}}}},
+	}
+	forwarder := newUDPForwarder(nil, tasks, &testUDPForwardDialer{addr: echo.LocalAddr().String()}, zap.NewNop())
+
+	local, remote := net.Pipe()
+	defer local.Close()
+	go forwarder.forward(context.Background(), remote)
+
+	require.NoError(t, xnet.DatagramHandshake(local, "task/27015", time.Second))
+
+	_, err = local.Write([]byte("ping"))
+	require.NoError(t, err)
+
+	buf := make([]byte, 64)
+	n, err := local.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf[:n]))
+
+	for _, request := range []string{"other/27015", "task/27016"} {
+		local, remote := net.Pipe()
+		go forwarder.forward(context.Background(), remote)
+
+		assert.Error(t, xnet.DatagramHandshake(local, request, time.Second), request)
+		local.Close()
+	}
+}
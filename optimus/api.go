@@ -0,0 +1,285 @@
+package optimus
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/insonmnia/auth"
+	"github.com/sonm-io/core/proto"
+	"github.com/sonm-io/core/util"
+	"github.com/sonm-io/core/util/rest"
+	"github.com/sonm-io/core/util/xgrpc"
+	"github.com/sonm-io/core/util/xnet"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+type apiConfig struct {
+	PrivateKey privateKey `yaml:"ethereum" json:"-"`
+	// Endpoint is the gRPC endpoint, the API is disabled when empty.
+	Endpoint string `yaml:"endpoint"`
+	// RESTPort is the loopback port of the REST API, which is disabled when
+	// zero.
+	RESTPort uint16 `yaml:"rest_port"`
+}
+
+func (m *apiConfig) Validate() error {
+	if m.Endpoint != "" && m.PrivateKey.D == nil {
+		return fmt.Errorf("private key is required for the API")
+	}
+
+	return nil
+}
+
+// workerRegistry is a thread-safe set of worker engines, that are controlled
+// via the API.
+type workerRegistry struct {
+	mu      sync.Mutex
+	engines map[common.Address]*workerEngine
+}
+
+func newWorkerRegistry() *workerRegistry {
+	return &workerRegistry{
+		engines: map[common.Address]*workerEngine{},
+	}
+}
+
+func (m *workerRegistry) Add(engine *workerEngine) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.engines[engine.addr] = engine
+}
+
+func (m *workerRegistry) Remove(addr common.Address) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.engines, addr)
+}
+
+func (m *workerRegistry) Get(addr common.Address) (*workerEngine, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	engine, ok := m.engines[addr]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "worker %s is not managed", addr.Hex())
+	}
+
+	return engine, nil
+}
+
+// List returns all registered engines sorted by worker address.
+func (m *workerRegistry) List() []*workerEngine {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	engines := make([]*workerEngine, 0, len(m.engines))
+	for _, engine := range m.engines {
+		engines = append(engines, engine)
+	}
+
+	sort.Slice(engines, func(i, j int) bool {
+		return bytes.Compare(engines[i].addr.Bytes(), engines[j].addr.Bytes()) < 0
+	})
+
+	return engines
+}
+
+// optimusAPI implements control and introspection API for managed workers.
+type optimusAPI struct {
+	workers *workerRegistry
+	log     *zap.SugaredLogger
+}
+
+func newOptimusAPI(workers *workerRegistry, log *zap.SugaredLogger) *optimusAPI {
+	return &optimusAPI{
+		workers: workers,
+		log:     log,
+	}
+}
+
+func (m *optimusAPI) Workers(ctx context.Context, request *sonm.Empty) (*sonm.OptimusWorkersReply, error) {
+	engines := m.workers.List()
+
+	workers := make([]*sonm.OptimusWorker, 0, len(engines))
+	for _, engine := range engines {
+		workers = append(workers, engine.Status())
+	}
+
+	return &sonm.OptimusWorkersReply{
+		Workers: workers,
+	}, nil
+}
+
+func (m *optimusAPI) LastEpoch(ctx context.Context, request *sonm.EthAddress) (*sonm.OptimusEpochReport, error) {
+	engine, err := m.workers.Get(request.Unwrap())
+	if err != nil {
+		return nil, err
+	}
+
+	report := engine.LastEpoch()
+	if report == nil {
+		return nil, status.Errorf(codes.NotFound, "no optimization epoch has been performed yet for %s", request.Unwrap().Hex())
+	}
+
+	return report, nil
+}
+
+func (m *optimusAPI) Execute(ctx context.Context, request *sonm.EthAddress) (*sonm.Empty, error) {
+	engine, err := m.workers.Get(request.Unwrap())
+	if err != nil {
+		return nil, err
+	}
+
+	if engine.IsPaused() {
+		return nil, status.Errorf(codes.FailedPrecondition, "worker %s management is paused", request.Unwrap().Hex())
+	}
+
+	m.log.Infow("triggering optimization epoch", zap.Stringer("addr", request.Unwrap()))
+	engine.Trigger()
+
+	return &sonm.Empty{}, nil
+}
+
+func (m *optimusAPI) Pause(ctx context.Context, request *sonm.EthAddress) (*sonm.Empty, error) {
+	engine, err := m.workers.Get(request.Unwrap())
+	if err != nil {
+		return nil, err
+	}
+
+	m.log.Infow("pausing worker management", zap.Stringer("addr", request.Unwrap()))
+	engine.SetPaused(true)
+
+	return &sonm.Empty{}, nil
+}
+
+func (m *optimusAPI) Resume(ctx context.Context, request *sonm.EthAddress) (*sonm.Empty, error) {
+	engine, err := m.workers.Get(request.Unwrap())
+	if err != nil {
+		return nil, err
+	}
+
+	m.log.Infow("resuming worker management", zap.Stringer("addr", request.Unwrap()))
+	engine.SetPaused(false)
+
+	return &sonm.Empty{}, nil
+}
+
+func (m *optimusAPI) SetPriceThreshold(ctx context.Context, request *sonm.OptimusPriceThresholdRequest) (*sonm.Empty, error) {
+	engine, err := m.workers.Get(request.GetAddr().Unwrap())
+	if err != nil {
+		return nil, err
+	}
+
+	m.log.Infow("changing price threshold", zap.Stringer("addr", request.GetAddr().Unwrap()), zap.String("threshold", request.GetThreshold().GetPerSecond().ToPriceString()))
+	engine.SetPriceThreshold(request.GetThreshold())
+
+	return &sonm.Empty{}, nil
+}
+
+// apiServer exposes Optimus API via gRPC and optionally REST.
+//
+// The gRPC API is protected using TLS with ETH-based authentication and only
+// the owner of the API key is allowed to call it. The REST API has no
+// authentication and thus is bound to loopback interfaces only.
+type apiServer struct {
+	cfg apiConfig
+	api *optimusAPI
+	log *zap.SugaredLogger
+}
+
+func newAPIServer(cfg apiConfig, workers *workerRegistry, log *zap.SugaredLogger) *apiServer {
+	return &apiServer{
+		cfg: cfg,
+		api: newOptimusAPI(workers, log),
+		log: log,
+	}
+}
+
+func (m *apiServer) Serve(ctx context.Context) error {
+	privateKey := m.cfg.PrivateKey.Unwrap()
+
+	certificate, TLSConfig, err := util.NewHitlessCertRotator(ctx, privateKey)
+	if err != nil {
+		return err
+	}
+	defer certificate.Close()
+
+	serverGRPC := m.newServerGRPC(ctx, privateKey, util.NewTLS(TLSConfig))
+
+	var serverREST *rest.Server
+	var listenersREST []net.Listener
+	if m.cfg.RESTPort != 0 {
+		serverREST = rest.NewServer(rest.WithLog(m.log.Desugar()))
+		if err := serverREST.RegisterService((*sonm.OptimusServer)(nil), m.api); err != nil {
+			return err
+		}
+
+		listenersREST, err = xnet.ListenLoopback("tcp", m.cfg.RESTPort)
+		if err != nil {
+			return fmt.Errorf("failed to listen REST API: %v", err)
+		}
+	}
+
+	listener, err := net.Listen("tcp", m.cfg.Endpoint)
+	if err != nil {
+		for _, listener := range listenersREST {
+			listener.Close()
+		}
+		return fmt.Errorf("failed to listen gRPC API: %v", err)
+	}
+
+	wg, ctx := errgroup.WithContext(ctx)
+	wg.Go(func() error {
+		m.log.Infow("serving gRPC API", zap.Stringer("endpoint", listener.Addr()))
+		return serverGRPC.Serve(listener)
+	})
+
+	if serverREST != nil {
+		wg.Go(func() error {
+			return serverREST.Serve(listenersREST...)
+		})
+	}
+
+	<-ctx.Done()
+
+	serverGRPC.Stop()
+	if serverREST != nil {
+		serverREST.Close()
+	}
+
+	if err := wg.Wait(); err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+func (m *apiServer) newServerGRPC(ctx context.Context, privateKey *ecdsa.PrivateKey, credentials credentials.TransportCredentials) *grpc.Server {
+	authorization := auth.NewEventAuthorization(ctx,
+		auth.WithLog(m.log.Desugar()),
+		auth.WithFallback(auth.NewTransportAuthorization(crypto.PubkeyToAddress(privateKey.PublicKey))),
+	)
+
+	server := xgrpc.NewServer(m.log.Desugar(),
+		xgrpc.Credentials(credentials),
+		xgrpc.DefaultTraceInterceptor(),
+		xgrpc.VerifyInterceptor(),
+		xgrpc.AuthorizationInterceptor(authorization),
+	)
+	sonm.RegisterOptimusServer(server, m.api)
+
+	return server
+}
@@ -0,0 +1,91 @@
+package optimus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestAPI(t *testing.T) (*optimusAPI, *workerEngine) {
+	cfg := &workerConfig{
+		PriceThreshold: sonm.Price{PerSecond: sonm.NewBigIntFromInt(1e6)},
+	}
+
+	engine, err := newWorkerEngine(cfg, common.HexToAddress("0x1"), common.HexToAddress("0x2"), newStaticBlacklist(nil), nil, nil, nil, nil, nil, zap.NewNop().Sugar())
+	require.NoError(t, err)
+
+	workers := newWorkerRegistry()
+	workers.Add(engine)
+
+	return newOptimusAPI(workers, zap.NewNop().Sugar()), engine
+}
+
+func TestAPIWorkers(t *testing.T) {
+	api, _ := newTestAPI(t)
+
+	reply, err := api.Workers(context.Background(), &sonm.Empty{})
+	require.NoError(t, err)
+	require.Len(t, reply.Workers, 1)
+
+	worker := reply.Workers[0]
+	assert.Equal(t, common.HexToAddress("0x1"), worker.GetAddr().Unwrap())
+	assert.Equal(t, common.HexToAddress("0x2"), worker.GetMasterAddr().Unwrap())
+	assert.False(t, worker.Paused)
+	assert.Equal(t, int64(1e6), worker.GetPriceThreshold().GetPerSecond().Unwrap().Int64())
+	assert.Nil(t, worker.LastEpoch)
+}
+
+func TestAPIUnknownWorker(t *testing.T) {
+	api, _ := newTestAPI(t)
+
+	_, err := api.Pause(context.Background(), sonm.NewEthAddress(common.HexToAddress("0x3")))
+	require.Error(t, err)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestAPIPauseResume(t *testing.T) {
+	api, engine := newTestAPI(t)
+	addr := sonm.NewEthAddress(common.HexToAddress("0x1"))
+
+	_, err := api.Pause(context.Background(), addr)
+	require.NoError(t, err)
+	assert.True(t, engine.IsPaused())
+
+	// Paused engine skips epochs without touching the worker.
+	engine.Execute(context.Background())
+	assert.Nil(t, engine.LastEpoch())
+
+	_, err = api.Execute(context.Background(), addr)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = api.Resume(context.Background(), addr)
+	require.NoError(t, err)
+	assert.False(t, engine.IsPaused())
+
+	_, err = api.Execute(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Len(t, engine.Triggered(), 1)
+
+	// Triggering twice must not block.
+	_, err = api.Execute(context.Background(), addr)
+	require.NoError(t, err)
+	assert.Len(t, engine.Triggered(), 1)
+}
+
+func TestAPISetPriceThreshold(t *testing.T) {
+	api, engine := newTestAPI(t)
+
+	_, err := api.SetPriceThreshold(context.Background(), &sonm.OptimusPriceThresholdRequest{
+		Addr:      sonm.NewEthAddress(common.HexToAddress("0x1")),
+		Threshold: &sonm.Price{PerSecond: sonm.NewBigIntFromInt(42)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), engine.PriceThreshold().GetPerSecond().Unwrap().Int64())
+}
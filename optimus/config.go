@@ -35,23 +35,50 @@ type Config struct {
 	Workers     map[auth.Addr]*workerConfig `yaml:"workers"`
 	Benchmarks  benchmarks.Config           `yaml:"benchmarks"`
 	Marketplace marketplaceConfig           `yaml:"marketplace"`
+	API         apiConfig                   `yaml:"api"`
+	Discovery   discoveryConfig             `yaml:"discovery"`
 }
 
 func (m *Config) Validate() error {
+	if err := m.API.Validate(); err != nil {
+		return err
+	}
+
 	for _, cfg := range m.Workers {
 		if err := cfg.Validate(); err != nil {
 			return err
 		}
 	}
+
+	if err := m.Discovery.Validate(); err != nil {
+		return err
+	}
+
+	if len(m.Workers) == 0 && !m.Discovery.Enabled() {
+		return fmt.Errorf("either workers or discovery must be configured")
+	}
+
 	return nil
 }
 
+// SetDefaults fills unspecified settings of worker configs with their
+// default values.
+func (m *Config) SetDefaults() {
+	for _, cfg := range m.Workers {
+		cfg.SetDefaults()
+	}
+
+	m.Discovery.SetDefaults()
+}
+
 func LoadConfig(path string) (*Config, error) {
 	cfg := &Config{}
 	if err := configor.Load(cfg, path); err != nil {
 		return nil, err
 	}
 
+	cfg.SetDefaults()
+
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
@@ -61,7 +88,7 @@ func LoadConfig(path string) (*Config, error) {
 
 type workerConfig struct {
 	PrivateKey     privateKey         `yaml:"ethereum" json:"-"`
-	Epoch          time.Duration      `yaml:"epoch"`
+	Epoch          time.Duration      `yaml:"epoch" default:"60s"`
 	OrderPolicy    OrderPolicy        `yaml:"order_policy"`
 	DryRun         bool               `yaml:"dry_run" default:"false"`
 	Identity       sonm.IdentityLevel `yaml:"identity" required:"true"`
@@ -69,6 +96,27 @@ type workerConfig struct {
 	StaleThreshold time.Duration      `yaml:"stale_threshold" default:"5m"`
 	PreludeTimeout time.Duration      `yaml:"prelude_timeout" default:"30s"`
 	Optimization   OptimizationConfig `yaml:"optimization"`
+	Recording      recordingConfig    `yaml:"recording"`
+	Cost           costConfig         `yaml:"cost"`
+}
+
+// SetDefaults fills unspecified settings with their default values.
+//
+// Worker configs may live inside maps, which are not processed by the
+// config loader, hence the explicit defaults.
+func (m *workerConfig) SetDefaults() {
+	if m.Epoch == 0 {
+		m.Epoch = 60 * time.Second
+	}
+	if m.StaleThreshold == 0 {
+		m.StaleThreshold = 5 * time.Minute
+	}
+	if m.PreludeTimeout == 0 {
+		m.PreludeTimeout = 30 * time.Second
+	}
+	if m.Optimization.Model.OptimizationMethodFactory == nil {
+		m.Optimization.Model = optimizationMethodFactory{OptimizationMethodFactory: &defaultOptimizationMethodFactory{}}
+	}
 }
 
 func (m *workerConfig) Validate() error {
@@ -76,8 +124,12 @@ func (m *workerConfig) Validate() error {
 		return fmt.Errorf("price threshold must be a positive number")
 	}
 
+	if err := m.Cost.Validate(); err != nil {
+		return err
+	}
+
 	if m.Optimization.Model.OptimizationMethodFactory == nil {
-		m.Optimization.Model = optimizationMethodFactory{OptimizationMethodFactory: &defaultOptimizationMethodFactory{}}
+		return fmt.Errorf("optimization model is required")
 	}
 
 	return nil
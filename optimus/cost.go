@@ -0,0 +1,189 @@
+package optimus
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+// costConfig describes the cost of running worker hardware.
+type costConfig struct {
+	// ElectricityPrice is the price of a single kWh in USD.
+	ElectricityPrice float64 `yaml:"electricity_price"`
+	// FixedCost is the cost of keeping the worker alive regardless of its
+	// load, like rent or internet access.
+	FixedCost *sonm.Price `yaml:"fixed_cost"`
+	// CPU is the power draw of the fully loaded CPU in watts.
+	CPU float64 `yaml:"cpu"`
+	// GPU is the default power draw of a single GPU in watts.
+	GPU float64 `yaml:"gpu"`
+	// GPUs overrides the power draw in watts for specific GPUs either by
+	// their name, like "GeForce GTX 1080 Ti", or by device hash.
+	GPUs map[string]float64 `yaml:"gpus"`
+}
+
+func (m *costConfig) Enabled() bool {
+	return m.ElectricityPrice > 0 || m.FixedCost != nil
+}
+
+func (m *costConfig) Validate() error {
+	if m.ElectricityPrice < 0 {
+		return fmt.Errorf("electricity price must not be negative")
+	}
+
+	if m.CPU < 0 || m.GPU < 0 {
+		return fmt.Errorf("power draw must not be negative")
+	}
+
+	for name, watts := range m.GPUs {
+		if watts < 0 {
+			return fmt.Errorf("power draw of %s GPU must not be negative", name)
+		}
+	}
+
+	if m.FixedCost != nil && m.FixedCost.GetPerSecond().Unwrap().Sign() < 0 {
+		return fmt.Errorf("fixed cost must not be negative")
+	}
+
+	return nil
+}
+
+// costModel estimates the cost of running plans on the worker hardware.
+//
+// Nil cost model is valid and means that running the hardware costs nothing.
+type costModel struct {
+	cfg  costConfig
+	cpu  *sonm.CPU
+	gpus map[string]*sonm.GPU
+}
+
+func newCostModel(cfg costConfig, devices *sonm.DevicesReply) *costModel {
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	gpus := map[string]*sonm.GPU{}
+	for _, gpu := range devices.GetGPUs() {
+		gpus[gpu.GetDevice().GetHash()] = gpu
+	}
+
+	return &costModel{
+		cfg:  cfg,
+		cpu:  devices.GetCPU(),
+		gpus: gpus,
+	}
+}
+
+// Watts returns the power draw of the given resources.
+func (m *costModel) Watts(resources *sonm.AskPlanResources) float64 {
+	if m == nil {
+		return 0.0
+	}
+
+	watts := 0.0
+	if cores := m.cpu.GetDevice().GetCores(); cores != 0 {
+		watts += m.cfg.CPU * float64(resources.GetCPU().GetCorePercents()) / 100.0 / float64(cores)
+	}
+
+	for _, hash := range resources.GetGPU().GetHashes() {
+		watts += m.gpuWatts(hash)
+	}
+
+	// Plans created outside of Optimus may specify GPUs by indexes only.
+	if len(resources.GetGPU().GetHashes()) == 0 {
+		watts += m.cfg.GPU * float64(len(resources.GetGPU().GetIndexes()))
+	}
+
+	return watts
+}
+
+func (m *costModel) gpuWatts(hash string) float64 {
+	if watts, ok := m.cfg.GPUs[hash]; ok {
+		return watts
+	}
+
+	if gpu, ok := m.gpus[hash]; ok {
+		if watts, ok := m.cfg.GPUs[gpu.GetDevice().GetDeviceName()]; ok {
+			return watts
+		}
+	}
+
+	return m.cfg.GPU
+}
+
+// Cost returns the cost of running the given resources in USD/s.
+func (m *costModel) Cost(resources *sonm.AskPlanResources) *big.Int {
+	if m == nil {
+		return big.NewInt(0)
+	}
+
+	// W -> kW, then USD/h -> USD/s.
+	usdPerSecond := m.Watts(resources) / 1000.0 * m.cfg.ElectricityPrice / 3600.0
+	cost, _ := new(big.Float).Mul(big.NewFloat(usdPerSecond), big.NewFloat(1e18)).Int(nil)
+
+	return cost
+}
+
+// FixedCost returns the cost of keeping the worker alive in USD/s.
+func (m *costModel) FixedCost() *big.Int {
+	if m == nil || m.cfg.FixedCost == nil {
+		return big.NewInt(0)
+	}
+
+	return m.cfg.FixedCost.GetPerSecond().Unwrap()
+}
+
+// IsProfitable checks whether the plan with the given price and resources
+// earns more than it costs to run it.
+func (m *costModel) IsProfitable(price *big.Int, resources *sonm.AskPlanResources) bool {
+	if m == nil {
+		return true
+	}
+
+	return price.Cmp(m.Cost(resources)) > 0
+}
+
+// MarginalPrice returns the total price of the given plans minus their
+// running costs.
+func (m *costModel) MarginalPrice(plans []*sonm.AskPlan) *sonm.Price {
+	price := sonm.SumPrice(plans).GetPerSecond().Unwrap()
+	for _, plan := range plans {
+		price.Sub(price, m.Cost(plan.GetResources()))
+	}
+
+	return &sonm.Price{PerSecond: sonm.NewBigInt(price)}
+}
+
+// NetPrice returns the total price of the given plans minus their running
+// costs and the fixed cost of keeping the worker alive, i.e. the profit.
+func (m *costModel) NetPrice(plans []*sonm.AskPlan) *sonm.Price {
+	price := m.MarginalPrice(plans).GetPerSecond().Unwrap()
+	price.Sub(price, m.FixedCost())
+
+	return &sonm.Price{PerSecond: sonm.NewBigInt(price)}
+}
+
+// PriceFields describes the given plans for logging, including their costs
+// and margin if the cost model is configured.
+func (m *costModel) PriceFields(plans []*sonm.AskPlan) []interface{} {
+	price := sonm.SumPrice(plans).GetPerSecond()
+	fields := []interface{}{zap.String("Σ USD/s", price.ToPriceString())}
+	if m == nil {
+		return fields
+	}
+
+	net := m.NetPrice(plans).GetPerSecond().Unwrap()
+
+	margin := "-"
+	if price.Unwrap().Sign() != 0 {
+		value, _ := new(big.Float).Quo(new(big.Float).SetInt(net), new(big.Float).SetInt(price.Unwrap())).Float64()
+		margin = fmt.Sprintf("%.2f%%", 100.0*value)
+	}
+
+	return append(fields,
+		zap.String("net Σ USD/s", sonm.NewBigInt(net).ToPriceString()),
+		zap.String("margin", margin),
+	)
+}
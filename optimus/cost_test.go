@@ -0,0 +1,101 @@
+package optimus
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCostModelDisabled(t *testing.T) {
+	costs := newCostModel(costConfig{CPU: 100.0}, newEmptyDevicesReply())
+	require.Nil(t, costs)
+
+	plans := []*sonm.AskPlan{{Price: &sonm.Price{PerSecond: sonm.NewBigIntFromInt(42)}}}
+	assert.Equal(t, int64(42), costs.NetPrice(plans).GetPerSecond().Unwrap().Int64())
+	assert.True(t, costs.IsProfitable(sonm.NewBigIntFromInt(0).Unwrap(), &sonm.AskPlanResources{}))
+}
+
+func TestCostModelCost(t *testing.T) {
+	devices := newEmptyDevicesReply()
+	devices.CPU.Device.Cores = 4
+	devices.GPUs = []*sonm.GPU{
+		{Device: &sonm.GPUDevice{Hash: "0", DeviceName: "GeForce GTX 1080 Ti"}},
+		{Device: &sonm.GPUDevice{Hash: "1", DeviceName: "Radeon RX 580"}},
+	}
+
+	costs := newCostModel(costConfig{
+		// 1 kWh costs 0.36 USD, i.e. 1 kW costs 1e-4 USD/s.
+		ElectricityPrice: 0.36,
+		FixedCost:        &sonm.Price{PerSecond: sonm.NewBigIntFromInt(1e6)},
+		CPU:              100.0,
+		GPU:              150.0,
+		GPUs: map[string]float64{
+			"GeForce GTX 1080 Ti": 250.0,
+		},
+	}, devices)
+	require.NotNil(t, costs)
+
+	resources := &sonm.AskPlanResources{
+		// Half of the CPU.
+		CPU: &sonm.AskPlanCPU{CorePercents: 200},
+		GPU: &sonm.AskPlanGPU{Hashes: []string{"0", "1"}},
+	}
+
+	assert.InDelta(t, 50.0+250.0+150.0, costs.Watts(resources), 1e-9)
+	// 450 W -> 4.5e-5 USD/s.
+	assert.InDelta(t, 4.5e13, float64(costs.Cost(resources).Int64()), 1e3)
+	assert.Equal(t, int64(1e6), costs.FixedCost().Int64())
+
+	assert.False(t, costs.IsProfitable(sonm.NewBigIntFromInt(4e13).Unwrap(), resources))
+	assert.True(t, costs.IsProfitable(sonm.NewBigIntFromInt(5e13).Unwrap(), resources))
+
+	// The fixed cost is subtracted from the total, but not from the marginal
+	// price.
+	plans := []*sonm.AskPlan{{Price: &sonm.Price{PerSecond: sonm.NewBigIntFromInt(5e13)}, Resources: resources}}
+	assert.InDelta(t, 5e12, float64(costs.MarginalPrice(plans).GetPerSecond().Unwrap().Int64()), 1e3)
+	assert.InDelta(t, 5e12-1e6, float64(costs.NetPrice(plans).GetPerSecond().Unwrap().Int64()), 1e3)
+	assert.Equal(t, int64(-1e6), costs.NetPrice(nil).GetPerSecond().Unwrap().Int64())
+}
+
+func TestKnapsackRejectsUnprofitableOrders(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	snapshot := newTestSnapshot()
+	cheap, expensive := snapshot.Orders[0].GetOrder(), snapshot.Orders[1].GetOrder()
+
+	manager, err := newDeviceManager(snapshot.Devices, snapshot.Devices, newMappingMock(controller))
+	require.NoError(t, err)
+
+	// Both orders require the same resources, so pick the electricity price to
+	// make running them cost 2e6 wei/s, which is between their prices.
+	resources, err := manager.Clone().Consume(*cheap.GetBenchmarks(), *cheap.GetNetflags())
+	require.NoError(t, err)
+
+	cfg := costConfig{CPU: 100.0}
+	cfg.ElectricityPrice = 2e-12 * 3600.0 * 1000.0 / (&costModel{cfg: cfg, cpu: snapshot.Devices.CPU}).Watts(resources)
+
+	knapsack := NewKnapsack(manager)
+	knapsack.costs = newCostModel(cfg, snapshot.Devices)
+
+	// Estimating costs does not consume resources.
+	for i := 0; i < 4; i++ {
+		cost, err := knapsack.Cost(cheap)
+		require.NoError(t, err)
+		assert.InDelta(t, 2e6, float64(cost.Int64()), 10)
+	}
+
+	assert.Equal(t, errUnprofitable, knapsack.Put(cheap))
+	require.NoError(t, knapsack.Put(expensive))
+	require.Len(t, knapsack.Plans(), 1)
+
+	// The rejected order must not consume resources, so it is possible to put
+	// the same expensive order again.
+	require.NoError(t, knapsack.Put(expensive))
+
+	assert.Equal(t, int64(6e6), knapsack.Price().GetPerSecond().Unwrap().Int64())
+	assert.InDelta(t, 2e6, float64(knapsack.NetPrice().GetPerSecond().Unwrap().Int64()), 10)
+}
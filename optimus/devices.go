@@ -19,7 +19,9 @@ const (
 )
 
 var (
-	errExhausted = errors.New("no resources left")
+	errExhausted    = errors.New("no resources left")
+	errUnprofitable = errors.New("order is unprofitable")
+	errEstimated    = errors.New("order cost has been estimated")
 )
 
 type Consumer interface {
@@ -221,12 +223,22 @@ func (m *DeviceManager) Contains(benchmarks sonm.Benchmarks, netflags sonm.NetFl
 }
 
 func (m *DeviceManager) Consume(benchmarks sonm.Benchmarks, netflags sonm.NetFlags) (*sonm.AskPlanResources, error) {
+	return m.TryConsume(benchmarks, netflags, nil)
+}
+
+// TryConsume consumes resources like "Consume" does, but restores them when
+// the given check, if any, rejects the consumed resources, returning its
+// error.
+func (m *DeviceManager) TryConsume(benchmarks sonm.Benchmarks, netflags sonm.NetFlags, check func(resources *sonm.AskPlanResources) error) (*sonm.AskPlanResources, error) {
 	// Transaction-like resource restoring while consuming in case of errors.
 	copyFreeBenchmarks := append([]uint64{}, m.freeBenchmarks...)
 	copyFreeGPUs := append([]*sonm.GPU{}, m.freeGPUs...)
 	copyFreeIncomingNetwork := m.freeIncomingNetwork
 
 	plan, err := m.consumeBenchmarks(benchmarks, netflags)
+	if err == nil && check != nil {
+		err = check(plan)
+	}
 	if err != nil {
 		m.freeIncomingNetwork = copyFreeIncomingNetwork
 		m.freeGPUs = append([]*sonm.GPU{}, copyFreeGPUs...)
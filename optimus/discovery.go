@@ -0,0 +1,289 @@
+package optimus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+const (
+	workersPullLimit = 200
+)
+
+type discoveryConfig struct {
+	// Master is the master address whose confirmed workers are managed
+	// automatically. Discovery is disabled when not specified.
+	Master   common.Address `yaml:"master"`
+	Interval time.Duration  `yaml:"interval" default:"60s"`
+	// Default is applied to discovered workers that have no tag. Workers
+	// without neither tag nor default config are not managed.
+	Default *workerConfig `yaml:"default"`
+	// Tags maps user-defined tag names to the settings applied to workers
+	// tagged with them.
+	Tags map[string]*workerTagConfig `yaml:"tags"`
+}
+
+type workerTagConfig struct {
+	Workers []common.Address `yaml:"workers"`
+	Config  *workerConfig    `yaml:"config"`
+}
+
+func (m *discoveryConfig) Enabled() bool {
+	return m.Master != common.Address{}
+}
+
+// SetDefaults fills unspecified settings of the default and tagged worker
+// configs with their default values.
+func (m *discoveryConfig) SetDefaults() {
+	if m.Default != nil {
+		m.Default.SetDefaults()
+	}
+
+	for _, tag := range m.Tags {
+		if tag != nil && tag.Config != nil {
+			tag.Config.SetDefaults()
+		}
+	}
+}
+
+func (m *discoveryConfig) Validate() error {
+	if !m.Enabled() {
+		return nil
+	}
+
+	if m.Interval <= 0 {
+		return fmt.Errorf("discovery interval must be positive")
+	}
+
+	if m.Default != nil {
+		if err := m.Default.Validate(); err != nil {
+			return fmt.Errorf("invalid default worker config: %v", err)
+		}
+	}
+
+	tagged := map[common.Address]string{}
+	for name, tag := range m.Tags {
+		if tag == nil || tag.Config == nil {
+			return fmt.Errorf("tag %s has no worker config", name)
+		}
+
+		if err := tag.Config.Validate(); err != nil {
+			return fmt.Errorf("invalid worker config for tag %s: %v", name, err)
+		}
+
+		for _, addr := range tag.Workers {
+			if other, ok := tagged[addr]; ok {
+				return fmt.Errorf("worker %s is tagged with both %s and %s", addr.Hex(), other, name)
+			}
+
+			tagged[addr] = name
+		}
+	}
+
+	return nil
+}
+
+// WorkerConfig returns the settings for the discovered worker, preferring
+// tagged configs over the default one.
+func (m *discoveryConfig) WorkerConfig(addr common.Address) (*workerConfig, bool) {
+	for _, tag := range m.Tags {
+		for _, worker := range tag.Workers {
+			if worker == addr {
+				return tag.Config, true
+			}
+		}
+	}
+
+	if m.Default != nil {
+		return m.Default, true
+	}
+
+	return nil, false
+}
+
+// WorkerSource provides the list of confirmed workers for some master.
+type WorkerSource interface {
+	Workers(ctx context.Context, master common.Address) ([]common.Address, error)
+}
+
+type dwhWorkerSource struct {
+	dwh sonm.DWHClient
+}
+
+func newDWHWorkerSource(dwh sonm.DWHClient) *dwhWorkerSource {
+	return &dwhWorkerSource{
+		dwh: dwh,
+	}
+}
+
+func (m *dwhWorkerSource) Workers(ctx context.Context, master common.Address) ([]common.Address, error) {
+	var workers []common.Address
+
+	offset := uint64(0)
+	for {
+		response, err := m.dwh.GetWorkers(ctx, &sonm.WorkersRequest{
+			MasterID: sonm.NewEthAddress(master),
+			Offset:   offset,
+			Limit:    workersPullLimit,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to pull workers: %v", err)
+		}
+
+		for _, worker := range response.GetWorkers() {
+			if worker.GetConfirmed() {
+				workers = append(workers, worker.GetSlaveID().Unwrap())
+			}
+		}
+
+		if len(response.GetWorkers()) < workersPullLimit {
+			break
+		}
+
+		offset += uint64(len(response.GetWorkers()))
+	}
+
+	return workers, nil
+}
+
+// workerSpawner starts managing the given worker, blocking until the context
+// is canceled.
+type workerSpawner func(ctx context.Context, addr, masterAddr common.Address, cfg *workerConfig) error
+
+// workerDiscovery periodically scans the master's confirmed workers and starts
+// or stops their management as they appear or disappear.
+//
+// Workers that are specified explicitly in the config are never touched.
+type workerDiscovery struct {
+	cfg    discoveryConfig
+	source WorkerSource
+	static map[common.Address]struct{}
+	spawn  workerSpawner
+	log    *zap.SugaredLogger
+
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	running map[common.Address]*discoveredWorker
+}
+
+// discoveredWorker is a handle of the managed worker, which allows to tell
+// apart subsequent management sessions of the same worker.
+type discoveredWorker struct {
+	cancel context.CancelFunc
+}
+
+func newWorkerDiscovery(cfg discoveryConfig, source WorkerSource, static []common.Address, spawn workerSpawner, log *zap.SugaredLogger) *workerDiscovery {
+	staticSet := map[common.Address]struct{}{}
+	for _, addr := range static {
+		staticSet[addr] = struct{}{}
+	}
+
+	return &workerDiscovery{
+		cfg:     cfg,
+		source:  source,
+		static:  staticSet,
+		spawn:   spawn,
+		log:     log.With(zap.String("source", "discovery"), zap.Stringer("master", cfg.Master)),
+		running: map[common.Address]*discoveredWorker{},
+	}
+}
+
+func (m *workerDiscovery) OnRun() {
+	m.log.Info("discovering workers")
+}
+
+func (m *workerDiscovery) OnShutdown() {
+	m.mu.Lock()
+	for addr, worker := range m.running {
+		worker.cancel()
+		delete(m.running, addr)
+	}
+	m.mu.Unlock()
+
+	m.wg.Wait()
+	m.log.Info("stop discovering workers")
+}
+
+func (m *workerDiscovery) Execute(ctx context.Context) {
+	if err := m.execute(ctx); err != nil {
+		m.log.Warnw("failed to discover workers", zap.Error(err))
+	}
+}
+
+func (m *workerDiscovery) execute(ctx context.Context) error {
+	workers, err := m.source.Workers(ctx, m.cfg.Master)
+	if err != nil {
+		return err
+	}
+
+	discovered := map[common.Address]*workerConfig{}
+	for _, addr := range workers {
+		if _, ok := m.static[addr]; ok {
+			continue
+		}
+
+		cfg, ok := m.cfg.WorkerConfig(addr)
+		if !ok {
+			m.log.Debugw("ignoring worker without config", zap.Stringer("addr", addr))
+			continue
+		}
+
+		discovered[addr] = cfg
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for addr, worker := range m.running {
+		if _, ok := discovered[addr]; !ok {
+			m.log.Infow("worker has been removed", zap.Stringer("addr", addr))
+			worker.cancel()
+			delete(m.running, addr)
+		}
+	}
+
+	for addr, cfg := range discovered {
+		if _, ok := m.running[addr]; ok {
+			continue
+		}
+
+		m.log.Infow("worker has been discovered", zap.Stringer("addr", addr))
+		m.start(ctx, addr, cfg)
+	}
+
+	return nil
+}
+
+// start spawns the worker management. Must be called with the mutex held.
+func (m *workerDiscovery) start(ctx context.Context, addr common.Address, cfg *workerConfig) {
+	ctx, cancel := context.WithCancel(ctx)
+	worker := &discoveredWorker{cancel: cancel}
+	m.running[addr] = worker
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		// Forget the worker when its management stops for whatever reason,
+		// so it is restarted during the next discovery unless removed.
+		defer m.forget(addr, worker)
+
+		if err := m.spawn(ctx, addr, m.cfg.Master, cfg); err != nil && err != context.Canceled {
+			m.log.Warnw("worker management has failed", zap.Stringer("addr", addr), zap.Error(err))
+		}
+	}()
+}
+
+func (m *workerDiscovery) forget(addr common.Address, worker *discoveredWorker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	worker.cancel()
+	if m.running[addr] == worker {
+		delete(m.running, addr)
+	}
+}
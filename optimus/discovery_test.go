@@ -0,0 +1,196 @@
+package optimus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type fakeWorkerSource struct {
+	workers []common.Address
+}
+
+func (m *fakeWorkerSource) Workers(ctx context.Context, master common.Address) ([]common.Address, error) {
+	return m.workers, nil
+}
+
+type fakeSpawner struct {
+	mu      sync.Mutex
+	configs map[common.Address]*workerConfig
+}
+
+func (m *fakeSpawner) Spawn(ctx context.Context, addr, masterAddr common.Address, cfg *workerConfig) error {
+	m.mu.Lock()
+	m.configs[addr] = cfg
+	m.mu.Unlock()
+
+	<-ctx.Done()
+
+	m.mu.Lock()
+	delete(m.configs, addr)
+	m.mu.Unlock()
+
+	return ctx.Err()
+}
+
+func (m *fakeSpawner) Running() map[common.Address]*workerConfig {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	running := map[common.Address]*workerConfig{}
+	for addr, cfg := range m.configs {
+		running[addr] = cfg
+	}
+
+	return running
+}
+
+// WaitRunning waits until exactly "count" workers are running.
+func (m *fakeSpawner) WaitRunning(count int, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if len(m.Running()) == count {
+			return true
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	return false
+}
+
+func TestDiscoveryConfigWorkerConfig(t *testing.T) {
+	defaultConfig := &workerConfig{Identity: 1}
+	gpuConfig := &workerConfig{Identity: 2}
+
+	cfg := discoveryConfig{
+		Default: defaultConfig,
+		Tags: map[string]*workerTagConfig{
+			"gpu": {Workers: []common.Address{common.HexToAddress("0x2")}, Config: gpuConfig},
+		},
+	}
+
+	workerCfg, ok := cfg.WorkerConfig(common.HexToAddress("0x1"))
+	require.True(t, ok)
+	assert.Equal(t, defaultConfig, workerCfg)
+
+	workerCfg, ok = cfg.WorkerConfig(common.HexToAddress("0x2"))
+	require.True(t, ok)
+	assert.Equal(t, gpuConfig, workerCfg)
+
+	cfg.Default = nil
+	_, ok = cfg.WorkerConfig(common.HexToAddress("0x1"))
+	assert.False(t, ok)
+}
+
+func TestDiscoveryStartStop(t *testing.T) {
+	gpuConfig := &workerConfig{Identity: 2}
+	cfg := discoveryConfig{
+		Master:  common.HexToAddress("0x100"),
+		Default: &workerConfig{Identity: 1},
+		Tags: map[string]*workerTagConfig{
+			"gpu": {Workers: []common.Address{common.HexToAddress("0x2")}, Config: gpuConfig},
+		},
+	}
+
+	source := &fakeWorkerSource{
+		workers: []common.Address{
+			common.HexToAddress("0x1"),
+			common.HexToAddress("0x2"),
+			common.HexToAddress("0x3"),
+		},
+	}
+	spawner := &fakeSpawner{configs: map[common.Address]*workerConfig{}}
+
+	// The third worker is configured explicitly, so it must be ignored.
+	discovery := newWorkerDiscovery(cfg, source, []common.Address{common.HexToAddress("0x3")}, spawner.Spawn, zap.NewNop().Sugar())
+	discovery.OnRun()
+
+	discovery.Execute(context.Background())
+
+	require.True(t, spawner.WaitRunning(2, time.Second))
+	running := spawner.Running()
+	assert.Equal(t, cfg.Default, running[common.HexToAddress("0x1")])
+	assert.Equal(t, gpuConfig, running[common.HexToAddress("0x2")])
+
+	source.workers = []common.Address{common.HexToAddress("0x2")}
+	discovery.Execute(context.Background())
+
+	require.True(t, spawner.WaitRunning(1, time.Second))
+	assert.Contains(t, spawner.Running(), common.HexToAddress("0x2"))
+
+	discovery.OnShutdown()
+	assert.Empty(t, spawner.Running())
+}
+
+func TestDiscoveryRestartsFailedWorkers(t *testing.T) {
+	cfg := discoveryConfig{
+		Master:  common.HexToAddress("0x100"),
+		Default: &workerConfig{Identity: 1},
+	}
+	source := &fakeWorkerSource{workers: []common.Address{common.HexToAddress("0x1")}}
+
+	spawned := make(chan struct{}, 2)
+	spawn := func(ctx context.Context, addr, masterAddr common.Address, cfg *workerConfig) error {
+		spawned <- struct{}{}
+		return fmt.Errorf("failed to connect to worker")
+	}
+
+	discovery := newWorkerDiscovery(cfg, source, nil, spawn, zap.NewNop().Sugar())
+	discovery.OnRun()
+	defer discovery.OnShutdown()
+
+	discovery.Execute(context.Background())
+	<-spawned
+
+	// Wait for the failed worker to be forgotten.
+	deadline := time.Now().Add(time.Second)
+	for {
+		discovery.mu.Lock()
+		running := len(discovery.running)
+		discovery.mu.Unlock()
+
+		if running == 0 {
+			break
+		}
+
+		require.True(t, time.Now().Before(deadline), "failed worker has not been forgotten")
+		time.Sleep(time.Millisecond)
+	}
+
+	discovery.Execute(context.Background())
+
+	select {
+	case <-spawned:
+	case <-time.After(time.Second):
+		t.Fatal("failed worker has not been restarted")
+	}
+}
+
+func TestDiscoveryConfigSetDefaults(t *testing.T) {
+	cfg := discoveryConfig{
+		Default: &workerConfig{Identity: 1},
+		Tags: map[string]*workerTagConfig{
+			"gpu": {Config: &workerConfig{Identity: 2, Epoch: time.Minute * 5}},
+		},
+	}
+
+	// Validation has no side effects.
+	workerCfg := *cfg.Default
+	workerCfg.Validate()
+	assert.Equal(t, time.Duration(0), workerCfg.Epoch)
+
+	cfg.SetDefaults()
+	assert.Equal(t, time.Minute, cfg.Default.Epoch)
+	assert.Equal(t, 5*time.Minute, cfg.Default.StaleThreshold)
+	assert.Equal(t, 30*time.Second, cfg.Default.PreludeTimeout)
+	assert.NotNil(t, cfg.Default.Optimization.Model.OptimizationMethodFactory)
+	assert.Equal(t, 5*time.Minute, cfg.Tags["gpu"].Config.Epoch)
+}
@@ -106,6 +106,12 @@ type workerEngine struct {
 	benchmarkMapping benchmarks.Mapping
 
 	tagger *Tagger
+
+	mu             sync.Mutex
+	paused         bool
+	priceThreshold *sonm.Price
+	lastEpoch      *sonm.OptimusEpochReport
+	trigger        chan struct{}
 }
 
 func newWorkerEngine(cfg *workerConfig, addr, masterAddr common.Address, blacklist Blacklist, worker sonm.WorkerManagementClient, market blockchain.MarketAPI, marketCache *MarketCache, benchmarkMapping benchmarks.Mapping, tagger *Tagger, log *zap.SugaredLogger) (*workerEngine, error) {
@@ -122,6 +128,9 @@ func newWorkerEngine(cfg *workerConfig, addr, masterAddr common.Address, blackli
 		benchmarkMapping: benchmarkMapping,
 
 		tagger: tagger,
+
+		priceThreshold: &cfg.PriceThreshold,
+		trigger:        make(chan struct{}, 1),
 	}
 
 	return m, nil
@@ -136,14 +145,104 @@ func (m *workerEngine) OnShutdown() {
 }
 
 func (m *workerEngine) Execute(ctx context.Context) {
+	if m.IsPaused() {
+		m.log.Info("optimization epoch skipped: worker management is paused")
+		return
+	}
+
 	m.log.Info("optimization epoch started")
 
-	if err := m.execute(ctx); err != nil {
+	report := &sonm.OptimusEpochReport{
+		Addr:         sonm.NewEthAddress(m.addr),
+		StartedAt:    sonm.CurrentTimestamp(),
+		CreatedPlans: map[string]string{},
+		PlanErrors:   map[string]string{},
+	}
+
+	if err := m.execute(ctx, report); err != nil {
 		m.log.Warn(err.Error())
+		report.Error = err.Error()
 	}
+
+	report.FinishedAt = sonm.CurrentTimestamp()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastEpoch = report
 }
 
-func (m *workerEngine) execute(ctx context.Context) error {
+// Trigger schedules an immediate optimization epoch. Does nothing if there is
+// already one scheduled.
+func (m *workerEngine) Trigger() {
+	select {
+	case m.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// Triggered returns a channel that fires when an immediate optimization epoch
+// is requested.
+func (m *workerEngine) Triggered() <-chan struct{} {
+	return m.trigger
+}
+
+func (m *workerEngine) IsPaused() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.paused
+}
+
+// SetPaused pauses or resumes this worker management. Paused engine skips
+// optimization epochs, but continues to be watched.
+func (m *workerEngine) SetPaused(paused bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.paused = paused
+}
+
+func (m *workerEngine) PriceThreshold() *sonm.Price {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.priceThreshold
+}
+
+// SetPriceThreshold changes the price threshold in runtime. The change is not
+// persisted and is lost after restart.
+func (m *workerEngine) SetPriceThreshold(threshold *sonm.Price) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.priceThreshold = threshold
+}
+
+// LastEpoch returns the report of the last performed optimization epoch or
+// nil if there was no one yet.
+func (m *workerEngine) LastEpoch() *sonm.OptimusEpochReport {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastEpoch
+}
+
+// Status describes the current worker management state.
+func (m *workerEngine) Status() *sonm.OptimusWorker {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	status := &sonm.OptimusWorker{
+		Addr:           sonm.NewEthAddress(m.addr),
+		MasterAddr:     sonm.NewEthAddress(m.masterAddr),
+		Paused:         m.paused,
+		PriceThreshold: m.priceThreshold,
+	}
+
+	if m.lastEpoch != nil {
+		status.LastEpoch = m.lastEpoch.StartedAt
+		status.Error = m.lastEpoch.Error
+	}
+
+	return status
+}
+
+func (m *workerEngine) execute(ctx context.Context, report *sonm.OptimusEpochReport) error {
 	maintenance, err := m.worker.NextMaintenance(ctx, &sonm.Empty{})
 	if err != nil {
 		return fmt.Errorf("failed to get maintenance: %v", err)
@@ -161,6 +260,11 @@ func (m *workerEngine) execute(ctx context.Context) error {
 		return err
 	}
 
+	report.OrdersCount = uint64(len(input.Orders))
+	report.Devices = input.Devices
+	report.Plans = input.Plans
+	report.CurrentPrice = input.Price()
+
 	m.log.Debugf("pulled %d orders from the marketplace", len(input.Orders))
 	m.log.Debugw("pulled worker devices", zap.Any("devices", *input.Devices))
 	m.log.Debugw("pulled worker plans", zap.Any("plans", input.Plans))
@@ -171,7 +275,8 @@ func (m *workerEngine) execute(ctx context.Context) error {
 	}
 
 	if len(removedPlans) != 0 {
-		return m.execute(ctx)
+		report.RemovedPlans = append(report.RemovedPlans, removedPlans...)
+		return m.execute(ctx, report)
 	}
 
 	victimPlans := input.VictimPlans()
@@ -202,6 +307,12 @@ func (m *workerEngine) execute(ctx context.Context) error {
 		return fmt.Errorf("failed to collect orders for victim plans: %v", err)
 	}
 
+	if m.cfg.Recording.Dir != "" {
+		if err := m.recordSnapshot(input, virtualFreeOrders); err != nil {
+			m.log.Warnw("failed to record market snapshot", zap.Error(err))
+		}
+	}
+
 	// Extended orders set, with added currently executed orders.
 	extOrders := append(append([]*MarketOrder{}, input.Orders...), virtualFreeOrders...)
 
@@ -232,18 +343,27 @@ func (m *workerEngine) execute(ctx context.Context) error {
 		return err
 	}
 
-	m.log.Infow("current worker price", zap.String("Σ USD/s", input.Price().GetPerSecond().ToPriceString()))
-	m.log.Infow("optimizing using natural free devices done", zap.String("Σ USD/s", naturalKnapsack.Price().GetPerSecond().ToPriceString()), zap.Any("plans", naturalKnapsack.Plans()))
-	m.log.Infow("optimizing using virtual free devices done", zap.String("Σ USD/s", virtualKnapsack.Price().GetPerSecond().ToPriceString()), zap.Any("plans", virtualKnapsack.Plans()))
+	costs := newCostModel(m.cfg.Cost, input.Devices)
+	currentPlans := make([]*sonm.AskPlan, 0, len(input.Plans))
+	for _, plan := range input.Plans {
+		currentPlans = append(currentPlans, plan)
+	}
+
+	report.Natural = &sonm.OptimusKnapsack{Price: naturalKnapsack.Price(), Plans: naturalKnapsack.Plans()}
+	report.Virtual = &sonm.OptimusKnapsack{Price: virtualKnapsack.Price(), Plans: virtualKnapsack.Plans()}
+
+	m.log.Infow("current worker price", costs.PriceFields(currentPlans)...)
+	m.log.Infow("optimizing using natural free devices done", append(costs.PriceFields(naturalKnapsack.Plans()), zap.Any("plans", naturalKnapsack.Plans()))...)
+	m.log.Infow("optimizing using virtual free devices done", append(costs.PriceFields(virtualKnapsack.Plans()), zap.Any("plans", virtualKnapsack.Plans()))...)
 
 	if m.cfg.DryRun {
 		return fmt.Errorf("further worker management has been interrupted: dry-run mode is active")
 	}
 
-	// Compare total USD/s before and after. Remove some plans if the diff is
-	// more than the threshold.
-	priceThreshold := m.cfg.PriceThreshold.GetPerSecond()
-	priceDiff := new(big.Int).Sub(virtualKnapsack.Price().GetPerSecond().Unwrap(), input.Price().GetPerSecond().Unwrap())
+	// Compare total net USD/s before and after. Remove some plans if the diff
+	// is more than the threshold.
+	priceThreshold := m.PriceThreshold().GetPerSecond()
+	priceDiff := new(big.Int).Sub(virtualKnapsack.NetPrice().GetPerSecond().Unwrap(), costs.NetPrice(currentPlans).GetPerSecond().Unwrap())
 	swingTime := new(big.Int).Sub(priceDiff, priceThreshold.Unwrap()).Sign() >= 0
 
 	var winners []*sonm.AskPlan
@@ -258,13 +378,16 @@ func (m *workerEngine) execute(ctx context.Context) error {
 		for _, plan := range remove {
 			victims = append(victims, plan.ID)
 		}
+		report.Strategy = sonm.OptimizationStrategy_STRATEGY_REPLACEMENT
 		if err := m.worker.RemoveAskPlans(ctx, victims); err != nil {
 			return err
 		}
+		report.RemovedPlans = append(report.RemovedPlans, victims...)
 
 		winners = create
 	} else {
 		m.log.Info("using appending strategy")
+		report.Strategy = sonm.OptimizationStrategy_STRATEGY_APPENDING
 		winners = naturalKnapsack.Plans()
 	}
 
@@ -272,7 +395,10 @@ func (m *workerEngine) execute(ctx context.Context) error {
 		return fmt.Errorf("no plans found")
 	}
 
-	for _, plan := range winners {
+	for _, winner := range winners {
+		// Copy the plan to keep the epoch report untouched.
+		plan := *winner
+
 		// Extract the order ID for whose the selling plan is created.
 		orderID := plan.GetOrderID()
 
@@ -281,12 +407,14 @@ func (m *workerEngine) execute(ctx context.Context) error {
 		plan.Identity = m.cfg.Identity
 		plan.Tag = m.tagger.Tag()
 
-		id, err := m.worker.CreateAskPlan(ctx, plan)
+		id, err := m.worker.CreateAskPlan(ctx, &plan)
 		if err != nil {
-			m.log.Warnw("failed to create sell plan", zap.Any("plan", *plan), zap.Error(err))
+			m.log.Warnw("failed to create sell plan", zap.Any("plan", plan), zap.Error(err))
+			report.PlanErrors[orderID.Unwrap().String()] = err.Error()
 			continue
 		}
 
+		report.CreatedPlans[orderID.Unwrap().String()] = id.Id
 		m.log.Infof("created sell plan %s for %s order", id.Id, orderID.String())
 	}
 
@@ -423,6 +551,42 @@ func (m *workerEngine) ordersForPlans(ctx context.Context, plans map[string]*son
 	return orders, nil
 }
 
+// recordSnapshot dumps the given optimization input into the configured
+// directory to be able to replay it later using simulation.
+func (m *workerEngine) recordSnapshot(input *optimizationInput, victimOrders []*MarketOrder) error {
+	// Remember authors that are not allowed by the blacklist right now,
+	// because it won't be available while replaying.
+	blacklist := map[common.Address]struct{}{}
+	for _, order := range append(append([]*MarketOrder{}, input.Orders...), victimOrders...) {
+		if addr := order.GetOrder().GetAuthorID().Unwrap(); !m.blacklist.IsAllowed(addr) {
+			blacklist[addr] = struct{}{}
+		}
+	}
+
+	snapshot := &Snapshot{
+		Addr:         m.addr,
+		MasterAddr:   m.masterAddr,
+		CreatedAt:    time.Now(),
+		Orders:       input.Orders,
+		VictimOrders: victimOrders,
+		Devices:      input.Devices,
+		Plans:        input.Plans,
+		Blacklist:    make([]common.Address, 0, len(blacklist)),
+	}
+	for addr := range blacklist {
+		snapshot.Blacklist = append(snapshot.Blacklist, addr)
+	}
+
+	path, err := newSnapshotRecorder(m.cfg.Recording).Record(snapshot)
+	if err != nil {
+		return err
+	}
+
+	m.log.Debugf("recorded market snapshot into %s", path)
+
+	return nil
+}
+
 func (m *workerEngine) optimize(devices, freeDevices *sonm.DevicesReply, orders []*MarketOrder, log *zap.SugaredLogger) (*Knapsack, error) {
 	deviceManager, err := newDeviceManager(devices, freeDevices, m.benchmarkMapping)
 	if err != nil {
@@ -439,6 +603,7 @@ func (m *workerEngine) optimize(devices, freeDevices *sonm.DevicesReply, orders
 
 	now := time.Now()
 	knapsack := NewKnapsack(deviceManager)
+	knapsack.costs = newCostModel(m.cfg.Cost, devices)
 	if err := m.optimizationMethod(orders, matchedOrders, log).Optimize(knapsack, matchedOrders); err != nil {
 		return nil, err
 	}
@@ -612,6 +777,7 @@ func (m *FittingFunc) Filter(order *sonm.Order) bool {
 
 type Knapsack struct {
 	manager *DeviceManager
+	costs   *costModel
 	plans   []*sonm.AskPlan
 }
 
@@ -627,12 +793,19 @@ func (m *Knapsack) Clone() *Knapsack {
 
 	return &Knapsack{
 		manager: m.manager.Clone(),
+		costs:   m.costs,
 		plans:   plans,
 	}
 }
 
 func (m *Knapsack) Put(order *sonm.Order) error {
-	resources, err := m.manager.Consume(*order.GetBenchmarks(), *order.GetNetflags())
+	resources, err := m.manager.TryConsume(*order.GetBenchmarks(), *order.GetNetflags(), func(resources *sonm.AskPlanResources) error {
+		if !m.costs.IsProfitable(order.GetPrice().Unwrap(), resources) {
+			return errUnprofitable
+		}
+
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -653,8 +826,39 @@ func (m *Knapsack) Price() *sonm.Price {
 	return sonm.SumPrice(m.plans)
 }
 
+// Cost estimates the cost of running the given order using currently free
+// resources without consuming them.
+//
+// Returns an error if the order does not fit into the knapsack.
+func (m *Knapsack) Cost(order *sonm.Order) (*big.Int, error) {
+	var cost *big.Int
+	_, err := m.manager.TryConsume(*order.GetBenchmarks(), *order.GetNetflags(), func(resources *sonm.AskPlanResources) error {
+		cost = m.costs.Cost(resources)
+		return errEstimated
+	})
+	if err != errEstimated {
+		return nil, err
+	}
+
+	return cost, nil
+}
+
+// NetPrice returns the total price of all plans minus their running costs
+// and the worker's fixed costs.
+func (m *Knapsack) NetPrice() *sonm.Price {
+	return m.costs.NetPrice(m.plans)
+}
+
+// PPSf64 returns the price per second of all plans minus their running
+// costs as a float number, which is used as an objective for optimization
+// methods.
+//
+// Fixed costs are not subtracted, because they are the same for all
+// knapsacks, while optimization methods treat non-positive prices as no
+// solution found.
 func (m *Knapsack) PPSf64() float64 {
-	return float64(m.Price().GetPerSecond().Unwrap().Uint64()) * 1e-18
+	price, _ := new(big.Float).SetInt(m.costs.MarginalPrice(m.plans).GetPerSecond().Unwrap()).Float64()
+	return price * 1e-18
 }
 
 func (m *Knapsack) Plans() []*sonm.AskPlan {
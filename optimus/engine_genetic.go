@@ -98,7 +98,7 @@ func (m *packedOrdersGenome) Evaluate() (float64, error) {
 		return 0.0, nil
 	}
 
-	price := float64(knapsack.Price().GetPerSecond().Unwrap().Uint64()) / 1e18
+	price := knapsack.PPSf64()
 
 	// We want to minimize the fitness, hence the reversing.
 	return -price, nil
@@ -214,7 +214,7 @@ func (m *decisionOrdersGenome) Evaluate() (float64, error) {
 		return 0.0, nil
 	}
 
-	price := float64(knapsack.Price().GetPerSecond().Unwrap().Uint64()) * 1e-18
+	price := knapsack.PPSf64()
 
 	// We want to minimize the fitness, hence the reversing.
 	return -price, nil
@@ -3,6 +3,7 @@ package optimus
 import (
 	"fmt"
 	"math"
+	"math/big"
 
 	"go.uber.org/zap"
 )
@@ -63,6 +64,12 @@ func (m *GreedyLinearRegressionModel) Optimize(knapsack *Knapsack, orders []*Mar
 		filter[order.GetOrder().GetId().Unwrap().String()] = struct{}{}
 	}
 
+	// Orders of the same price may cost differently to run, hence the
+	// ranking by net price when running costs are known.
+	if knapsack.costs != nil {
+		m.weighNetPrices(knapsack, weightedOrders, filter)
+	}
+
 	exhaustedCounter := 0
 	for _, weightedOrder := range weightedOrders {
 		// Ignore orders with too low relative weight, i.e. orders that have
@@ -96,6 +103,9 @@ func (m *GreedyLinearRegressionModel) Optimize(knapsack *Knapsack, orders []*Mar
 		case errExhausted:
 			exhaustedCounter += 1
 			continue
+		case errUnprofitable:
+			m.log.Debugf("ignore `%s` order - unprofitable", weightedOrder.ID().String())
+			continue
 		default:
 			return fmt.Errorf("failed to consume order: %v", err)
 		}
@@ -103,3 +113,24 @@ func (m *GreedyLinearRegressionModel) Optimize(knapsack *Knapsack, orders []*Mar
 
 	return nil
 }
+
+// weighNetPrices recalculates weights of the matching orders using their
+// prices minus estimated running costs, then sorts orders by new weights.
+func (m *GreedyLinearRegressionModel) weighNetPrices(knapsack *Knapsack, orders []WeightedOrder, filter map[string]struct{}) {
+	for id := range orders {
+		if _, ok := filter[orders[id].ID().String()]; !ok {
+			continue
+		}
+
+		cost, err := knapsack.Cost(orders[id].Order.GetOrder())
+		if err != nil {
+			// Such orders do not fit anyway.
+			continue
+		}
+
+		costf64, _ := new(big.Float).SetInt(cost).Float64()
+		orders[id].Weight = (orders[id].Price - costf64*priceMultiplier) / orders[id].PredictedPrice
+	}
+
+	SortOrders(orders)
+}
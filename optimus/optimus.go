@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/sonm-io/core/blockchain"
+	"github.com/sonm-io/core/insonmnia/auth"
 	"github.com/sonm-io/core/insonmnia/benchmarks"
 	"github.com/sonm-io/core/util"
 	"go.uber.org/zap"
@@ -49,7 +51,7 @@ func (m *Optimus) Run(ctx context.Context) error {
 
 	marketCache := newMarketCache(newMarketScanner(m.cfg.Marketplace, dwh), m.cfg.Marketplace.Interval)
 
-	wg := errgroup.Group{}
+	wg, ctx := errgroup.WithContext(ctx)
 	benchmarkMapping, err := benchmarks.NewLoader(m.cfg.Benchmarks.URL).Load(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to load benchmarks: %v", err)
@@ -60,13 +62,26 @@ func (m *Optimus) Run(ctx context.Context) error {
 		return err
 	}
 
-	for addr, cfg := range m.cfg.Workers {
-		ethAddr, err := addr.ETH()
-		if err != nil {
-			return err
-		}
+	workers := newWorkerRegistry()
 
-		masterAddr, err := market.Market().GetMaster(ctx, ethAddr)
+	if m.cfg.API.Endpoint != "" {
+		server := newAPIServer(m.cfg.API, workers, m.log.With(zap.String("source", "api")))
+		wg.Go(func() error {
+			return server.Serve(ctx)
+		})
+	}
+
+	worker, err := registry.NewWorkerManagement(ctx, m.cfg.Node.Endpoint, m.cfg.Node.PrivateKey.Unwrap())
+	if err != nil {
+		return err
+	}
+
+	// Runs the management of the given worker until the context is canceled.
+	// The worker is accessed through the node using its address passed via
+	// metadata, which includes the explicit endpoint for static workers if
+	// configured.
+	run := func(ctx context.Context, addr auth.Addr, masterAddr common.Address, cfg *workerConfig) error {
+		ethAddr, err := addr.ETH()
 		if err != nil {
 			return err
 		}
@@ -76,11 +91,6 @@ func (m *Optimus) Run(ctx context.Context) error {
 			newBlacklist(masterAddr, dwh, m.log),
 		)
 
-		worker, err := registry.NewWorkerManagement(ctx, m.cfg.Node.Endpoint, m.cfg.Node.PrivateKey.Unwrap())
-		if err != nil {
-			return err
-		}
-
 		// TODO: Well, 10 parameters seems to be WAT.
 		control, err := newWorkerEngine(cfg, ethAddr, masterAddr, blacklist, worker, market.Market(), marketCache, benchmarkMapping, newTagger(m.version), m.log)
 		if err != nil {
@@ -91,8 +101,41 @@ func (m *Optimus) Run(ctx context.Context) error {
 			util.WorkerAddressHeader: []string{addr.String()},
 		}
 
+		workers.Add(control)
+		defer workers.Remove(ethAddr)
+
+		return newManagedWatcher(control, cfg.Epoch).Run(metadata.NewOutgoingContext(ctx, md))
+	}
+
+	spawn := func(ctx context.Context, ethAddr, masterAddr common.Address, cfg *workerConfig) error {
+		return run(ctx, auth.NewAddrRaw(ethAddr, ""), masterAddr, cfg)
+	}
+
+	var staticWorkers []common.Address
+	for addr, cfg := range m.cfg.Workers {
+		ethAddr, err := addr.ETH()
+		if err != nil {
+			return err
+		}
+
+		masterAddr, err := market.Market().GetMaster(ctx, ethAddr)
+		if err != nil {
+			return err
+		}
+
+		staticWorkers = append(staticWorkers, ethAddr)
+
+		addr := addr
+		cfg := cfg
+		wg.Go(func() error {
+			return run(ctx, addr, masterAddr, cfg)
+		})
+	}
+
+	if m.cfg.Discovery.Enabled() {
+		discovery := newWorkerDiscovery(m.cfg.Discovery, newDWHWorkerSource(dwh), staticWorkers, spawn, m.log)
 		wg.Go(func() error {
-			return newManagedWatcher(control, cfg.Epoch).Run(metadata.NewOutgoingContext(ctx, md))
+			return newManagedWatcher(discovery, m.cfg.Discovery.Interval).Run(ctx)
 		})
 	}
 
@@ -0,0 +1,244 @@
+package optimus
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jinzhu/configor"
+	"github.com/sonm-io/core/insonmnia/benchmarks"
+	"github.com/sonm-io/core/insonmnia/logging"
+	"github.com/sonm-io/core/proto"
+	"go.uber.org/zap"
+)
+
+// SimulationConfig describes how recorded market snapshots should be replayed.
+type SimulationConfig struct {
+	Logging     logging.Config    `yaml:"logging"`
+	Benchmarks  benchmarks.Config `yaml:"benchmarks"`
+	OrderPolicy OrderPolicy       `yaml:"order_policy"`
+	// Cost describes hardware running costs, which are considered while
+	// optimizing.
+	Cost costConfig `yaml:"cost"`
+	// Models maps user-defined model names to optimization methods that
+	// should be compared.
+	Models map[string]optimizationMethodFactory `yaml:"models" required:"true"`
+}
+
+func (m *SimulationConfig) Validate() error {
+	if len(m.Models) == 0 {
+		return fmt.Errorf("at least one optimization model is required")
+	}
+
+	if err := m.Cost.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func LoadSimulationConfig(path string) (*SimulationConfig, error) {
+	cfg := &SimulationConfig{}
+	if err := configor.Load(cfg, path); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// Simulation replays recorded market snapshots through the optimization
+// engine using each of the configured models, allowing to compare them
+// offline.
+type Simulation struct {
+	cfg *SimulationConfig
+	log *zap.SugaredLogger
+}
+
+func NewSimulation(cfg *SimulationConfig, options ...Option) *Simulation {
+	opts := newOptions()
+	for _, o := range options {
+		o(opts)
+	}
+
+	return &Simulation{
+		cfg: cfg,
+		log: opts.Log.With(zap.String("source", "simulation")),
+	}
+}
+
+// Run replays snapshots from the given files, returning the comparison
+// report.
+func (m *Simulation) Run(ctx context.Context, paths []string) (*SimulationReport, error) {
+	benchmarkMapping, err := benchmarks.NewLoader(m.cfg.Benchmarks.URL).Load(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load benchmarks: %v", err)
+	}
+
+	report := &SimulationReport{}
+	for _, path := range paths {
+		snapshot, err := LoadSnapshot(path)
+		if err != nil {
+			return nil, err
+		}
+
+		results, err := m.replay(ctx, filepath.Base(path), snapshot, benchmarkMapping)
+		if err != nil {
+			return nil, err
+		}
+
+		report.Results = append(report.Results, results...)
+	}
+
+	return report, nil
+}
+
+func (m *Simulation) replay(ctx context.Context, name string, snapshot *Snapshot, benchmarkMapping benchmarks.Mapping) ([]*SimulationResult, error) {
+	input := snapshot.OptimizationInput()
+
+	naturalFreeDevices, err := input.FreeDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay %s: %v", name, err)
+	}
+
+	virtualFreeDevices, err := input.VirtualFreeDevices()
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay %s: %v", name, err)
+	}
+
+	extOrders := append(append([]*MarketOrder{}, input.Orders...), snapshot.VictimOrders...)
+
+	// Run models in a stable order.
+	models := make([]string, 0, len(m.cfg.Models))
+	for model := range m.cfg.Models {
+		models = append(models, model)
+	}
+	sort.Strings(models)
+
+	results := make([]*SimulationResult, 0, len(models))
+	for _, model := range models {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		cfg := &workerConfig{
+			OrderPolicy: m.cfg.OrderPolicy,
+			Cost:        m.cfg.Cost,
+			Optimization: OptimizationConfig{
+				Model: m.cfg.Models[model],
+			},
+		}
+
+		log := m.log.With(zap.String("snapshot", name), zap.String("model", model))
+		engine, err := newWorkerEngine(cfg, snapshot.Addr, snapshot.MasterAddr, newStaticBlacklist(snapshot.Blacklist), nil, nil, nil, benchmarkMapping, nil, log)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &SimulationResult{
+			Snapshot:     name,
+			Model:        model,
+			CurrentPrice: input.Price(),
+			Natural:      m.optimize(engine, input.Devices, naturalFreeDevices, input.Orders, log.With(zap.String("optimization", "natural"))),
+			Virtual:      m.optimize(engine, input.Devices, virtualFreeDevices, extOrders, log.With(zap.String("optimization", "virtual"))),
+		}
+
+		log.Infow("snapshot has been replayed",
+			zap.String("natural Σ USD/s", result.Natural.Price.GetPerSecond().ToPriceString()),
+			zap.String("virtual Σ USD/s", result.Virtual.Price.GetPerSecond().ToPriceString()),
+		)
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (m *Simulation) optimize(engine *workerEngine, devices, freeDevices *sonm.DevicesReply, orders []*MarketOrder, log *zap.SugaredLogger) *SimulationOutcome {
+	now := time.Now()
+	knapsack, err := engine.optimize(devices, freeDevices, orders, log)
+	outcome := &SimulationOutcome{
+		Duration: time.Since(now),
+	}
+
+	if err != nil {
+		outcome.Price = sonm.SumPrice(nil)
+		outcome.Error = err.Error()
+		return outcome
+	}
+
+	outcome.Price = knapsack.Price()
+	outcome.Plans = knapsack.Plans()
+
+	return outcome
+}
+
+// SimulationOutcome describes the result of a single optimization.
+type SimulationOutcome struct {
+	Price    *sonm.Price     `json:"price"`
+	Plans    []*sonm.AskPlan `json:"plans"`
+	Duration time.Duration   `json:"duration"`
+	Error    string          `json:"error,omitempty"`
+}
+
+// SimulationResult describes how some model optimized a single snapshot.
+type SimulationResult struct {
+	Snapshot string `json:"snapshot"`
+	Model    string `json:"model"`
+	// CurrentPrice is the total price of worker plans at the moment of
+	// recording.
+	CurrentPrice *sonm.Price `json:"currentPrice"`
+	// Natural is the result of optimization using free devices only.
+	Natural *SimulationOutcome `json:"natural"`
+	// Virtual is the result of optimization using free devices plus devices
+	// occupied by plans that can be replaced.
+	Virtual *SimulationOutcome `json:"virtual"`
+}
+
+// SimulationReport is a comparison report of all replayed snapshots.
+type SimulationReport struct {
+	Results []*SimulationResult `json:"results"`
+}
+
+// WriteTable writes this report as a human-readable table.
+func (m *SimulationReport) WriteTable(wr io.Writer) error {
+	w := tabwriter.NewWriter(wr, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(w, "SNAPSHOT\tMODEL\tCURRENT USD/s\tNATURAL USD/s\tPLANS\tRUNTIME\tVIRTUAL USD/s\tPLANS\tRUNTIME\tERROR")
+	for _, result := range m.Results {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\t%d\t%s\t%s\n",
+			result.Snapshot,
+			result.Model,
+			result.CurrentPrice.GetPerSecond().ToPriceString(),
+			result.Natural.Price.GetPerSecond().ToPriceString(),
+			len(result.Natural.Plans),
+			result.Natural.Duration,
+			result.Virtual.Price.GetPerSecond().ToPriceString(),
+			len(result.Virtual.Plans),
+			result.Virtual.Duration,
+			joinErrors(result.Natural.Error, result.Virtual.Error),
+		)
+	}
+
+	return w.Flush()
+}
+
+func joinErrors(natural, virtual string) string {
+	switch {
+	case natural == "" && virtual == "":
+		return "-"
+	case virtual == "":
+		return "natural: " + natural
+	case natural == "":
+		return "virtual: " + virtual
+	default:
+		return fmt.Sprintf("natural: %s; virtual: %s", natural, virtual)
+	}
+}
@@ -0,0 +1,158 @@
+package optimus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sonm-io/core/proto"
+)
+
+const (
+	snapshotExt = ".json"
+)
+
+// Snapshot is a recorded optimization input of a single worker for some
+// epoch.
+//
+// It contains everything required to replay the optimization offline without
+// any live services, i.e. the marketplace orders, worker devices and plans
+// and the blacklisted order authors.
+type Snapshot struct {
+	Addr       common.Address `json:"addr"`
+	MasterAddr common.Address `json:"masterAddr"`
+	CreatedAt  time.Time      `json:"createdAt"`
+	// Orders are active orders pulled from the marketplace.
+	Orders []*MarketOrder `json:"orders"`
+	// VictimOrders are orders of currently executed plans that can be
+	// replaced.
+	VictimOrders []*MarketOrder           `json:"victimOrders"`
+	Devices      *sonm.DevicesReply       `json:"devices"`
+	Plans        map[string]*sonm.AskPlan `json:"plans"`
+	// Blacklist contains authors of recorded orders that were not allowed
+	// by the blacklist at the moment of recording.
+	Blacklist []common.Address `json:"blacklist"`
+}
+
+// OptimizationInput converts this snapshot into the optimization input.
+func (m *Snapshot) OptimizationInput() *optimizationInput {
+	return &optimizationInput{
+		Orders:  m.Orders,
+		Devices: m.Devices,
+		Plans:   m.Plans,
+	}
+}
+
+// LoadSnapshot reads the snapshot from the specified file.
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot %s: %v", path, err)
+	}
+
+	if snapshot.Devices == nil {
+		return nil, fmt.Errorf("snapshot %s has no devices", path)
+	}
+
+	return snapshot, nil
+}
+
+// SnapshotPaths expands the given paths into snapshot files. Directories are
+// scanned non-recursively for "*.json" files, while regular files are
+// returned as is.
+func SnapshotPaths(paths []string) ([]string, error) {
+	var result []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !info.IsDir() {
+			result = append(result, path)
+			continue
+		}
+
+		matches, err := filepath.Glob(filepath.Join(path, "*"+snapshotExt))
+		if err != nil {
+			return nil, err
+		}
+
+		sort.Strings(matches)
+		result = append(result, matches...)
+	}
+
+	return result, nil
+}
+
+type recordingConfig struct {
+	Dir string `yaml:"dir"`
+}
+
+// snapshotRecorder dumps optimization inputs into the configured directory.
+type snapshotRecorder struct {
+	dir string
+}
+
+func newSnapshotRecorder(cfg recordingConfig) *snapshotRecorder {
+	return &snapshotRecorder{
+		dir: cfg.Dir,
+	}
+}
+
+// Record saves the given snapshot, returning the path of the created file.
+func (m *snapshotRecorder) Record(snapshot *Snapshot) (string, error) {
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshots directory: %v", err)
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot: %v", err)
+	}
+
+	name := fmt.Sprintf("%s-%d%s", snapshot.Addr.Hex(), snapshot.CreatedAt.UnixNano(), snapshotExt)
+	path := filepath.Join(m.dir, name)
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// staticBlacklist is a blacklist with a predefined set of addresses, that is
+// used while replaying snapshots.
+type staticBlacklist struct {
+	blacklist map[common.Address]struct{}
+}
+
+func newStaticBlacklist(addrs []common.Address) *staticBlacklist {
+	blacklist := map[common.Address]struct{}{}
+	for _, addr := range addrs {
+		blacklist[addr] = struct{}{}
+	}
+
+	return &staticBlacklist{
+		blacklist: blacklist,
+	}
+}
+
+func (m *staticBlacklist) Update(ctx context.Context) error {
+	return nil
+}
+
+func (m *staticBlacklist) IsAllowed(addr common.Address) bool {
+	_, ok := m.blacklist[addr]
+	return !ok
+}
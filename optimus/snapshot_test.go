@@ -0,0 +1,122 @@
+package optimus
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/mock/gomock"
+	"github.com/sonm-io/core/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func newTestSnapshot() *Snapshot {
+	devices := newEmptyDevicesReply()
+	devices.CPU.Device.Cores = 4
+	devices.CPU.Benchmarks = map[uint64]*sonm.Benchmark{
+		0: {ID: 0, Result: 5680},
+		1: {ID: 1, Result: 1526},
+		2: {ID: 2, Result: 4},
+	}
+	devices.RAM.Device.Total = 16e9
+	devices.RAM.Benchmarks = map[uint64]*sonm.Benchmark{
+		3: {ID: 3, Result: 16e9},
+	}
+	devices.Storage.Device.BytesAvailable = 100e9
+	devices.Storage.Benchmarks = map[uint64]*sonm.Benchmark{
+		4: {ID: 4, Result: 100e9},
+	}
+	devices.Network.In = 100e6
+	devices.Network.Out = 100e6
+	devices.Network.BenchmarksIn = map[uint64]*sonm.Benchmark{
+		5: {ID: 5, Result: 100e6},
+	}
+	devices.Network.BenchmarksOut = map[uint64]*sonm.Benchmark{
+		6: {ID: 6, Result: 100e6},
+	}
+
+	newOrder := func(id int64, price int64, author common.Address, benchmarks []uint64) *MarketOrder {
+		return &MarketOrder{
+			Order: &sonm.Order{
+				Id:             sonm.NewBigIntFromInt(id),
+				OrderType:      sonm.OrderType_BID,
+				OrderStatus:    sonm.OrderStatus_ORDER_ACTIVE,
+				AuthorID:       sonm.NewEthAddress(author),
+				CounterpartyID: sonm.NewEthAddress(common.Address{}),
+				Price:          sonm.NewBigIntFromInt(price),
+				Netflags:       &sonm.NetFlags{},
+				Benchmarks:     &sonm.Benchmarks{Values: benchmarks},
+			},
+		}
+	}
+
+	return &Snapshot{
+		Addr:       common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fd"),
+		MasterAddr: common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fe"),
+		CreatedAt:  time.Date(2018, 7, 1, 12, 0, 0, 0, time.UTC),
+		Orders: []*MarketOrder{
+			newOrder(1, 1e6, common.HexToAddress("0x1"), []uint64{2000, 0, 0, 6e9, 1e9, 10e6, 10e6, 0, 0, 0, 0, 0}),
+			newOrder(2, 3e6, common.HexToAddress("0x2"), []uint64{2000, 0, 0, 6e9, 1e9, 10e6, 10e6, 0, 0, 0, 0, 0}),
+			newOrder(3, 1e9, common.HexToAddress("0x3"), []uint64{100, 0, 0, 1e9, 1e9, 10e6, 10e6, 0, 0, 0, 0, 0}),
+		},
+		Devices:   devices,
+		Plans:     map[string]*sonm.AskPlan{},
+		Blacklist: []common.Address{common.HexToAddress("0x3")},
+	}
+}
+
+func TestSnapshotRecordLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "optimus-snapshots")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	snapshot := newTestSnapshot()
+
+	path, err := newSnapshotRecorder(recordingConfig{Dir: dir}).Record(snapshot)
+	require.NoError(t, err)
+
+	paths, err := SnapshotPaths([]string{dir})
+	require.NoError(t, err)
+	assert.Equal(t, []string{path}, paths)
+
+	loaded, err := LoadSnapshot(path)
+	require.NoError(t, err)
+
+	// Empty collections are omitted while encoding, hence the separate
+	// devices comparison.
+	assert.True(t, proto.Equal(snapshot.Devices, loaded.Devices))
+	snapshot.Devices, loaded.Devices = nil, nil
+	assert.Equal(t, snapshot, loaded)
+}
+
+func TestSimulationReplay(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	simulation := NewSimulation(&SimulationConfig{
+		OrderPolicy: PolicySpotOnly,
+		Models: map[string]optimizationMethodFactory{
+			"branch_bound": {OptimizationMethodFactory: &BranchBoundModelFactory{}},
+		},
+	}, WithLog(zap.NewNop().Sugar()))
+
+	results, err := simulation.replay(context.Background(), "snapshot", newTestSnapshot(), newMappingMock(controller))
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	result := results[0]
+	assert.Equal(t, "branch_bound", result.Model)
+	assert.Empty(t, result.Natural.Error)
+	assert.Empty(t, result.Virtual.Error)
+
+	// Both orders fit, while the most profitable one is blacklisted.
+	require.Len(t, result.Natural.Plans, 2)
+	assert.Equal(t, int64(4e6), result.Natural.Price.GetPerSecond().Unwrap().Int64())
+	assert.Equal(t, int64(4e6), result.Virtual.Price.GetPerSecond().Unwrap().Int64())
+}
@@ -11,6 +11,13 @@ type Watcher interface {
 	Execute(ctx context.Context)
 }
 
+// TriggeredWatcher is a watcher that can additionally request to be executed
+// out of its schedule.
+type TriggeredWatcher interface {
+	Watcher
+	Triggered() <-chan struct{}
+}
+
 type managedWatcher struct {
 	watcher Watcher
 	timeout time.Duration
@@ -30,6 +37,13 @@ func (m *managedWatcher) Run(ctx context.Context) error {
 	timer := time.NewTicker(m.timeout)
 	defer timer.Stop()
 
+	// Nil channel blocks forever, which is exactly what we need for watchers
+	// that can't be triggered.
+	var trigger <-chan struct{}
+	if watcher, ok := m.watcher.(TriggeredWatcher); ok {
+		trigger = watcher.Triggered()
+	}
+
 	m.watcher.Execute(ctx)
 
 	for {
@@ -38,6 +52,8 @@ func (m *managedWatcher) Run(ctx context.Context) error {
 			return ctx.Err()
 		case <-timer.C:
 			m.watcher.Execute(ctx)
+		case <-trigger:
+			m.watcher.Execute(ctx)
 		}
 	}
 }
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
@@ -43,6 +45,21 @@ func (c *AskPlanCPU) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	return nil
 }
 
+func (m *AskPlan_ImagePolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v string
+	if err := unmarshal(&v); err != nil {
+		return err
+	}
+
+	policy, ok := AskPlan_ImagePolicy_value[strings.ToUpper(v)]
+	if !ok {
+		return fmt.Errorf("unknown image policy: %s", v)
+	}
+
+	*m = AskPlan_ImagePolicy(policy)
+	return nil
+}
+
 func (m *AskPlan) Validate() error {
 	if m.GetIdentity() == IdentityLevel_UNKNOWN {
 		return errors.New("identity level is required and should not be 0")
@@ -59,9 +76,142 @@ func (m *AskPlan) Validate() error {
 		return errors.New("storage size is too low")
 	}
 
+	if err := m.GetEgress().Validate(); err != nil {
+		return err
+	}
+
 	return m.GetResources().GetGPU().Validate()
 }
 
+func (m *EgressPolicy) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	impl := struct {
+		DenyPrivate       bool     `yaml:"deny_private"`
+		DenyPorts         []uint32 `yaml:"deny_ports"`
+		AllowCIDRs        []string `yaml:"allow_cidrs"`
+		MaxConnectionRate uint32   `yaml:"max_connection_rate"`
+	}{}
+
+	if err := unmarshal(&impl); err != nil {
+		return err
+	}
+
+	m.DenyPrivate = impl.DenyPrivate
+	m.DenyPorts = impl.DenyPorts
+	m.AllowCIDRs = impl.AllowCIDRs
+	m.MaxConnectionRate = impl.MaxConnectionRate
+
+	return m.Validate()
+}
+
+func (m *EgressPolicy) Validate() error {
+	for _, port := range m.GetDenyPorts() {
+		if port == 0 || port > 65535 {
+			return fmt.Errorf("invalid egress port: %d", port)
+		}
+	}
+
+	for _, cidr := range m.GetAllowCIDRs() {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid egress CIDR: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if the policy does not restrict anything.
+func (m *EgressPolicy) IsEmpty() bool {
+	return !m.GetDenyPrivate() && len(m.GetDenyPorts()) == 0 && len(m.GetAllowCIDRs()) == 0 && m.GetMaxConnectionRate() == 0
+}
+
+// Merge returns a policy that is at least as strict as both the given ones.
+//
+// Denied ports and private networks are united, allowed networks are
+// intersected and the lowest connection rate limit wins. When both policies
+// allow disjoint sets of networks no destination is allowed at all.
+// Both policies are expected to be validated.
+func (m *EgressPolicy) Merge(other *EgressPolicy) *EgressPolicy {
+	policy := &EgressPolicy{
+		DenyPrivate:       m.GetDenyPrivate() || other.GetDenyPrivate(),
+		DenyPorts:         mergePorts(m.GetDenyPorts(), other.GetDenyPorts()),
+		AllowCIDRs:        intersectCIDRs(m.GetAllowCIDRs(), other.GetAllowCIDRs()),
+		MaxConnectionRate: m.GetMaxConnectionRate(),
+	}
+
+	if rate := other.GetMaxConnectionRate(); rate != 0 && (policy.MaxConnectionRate == 0 || rate < policy.MaxConnectionRate) {
+		policy.MaxConnectionRate = rate
+	}
+
+	return policy
+}
+
+func mergePorts(a, b []uint32) []uint32 {
+	var ports []uint32
+	seen := map[uint32]bool{}
+	for _, port := range append(append([]uint32{}, a...), b...) {
+		if !seen[port] {
+			seen[port] = true
+			ports = append(ports, port)
+		}
+	}
+
+	return ports
+}
+
+// denyAllCIDR is an allowed network that matches no real destination, used
+// to express an empty intersection of allowed networks, because an empty
+// list means no restrictions.
+const denyAllCIDR = "0.0.0.0/32"
+
+func intersectCIDRs(a, b []string) []string {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+
+	var cidrs []string
+	seen := map[string]bool{}
+	for _, lhs := range a {
+		_, lhsNet, err := net.ParseCIDR(lhs)
+		if err != nil {
+			continue
+		}
+
+		for _, rhs := range b {
+			_, rhsNet, err := net.ParseCIDR(rhs)
+			if err != nil {
+				continue
+			}
+
+			// Two networks either do not overlap or one contains another,
+			// so the narrower one is their intersection.
+			if !lhsNet.Contains(rhsNet.IP) && !rhsNet.Contains(lhsNet.IP) {
+				continue
+			}
+
+			narrow := lhsNet
+			lhsOnes, _ := lhsNet.Mask.Size()
+			rhsOnes, _ := rhsNet.Mask.Size()
+			if rhsOnes > lhsOnes {
+				narrow = rhsNet
+			}
+
+			if cidr := narrow.String(); !seen[cidr] {
+				seen[cidr] = true
+				cidrs = append(cidrs, cidr)
+			}
+		}
+	}
+
+	if len(cidrs) == 0 {
+		return []string{denyAllCIDR}
+	}
+
+	return cidrs
+}
+
 func (m *AskPlan) UnsoldDuration() time.Duration {
 	if !m.GetDealID().IsZero() {
 		return time.Duration(0)
@@ -329,6 +479,7 @@ func (m *AskPlanNetwork) UnmarshalYAML(unmarshal func(interface{}) error) error
 		Overlay       bool
 		Outbound      bool
 		Incoming      bool
+		Sandbox       bool
 	}
 	impl := &Impl{}
 
@@ -342,6 +493,7 @@ func (m *AskPlanNetwork) UnmarshalYAML(unmarshal func(interface{}) error) error
 	m.NetFlags.SetOverlay(impl.Overlay)
 	m.NetFlags.SetOutbound(impl.Outbound)
 	m.NetFlags.SetIncoming(impl.Incoming)
+	m.NetFlags.SetSandbox(impl.Sandbox)
 
 	return nil
 }
@@ -15,6 +15,7 @@ It is generated from these files:
 	marketplace.proto
 	net.proto
 	node.proto
+	optimus.proto
 	relay.proto
 	rendezvous.proto
 	timestamp.proto
@@ -27,6 +28,7 @@ It has these top-level messages:
 	AskPlanRAM
 	AskPlanStorage
 	AskPlanNetwork
+	EgressPolicy
 	AskPlanResources
 	AskPlan
 	Benchmark
@@ -116,12 +118,25 @@ It has these top-level messages:
 	WorkerRemoveRequest
 	WorkerListReply
 	BalanceReply
+	MarketOrdersRequest
+	MatchBidRequest
 	TokenTransferRequest
+	NPPDiagnoseRequest
+	NPPReport
+	NPPStageReport
+	NPPPunchAttempt
+	OptimusWorker
+	OptimusWorkersReply
+	OptimusPriceThresholdRequest
+	OptimusKnapsack
+	OptimusEpochReport
 	HandshakeRequest
+	RelayACL
 	DiscoverResponse
 	HandshakeResponse
 	RelayClusterReply
 	RelayMetrics
+	RelayUsage
 	NetMetrics
 	ConnectRequest
 	PublishRequest
@@ -131,6 +146,12 @@ It has these top-level messages:
 	ResolveMetaReply
 	Timestamp
 	Volume
+	PingRequest
+	PingReply
+	DelegationToken
+	DelegationTokenStatus
+	DelegationTokensReply
+	RevokeDelegationTokenRequest
 	TaskSpec
 	StartTaskRequest
 	WorkerJoinNetworkRequest
@@ -183,7 +204,44 @@ var AskPlan_Status_value = map[string]int32{
 func (x AskPlan_Status) String() string {
 	return proto.EnumName(AskPlan_Status_name, int32(x))
 }
-func (AskPlan_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{6, 0} }
+func (AskPlan_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 0} }
+
+// ImagePolicy specifies how images allowed to run within the plan's
+// deals are verified.
+type AskPlan_ImagePolicy int32
+
+const (
+	// DEFAULT means the worker's policy.
+	AskPlan_DEFAULT AskPlan_ImagePolicy = 0
+	// WHITELIST allows images which digests are whitelisted.
+	AskPlan_WHITELIST AskPlan_ImagePolicy = 1
+	// SIGNATURE allows images signed by trusted publishers.
+	AskPlan_SIGNATURE AskPlan_ImagePolicy = 2
+	// WHITELIST_OR_SIGNATURE allows images satisfying any of the above.
+	AskPlan_WHITELIST_OR_SIGNATURE AskPlan_ImagePolicy = 3
+	// WHITELIST_AND_SIGNATURE allows images satisfying both of the above.
+	AskPlan_WHITELIST_AND_SIGNATURE AskPlan_ImagePolicy = 4
+)
+
+var AskPlan_ImagePolicy_name = map[int32]string{
+	0: "DEFAULT",
+	1: "WHITELIST",
+	2: "SIGNATURE",
+	3: "WHITELIST_OR_SIGNATURE",
+	4: "WHITELIST_AND_SIGNATURE",
+}
+var AskPlan_ImagePolicy_value = map[string]int32{
+	"DEFAULT":                 0,
+	"WHITELIST":               1,
+	"SIGNATURE":               2,
+	"WHITELIST_OR_SIGNATURE":  3,
+	"WHITELIST_AND_SIGNATURE": 4,
+}
+
+func (x AskPlan_ImagePolicy) String() string {
+	return proto.EnumName(AskPlan_ImagePolicy_name, int32(x))
+}
+func (AskPlan_ImagePolicy) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{7, 1} }
 
 type AskPlanCPU struct {
 	CorePercents uint64 `protobuf:"varint,1,opt,name=core_percents,json=corePercents" json:"core_percents,omitempty"`
@@ -289,6 +347,54 @@ func (m *AskPlanNetwork) GetNetFlags() *NetFlags {
 	return nil
 }
 
+// EgressPolicy restricts outgoing connections of tasks.
+type EgressPolicy struct {
+	// DenyPrivate denies connections to private, loopback, link-local and
+	// shared address space networks, like RFC 1918 ones.
+	DenyPrivate bool `protobuf:"varint,1,opt,name=denyPrivate" json:"denyPrivate,omitempty"`
+	// DenyPorts are destination TCP and UDP ports, like 25 for SMTP.
+	DenyPorts []uint32 `protobuf:"varint,2,rep,packed,name=denyPorts" json:"denyPorts,omitempty"`
+	// AllowCIDRs, if specified, are the only networks connections are
+	// allowed to.
+	AllowCIDRs []string `protobuf:"bytes,3,rep,name=allowCIDRs" json:"allowCIDRs,omitempty"`
+	// MaxConnectionRate limits the number of new connections per second,
+	// zero means unlimited.
+	MaxConnectionRate uint32 `protobuf:"varint,4,opt,name=maxConnectionRate" json:"maxConnectionRate,omitempty"`
+}
+
+func (m *EgressPolicy) Reset()                    { *m = EgressPolicy{} }
+func (m *EgressPolicy) String() string            { return proto.CompactTextString(m) }
+func (*EgressPolicy) ProtoMessage()               {}
+func (*EgressPolicy) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *EgressPolicy) GetDenyPrivate() bool {
+	if m != nil {
+		return m.DenyPrivate
+	}
+	return false
+}
+
+func (m *EgressPolicy) GetDenyPorts() []uint32 {
+	if m != nil {
+		return m.DenyPorts
+	}
+	return nil
+}
+
+func (m *EgressPolicy) GetAllowCIDRs() []string {
+	if m != nil {
+		return m.AllowCIDRs
+	}
+	return nil
+}
+
+func (m *EgressPolicy) GetMaxConnectionRate() uint32 {
+	if m != nil {
+		return m.MaxConnectionRate
+	}
+	return 0
+}
+
 type AskPlanResources struct {
 	CPU     *AskPlanCPU     `protobuf:"bytes,1,opt,name=CPU" json:"CPU,omitempty"`
 	RAM     *AskPlanRAM     `protobuf:"bytes,2,opt,name=RAM" json:"RAM,omitempty"`
@@ -300,7 +406,7 @@ type AskPlanResources struct {
 func (m *AskPlanResources) Reset()                    { *m = AskPlanResources{} }
 func (m *AskPlanResources) String() string            { return proto.CompactTextString(m) }
 func (*AskPlanResources) ProtoMessage()               {}
-func (*AskPlanResources) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+func (*AskPlanResources) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
 
 func (m *AskPlanResources) GetCPU() *AskPlanCPU {
 	if m != nil {
@@ -338,25 +444,32 @@ func (m *AskPlanResources) GetNetwork() *AskPlanNetwork {
 }
 
 type AskPlan struct {
-	ID                  string            `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
-	OrderID             *BigInt           `protobuf:"bytes,2,opt,name=orderID" json:"orderID,omitempty"`
-	DealID              *BigInt           `protobuf:"bytes,3,opt,name=dealID" json:"dealID,omitempty"`
-	Duration            *Duration         `protobuf:"bytes,4,opt,name=duration" json:"duration,omitempty"`
-	Price               *Price            `protobuf:"bytes,5,opt,name=price" json:"price,omitempty"`
-	Blacklist           *EthAddress       `protobuf:"bytes,6,opt,name=blacklist" json:"blacklist,omitempty"`
-	Counterparty        *EthAddress       `protobuf:"bytes,7,opt,name=counterparty" json:"counterparty,omitempty"`
-	Identity            IdentityLevel     `protobuf:"varint,8,opt,name=identity,enum=sonm.IdentityLevel" json:"identity,omitempty"`
-	Tag                 []byte            `protobuf:"bytes,9,opt,name=tag,proto3" json:"tag,omitempty"`
-	Resources           *AskPlanResources `protobuf:"bytes,10,opt,name=resources" json:"resources,omitempty"`
-	Status              AskPlan_Status    `protobuf:"varint,11,opt,name=status,enum=sonm.AskPlan_Status" json:"status,omitempty"`
-	CreateTime          *Timestamp        `protobuf:"bytes,12,opt,name=createTime" json:"createTime,omitempty"`
-	LastOrderPlacedTime *Timestamp        `protobuf:"bytes,13,opt,name=lastOrderPlacedTime" json:"lastOrderPlacedTime,omitempty"`
+	ID                  string              `protobuf:"bytes,1,opt,name=ID" json:"ID,omitempty"`
+	OrderID             *BigInt             `protobuf:"bytes,2,opt,name=orderID" json:"orderID,omitempty"`
+	DealID              *BigInt             `protobuf:"bytes,3,opt,name=dealID" json:"dealID,omitempty"`
+	Duration            *Duration           `protobuf:"bytes,4,opt,name=duration" json:"duration,omitempty"`
+	Price               *Price              `protobuf:"bytes,5,opt,name=price" json:"price,omitempty"`
+	Blacklist           *EthAddress         `protobuf:"bytes,6,opt,name=blacklist" json:"blacklist,omitempty"`
+	Counterparty        *EthAddress         `protobuf:"bytes,7,opt,name=counterparty" json:"counterparty,omitempty"`
+	Identity            IdentityLevel       `protobuf:"varint,8,opt,name=identity,enum=sonm.IdentityLevel" json:"identity,omitempty"`
+	Tag                 []byte              `protobuf:"bytes,9,opt,name=tag,proto3" json:"tag,omitempty"`
+	Resources           *AskPlanResources   `protobuf:"bytes,10,opt,name=resources" json:"resources,omitempty"`
+	Status              AskPlan_Status      `protobuf:"varint,11,opt,name=status,enum=sonm.AskPlan_Status" json:"status,omitempty"`
+	CreateTime          *Timestamp          `protobuf:"bytes,12,opt,name=createTime" json:"createTime,omitempty"`
+	LastOrderPlacedTime *Timestamp          `protobuf:"bytes,13,opt,name=lastOrderPlacedTime" json:"lastOrderPlacedTime,omitempty"`
+	ImagePolicy         AskPlan_ImagePolicy `protobuf:"varint,14,opt,name=imagePolicy,enum=sonm.AskPlan_ImagePolicy" json:"imagePolicy,omitempty"`
+	// Runtime is the OCI runtime tasks are run with, overriding the one
+	// configured for the consumer's identity level.
+	Runtime string `protobuf:"bytes,15,opt,name=runtime" json:"runtime,omitempty"`
+	// Egress is the policy for outgoing connections of tasks, overriding
+	// the worker's one.
+	Egress *EgressPolicy `protobuf:"bytes,16,opt,name=egress" json:"egress,omitempty"`
 }
 
 func (m *AskPlan) Reset()                    { *m = AskPlan{} }
 func (m *AskPlan) String() string            { return proto.CompactTextString(m) }
 func (*AskPlan) ProtoMessage()               {}
-func (*AskPlan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*AskPlan) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *AskPlan) GetID() string {
 	if m != nil {
@@ -449,61 +562,96 @@ func (m *AskPlan) GetLastOrderPlacedTime() *Timestamp {
 	return nil
 }
 
+func (m *AskPlan) GetImagePolicy() AskPlan_ImagePolicy {
+	if m != nil {
+		return m.ImagePolicy
+	}
+	return AskPlan_DEFAULT
+}
+
+func (m *AskPlan) GetRuntime() string {
+	if m != nil {
+		return m.Runtime
+	}
+	return ""
+}
+
+func (m *AskPlan) GetEgress() *EgressPolicy {
+	if m != nil {
+		return m.Egress
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*AskPlanCPU)(nil), "sonm.AskPlanCPU")
 	proto.RegisterType((*AskPlanGPU)(nil), "sonm.AskPlanGPU")
 	proto.RegisterType((*AskPlanRAM)(nil), "sonm.AskPlanRAM")
 	proto.RegisterType((*AskPlanStorage)(nil), "sonm.AskPlanStorage")
 	proto.RegisterType((*AskPlanNetwork)(nil), "sonm.AskPlanNetwork")
+	proto.RegisterType((*EgressPolicy)(nil), "sonm.EgressPolicy")
 	proto.RegisterType((*AskPlanResources)(nil), "sonm.AskPlanResources")
 	proto.RegisterType((*AskPlan)(nil), "sonm.AskPlan")
 	proto.RegisterEnum("sonm.AskPlan_Status", AskPlan_Status_name, AskPlan_Status_value)
+	proto.RegisterEnum("sonm.AskPlan_ImagePolicy", AskPlan_ImagePolicy_name, AskPlan_ImagePolicy_value)
 }
 
 func init() { proto.RegisterFile("ask_plan.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 672 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x5f, 0x6f, 0xda, 0x3a,
-	0x18, 0xc6, 0x0f, 0x7f, 0x0a, 0xe5, 0x2d, 0xa5, 0x1c, 0xb7, 0xaa, 0xa2, 0x5e, 0x71, 0x72, 0x8e,
-	0x8e, 0x50, 0x35, 0xd1, 0xad, 0xab, 0xa6, 0x5d, 0x4d, 0x62, 0x85, 0xa1, 0x48, 0x2d, 0x8d, 0x5c,
-	0xd8, 0x6d, 0x65, 0x92, 0x57, 0x60, 0x11, 0x9c, 0xc8, 0x36, 0xdb, 0xda, 0x0f, 0xb5, 0x8f, 0xb4,
-	0x9b, 0x7d, 0x91, 0xc9, 0x89, 0x03, 0xcd, 0x44, 0xa5, 0xdd, 0x25, 0xcf, 0xf3, 0x7b, 0xec, 0xf7,
-	0x7d, 0x6d, 0x19, 0x5a, 0x4c, 0x2d, 0x1f, 0x92, 0x88, 0x89, 0x5e, 0x22, 0x63, 0x1d, 0x93, 0xaa,
-	0x8a, 0xc5, 0xea, 0xac, 0x39, 0xe3, 0x73, 0x2e, 0x74, 0xa6, 0x9d, 0x91, 0x80, 0x25, 0x6c, 0xc6,
-	0x23, 0xae, 0x39, 0x2a, 0xab, 0x1d, 0x71, 0x61, 0x48, 0xc1, 0x99, 0x15, 0xfe, 0x5e, 0x31, 0xb9,
-	0x44, 0x9d, 0x44, 0x2c, 0xc0, 0x9c, 0xd1, 0x7c, 0x85, 0x4a, 0xb3, 0x55, 0x92, 0x09, 0xee, 0x1b,
-	0x80, 0xbe, 0x5a, 0xfa, 0x11, 0x13, 0xd7, 0xfe, 0x94, 0xfc, 0x0b, 0x87, 0x41, 0x2c, 0xf1, 0x21,
-	0x41, 0x19, 0xa0, 0xd0, 0xca, 0x29, 0x75, 0x4a, 0xdd, 0x2a, 0x6d, 0x1a, 0xd1, 0xb7, 0x9a, 0xfb,
-	0x61, 0x13, 0x19, 0xf9, 0x53, 0xe2, 0x40, 0x9d, 0x8b, 0x10, 0xbf, 0xa1, 0x81, 0x2b, 0xdd, 0x2a,
-	0xcd, 0x7f, 0xc9, 0x29, 0xd4, 0x16, 0x4c, 0x2d, 0x50, 0x39, 0xe5, 0x4e, 0xa5, 0xdb, 0xa0, 0xf6,
-	0xcf, 0x7d, 0xbd, 0xc9, 0xd3, 0xfe, 0x2d, 0x71, 0xa1, 0xaa, 0xf8, 0x13, 0xa6, 0x3b, 0x1d, 0x5c,
-	0xb6, 0x7a, 0xa6, 0x85, 0xde, 0x80, 0x69, 0x76, 0xcf, 0x9f, 0x90, 0xa6, 0x9e, 0x7b, 0x05, 0x2d,
-	0x9b, 0xb8, 0xd7, 0xb1, 0x64, 0x73, 0xfc, 0xa3, 0xd4, 0xf7, 0xd2, 0x26, 0x36, 0x46, 0xfd, 0x35,
-	0x96, 0x4b, 0xf2, 0x0e, 0x9a, 0x7a, 0x21, 0xe3, 0xf5, 0x7c, 0x91, 0xac, 0xb5, 0x27, 0x6c, 0x9c,
-	0xfc, 0x16, 0x67, 0x1a, 0x69, 0x81, 0x23, 0xef, 0xe1, 0x70, 0xfb, 0x7f, 0xb7, 0xd6, 0x4e, 0xf9,
-	0xc5, 0x60, 0x11, 0x24, 0xe7, 0xb0, 0x2f, 0x50, 0x7f, 0x8a, 0xd8, 0x5c, 0x39, 0x95, 0xe7, 0xc5,
-	0x8e, 0xad, 0x4a, 0x37, 0xbe, 0xfb, 0xa3, 0x04, 0xed, 0x7c, 0x32, 0xa8, 0xe2, 0xb5, 0x0c, 0x50,
-	0x11, 0x17, 0x2a, 0xd7, 0xfe, 0xd4, 0x56, 0xda, 0xce, 0xb2, 0xdb, 0x13, 0xa3, 0xc6, 0x34, 0x0c,
-	0xed, 0xdf, 0xda, 0xa2, 0x8a, 0x0c, 0xed, 0xdf, 0x52, 0x63, 0x92, 0x1e, 0xd4, 0x55, 0x36, 0x3c,
-	0x5b, 0xc7, 0x49, 0x81, 0xb3, 0x83, 0xa5, 0x39, 0x64, 0xd6, 0x1c, 0xf9, 0x53, 0xa7, 0xba, 0x63,
-	0xcd, 0x91, 0xd9, 0xd7, 0x9c, 0x7d, 0x0f, 0xea, 0x22, 0x9b, 0xac, 0xb3, 0xb7, 0x63, 0x4d, 0x3b,
-	0x75, 0x9a, 0x43, 0xee, 0xcf, 0x2a, 0xd4, 0xad, 0x47, 0x5a, 0x50, 0xf6, 0x06, 0x69, 0x5b, 0x0d,
-	0x5a, 0xf6, 0x06, 0xe4, 0x7f, 0xa8, 0xc7, 0x32, 0x44, 0xe9, 0x0d, 0x6c, 0x1f, 0xcd, 0x6c, 0xad,
-	0x8f, 0x7c, 0xee, 0x09, 0x4d, 0x73, 0x93, 0xfc, 0x07, 0xb5, 0x10, 0x59, 0xe4, 0x0d, 0x6c, 0x1b,
-	0x45, 0xcc, 0x7a, 0x66, 0xec, 0xe1, 0x5a, 0x32, 0xcd, 0x63, 0x61, 0x5b, 0xc8, 0xef, 0x88, 0x55,
-	0xe9, 0xc6, 0x27, 0xff, 0xc0, 0x5e, 0x22, 0x79, 0x80, 0xb6, 0x87, 0x83, 0x0c, 0xf4, 0x8d, 0x44,
-	0x33, 0x87, 0xf4, 0xa0, 0x31, 0x8b, 0x58, 0xb0, 0x8c, 0xb8, 0xd2, 0x4e, 0xed, 0xf9, 0x48, 0x86,
-	0x7a, 0xd1, 0x0f, 0x43, 0x89, 0x4a, 0xd1, 0x2d, 0x42, 0xae, 0xa0, 0x19, 0xc4, 0x6b, 0xa1, 0x51,
-	0x26, 0x4c, 0xea, 0x47, 0xa7, 0xfe, 0x42, 0xa4, 0x40, 0x91, 0x0b, 0xd8, 0xe7, 0x21, 0x0a, 0xcd,
-	0xf5, 0xa3, 0xb3, 0xdf, 0x29, 0x75, 0x5b, 0x97, 0xc7, 0x59, 0xc2, 0xb3, 0xea, 0x0d, 0x7e, 0xc1,
-	0x88, 0x6e, 0x20, 0xd2, 0x86, 0x8a, 0x66, 0x73, 0xa7, 0xd1, 0x29, 0x75, 0x9b, 0xd4, 0x7c, 0x92,
-	0x2b, 0x68, 0xc8, 0xfc, 0xea, 0x38, 0x90, 0xee, 0x7a, 0x5a, 0xbc, 0x0f, 0xb9, 0x4b, 0xb7, 0x20,
-	0x79, 0x05, 0x35, 0xa5, 0x99, 0x5e, 0x2b, 0xe7, 0x20, 0xdd, 0xb6, 0x78, 0x8c, 0xbd, 0xfb, 0xd4,
-	0xa3, 0x96, 0x21, 0x17, 0x00, 0x81, 0x44, 0xa6, 0x71, 0xc2, 0x57, 0xe8, 0x34, 0xd3, 0x4d, 0x8e,
-	0xb2, 0xc4, 0x24, 0x7f, 0x5d, 0xe8, 0x33, 0x84, 0xf4, 0xe1, 0x38, 0x62, 0x4a, 0xdf, 0x99, 0x13,
-	0xf4, 0xcd, 0x63, 0x14, 0xa6, 0xc9, 0xc3, 0xdd, 0xc9, 0x5d, 0xac, 0x7b, 0x0e, 0xb5, 0xac, 0x0a,
-	0x02, 0x50, 0xeb, 0x5f, 0x4f, 0xbc, 0xcf, 0xc3, 0xf6, 0x5f, 0xe4, 0x04, 0xda, 0xfe, 0x70, 0x3c,
-	0xf0, 0xc6, 0xa3, 0x87, 0xc1, 0xf0, 0x66, 0x38, 0xf1, 0xee, 0xc6, 0xed, 0xd2, 0xac, 0x96, 0xbe,
-	0x6c, 0x6f, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0x87, 0x32, 0x79, 0x4d, 0x48, 0x05, 0x00, 0x00,
+	// 864 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xdd, 0x8e, 0xe2, 0xb6,
+	0x17, 0xdf, 0x00, 0x0b, 0xc3, 0xe1, 0x63, 0xb2, 0xde, 0xd5, 0xfc, 0xfd, 0x9f, 0x56, 0x15, 0x4d,
+	0xab, 0x0a, 0x8d, 0x56, 0x6c, 0xbb, 0x1d, 0x55, 0x95, 0x2a, 0x55, 0x4a, 0x27, 0x2c, 0x8d, 0x34,
+	0xc3, 0x44, 0x06, 0xda, 0x4b, 0xe4, 0x09, 0x16, 0x58, 0x04, 0x87, 0xda, 0x66, 0x77, 0x67, 0x9f,
+	0xa5, 0xcf, 0xd0, 0xf7, 0xe9, 0x4d, 0x9f, 0xa5, 0x72, 0xe2, 0x40, 0x68, 0x59, 0xa9, 0x77, 0xf8,
+	0xf7, 0xe1, 0xe3, 0xf3, 0xc1, 0x09, 0x74, 0xa9, 0x5a, 0xcf, 0xb7, 0x09, 0x15, 0x83, 0xad, 0x4c,
+	0x75, 0x8a, 0x6a, 0x2a, 0x15, 0x9b, 0xcb, 0xf6, 0x03, 0x5f, 0x72, 0xa1, 0x73, 0xec, 0x12, 0xc5,
+	0x74, 0x4b, 0x1f, 0x78, 0xc2, 0x35, 0x67, 0xca, 0x62, 0xe7, 0x5c, 0x18, 0xa5, 0xe0, 0xd4, 0x02,
+	0xcf, 0x36, 0x54, 0xae, 0x99, 0xde, 0x26, 0x34, 0x66, 0x85, 0x46, 0xf3, 0x0d, 0x53, 0x9a, 0x6e,
+	0xb6, 0x39, 0xe0, 0x7d, 0x03, 0xe0, 0xab, 0x75, 0x94, 0x50, 0x71, 0x13, 0xcd, 0xd0, 0x17, 0xd0,
+	0x89, 0x53, 0xc9, 0xe6, 0x5b, 0x26, 0x63, 0x26, 0xb4, 0xc2, 0x4e, 0xcf, 0xe9, 0xd7, 0x48, 0xdb,
+	0x80, 0x91, 0xc5, 0xbc, 0x1f, 0xf7, 0x96, 0x51, 0x34, 0x43, 0x18, 0x1a, 0x5c, 0x2c, 0xd8, 0x7b,
+	0x66, 0xc4, 0xd5, 0x7e, 0x8d, 0x14, 0x47, 0x74, 0x01, 0xf5, 0x15, 0x55, 0x2b, 0xa6, 0x70, 0xa5,
+	0x57, 0xed, 0x37, 0x89, 0x3d, 0x79, 0x5f, 0xef, 0xfd, 0xc4, 0xbf, 0x43, 0x1e, 0xd4, 0x14, 0xff,
+	0xc0, 0xb2, 0x48, 0xad, 0xd7, 0xdd, 0x81, 0x49, 0x61, 0x10, 0x50, 0x4d, 0x27, 0xfc, 0x03, 0x23,
+	0x19, 0xe7, 0x5d, 0x43, 0xd7, 0x3a, 0x26, 0x3a, 0x95, 0x74, 0xc9, 0xfe, 0x93, 0xeb, 0x0f, 0x67,
+	0x6f, 0x1b, 0x33, 0xfd, 0x2e, 0x95, 0x6b, 0xf4, 0x1d, 0xb4, 0xf5, 0x4a, 0xa6, 0xbb, 0xe5, 0x6a,
+	0xbb, 0xd3, 0xa1, 0xb0, 0x76, 0xf4, 0x0f, 0x3b, 0xd5, 0x8c, 0x1c, 0xe9, 0xd0, 0xf7, 0xd0, 0x39,
+	0x9c, 0xef, 0x77, 0x1a, 0x57, 0x3e, 0x6a, 0x3c, 0x16, 0xa2, 0x2b, 0x38, 0x13, 0x4c, 0xbf, 0x49,
+	0xe8, 0x52, 0xe1, 0x6a, 0xf9, 0xb1, 0x63, 0x8b, 0x92, 0x3d, 0xef, 0xfd, 0xee, 0x40, 0x7b, 0xb8,
+	0x94, 0x4c, 0xa9, 0x28, 0x4d, 0x78, 0xfc, 0x88, 0x7a, 0xd0, 0x5a, 0x30, 0xf1, 0x18, 0x49, 0xfe,
+	0x96, 0xea, 0x3c, 0xd9, 0x33, 0x52, 0x86, 0xd0, 0xa7, 0xd0, 0xcc, 0x8e, 0xa9, 0xd4, 0x79, 0x99,
+	0x3b, 0xe4, 0x00, 0xa0, 0xcf, 0x00, 0x68, 0x92, 0xa4, 0xef, 0x6e, 0xc2, 0x80, 0x98, 0xf0, 0xa6,
+	0x0b, 0x25, 0x04, 0xbd, 0x84, 0x67, 0x1b, 0xfa, 0xfe, 0x26, 0x15, 0x82, 0xc5, 0x9a, 0xa7, 0xc2,
+	0x24, 0x80, 0x6b, 0x3d, 0xa7, 0xdf, 0x21, 0xff, 0x26, 0xbc, 0xbf, 0x1c, 0x70, 0x8b, 0xc6, 0x31,
+	0x95, 0xee, 0x64, 0xcc, 0x14, 0xf2, 0xa0, 0x7a, 0x13, 0xcd, 0x6c, 0x21, 0xdd, 0x3c, 0xb5, 0xc3,
+	0x40, 0x11, 0x43, 0x1a, 0x0d, 0xf1, 0xef, 0x70, 0xe5, 0x84, 0x86, 0xf8, 0x77, 0xc4, 0x90, 0x68,
+	0x00, 0x0d, 0x95, 0xf7, 0xd6, 0x96, 0xe9, 0xc5, 0x91, 0xce, 0xf6, 0x9d, 0x14, 0x22, 0x73, 0xe7,
+	0x28, 0x9a, 0xe1, 0xda, 0x89, 0x3b, 0x47, 0x26, 0xae, 0x19, 0xcd, 0x01, 0x34, 0x44, 0xde, 0x78,
+	0xfc, 0xf4, 0xc4, 0x9d, 0x76, 0x28, 0x48, 0x21, 0xf2, 0xfe, 0xac, 0x43, 0xc3, 0x72, 0xa8, 0x0b,
+	0x95, 0x30, 0xc8, 0xd2, 0x6a, 0x92, 0x4a, 0x18, 0xa0, 0xaf, 0xa0, 0x91, 0xca, 0x05, 0x93, 0x61,
+	0x60, 0xf3, 0x68, 0xe7, 0x77, 0xfd, 0xc4, 0x97, 0xa1, 0xd0, 0xa4, 0x20, 0xd1, 0x97, 0x50, 0x5f,
+	0x30, 0x9a, 0x84, 0x01, 0xae, 0x9e, 0x90, 0x59, 0xce, 0x4c, 0xc5, 0x62, 0x27, 0xa9, 0x29, 0x2d,
+	0xae, 0x95, 0xa7, 0x22, 0xb0, 0x28, 0xd9, 0xf3, 0xe8, 0x73, 0x78, 0xba, 0x95, 0x3c, 0x66, 0x36,
+	0x87, 0x56, 0x2e, 0x8c, 0x0c, 0x44, 0x72, 0x06, 0x0d, 0xa0, 0xf9, 0x90, 0xd0, 0x78, 0x9d, 0x70,
+	0xa5, 0x71, 0xbd, 0x5c, 0x92, 0xa1, 0x5e, 0xf9, 0x8b, 0x85, 0x19, 0x29, 0x72, 0x90, 0xa0, 0x6b,
+	0x68, 0xc7, 0xe9, 0x4e, 0x68, 0x26, 0xb7, 0x54, 0xea, 0x47, 0xdc, 0xf8, 0x88, 0xe5, 0x48, 0x85,
+	0x5e, 0xc1, 0x19, 0x5f, 0x30, 0xa1, 0xb9, 0x7e, 0xc4, 0x67, 0x3d, 0xa7, 0xdf, 0x7d, 0xfd, 0x3c,
+	0x77, 0x84, 0x16, 0xbd, 0x65, 0x6f, 0x59, 0x42, 0xf6, 0x22, 0xe4, 0x42, 0x55, 0xd3, 0x25, 0x6e,
+	0xf6, 0x9c, 0x7e, 0x9b, 0x98, 0x9f, 0xe8, 0x1a, 0x9a, 0xb2, 0x18, 0x1d, 0x0c, 0x59, 0xd4, 0x8b,
+	0xe3, 0x79, 0x28, 0x58, 0x72, 0x10, 0xa2, 0x97, 0x50, 0x57, 0x9a, 0xea, 0x9d, 0xc2, 0xad, 0x2c,
+	0xec, 0x71, 0x1b, 0x07, 0x93, 0x8c, 0x23, 0x56, 0x83, 0x5e, 0x01, 0xc4, 0x92, 0x51, 0xcd, 0xa6,
+	0x7c, 0xc3, 0x70, 0x3b, 0x0b, 0x72, 0x9e, 0x3b, 0xa6, 0xc5, 0xf2, 0x23, 0x25, 0x09, 0xf2, 0xe1,
+	0x79, 0x42, 0x95, 0xbe, 0x37, 0x1d, 0x8c, 0xcc, 0xae, 0x5c, 0x64, 0xce, 0xce, 0x69, 0xe7, 0x29,
+	0x2d, 0xfa, 0x01, 0x5a, 0x7c, 0x43, 0x97, 0x2c, 0xff, 0xdf, 0xe2, 0x6e, 0xf6, 0xcc, 0xff, 0x1f,
+	0x3f, 0x33, 0x3c, 0x08, 0x48, 0x59, 0x6d, 0x36, 0xa8, 0xdc, 0x09, 0xb3, 0x98, 0xf1, 0x79, 0x36,
+	0x6f, 0xc5, 0x11, 0x5d, 0x41, 0x9d, 0x65, 0xfb, 0x00, 0xbb, 0xe5, 0x7d, 0x53, 0xde, 0x11, 0xc4,
+	0x2a, 0xbc, 0x2b, 0xa8, 0xe7, 0x85, 0x40, 0x00, 0x75, 0xff, 0x66, 0x1a, 0xfe, 0x32, 0x74, 0x9f,
+	0xa0, 0x17, 0xe0, 0x46, 0xc3, 0x71, 0x10, 0x8e, 0x47, 0xf3, 0x60, 0x78, 0x3b, 0x9c, 0x86, 0xf7,
+	0x63, 0xd7, 0xf1, 0x7e, 0x83, 0x56, 0xe9, 0x35, 0xa8, 0x05, 0x8d, 0x60, 0xf8, 0xc6, 0x9f, 0xdd,
+	0x4e, 0xdd, 0x27, 0xa8, 0x03, 0xcd, 0x5f, 0x7f, 0x0e, 0xa7, 0xc3, 0xdb, 0x70, 0x32, 0x75, 0x1d,
+	0x73, 0x9c, 0x84, 0xa3, 0xb1, 0x3f, 0x9d, 0x91, 0xa1, 0x5b, 0x41, 0x97, 0x70, 0xb1, 0x67, 0xe7,
+	0xf7, 0x64, 0x7e, 0xe0, 0xaa, 0xe8, 0x13, 0xf8, 0xdf, 0x81, 0xf3, 0xc7, 0x41, 0x89, 0xac, 0x3d,
+	0xd4, 0xb3, 0xcf, 0xcd, 0xb7, 0x7f, 0x0f, 0x00, 0xd9, 0xcb, 0xac, 0x43, 0xdd, 0x06, 0x00, 0x00,
 }
@@ -65,6 +65,68 @@ resources:
 	assert.True(t, ask.Resources.GetNetwork().GetNetFlags().GetIncoming())
 }
 
+func TestEgressPolicyUnmarshal(t *testing.T) {
+	data := []byte(`
+identity: anonymous
+egress:
+  deny_private: true
+  deny_ports: [25, 465]
+  allow_cidrs: ["8.8.8.8/32"]
+  max_connection_rate: 10
+`)
+	ask := &AskPlan{}
+	require.NoError(t, yaml.Unmarshal(data, ask))
+
+	assert.True(t, ask.GetEgress().GetDenyPrivate())
+	assert.Equal(t, []uint32{25, 465}, ask.GetEgress().GetDenyPorts())
+	assert.Equal(t, []string{"8.8.8.8/32"}, ask.GetEgress().GetAllowCIDRs())
+	assert.Equal(t, uint32(10), ask.GetEgress().GetMaxConnectionRate())
+	assert.False(t, ask.GetEgress().IsEmpty())
+	assert.True(t, (&AskPlan{}).GetEgress().IsEmpty())
+
+	require.Error(t, yaml.Unmarshal([]byte("egress:\n  allow_cidrs: [8.8.8.8]\n"), &AskPlan{}))
+	require.Error(t, yaml.Unmarshal([]byte("egress:\n  deny_ports: [70000]\n"), &AskPlan{}))
+}
+
+func TestEgressPolicyMerge(t *testing.T) {
+	worker := &EgressPolicy{
+		DenyPorts:         []uint32{25, 465},
+		AllowCIDRs:        []string{"10.0.0.0/8", "8.8.8.8/32"},
+		MaxConnectionRate: 100,
+	}
+	ask := &EgressPolicy{
+		DenyPrivate:       true,
+		DenyPorts:         []uint32{465, 587},
+		AllowCIDRs:        []string{"10.1.0.0/16", "1.1.1.1/32"},
+		MaxConnectionRate: 10,
+	}
+
+	policy := worker.Merge(ask)
+	assert.True(t, policy.GetDenyPrivate())
+	assert.Equal(t, []uint32{25, 465, 587}, policy.GetDenyPorts())
+	assert.Equal(t, []string{"10.1.0.0/16"}, policy.GetAllowCIDRs())
+	assert.Equal(t, uint32(10), policy.GetMaxConnectionRate())
+
+	// The merge is symmetric in strictness.
+	policy = ask.Merge(worker)
+	assert.Equal(t, []string{"10.1.0.0/16"}, policy.GetAllowCIDRs())
+	assert.Equal(t, uint32(10), policy.GetMaxConnectionRate())
+
+	// An ask plan can not lift the worker's restrictions.
+	policy = worker.Merge(&EgressPolicy{})
+	assert.Equal(t, worker.GetDenyPorts(), policy.GetDenyPorts())
+	assert.Equal(t, worker.GetAllowCIDRs(), policy.GetAllowCIDRs())
+	assert.Equal(t, uint32(100), policy.GetMaxConnectionRate())
+
+	policy = (*EgressPolicy)(nil).Merge(ask)
+	assert.Equal(t, ask.GetAllowCIDRs(), policy.GetAllowCIDRs())
+	assert.True(t, (*EgressPolicy)(nil).Merge(nil).IsEmpty())
+
+	// Disjoint allowed networks leave nothing allowed.
+	policy = worker.Merge(&EgressPolicy{AllowCIDRs: []string{"1.1.1.1/32"}})
+	assert.Equal(t, []string{denyAllCIDR}, policy.GetAllowCIDRs())
+}
+
 func TestAskPlanIDsAndHashes(t *testing.T) {
 	data := []byte(`
 resources:
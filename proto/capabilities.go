@@ -18,6 +18,11 @@ const (
 	NetworkOverlay  = uint64(0x1)
 	NetworkOutbound = uint64(0x2)
 	NetworkIncoming = uint64(0x4)
+	// IsolationSandbox is not a network capability, but is carried with
+	// network flags, because these are the only capabilities orders have.
+	// It means that tasks are run within a sandbox, see "SANDBOX" isolation
+	// level.
+	IsolationSandbox = uint64(0x8)
 )
 
 func (m *NetFlags) ToBoolSlice() []bool {
@@ -70,6 +75,13 @@ func (m *NetFlags) GetOverlay() bool {
 	return m.Flags&NetworkOverlay == NetworkOverlay
 }
 
+func (m *NetFlags) GetSandbox() bool {
+	if m == nil {
+		return false
+	}
+	return m.Flags&IsolationSandbox == IsolationSandbox
+}
+
 func (m *NetFlags) SetIncoming(value bool) *NetFlags {
 	if value {
 		m.Flags |= NetworkIncoming
@@ -97,6 +109,15 @@ func (m *NetFlags) SetOverlay(value bool) *NetFlags {
 	return m
 }
 
+func (m *NetFlags) SetSandbox(value bool) *NetFlags {
+	if value {
+		m.Flags |= IsolationSandbox
+	} else {
+		m.Flags &= ^IsolationSandbox
+	}
+	return m
+}
+
 func (m *NetFlags) ConverseImplication(cmp *NetFlags) bool {
 	return m.GetFlags()|^cmp.GetFlags() == 1<<64-1
 }
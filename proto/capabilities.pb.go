@@ -39,6 +39,36 @@ func (x GPUVendorType) String() string {
 }
 func (GPUVendorType) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{0} }
 
+// IsolationLevel describes how tasks are isolated from the host.
+type IsolationLevel int32
+
+const (
+	// CONTAINER means regular containers sharing the host kernel.
+	IsolationLevel_CONTAINER IsolationLevel = 0
+	// USER_NAMESPACE means containers with the root user remapped to an
+	// unprivileged host user.
+	IsolationLevel_USER_NAMESPACE IsolationLevel = 1
+	// SANDBOX means tasks run within a sandbox having its own kernel, like
+	// gVisor or Kata Containers do.
+	IsolationLevel_SANDBOX IsolationLevel = 2
+)
+
+var IsolationLevel_name = map[int32]string{
+	0: "CONTAINER",
+	1: "USER_NAMESPACE",
+	2: "SANDBOX",
+}
+var IsolationLevel_value = map[string]int32{
+	"CONTAINER":      0,
+	"USER_NAMESPACE": 1,
+	"SANDBOX":        2,
+}
+
+func (x IsolationLevel) String() string {
+	return proto.EnumName(IsolationLevel_name, int32(x))
+}
+func (IsolationLevel) EnumDescriptor() ([]byte, []int) { return fileDescriptor3, []int{1} }
+
 type CPUDevice struct {
 	// ModelName describes full model name.
 	// For example "Intel(R) Core(TM) i5-5257U CPU @ 2.70GHz".
@@ -377,51 +407,55 @@ func init() {
 	proto.RegisterType((*StorageDevice)(nil), "sonm.StorageDevice")
 	proto.RegisterType((*Storage)(nil), "sonm.Storage")
 	proto.RegisterEnum("sonm.GPUVendorType", GPUVendorType_name, GPUVendorType_value)
+	proto.RegisterEnum("sonm.IsolationLevel", IsolationLevel_name, IsolationLevel_value)
 }
 
 func init() { proto.RegisterFile("capabilities.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 645 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0x4b, 0x6f, 0xd3, 0x40,
-	0x10, 0xc6, 0xce, 0x7b, 0x42, 0xda, 0xb0, 0x54, 0xc8, 0x44, 0x3c, 0xa2, 0x48, 0x40, 0x55, 0xa4,
-	0x1c, 0xca, 0x81, 0x87, 0x84, 0x90, 0x5b, 0xb7, 0x91, 0x55, 0xc5, 0x09, 0x0b, 0x29, 0xe2, 0x84,
-	0x36, 0xe9, 0xd2, 0x9a, 0xd8, 0xde, 0xca, 0xde, 0x04, 0xe5, 0xcc, 0x89, 0x9f, 0xc4, 0x0f, 0xe0,
-	0x7f, 0xa1, 0x7d, 0x64, 0x9d, 0xb4, 0x54, 0x02, 0x55, 0xea, 0x6d, 0x5e, 0xdf, 0x37, 0x3b, 0x33,
-	0x5f, 0x1c, 0x40, 0x13, 0x72, 0x4e, 0xc6, 0x61, 0x14, 0xf2, 0x90, 0x66, 0xdd, 0xf3, 0x94, 0x71,
-	0x86, 0x8a, 0x19, 0x4b, 0xe2, 0x56, 0x73, 0x4c, 0x93, 0xc9, 0x59, 0x4c, 0xd2, 0xa9, 0x8e, 0x77,
-	0x3e, 0x43, 0x6d, 0x7f, 0x38, 0xf2, 0xe8, 0x3c, 0x9c, 0x50, 0xf4, 0x00, 0x6a, 0x31, 0x3b, 0xa1,
-	0x51, 0x40, 0x62, 0xea, 0x58, 0x6d, 0x6b, 0xbb, 0x86, 0xf3, 0x00, 0xda, 0x82, 0xd2, 0x84, 0xa5,
-	0x34, 0x73, 0xec, 0xb6, 0xb5, 0xdd, 0xc0, 0xca, 0x41, 0x0e, 0x54, 0x32, 0x36, 0x99, 0x52, 0x9e,
-	0x39, 0x05, 0x19, 0x5f, 0xba, 0x9d, 0x5f, 0x16, 0x14, 0xf6, 0x87, 0x23, 0xf4, 0x0c, 0xca, 0x27,
-	0x92, 0x5f, 0x52, 0xd6, 0x77, 0x37, 0xbb, 0xe2, 0x2d, 0x5d, 0xd3, 0x16, 0xeb, 0x34, 0x7a, 0x0d,
-	0x90, 0xbf, 0xcf, 0xb1, 0xdb, 0x85, 0xed, 0xfa, 0xee, 0x7d, 0x53, 0xdc, 0xdd, 0x33, 0xb9, 0x83,
-	0x84, 0xa7, 0x0b, 0xbc, 0x52, 0xdc, 0x0a, 0x60, 0xf3, 0x42, 0x1a, 0x35, 0xa1, 0x30, 0xa5, 0x0b,
-	0xd9, 0xb3, 0x88, 0x85, 0x89, 0x9e, 0x40, 0x69, 0x4e, 0xa2, 0x19, 0x95, 0x03, 0x98, 0x77, 0x18,
-	0x1c, 0x56, 0xd9, 0x37, 0xf6, 0x2b, 0xab, 0xf3, 0x0e, 0x6a, 0xd8, 0xed, 0xeb, 0xb5, 0x6c, 0x41,
-	0x89, 0x33, 0x4e, 0x22, 0xcd, 0xa5, 0x1c, 0xb1, 0x2c, 0x32, 0x27, 0x61, 0x44, 0xc6, 0x91, 0x62,
-	0x2c, 0xe2, 0x3c, 0x20, 0x87, 0xc7, 0x6e, 0xff, 0xaa, 0xe1, 0x0d, 0xf9, 0xbf, 0x0c, 0x8f, 0xdd,
-	0xfe, 0x8d, 0x0e, 0xff, 0xc3, 0x86, 0x5a, 0xcf, 0x88, 0x62, 0x03, 0x6c, 0xdf, 0xd3, 0x6a, 0xb0,
-	0x7d, 0x0f, 0xb5, 0xa0, 0x3a, 0xa7, 0xc9, 0x09, 0x4b, 0x7d, 0x4f, 0x8f, 0x6d, 0x7c, 0xf4, 0x08,
-	0x40, 0xd9, 0x52, 0x41, 0x05, 0x89, 0x59, 0x89, 0x08, 0xac, 0x1a, 0xd7, 0xf7, 0x9c, 0x92, 0xc2,
-	0x2e, 0x7d, 0x81, 0x55, 0xb6, 0xc4, 0x96, 0x15, 0x36, 0x8f, 0xa0, 0x36, 0xd4, 0x63, 0xf2, 0x8d,
-	0xa5, 0xc1, 0x2c, 0x1e, 0xd3, 0xd4, 0xa9, 0x48, 0xf8, 0x6a, 0x48, 0x56, 0x84, 0x89, 0xa9, 0xa8,
-	0xea, 0x8a, 0x3c, 0x84, 0xee, 0x41, 0xb9, 0x4f, 0x63, 0x96, 0x2e, 0x9c, 0x9a, 0x4c, 0x6a, 0x0f,
-	0x21, 0x28, 0x9e, 0x91, 0xec, 0xcc, 0x01, 0xd9, 0x55, 0xda, 0xf2, 0x82, 0xbd, 0xab, 0xe5, 0xdb,
-	0xfb, 0x1f, 0xf9, 0xf6, 0x6e, 0x58, 0xbe, 0x6d, 0xa8, 0x06, 0x94, 0x1f, 0x46, 0xe4, 0x34, 0x13,
-	0xea, 0xfd, 0x2a, 0x8c, 0xa5, 0x7a, 0xa5, 0xd3, 0xf9, 0x59, 0x80, 0x4a, 0x40, 0xf9, 0x77, 0x96,
-	0x4e, 0xc5, 0x85, 0xc3, 0x44, 0xa7, 0xed, 0x30, 0x11, 0xad, 0xd9, 0x8c, 0xeb, 0xe3, 0x0a, 0x13,
-	0xed, 0x40, 0x35, 0xd1, 0x7c, 0xf2, 0xaa, 0xf5, 0xdd, 0x0d, 0xd5, 0x7d, 0xd9, 0x05, 0x9b, 0x3c,
-	0xda, 0x87, 0xdb, 0xf9, 0x64, 0x7e, 0xe2, 0x14, 0xe5, 0x22, 0x1e, 0x9b, 0x7a, 0xd1, 0x72, 0x65,
-	0x19, 0x7e, 0xa2, 0xd6, 0xb1, 0x06, 0x42, 0x87, 0xd0, 0xc8, 0xfd, 0xc1, 0x8c, 0x3b, 0x25, 0xc9,
-	0xd2, 0xbe, 0x8a, 0x65, 0x30, 0xe3, 0x8a, 0x66, 0x1d, 0xd6, 0x1a, 0xc2, 0x9d, 0x4b, 0xad, 0xae,
-	0xb5, 0xda, 0xd6, 0x7b, 0x40, 0x97, 0xdb, 0x5e, 0xef, 0x5a, 0x2f, 0xa1, 0xf1, 0x81, 0xb3, 0x94,
-	0x9c, 0x52, 0xfd, 0x93, 0x7b, 0x0a, 0x1b, 0xe3, 0x05, 0xa7, 0x99, 0x6b, 0xbe, 0x2f, 0x8a, 0xf8,
-	0x42, 0xb4, 0xf3, 0xdb, 0x82, 0x8a, 0x46, 0xa2, 0xe7, 0x17, 0x64, 0x7a, 0x57, 0x35, 0x5c, 0x23,
-	0x36, 0x52, 0x7d, 0xfb, 0x17, 0xa9, 0x3e, 0x5c, 0x03, 0xdc, 0xa4, 0x5c, 0x77, 0xf6, 0xa0, 0xd1,
-	0x1b, 0x8e, 0x8e, 0xe5, 0x77, 0xe2, 0xe3, 0xe2, 0x9c, 0xa2, 0x4d, 0xa8, 0xf7, 0x86, 0xa3, 0x2f,
-	0xa3, 0xe0, 0x28, 0x18, 0x7c, 0x0a, 0x9a, 0xb7, 0x10, 0x40, 0x39, 0x38, 0xf6, 0x3d, 0xdf, 0x6d,
-	0x5a, 0xc2, 0xc6, 0xae, 0x77, 0x30, 0x08, 0x9a, 0x36, 0xaa, 0x42, 0xf1, 0xd0, 0x3d, 0x3a, 0x68,
-	0x4e, 0xc6, 0x65, 0xf9, 0x7f, 0xf6, 0xe2, 0x4f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xfa, 0xd4, 0x5e,
-	0x77, 0xfd, 0x06, 0x00, 0x00,
+	// 698 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x55, 0xcd, 0x6e, 0xd3, 0x4a,
+	0x14, 0xae, 0x9d, 0xff, 0x93, 0x9b, 0xd4, 0x77, 0x6e, 0x75, 0xe5, 0x1b, 0x5d, 0x20, 0x8a, 0x04,
+	0x54, 0x45, 0xca, 0xa2, 0x2c, 0xf8, 0x91, 0x10, 0xb8, 0x71, 0x1a, 0x59, 0x25, 0x93, 0x30, 0x69,
+	0x0a, 0xac, 0xaa, 0x49, 0x3a, 0xb4, 0x26, 0xb6, 0xa7, 0xb2, 0x27, 0x41, 0x59, 0xb3, 0xe2, 0x91,
+	0x78, 0x00, 0xde, 0x0b, 0x79, 0xc6, 0xb1, 0x93, 0x96, 0x4a, 0xa0, 0x4a, 0xdd, 0x9d, 0xbf, 0xef,
+	0x3b, 0x73, 0xce, 0xf9, 0xe2, 0x00, 0x9a, 0xd2, 0x4b, 0x3a, 0x71, 0x3d, 0x57, 0xb8, 0x2c, 0x6a,
+	0x5f, 0x86, 0x5c, 0x70, 0x94, 0x8f, 0x78, 0xe0, 0x37, 0x8c, 0x09, 0x0b, 0xa6, 0x17, 0x3e, 0x0d,
+	0x67, 0x49, 0xbc, 0xf5, 0x11, 0x2a, 0x9d, 0xe1, 0xd8, 0x66, 0x0b, 0x77, 0xca, 0xd0, 0xff, 0x50,
+	0xf1, 0xf9, 0x19, 0xf3, 0x30, 0xf5, 0x99, 0xa9, 0x35, 0xb5, 0xdd, 0x0a, 0xc9, 0x02, 0x68, 0x07,
+	0x0a, 0x53, 0x1e, 0xb2, 0xc8, 0xd4, 0x9b, 0xda, 0x6e, 0x8d, 0x28, 0x07, 0x99, 0x50, 0x8a, 0xf8,
+	0x74, 0xc6, 0x44, 0x64, 0xe6, 0x64, 0x7c, 0xe5, 0xb6, 0xbe, 0x6b, 0x90, 0xeb, 0x0c, 0xc7, 0xe8,
+	0x31, 0x14, 0xcf, 0x24, 0xbf, 0xa4, 0xac, 0xee, 0x6f, 0xb7, 0xe3, 0xb7, 0xb4, 0xd3, 0xb6, 0x24,
+	0x49, 0xa3, 0x17, 0x00, 0xd9, 0xfb, 0x4c, 0xbd, 0x99, 0xdb, 0xad, 0xee, 0xff, 0x97, 0x16, 0xb7,
+	0x0f, 0xd2, 0x5c, 0x37, 0x10, 0xe1, 0x92, 0xac, 0x15, 0x37, 0x30, 0x6c, 0x5f, 0x49, 0x23, 0x03,
+	0x72, 0x33, 0xb6, 0x94, 0x3d, 0xf3, 0x24, 0x36, 0xd1, 0x43, 0x28, 0x2c, 0xa8, 0x37, 0x67, 0xa6,
+	0xbe, 0xfe, 0x8e, 0x14, 0x47, 0x54, 0xf6, 0xa5, 0xfe, 0x5c, 0x6b, 0xbd, 0x86, 0x0a, 0xb1, 0xfa,
+	0xc9, 0x5a, 0x76, 0xa0, 0x20, 0xb8, 0xa0, 0x5e, 0xc2, 0xa5, 0x9c, 0x78, 0x59, 0x74, 0x41, 0x5d,
+	0x8f, 0x4e, 0x3c, 0xc5, 0x98, 0x27, 0x59, 0x40, 0x0e, 0x4f, 0xac, 0xfe, 0x4d, 0xc3, 0xa7, 0xe4,
+	0xbf, 0x33, 0x3c, 0xb1, 0xfa, 0x77, 0x3a, 0xfc, 0x57, 0x1d, 0x2a, 0xbd, 0x54, 0x14, 0x75, 0xd0,
+	0x1d, 0x3b, 0x51, 0x83, 0xee, 0xd8, 0xa8, 0x01, 0xe5, 0x05, 0x0b, 0xce, 0x78, 0xe8, 0xd8, 0xc9,
+	0xd8, 0xa9, 0x8f, 0xee, 0x03, 0x28, 0x5b, 0x2a, 0x28, 0x27, 0x31, 0x6b, 0x91, 0x18, 0xab, 0xc6,
+	0x75, 0x6c, 0xb3, 0xa0, 0xb0, 0x2b, 0x3f, 0xc6, 0x2a, 0x5b, 0x62, 0x8b, 0x0a, 0x9b, 0x45, 0x50,
+	0x13, 0xaa, 0x3e, 0xfd, 0xcc, 0x43, 0x3c, 0xf7, 0x27, 0x2c, 0x34, 0x4b, 0x12, 0xbe, 0x1e, 0x92,
+	0x15, 0x6e, 0x90, 0x56, 0x94, 0x93, 0x8a, 0x2c, 0x84, 0xfe, 0x85, 0x62, 0x9f, 0xf9, 0x3c, 0x5c,
+	0x9a, 0x15, 0x99, 0x4c, 0x3c, 0x84, 0x20, 0x7f, 0x41, 0xa3, 0x0b, 0x13, 0x64, 0x57, 0x69, 0xcb,
+	0x0b, 0xf6, 0x6e, 0x96, 0x6f, 0xef, 0x4f, 0xe4, 0xdb, 0xbb, 0x63, 0xf9, 0x36, 0xa1, 0x8c, 0x99,
+	0x38, 0xf4, 0xe8, 0x79, 0x14, 0xab, 0xf7, 0x53, 0x6c, 0xac, 0xd4, 0x2b, 0x9d, 0xd6, 0xb7, 0x1c,
+	0x94, 0x30, 0x13, 0x5f, 0x78, 0x38, 0x8b, 0x2f, 0xec, 0x06, 0x49, 0x5a, 0x77, 0x83, 0xb8, 0x35,
+	0x9f, 0x8b, 0xe4, 0xb8, 0xb1, 0x89, 0xf6, 0xa0, 0x1c, 0x24, 0x7c, 0xf2, 0xaa, 0xd5, 0xfd, 0xba,
+	0xea, 0xbe, 0xea, 0x42, 0xd2, 0x3c, 0xea, 0xc0, 0x5f, 0xd9, 0x64, 0x4e, 0x60, 0xe6, 0xe5, 0x22,
+	0x1e, 0xa4, 0xf5, 0x71, 0xcb, 0xb5, 0x65, 0x38, 0x81, 0x5a, 0xc7, 0x06, 0x08, 0x1d, 0x42, 0x2d,
+	0xf3, 0x07, 0x73, 0x61, 0x16, 0x24, 0x4b, 0xf3, 0x26, 0x96, 0xc1, 0x5c, 0x28, 0x9a, 0x4d, 0x58,
+	0x63, 0x08, 0x7f, 0x5f, 0x6b, 0x75, 0xab, 0xd5, 0x36, 0xde, 0x01, 0xba, 0xde, 0xf6, 0x76, 0xd7,
+	0x7a, 0x06, 0xb5, 0x91, 0xe0, 0x21, 0x3d, 0x67, 0xc9, 0x4f, 0xee, 0x11, 0xd4, 0x27, 0x4b, 0xc1,
+	0x22, 0x2b, 0xfd, 0xbe, 0x28, 0xe2, 0x2b, 0xd1, 0xd6, 0x0f, 0x0d, 0x4a, 0x09, 0x12, 0x3d, 0xb9,
+	0x22, 0xd3, 0x7f, 0x54, 0xc3, 0x0d, 0xe2, 0x54, 0xaa, 0xaf, 0x7e, 0x21, 0xd5, 0x7b, 0x1b, 0x80,
+	0xbb, 0x94, 0xeb, 0xde, 0x01, 0xd4, 0x7a, 0xc3, 0xf1, 0x89, 0xfc, 0x4e, 0x1c, 0x2f, 0x2f, 0x19,
+	0xda, 0x86, 0x6a, 0x6f, 0x38, 0x3e, 0x1d, 0xe3, 0x23, 0x3c, 0x78, 0x8f, 0x8d, 0x2d, 0x04, 0x50,
+	0xc4, 0x27, 0x8e, 0xed, 0x58, 0x86, 0x16, 0xdb, 0xc4, 0xb2, 0xbb, 0x03, 0x6c, 0xe8, 0xa8, 0x0c,
+	0xf9, 0x43, 0xeb, 0xa8, 0x6b, 0x4c, 0xf7, 0xde, 0x40, 0xdd, 0x89, 0xb8, 0x47, 0x85, 0xcb, 0x83,
+	0xb7, 0x6c, 0xc1, 0x3c, 0x54, 0x83, 0x4a, 0x67, 0x80, 0x8f, 0x2d, 0x07, 0x77, 0x89, 0xb1, 0x85,
+	0x10, 0xd4, 0xc7, 0xa3, 0x2e, 0x39, 0xc5, 0x56, 0xbf, 0x3b, 0x1a, 0x5a, 0x9d, 0xae, 0xa1, 0xa1,
+	0x2a, 0x94, 0x46, 0x16, 0xb6, 0x0f, 0x06, 0x1f, 0x0c, 0x7d, 0x52, 0x94, 0xff, 0x88, 0x4f, 0x7f,
+	0x0e, 0x00, 0x57, 0xf5, 0xae, 0xf8, 0x3f, 0x07, 0x00, 0x00,
 }
@@ -0,0 +1,142 @@
+package sonm
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/golang/protobuf/proto"
+)
+
+// SignHash returns the hash of token fields that must be signed by the
+// issuer.
+//
+// Fields are length-prefixed, so the signature can't be reused for another
+// token by moving bytes between adjacent fields.
+func (m *DelegationToken) SignHash() []byte {
+	expiresAt := make([]byte, 8)
+	binary.BigEndian.PutUint64(expiresAt, uint64(m.GetExpiresAt().GetSeconds()))
+
+	var dealID []byte
+	if !m.GetDealID().IsZero() {
+		dealID = m.GetDealID().Unwrap().Bytes()
+	}
+
+	numMethods := make([]byte, 4)
+	binary.BigEndian.PutUint32(numMethods, uint32(len(m.GetMethods())))
+
+	var data []byte
+	data = appendSignField(data, []byte(m.GetId()))
+	data = appendSignField(data, dealID)
+	data = appendSignField(data, m.GetIssuer().GetAddress())
+	data = appendSignField(data, m.GetDelegate().GetAddress())
+	data = append(data, numMethods...)
+	for _, method := range m.GetMethods() {
+		data = appendSignField(data, []byte(method))
+	}
+	data = appendSignField(data, expiresAt)
+
+	return chainhash.DoubleHashB(data)
+}
+
+// SignWith fills the issuer field using the given key and signs the token.
+func (m *DelegationToken) SignWith(key *ecdsa.PrivateKey) error {
+	m.Issuer = NewEthAddress(crypto.PubkeyToAddress(key.PublicKey))
+
+	sign, err := crypto.Sign(m.SignHash(), key)
+	if err != nil {
+		return fmt.Errorf("failed to sign delegation token: %v", err)
+	}
+
+	m.Sign = sign
+	return nil
+}
+
+// Validate checks that the token is well-formed and signed by its issuer.
+// Note that expiration is not checked here.
+func (m *DelegationToken) Validate() error {
+	if len(m.GetId()) == 0 {
+		return errors.New("token ID is required")
+	}
+
+	if m.GetDealID().IsZero() {
+		return errors.New("deal ID is required")
+	}
+
+	if len(m.GetIssuer().GetAddress()) != 20 {
+		return errors.New("issuer address must have exactly 20 bytes format")
+	}
+
+	if len(m.GetDelegate().GetAddress()) != 20 {
+		return errors.New("delegate address must have exactly 20 bytes format")
+	}
+
+	if len(m.GetMethods()) == 0 {
+		return errors.New("at least one method must be delegated")
+	}
+
+	if m.GetExpiresAt() == nil {
+		return errors.New("expiration time is required")
+	}
+
+	publicKey, err := crypto.SigToPub(m.SignHash(), m.GetSign())
+	if err != nil {
+		return errors.New("invalid signature")
+	}
+
+	if !bytes.Equal(crypto.PubkeyToAddress(*publicKey).Bytes(), m.GetIssuer().GetAddress()) {
+		return errors.New("invalid signature for provided issuer ETH address")
+	}
+
+	return nil
+}
+
+// IsExpired checks whether the token is expired at the given time. Only
+// seconds are taken into account, because nanoseconds are not signed.
+func (m *DelegationToken) IsExpired(now time.Time) bool {
+	return now.Unix() >= m.GetExpiresAt().GetSeconds()
+}
+
+// Allows checks whether the given method is delegated by the token.
+func (m *DelegationToken) Allows(method string) bool {
+	for _, allowed := range m.GetMethods() {
+		if allowed == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Encode encodes the token into the form suitable for passing through gRPC
+// metadata or command line.
+func (m *DelegationToken) Encode() (string, error) {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeDelegationToken decodes the token previously encoded using
+// "DelegationToken.Encode".
+func DecodeDelegationToken(encoded string) (*DelegationToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode delegation token: %v", err)
+	}
+
+	token := &DelegationToken{}
+	if err := proto.Unmarshal(data, token); err != nil {
+		return nil, fmt.Errorf("failed to decode delegation token: %v", err)
+	}
+
+	return token, nil
+}
@@ -0,0 +1,98 @@
+package sonm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestDelegationToken(t *testing.T) *DelegationToken {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	token := &DelegationToken{
+		Id:        "token",
+		DealID:    NewBigIntFromInt(42),
+		Delegate:  NewEthAddress(common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fd")),
+		Methods:   []string{"TaskStatus", "TaskLogs"},
+		ExpiresAt: &Timestamp{Seconds: time.Now().Add(time.Hour).Unix()},
+	}
+	require.NoError(t, token.SignWith(key))
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), token.GetIssuer().Unwrap())
+
+	return token
+}
+
+func TestDelegationTokenValidate(t *testing.T) {
+	token := newTestDelegationToken(t)
+	require.NoError(t, token.Validate())
+
+	assert.True(t, token.Allows("TaskLogs"))
+	assert.False(t, token.Allows("StartTask"))
+	assert.False(t, token.IsExpired(time.Now()))
+	assert.True(t, token.IsExpired(time.Now().Add(2*time.Hour)))
+}
+
+func TestDelegationTokenValidateTampered(t *testing.T) {
+	token := newTestDelegationToken(t)
+	token.Methods = append(token.Methods, "StartTask")
+	assert.Error(t, token.Validate())
+
+	token = newTestDelegationToken(t)
+	token.DealID = NewBigIntFromInt(43)
+	assert.Error(t, token.Validate())
+
+	token = newTestDelegationToken(t)
+	token.ExpiresAt.Seconds++
+	assert.Error(t, token.Validate())
+
+	token = newTestDelegationToken(t)
+	token.Sign = nil
+	assert.Error(t, token.Validate())
+}
+
+func TestDelegationTokenValidateResplit(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	token := &DelegationToken{
+		Id:        "x\x00\x01",
+		DealID:    NewBigIntFromInt(5),
+		Delegate:  NewEthAddress(common.HexToAddress("0x8125721c2413d99a33e351e1f6bb4e56b6b633fd")),
+		Methods:   []string{"TaskStatus"},
+		ExpiresAt: &Timestamp{Seconds: time.Now().Add(time.Hour).Unix()},
+	}
+	require.NoError(t, token.SignWith(key))
+	require.NoError(t, token.Validate())
+
+	// The same bytes split differently between the ID and the deal must
+	// not share the signature.
+	resplit := *token
+	resplit.Id = "x"
+	resplit.DealID = NewBigIntFromInt(0x010005)
+	assert.Error(t, resplit.Validate())
+
+	resplit = *token
+	resplit.Methods = []string{"Task", "Status"}
+	assert.Error(t, resplit.Validate())
+}
+
+func TestDelegationTokenEncodeDecode(t *testing.T) {
+	token := newTestDelegationToken(t)
+
+	encoded, err := token.Encode()
+	require.NoError(t, err)
+
+	decoded, err := DecodeDelegationToken(encoded)
+	require.NoError(t, err)
+	require.NoError(t, decoded.Validate())
+	assert.Equal(t, token.GetId(), decoded.GetId())
+	assert.Equal(t, token.GetMethods(), decoded.GetMethods())
+
+	_, err = DecodeDelegationToken("not a token")
+	assert.Error(t, err)
+}
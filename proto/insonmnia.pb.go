@@ -241,6 +241,9 @@ type ResourceUsage struct {
 	Cpu     *CPUUsage                `protobuf:"bytes,1,opt,name=cpu" json:"cpu,omitempty"`
 	Memory  *MemoryUsage             `protobuf:"bytes,2,opt,name=memory" json:"memory,omitempty"`
 	Network map[string]*NetworkUsage `protobuf:"bytes,3,rep,name=network" json:"network,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// EgressViolations is the number of packets dropped by the egress
+	// policy.
+	EgressViolations uint64 `protobuf:"varint,4,opt,name=egressViolations" json:"egressViolations,omitempty"`
 }
 
 func (m *ResourceUsage) Reset()                    { *m = ResourceUsage{} }
@@ -269,6 +272,13 @@ func (m *ResourceUsage) GetNetwork() map[string]*NetworkUsage {
 	return nil
 }
 
+func (m *ResourceUsage) GetEgressViolations() uint64 {
+	if m != nil {
+		return m.EgressViolations
+	}
+	return 0
+}
+
 type TaskLogsRequest struct {
 	Type          TaskLogsRequest_Type `protobuf:"varint,1,opt,name=type,enum=sonm.TaskLogsRequest_Type" json:"type,omitempty"`
 	Id            string               `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
@@ -495,51 +505,52 @@ func init() {
 func init() { proto.RegisterFile("insonmnia.proto", fileDescriptor6) }
 
 var fileDescriptor6 = []byte{
-	// 735 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xdd, 0x6e, 0xda, 0x4a,
-	0x10, 0x3e, 0x18, 0x0c, 0x66, 0x20, 0x09, 0x67, 0x15, 0x1d, 0x21, 0xce, 0x39, 0x15, 0x72, 0xa3,
-	0x8a, 0x54, 0x15, 0x17, 0x49, 0x2f, 0xaa, 0x5c, 0x54, 0x6a, 0x62, 0xaa, 0x22, 0xb5, 0x09, 0xda,
-	0x90, 0x07, 0x58, 0xec, 0x15, 0x59, 0x61, 0x7b, 0xdd, 0xdd, 0x75, 0x03, 0x79, 0x9d, 0xbe, 0x5c,
-	0xaf, 0xfb, 0x04, 0xd5, 0xfe, 0x98, 0x40, 0x2a, 0xf5, 0x6e, 0xbe, 0xf9, 0xbe, 0xf5, 0xac, 0xe7,
-	0x9b, 0x59, 0x38, 0x62, 0xb9, 0xe4, 0x79, 0x96, 0x33, 0x32, 0x2e, 0x04, 0x57, 0x1c, 0x35, 0x34,
-	0x1c, 0x74, 0x17, 0x6c, 0xc9, 0x72, 0x65, 0x73, 0x03, 0x14, 0x93, 0x82, 0x2c, 0x58, 0xca, 0x14,
-	0xa3, 0xd2, 0xe5, 0x8e, 0x14, 0xcb, 0xa8, 0x54, 0x24, 0x2b, 0x6c, 0x22, 0x6c, 0x81, 0x3f, 0xc9,
-	0x0a, 0xb5, 0x09, 0x8f, 0xc1, 0x9b, 0x46, 0xe8, 0x10, 0x3c, 0x96, 0xf4, 0x6b, 0xc3, 0xda, 0xa8,
-	0x8d, 0x3d, 0x96, 0x84, 0xff, 0x42, 0xfb, 0xba, 0xcc, 0xa8, 0x60, 0xf1, 0x1e, 0xd9, 0x30, 0xe4,
-	0x29, 0xf8, 0x13, 0x75, 0x3f, 0x8d, 0xd0, 0x70, 0x4b, 0x74, 0xce, 0x7a, 0x63, 0x7d, 0x95, 0xf1,
-	0x44, 0xdd, 0x7f, 0x48, 0x12, 0x41, 0xa5, 0x34, 0xd2, 0xf7, 0xd0, 0x9c, 0x13, 0xb9, 0xfa, 0xbd,
-	0x02, 0x3a, 0x81, 0x66, 0x42, 0x49, 0x3a, 0x8d, 0xfa, 0x9e, 0x39, 0xdf, 0xb5, 0xe7, 0x2f, 0xd9,
-	0x72, 0x9a, 0x2b, 0xec, 0xb8, 0xf0, 0x7f, 0xf0, 0xaf, 0x78, 0x99, 0x2b, 0x74, 0x0c, 0x7e, 0xac,
-	0x03, 0x77, 0x0d, 0x0b, 0xc2, 0x21, 0x04, 0x57, 0xb3, 0xbb, 0x3b, 0x49, 0x96, 0x54, 0x2b, 0x14,
-	0x57, 0x24, 0xad, 0x14, 0x06, 0x84, 0xa7, 0xd0, 0xf9, 0x42, 0x33, 0x2e, 0x36, 0x56, 0x34, 0x80,
-	0x20, 0x23, 0x6b, 0x13, 0x3b, 0xdd, 0x16, 0x87, 0x3f, 0x6b, 0xd0, 0xbd, 0xa6, 0xea, 0x81, 0x8b,
-	0x95, 0x15, 0xf7, 0xa1, 0xa5, 0xd6, 0x97, 0x1b, 0x45, 0xa5, 0xd3, 0x56, 0x50, 0x33, 0xc2, 0x31,
-	0x9e, 0x65, 0x1c, 0x44, 0xff, 0x41, 0x5b, 0xad, 0x67, 0x24, 0x5e, 0x51, 0x25, 0xfb, 0x75, 0xc3,
-	0x3d, 0x25, 0x34, 0x2b, 0xb6, 0x6c, 0xc3, 0xb2, 0xdb, 0x84, 0xbe, 0x9c, 0x5a, 0x4f, 0x84, 0xe0,
-	0x42, 0xf6, 0x7d, 0x7b, 0xb9, 0x0a, 0x6b, 0x4e, 0x54, 0x5c, 0xd3, 0x72, 0x15, 0xb6, 0x35, 0x23,
-	0xc1, 0x8b, 0x82, 0x26, 0xfd, 0x56, 0x55, 0xd3, 0x25, 0x6c, 0xcd, 0x8a, 0x0d, 0xaa, 0x9a, 0x2e,
-	0x11, 0xfe, 0xa8, 0xc1, 0x01, 0xa6, 0x92, 0x97, 0x22, 0xa6, 0xf6, 0xaf, 0x87, 0x50, 0x8f, 0x8b,
-	0xd2, 0xb9, 0x7a, 0x68, 0x5d, 0xa9, 0x9a, 0x8c, 0x35, 0x85, 0x4e, 0xa1, 0x99, 0x99, 0x9e, 0x3a,
-	0xeb, 0xfe, 0xb6, 0xa2, 0x9d, 0x3e, 0x63, 0x27, 0x40, 0x17, 0xd0, 0xca, 0x6d, 0x4b, 0xfb, 0xf5,
-	0x61, 0x7d, 0xd4, 0x39, 0x1b, 0x5a, 0xed, 0x5e, 0xc9, 0xb1, 0xeb, 0xfa, 0x24, 0x57, 0x62, 0x83,
-	0xab, 0x03, 0x83, 0xeb, 0xad, 0x1d, 0x86, 0x40, 0x3d, 0xa8, 0xaf, 0xe8, 0xc6, 0x8d, 0x90, 0x0e,
-	0xd1, 0x08, 0xfc, 0x6f, 0x24, 0x2d, 0xa9, 0xbb, 0x07, 0xb2, 0xdf, 0xde, 0xf5, 0x10, 0x5b, 0xc1,
-	0x85, 0xf7, 0xae, 0x16, 0x7e, 0xf7, 0xe0, 0x48, 0x0f, 0xe3, 0x67, 0xbe, 0x94, 0x98, 0x7e, 0x2d,
-	0xa9, 0x54, 0x68, 0x0c, 0x0d, 0xb5, 0x29, 0xec, 0x2c, 0x1c, 0x9e, 0x0d, 0xec, 0x07, 0x9e, 0x89,
-	0xc6, 0xf3, 0x4d, 0x41, 0xb1, 0xd1, 0xb9, 0x29, 0xf6, 0xb6, 0x53, 0x7c, 0x0c, 0xbe, 0x64, 0x79,
-	0x4c, 0x8d, 0xd5, 0x6d, 0x6c, 0x01, 0x3a, 0x81, 0x03, 0x92, 0x24, 0xf3, 0x6a, 0xe5, 0xac, 0xd5,
-	0x01, 0xde, 0x4f, 0xa2, 0x7f, 0xa0, 0xf9, 0x91, 0xa7, 0x29, 0x7f, 0x30, 0x66, 0x07, 0xd8, 0x21,
-	0x84, 0xa0, 0x31, 0x27, 0x2c, 0x35, 0x36, 0xb7, 0xb1, 0x89, 0xf5, 0xc0, 0x45, 0x54, 0x11, 0x96,
-	0x4a, 0x63, 0x70, 0x80, 0x2b, 0xb8, 0xb3, 0x47, 0xc1, 0x1f, 0xf6, 0x68, 0x04, 0x0d, 0xfd, 0x17,
-	0x08, 0xa0, 0x79, 0x3b, 0x8f, 0x6e, 0xee, 0xe6, 0xbd, 0xbf, 0x5c, 0x3c, 0xc1, 0xb8, 0x57, 0x43,
-	0x01, 0x34, 0x2e, 0x6f, 0xe6, 0x9f, 0x7a, 0x5e, 0xf8, 0x12, 0x0e, 0xaa, 0xff, 0xbf, 0xba, 0x2f,
-	0xf3, 0x95, 0xbe, 0x4e, 0x42, 0x14, 0x31, 0x2d, 0xea, 0x62, 0x13, 0x9b, 0xb5, 0x34, 0xa4, 0x5e,
-	0x4b, 0x1d, 0x38, 0xd6, 0x82, 0xf0, 0x05, 0x04, 0x33, 0xc1, 0x97, 0xfa, 0x15, 0xd0, 0xc7, 0x25,
-	0x7b, 0xb4, 0x1d, 0xae, 0x63, 0x13, 0x87, 0x6f, 0x20, 0x88, 0x4a, 0x41, 0x14, 0xe3, 0x39, 0x1a,
-	0x42, 0x27, 0x27, 0x39, 0x97, 0x34, 0xe6, 0x79, 0x22, 0x9d, 0x6c, 0x37, 0x15, 0xbe, 0x02, 0x78,
-	0x7a, 0x55, 0x74, 0x27, 0x88, 0x0d, 0x5d, 0xcd, 0x0a, 0xea, 0xc7, 0x20, 0x22, 0x8a, 0xdc, 0xb2,
-	0x47, 0xf3, 0x18, 0x2c, 0x76, 0x16, 0xd7, 0x82, 0xf0, 0x2d, 0x74, 0x2b, 0x05, 0x26, 0xca, 0xf8,
-	0xb4, 0x60, 0x4a, 0xce, 0xa8, 0xb8, 0x35, 0xb5, 0x9c, 0x7a, 0x3f, 0x19, 0x9e, 0x83, 0x3f, 0x13,
-	0x2c, 0xa6, 0xe8, 0x35, 0xb4, 0x8b, 0x3d, 0xe9, 0xf3, 0x6e, 0x3f, 0xd1, 0x8b, 0xa6, 0x79, 0x66,
-	0xcf, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff, 0xb5, 0x9d, 0xef, 0xfa, 0xb2, 0x05, 0x00, 0x00,
+	// 745 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x54, 0xdb, 0x6e, 0x32, 0x37,
+	0x10, 0x2e, 0x0b, 0x0b, 0xcb, 0x40, 0x12, 0x6a, 0x45, 0x15, 0xa2, 0x07, 0xa1, 0xed, 0xaf, 0x8a,
+	0x44, 0x15, 0x17, 0x49, 0x2f, 0xaa, 0x5c, 0x54, 0x6a, 0xb2, 0x54, 0x45, 0x6a, 0x13, 0xe4, 0x90,
+	0xde, 0x9b, 0x5d, 0x8b, 0x58, 0xec, 0xae, 0xb7, 0xb6, 0xb7, 0x81, 0x3c, 0x47, 0xdf, 0xa0, 0x6f,
+	0xd8, 0x27, 0xa8, 0x7c, 0x22, 0x90, 0x48, 0xbd, 0x9b, 0x6f, 0xbe, 0xcf, 0x9e, 0xf1, 0x1c, 0x0c,
+	0x67, 0xac, 0x94, 0xbc, 0x2c, 0x4a, 0x46, 0xa6, 0x95, 0xe0, 0x8a, 0xa3, 0x96, 0x86, 0xa3, 0xfe,
+	0x8a, 0xad, 0x59, 0xa9, 0xac, 0x6f, 0x84, 0x52, 0x52, 0x91, 0x15, 0xcb, 0x99, 0x62, 0x54, 0x3a,
+	0xdf, 0x99, 0x62, 0x05, 0x95, 0x8a, 0x14, 0x95, 0x75, 0xc4, 0x1d, 0x08, 0x67, 0x45, 0xa5, 0x76,
+	0xf1, 0x39, 0x04, 0xf3, 0x04, 0x9d, 0x42, 0xc0, 0xb2, 0x61, 0x63, 0xdc, 0x98, 0x74, 0x71, 0xc0,
+	0xb2, 0xf8, 0x4b, 0xe8, 0xde, 0xd7, 0x05, 0x15, 0x2c, 0x3d, 0x22, 0x5b, 0x86, 0xbc, 0x80, 0x70,
+	0xa6, 0x9e, 0xe7, 0x09, 0x1a, 0xef, 0x89, 0xde, 0xd5, 0x60, 0xaa, 0x53, 0x99, 0xce, 0xd4, 0xf3,
+	0xcf, 0x59, 0x26, 0xa8, 0x94, 0x46, 0xfa, 0x13, 0xb4, 0x97, 0x44, 0x6e, 0x3e, 0x46, 0x40, 0x9f,
+	0xa0, 0x9d, 0x51, 0x92, 0xcf, 0x93, 0x61, 0x60, 0xce, 0xf7, 0xed, 0xf9, 0x5b, 0xb6, 0x9e, 0x97,
+	0x0a, 0x3b, 0x2e, 0xfe, 0x1a, 0xc2, 0x3b, 0x5e, 0x97, 0x0a, 0x9d, 0x43, 0x98, 0x6a, 0xc3, 0xa5,
+	0x61, 0x41, 0x3c, 0x86, 0xe8, 0x6e, 0xf1, 0xf4, 0x24, 0xc9, 0x9a, 0x6a, 0x85, 0xe2, 0x8a, 0xe4,
+	0x5e, 0x61, 0x40, 0x7c, 0x01, 0xbd, 0xdf, 0x69, 0xc1, 0xc5, 0xce, 0x8a, 0x46, 0x10, 0x15, 0x64,
+	0x6b, 0x6c, 0xa7, 0xdb, 0xe3, 0xf8, 0xdf, 0x06, 0xf4, 0xef, 0xa9, 0x7a, 0xe1, 0x62, 0x63, 0xc5,
+	0x43, 0xe8, 0xa8, 0xed, 0xed, 0x4e, 0x51, 0xe9, 0xb4, 0x1e, 0x6a, 0x46, 0x38, 0x26, 0xb0, 0x8c,
+	0x83, 0xe8, 0x2b, 0xe8, 0xaa, 0xed, 0x82, 0xa4, 0x1b, 0xaa, 0xe4, 0xb0, 0x69, 0xb8, 0x37, 0x87,
+	0x66, 0xc5, 0x9e, 0x6d, 0x59, 0x76, 0xef, 0xd0, 0xc9, 0xa9, 0xed, 0x4c, 0x08, 0x2e, 0xe4, 0x30,
+	0xb4, 0xc9, 0x79, 0xac, 0x39, 0xe1, 0xb9, 0xb6, 0xe5, 0x3c, 0xb6, 0x31, 0x13, 0xc1, 0xab, 0x8a,
+	0x66, 0xc3, 0x8e, 0x8f, 0xe9, 0x1c, 0x36, 0xa6, 0x67, 0x23, 0x1f, 0xd3, 0x39, 0xe2, 0xbf, 0x03,
+	0x38, 0xc1, 0x54, 0xf2, 0x5a, 0xa4, 0xd4, 0xbe, 0x7a, 0x0c, 0xcd, 0xb4, 0xaa, 0x5d, 0x57, 0x4f,
+	0x6d, 0x57, 0x7c, 0x91, 0xb1, 0xa6, 0xd0, 0x05, 0xb4, 0x0b, 0x53, 0x53, 0xd7, 0xba, 0xcf, 0xad,
+	0xe8, 0xa0, 0xce, 0xd8, 0x09, 0xd0, 0x0d, 0x74, 0x4a, 0x5b, 0xd2, 0x61, 0x73, 0xdc, 0x9c, 0xf4,
+	0xae, 0xc6, 0x56, 0x7b, 0x14, 0x72, 0xea, 0xaa, 0x3e, 0x2b, 0x95, 0xd8, 0x61, 0x7f, 0x00, 0x5d,
+	0xc2, 0x80, 0xae, 0xf5, 0x24, 0xfd, 0xc1, 0x78, 0x4e, 0x14, 0xe3, 0xa5, 0xaf, 0xd9, 0x07, 0xff,
+	0xe8, 0x1e, 0xfa, 0x87, 0x97, 0xa0, 0x01, 0x34, 0x37, 0x74, 0xe7, 0xc6, 0x4d, 0x9b, 0x68, 0x02,
+	0xe1, 0x5f, 0x24, 0xaf, 0xa9, 0xcb, 0x19, 0xd9, 0x3c, 0x0e, 0xfb, 0x8d, 0xad, 0xe0, 0x26, 0xf8,
+	0xb1, 0x11, 0xff, 0x13, 0xc0, 0x99, 0x1e, 0xdc, 0xdf, 0xf8, 0x5a, 0x62, 0xfa, 0x67, 0x4d, 0xa5,
+	0x42, 0x53, 0x68, 0xa9, 0x5d, 0x65, 0xe7, 0xe6, 0xf4, 0x6a, 0x64, 0x2f, 0x78, 0x27, 0x9a, 0x2e,
+	0x77, 0x15, 0xc5, 0x46, 0xe7, 0x26, 0x3e, 0xd8, 0x4f, 0xfc, 0x39, 0x84, 0x92, 0x95, 0x29, 0x35,
+	0x63, 0xd1, 0xc5, 0x16, 0xa0, 0x4f, 0x70, 0x42, 0xb2, 0x6c, 0xe9, 0xd7, 0xd3, 0x3e, 0x31, 0xc2,
+	0xc7, 0x4e, 0xf4, 0x05, 0xb4, 0x7f, 0xe1, 0x79, 0xce, 0x5f, 0xcc, 0x60, 0x44, 0xd8, 0x21, 0x84,
+	0xa0, 0xb5, 0x24, 0x2c, 0x37, 0x23, 0xd1, 0xc5, 0xc6, 0xd6, 0xc3, 0x99, 0x50, 0x45, 0x58, 0x2e,
+	0xcd, 0x30, 0x44, 0xd8, 0xc3, 0x83, 0x9d, 0x8b, 0xfe, 0x67, 0xe7, 0x26, 0xd0, 0xd2, 0xaf, 0x40,
+	0x00, 0xed, 0xc7, 0x65, 0xf2, 0xf0, 0xb4, 0x1c, 0x7c, 0xe6, 0xec, 0x19, 0xc6, 0x83, 0x06, 0x8a,
+	0xa0, 0x75, 0xfb, 0xb0, 0xfc, 0x75, 0x10, 0xc4, 0xdf, 0xc2, 0x89, 0x7f, 0xff, 0xdd, 0x73, 0x5d,
+	0x6e, 0x74, 0x3a, 0x19, 0x51, 0xc4, 0x94, 0xa8, 0x8f, 0x8d, 0x6d, 0x56, 0xd8, 0x90, 0x7a, 0x85,
+	0xb5, 0xe1, 0x58, 0x0b, 0xe2, 0x6f, 0x20, 0x5a, 0x08, 0x6e, 0xfa, 0xa9, 0x8f, 0x4b, 0xf6, 0x6a,
+	0x2b, 0xdc, 0xc4, 0xc6, 0x8e, 0xbf, 0x87, 0x28, 0xa9, 0x85, 0x69, 0x33, 0x1a, 0x43, 0xaf, 0x24,
+	0x25, 0x97, 0x34, 0xe5, 0x65, 0x26, 0x9d, 0xec, 0xd0, 0x15, 0x7f, 0x07, 0xf0, 0xf6, 0x03, 0xe9,
+	0x4a, 0x10, 0x6b, 0xba, 0x98, 0x1e, 0xea, 0x8f, 0x23, 0x21, 0x8a, 0x3c, 0xb2, 0x57, 0xf3, 0x71,
+	0xac, 0x0e, 0x96, 0xdc, 0x82, 0xf8, 0x07, 0xe8, 0x7b, 0x05, 0x26, 0xca, 0xf4, 0x69, 0xc5, 0x94,
+	0x5c, 0x50, 0xf1, 0x68, 0x62, 0x39, 0xf5, 0xb1, 0x33, 0xbe, 0x86, 0x70, 0x21, 0x58, 0x4a, 0xd1,
+	0x25, 0x74, 0xab, 0x23, 0xe9, 0xfb, 0x6a, 0xbf, 0xd1, 0xab, 0xb6, 0xf9, 0x92, 0xaf, 0xff, 0x1b,
+	0x00, 0x39, 0xb2, 0x1e, 0x3d, 0xde, 0x05, 0x00, 0x00,
 }
@@ -17,9 +17,40 @@ func NewAddr(addr net.Addr) (*Addr, error) {
 	return &Addr{
 		Protocol: addr.Network(),
 		Addr:     socketAddr,
+		Family:   socketAddr.Family(),
 	}, nil
 }
 
+// NewAddrFamily returns the family of the given IP address.
+func NewAddrFamily(ip net.IP) AddrFamily {
+	switch {
+	case ip == nil:
+		return AddrFamily_FAMILY_UNSPECIFIED
+	case ip.To4() != nil:
+		return AddrFamily_FAMILY_IPV4
+	case len(ip) == net.IPv6len:
+		return AddrFamily_FAMILY_IPV6
+	default:
+		return AddrFamily_FAMILY_UNSPECIFIED
+	}
+}
+
+// ContainsFamily checks whether the given family list contains the
+// specified family. Empty list means any family.
+func ContainsFamily(families []AddrFamily, family AddrFamily) bool {
+	if len(families) == 0 {
+		return true
+	}
+
+	for _, f := range families {
+		if f == family {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (m *Addr) IsValid() bool {
 	return m != nil && m.Addr != nil
 }
@@ -31,6 +62,23 @@ func (m *Addr) IntoTCP() (net.Addr, error) {
 	return m.Addr.IntoTCP()
 }
 
+func (m *Addr) IntoUDP() (*net.UDPAddr, error) {
+	if m.Protocol != "udp" {
+		return nil, fmt.Errorf("invalid protocol: %s", m.Protocol)
+	}
+	return m.Addr.IntoUDP()
+}
+
+// IPFamily returns the IP address family, deriving it from the address
+// itself if not specified explicitly.
+func (m *Addr) IPFamily() AddrFamily {
+	if m.GetFamily() != AddrFamily_FAMILY_UNSPECIFIED {
+		return m.GetFamily()
+	}
+
+	return m.GetAddr().Family()
+}
+
 // IsPrivate returns true if this address can't be reached from the Internet directly.
 func (m *Addr) IsPrivate() bool {
 	return m.Addr.IsPrivate()
@@ -56,10 +104,24 @@ func (m *SocketAddr) IsPrivate() bool {
 	return netutil.IsPrivateIP(net.ParseIP(m.Addr))
 }
 
+// Family returns the IP address family.
+func (m *SocketAddr) Family() AddrFamily {
+	return NewAddrFamily(net.ParseIP(m.GetAddr()))
+}
+
 func (m *SocketAddr) IntoTCP() (net.Addr, error) {
 	return m.intoNet("tcp")
 }
 
+func (m *SocketAddr) IntoUDP() (*net.UDPAddr, error) {
+	return net.ResolveUDPAddr("udp", m.hostPort())
+}
+
 func (m *SocketAddr) intoNet(protocol string) (net.Addr, error) {
-	return net.ResolveTCPAddr(protocol, fmt.Sprintf("%s:%d", m.Addr, m.Port))
+	return net.ResolveTCPAddr(protocol, m.hostPort())
+}
+
+// hostPort formats the address, enclosing IPv6 hosts in square brackets.
+func (m *SocketAddr) hostPort() string {
+	return net.JoinHostPort(m.Addr, strconv.FormatUint(uint64(m.Port), 10))
 }
@@ -12,9 +12,37 @@ var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
 
+// AddrFamily describes an IP address family.
+type AddrFamily int32
+
+const (
+	AddrFamily_FAMILY_UNSPECIFIED AddrFamily = 0
+	AddrFamily_FAMILY_IPV4        AddrFamily = 1
+	AddrFamily_FAMILY_IPV6        AddrFamily = 2
+)
+
+var AddrFamily_name = map[int32]string{
+	0: "FAMILY_UNSPECIFIED",
+	1: "FAMILY_IPV4",
+	2: "FAMILY_IPV6",
+}
+var AddrFamily_value = map[string]int32{
+	"FAMILY_UNSPECIFIED": 0,
+	"FAMILY_IPV4":        1,
+	"FAMILY_IPV6":        2,
+}
+
+func (x AddrFamily) String() string {
+	return proto.EnumName(AddrFamily_name, int32(x))
+}
+func (AddrFamily) EnumDescriptor() ([]byte, []int) { return fileDescriptor8, []int{0} }
+
 type Addr struct {
 	Protocol string      `protobuf:"bytes,1,opt,name=protocol" json:"protocol,omitempty"`
 	Addr     *SocketAddr `protobuf:"bytes,2,opt,name=addr" json:"addr,omitempty"`
+	// Family describes the IP address family. Can be omitted by older
+	// peers, in this case it's derived from the address itself.
+	Family AddrFamily `protobuf:"varint,3,opt,name=family,enum=sonm.AddrFamily" json:"family,omitempty"`
 }
 
 func (m *Addr) Reset()                    { *m = Addr{} }
@@ -36,6 +64,13 @@ func (m *Addr) GetAddr() *SocketAddr {
 	return nil
 }
 
+func (m *Addr) GetFamily() AddrFamily {
+	if m != nil {
+		return m.Family
+	}
+	return AddrFamily_FAMILY_UNSPECIFIED
+}
+
 type SocketAddr struct {
 	// Addr describes an IP address.
 	Addr string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
@@ -83,20 +118,26 @@ func init() {
 	proto.RegisterType((*Addr)(nil), "sonm.Addr")
 	proto.RegisterType((*SocketAddr)(nil), "sonm.SocketAddr")
 	proto.RegisterType((*Endpoints)(nil), "sonm.Endpoints")
+	proto.RegisterEnum("sonm.AddrFamily", AddrFamily_name, AddrFamily_value)
 }
 
 func init() { proto.RegisterFile("net.proto", fileDescriptor8) }
 
 var fileDescriptor8 = []byte{
-	// 156 bytes of a gzipped FileDescriptorProto
+	// 228 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xcc, 0x4b, 0x2d, 0xd1,
-	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x29, 0xce, 0xcf, 0xcb, 0x55, 0xf2, 0xe0, 0x62, 0x71,
+	0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x29, 0xce, 0xcf, 0xcb, 0x55, 0x2a, 0xe2, 0x62, 0x71,
 	0x4c, 0x49, 0x29, 0x12, 0x92, 0xe2, 0xe2, 0x00, 0x0b, 0x27, 0xe7, 0xe7, 0x48, 0x30, 0x2a, 0x30,
 	0x6a, 0x70, 0x06, 0xc1, 0xf9, 0x42, 0x2a, 0x5c, 0x2c, 0x89, 0x29, 0x29, 0x45, 0x12, 0x4c, 0x0a,
 	0x8c, 0x1a, 0xdc, 0x46, 0x02, 0x7a, 0x20, 0x8d, 0x7a, 0xc1, 0xf9, 0xc9, 0xd9, 0xa9, 0x25, 0x20,
-	0xbd, 0x41, 0x60, 0x59, 0x25, 0x13, 0x2e, 0x2e, 0x84, 0x98, 0x90, 0x10, 0x54, 0x0f, 0xc4, 0x2c,
-	0x30, 0x1b, 0x24, 0x56, 0x90, 0x5f, 0x54, 0x02, 0x36, 0x87, 0x37, 0x08, 0xcc, 0x56, 0xb2, 0xe6,
-	0xe2, 0x74, 0xcd, 0x4b, 0x29, 0xc8, 0xcf, 0xcc, 0x2b, 0x29, 0x16, 0xd2, 0xe3, 0xe2, 0x4c, 0x85,
-	0x71, 0x24, 0x18, 0x15, 0x98, 0xb1, 0xda, 0x86, 0x50, 0x92, 0xc4, 0x06, 0x76, 0xa2, 0x31, 0x20,
-	0x00, 0x00, 0xff, 0xff, 0x86, 0xb8, 0x6a, 0x95, 0xd6, 0x00, 0x00, 0x00,
+	0xbd, 0x41, 0x60, 0x59, 0x21, 0x0d, 0x2e, 0xb6, 0xb4, 0xc4, 0xdc, 0xcc, 0x9c, 0x4a, 0x09, 0x66,
+	0x05, 0x46, 0x0d, 0x3e, 0x98, 0x3a, 0x90, 0x0a, 0x37, 0xb0, 0x78, 0x10, 0x54, 0x5e, 0xc9, 0x84,
+	0x8b, 0x0b, 0xa1, 0x5b, 0x48, 0x08, 0x6a, 0x3a, 0xc4, 0x56, 0x88, 0x59, 0x42, 0x5c, 0x2c, 0x05,
+	0xf9, 0x45, 0x25, 0x60, 0x1b, 0x79, 0x83, 0xc0, 0x6c, 0x25, 0x6b, 0x2e, 0x4e, 0xd7, 0xbc, 0x94,
+	0x82, 0xfc, 0xcc, 0xbc, 0x92, 0x62, 0x21, 0x3d, 0x2e, 0xce, 0x54, 0x18, 0x47, 0x82, 0x51, 0x81,
+	0x19, 0xab, 0xbb, 0x10, 0x4a, 0xb4, 0xdc, 0xb8, 0xb8, 0x10, 0x0e, 0x11, 0x12, 0xe3, 0x12, 0x72,
+	0x73, 0xf4, 0xf5, 0xf4, 0x89, 0x8c, 0x0f, 0xf5, 0x0b, 0x0e, 0x70, 0x75, 0xf6, 0x74, 0xf3, 0x74,
+	0x75, 0x11, 0x60, 0x10, 0xe2, 0xe7, 0xe2, 0x86, 0x8a, 0x7b, 0x06, 0x84, 0x99, 0x08, 0x30, 0xa2,
+	0x0a, 0x98, 0x09, 0x30, 0x25, 0xb1, 0x81, 0x03, 0xc5, 0x18, 0x30, 0x00, 0xc6, 0xf6, 0x6e, 0x10,
+	0x48, 0x01, 0x00, 0x00,
 }
@@ -0,0 +1,33 @@
+package sonm
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAddrFamily(t *testing.T) {
+	assert.Equal(t, AddrFamily_FAMILY_IPV4, NewAddrFamily(net.ParseIP("192.168.1.1")))
+	assert.Equal(t, AddrFamily_FAMILY_IPV4, NewAddrFamily(net.ParseIP("::ffff:192.168.1.1")))
+	assert.Equal(t, AddrFamily_FAMILY_IPV6, NewAddrFamily(net.ParseIP("2001:db8::1")))
+	assert.Equal(t, AddrFamily_FAMILY_UNSPECIFIED, NewAddrFamily(nil))
+}
+
+func TestSocketAddrIPv6RoundTrip(t *testing.T) {
+	addr, err := NewAddr(&net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 8080})
+	require.NoError(t, err)
+
+	assert.Equal(t, AddrFamily_FAMILY_IPV6, addr.IPFamily())
+
+	tcpAddr, err := addr.IntoTCP()
+	require.NoError(t, err)
+	assert.Equal(t, "[2001:db8::1]:8080", tcpAddr.String())
+}
+
+func TestContainsFamily(t *testing.T) {
+	assert.True(t, ContainsFamily(nil, AddrFamily_FAMILY_IPV6))
+	assert.True(t, ContainsFamily([]AddrFamily{AddrFamily_FAMILY_IPV4, AddrFamily_FAMILY_IPV6}, AddrFamily_FAMILY_IPV6))
+	assert.False(t, ContainsFamily([]AddrFamily{AddrFamily_FAMILY_IPV4}, AddrFamily_FAMILY_IPV6))
+}
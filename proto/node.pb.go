@@ -241,6 +241,81 @@ func (m *BalanceReply) GetLiveEthBalance() *BigInt {
 	return nil
 }
 
+type MarketOrdersRequest struct {
+	// Filter is passed to the DWH as is, except benchmarks.
+	Filter *OrdersRequest `protobuf:"bytes,1,opt,name=filter" json:"filter,omitempty"`
+	// Benchmarks contains benchmark value ranges by their codes, for
+	// example "gpu-eth-hashrate". Zero max means no upper bound.
+	Benchmarks map[string]*MaxMinUint64 `protobuf:"bytes,2,rep,name=benchmarks" json:"benchmarks,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *MarketOrdersRequest) Reset()                    { *m = MarketOrdersRequest{} }
+func (m *MarketOrdersRequest) String() string            { return proto.CompactTextString(m) }
+func (*MarketOrdersRequest) ProtoMessage()               {}
+func (*MarketOrdersRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{9} }
+
+func (m *MarketOrdersRequest) GetFilter() *OrdersRequest {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (m *MarketOrdersRequest) GetBenchmarks() map[string]*MaxMinUint64 {
+	if m != nil {
+		return m.Benchmarks
+	}
+	return nil
+}
+
+type MatchBidRequest struct {
+	Bid       *BidOrder        `protobuf:"bytes,1,opt,name=bid" json:"bid,omitempty"`
+	Limit     uint64           `protobuf:"varint,2,opt,name=limit" json:"limit,omitempty"`
+	Offset    uint64           `protobuf:"varint,3,opt,name=offset" json:"offset,omitempty"`
+	Sortings  []*SortingOption `protobuf:"bytes,4,rep,name=sortings" json:"sortings,omitempty"`
+	WithCount bool             `protobuf:"varint,5,opt,name=withCount" json:"withCount,omitempty"`
+}
+
+func (m *MatchBidRequest) Reset()                    { *m = MatchBidRequest{} }
+func (m *MatchBidRequest) String() string            { return proto.CompactTextString(m) }
+func (*MatchBidRequest) ProtoMessage()               {}
+func (*MatchBidRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{10} }
+
+func (m *MatchBidRequest) GetBid() *BidOrder {
+	if m != nil {
+		return m.Bid
+	}
+	return nil
+}
+
+func (m *MatchBidRequest) GetLimit() uint64 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *MatchBidRequest) GetOffset() uint64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *MatchBidRequest) GetSortings() []*SortingOption {
+	if m != nil {
+		return m.Sortings
+	}
+	return nil
+}
+
+func (m *MatchBidRequest) GetWithCount() bool {
+	if m != nil {
+		return m.WithCount
+	}
+	return false
+}
+
 type TokenTransferRequest struct {
 	To     *EthAddress `protobuf:"bytes,1,opt,name=to" json:"to,omitempty"`
 	Amount *BigInt     `protobuf:"bytes,2,opt,name=amount" json:"amount,omitempty"`
@@ -249,7 +324,7 @@ type TokenTransferRequest struct {
 func (m *TokenTransferRequest) Reset()                    { *m = TokenTransferRequest{} }
 func (m *TokenTransferRequest) String() string            { return proto.CompactTextString(m) }
 func (*TokenTransferRequest) ProtoMessage()               {}
-func (*TokenTransferRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{9} }
+func (*TokenTransferRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{11} }
 
 func (m *TokenTransferRequest) GetTo() *EthAddress {
 	if m != nil {
@@ -265,6 +340,235 @@ func (m *TokenTransferRequest) GetAmount() *BigInt {
 	return nil
 }
 
+type NPPDiagnoseRequest struct {
+	// Addr is the worker's ETH address, optionally with the direct endpoint
+	// in "0x...@host:port" form.
+	Addr string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	// Pings is the number of RTT measurements.
+	Pings uint32 `protobuf:"varint,2,opt,name=pings" json:"pings,omitempty"`
+	// Size is the number of bytes transferred in each direction to measure
+	// the throughput. Zero disables the measurement.
+	Size uint64 `protobuf:"varint,3,opt,name=size" json:"size,omitempty"`
+}
+
+func (m *NPPDiagnoseRequest) Reset()                    { *m = NPPDiagnoseRequest{} }
+func (m *NPPDiagnoseRequest) String() string            { return proto.CompactTextString(m) }
+func (*NPPDiagnoseRequest) ProtoMessage()               {}
+func (*NPPDiagnoseRequest) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{12} }
+
+func (m *NPPDiagnoseRequest) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *NPPDiagnoseRequest) GetPings() uint32 {
+	if m != nil {
+		return m.Pings
+	}
+	return 0
+}
+
+func (m *NPPDiagnoseRequest) GetSize() uint64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type NPPReport struct {
+	// Stages describes connection stages in the order they are tried by the
+	// NPP dialer.
+	Stages []*NPPStageReport `protobuf:"bytes,1,rep,name=stages" json:"stages,omitempty"`
+	// Path is the name of the stage the NPP dialer would choose, i.e. the
+	// first successful one. Empty if all of them have failed.
+	Path string `protobuf:"bytes,2,opt,name=path" json:"path,omitempty"`
+	// RTT contains round-trip times measured over the chosen path.
+	RTT []*Duration `protobuf:"bytes,3,rep,name=RTT" json:"RTT,omitempty"`
+	// UploadRate shows the measured upload throughput in bytes per second.
+	UploadRate uint64 `protobuf:"varint,4,opt,name=uploadRate" json:"uploadRate,omitempty"`
+	// DownloadRate shows the measured download throughput in bytes per
+	// second.
+	DownloadRate uint64 `protobuf:"varint,5,opt,name=downloadRate" json:"downloadRate,omitempty"`
+	// Error describes why measurements have failed, if so.
+	Error string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *NPPReport) Reset()                    { *m = NPPReport{} }
+func (m *NPPReport) String() string            { return proto.CompactTextString(m) }
+func (*NPPReport) ProtoMessage()               {}
+func (*NPPReport) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{13} }
+
+func (m *NPPReport) GetStages() []*NPPStageReport {
+	if m != nil {
+		return m.Stages
+	}
+	return nil
+}
+
+func (m *NPPReport) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *NPPReport) GetRTT() []*Duration {
+	if m != nil {
+		return m.RTT
+	}
+	return nil
+}
+
+func (m *NPPReport) GetUploadRate() uint64 {
+	if m != nil {
+		return m.UploadRate
+	}
+	return 0
+}
+
+func (m *NPPReport) GetDownloadRate() uint64 {
+	if m != nil {
+		return m.DownloadRate
+	}
+	return 0
+}
+
+func (m *NPPReport) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type NPPStageReport struct {
+	// Name is the stage name, one of "direct", "rendezvous", "punch" or
+	// "relay".
+	Name     string    `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Duration *Duration `protobuf:"bytes,2,opt,name=duration" json:"duration,omitempty"`
+	// Error is empty when the stage has succeeded.
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	// RemoteAddr is the address of the remote peer connected to.
+	RemoteAddr string `protobuf:"bytes,4,opt,name=remoteAddr" json:"remoteAddr,omitempty"`
+	// Server is the rendezvous or relay server address the stage has used.
+	Server string `protobuf:"bytes,5,opt,name=server" json:"server,omitempty"`
+	// PublicAddr is the remote peer's public address resolved via the
+	// rendezvous.
+	PublicAddr *Addr `protobuf:"bytes,6,opt,name=publicAddr" json:"publicAddr,omitempty"`
+	// PrivateAddrs are the remote peer's private addresses resolved via the
+	// rendezvous.
+	PrivateAddrs []*Addr `protobuf:"bytes,7,rep,name=privateAddrs" json:"privateAddrs,omitempty"`
+	// Attempts describes punching attempts per remote address.
+	Attempts []*NPPPunchAttempt `protobuf:"bytes,8,rep,name=attempts" json:"attempts,omitempty"`
+}
+
+func (m *NPPStageReport) Reset()                    { *m = NPPStageReport{} }
+func (m *NPPStageReport) String() string            { return proto.CompactTextString(m) }
+func (*NPPStageReport) ProtoMessage()               {}
+func (*NPPStageReport) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{14} }
+
+func (m *NPPStageReport) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *NPPStageReport) GetDuration() *Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *NPPStageReport) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *NPPStageReport) GetRemoteAddr() string {
+	if m != nil {
+		return m.RemoteAddr
+	}
+	return ""
+}
+
+func (m *NPPStageReport) GetServer() string {
+	if m != nil {
+		return m.Server
+	}
+	return ""
+}
+
+func (m *NPPStageReport) GetPublicAddr() *Addr {
+	if m != nil {
+		return m.PublicAddr
+	}
+	return nil
+}
+
+func (m *NPPStageReport) GetPrivateAddrs() []*Addr {
+	if m != nil {
+		return m.PrivateAddrs
+	}
+	return nil
+}
+
+func (m *NPPStageReport) GetAttempts() []*NPPPunchAttempt {
+	if m != nil {
+		return m.Attempts
+	}
+	return nil
+}
+
+type NPPPunchAttempt struct {
+	// Addr is the remote address punched. Incoming connections have the
+	// address they have come from.
+	Addr     string    `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Duration *Duration `protobuf:"bytes,2,opt,name=duration" json:"duration,omitempty"`
+	// Error is empty when the attempt has succeeded.
+	Error string `protobuf:"bytes,3,opt,name=error" json:"error,omitempty"`
+	// Incoming is true if the connection has been accepted from the remote
+	// peer instead of being dialed.
+	Incoming bool `protobuf:"varint,4,opt,name=incoming" json:"incoming,omitempty"`
+}
+
+func (m *NPPPunchAttempt) Reset()                    { *m = NPPPunchAttempt{} }
+func (m *NPPPunchAttempt) String() string            { return proto.CompactTextString(m) }
+func (*NPPPunchAttempt) ProtoMessage()               {}
+func (*NPPPunchAttempt) Descriptor() ([]byte, []int) { return fileDescriptor9, []int{15} }
+
+func (m *NPPPunchAttempt) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *NPPPunchAttempt) GetDuration() *Duration {
+	if m != nil {
+		return m.Duration
+	}
+	return nil
+}
+
+func (m *NPPPunchAttempt) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *NPPPunchAttempt) GetIncoming() bool {
+	if m != nil {
+		return m.Incoming
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*JoinNetworkRequest)(nil), "sonm.JoinNetworkRequest")
 	proto.RegisterType((*TaskListRequest)(nil), "sonm.TaskListRequest")
@@ -275,7 +579,13 @@ func init() {
 	proto.RegisterType((*WorkerRemoveRequest)(nil), "sonm.WorkerRemoveRequest")
 	proto.RegisterType((*WorkerListReply)(nil), "sonm.WorkerListReply")
 	proto.RegisterType((*BalanceReply)(nil), "sonm.BalanceReply")
+	proto.RegisterType((*MarketOrdersRequest)(nil), "sonm.MarketOrdersRequest")
+	proto.RegisterType((*MatchBidRequest)(nil), "sonm.MatchBidRequest")
 	proto.RegisterType((*TokenTransferRequest)(nil), "sonm.TokenTransferRequest")
+	proto.RegisterType((*NPPDiagnoseRequest)(nil), "sonm.NPPDiagnoseRequest")
+	proto.RegisterType((*NPPReport)(nil), "sonm.NPPReport")
+	proto.RegisterType((*NPPStageReport)(nil), "sonm.NPPStageReport")
+	proto.RegisterType((*NPPPunchAttempt)(nil), "sonm.NPPPunchAttempt")
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -706,6 +1016,11 @@ type DealManagementClient interface {
 	// QuickBuy places BID order with the same parameters as given ASK order have,
 	// then opens deal with this two orders.
 	QuickBuy(ctx context.Context, in *QuickBuyRequest, opts ...grpc.CallOption) (*DealInfoReply, error)
+	// DelegationTokens returns delegation tokens that were used to access
+	// the deal with given ID on its worker.
+	DelegationTokens(ctx context.Context, in *BigInt, opts ...grpc.CallOption) (*DelegationTokensReply, error)
+	// RevokeDelegationToken revokes the delegation token on the deal's worker.
+	RevokeDelegationToken(ctx context.Context, in *RevokeDelegationTokenRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type dealManagementClient struct {
@@ -797,6 +1112,24 @@ func (c *dealManagementClient) QuickBuy(ctx context.Context, in *QuickBuyRequest
 	return out, nil
 }
 
+func (c *dealManagementClient) DelegationTokens(ctx context.Context, in *BigInt, opts ...grpc.CallOption) (*DelegationTokensReply, error) {
+	out := new(DelegationTokensReply)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/DelegationTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dealManagementClient) RevokeDelegationToken(ctx context.Context, in *RevokeDelegationTokenRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.DealManagement/RevokeDelegationToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for DealManagement service
 
 type DealManagementServer interface {
@@ -819,6 +1152,11 @@ type DealManagementServer interface {
 	// QuickBuy places BID order with the same parameters as given ASK order have,
 	// then opens deal with this two orders.
 	QuickBuy(context.Context, *QuickBuyRequest) (*DealInfoReply, error)
+	// DelegationTokens returns delegation tokens that were used to access
+	// the deal with given ID on its worker.
+	DelegationTokens(context.Context, *BigInt) (*DelegationTokensReply, error)
+	// RevokeDelegationToken revokes the delegation token on the deal's worker.
+	RevokeDelegationToken(context.Context, *RevokeDelegationTokenRequest) (*Empty, error)
 }
 
 func RegisterDealManagementServer(s *grpc.Server, srv DealManagementServer) {
@@ -987,6 +1325,42 @@ func _DealManagement_QuickBuy_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DealManagement_DelegationTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BigInt)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DealManagementServer).DelegationTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DealManagement/DelegationTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DealManagementServer).DelegationTokens(ctx, req.(*BigInt))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DealManagement_RevokeDelegationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDelegationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DealManagementServer).RevokeDelegationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.DealManagement/RevokeDelegationToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DealManagementServer).RevokeDelegationToken(ctx, req.(*RevokeDelegationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _DealManagement_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.DealManagement",
 	HandlerType: (*DealManagementServer)(nil),
@@ -1027,6 +1401,14 @@ var _DealManagement_serviceDesc = grpc.ServiceDesc{
 			MethodName: "QuickBuy",
 			Handler:    _DealManagement_QuickBuy_Handler,
 		},
+		{
+			MethodName: "DelegationTokens",
+			Handler:    _DealManagement_DelegationTokens_Handler,
+		},
+		{
+			MethodName: "RevokeDelegationToken",
+			Handler:    _DealManagement_RevokeDelegationToken_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "node.proto",
@@ -1652,6 +2034,214 @@ var _Profiles_serviceDesc = grpc.ServiceDesc{
 	Metadata: "node.proto",
 }
 
+// Client API for MarketSearch service
+
+type MarketSearchClient interface {
+	// Orders returns orders by the given filter.
+	Orders(ctx context.Context, in *MarketOrdersRequest, opts ...grpc.CallOption) (*DWHOrdersReply, error)
+	// Matching returns orders matching the order with the given ID.
+	Matching(ctx context.Context, in *MatchingOrdersRequest, opts ...grpc.CallOption) (*DWHOrdersReply, error)
+	// MatchBid returns ASK orders that would satisfy the given BID order if
+	// it was placed on the Marketplace.
+	MatchBid(ctx context.Context, in *MatchBidRequest, opts ...grpc.CallOption) (*DWHOrdersReply, error)
+}
+
+type marketSearchClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewMarketSearchClient(cc *grpc.ClientConn) MarketSearchClient {
+	return &marketSearchClient{cc}
+}
+
+func (c *marketSearchClient) Orders(ctx context.Context, in *MarketOrdersRequest, opts ...grpc.CallOption) (*DWHOrdersReply, error) {
+	out := new(DWHOrdersReply)
+	err := grpc.Invoke(ctx, "/sonm.MarketSearch/Orders", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketSearchClient) Matching(ctx context.Context, in *MatchingOrdersRequest, opts ...grpc.CallOption) (*DWHOrdersReply, error) {
+	out := new(DWHOrdersReply)
+	err := grpc.Invoke(ctx, "/sonm.MarketSearch/Matching", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *marketSearchClient) MatchBid(ctx context.Context, in *MatchBidRequest, opts ...grpc.CallOption) (*DWHOrdersReply, error) {
+	out := new(DWHOrdersReply)
+	err := grpc.Invoke(ctx, "/sonm.MarketSearch/MatchBid", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for MarketSearch service
+
+type MarketSearchServer interface {
+	// Orders returns orders by the given filter.
+	Orders(context.Context, *MarketOrdersRequest) (*DWHOrdersReply, error)
+	// Matching returns orders matching the order with the given ID.
+	Matching(context.Context, *MatchingOrdersRequest) (*DWHOrdersReply, error)
+	// MatchBid returns ASK orders that would satisfy the given BID order if
+	// it was placed on the Marketplace.
+	MatchBid(context.Context, *MatchBidRequest) (*DWHOrdersReply, error)
+}
+
+func RegisterMarketSearchServer(s *grpc.Server, srv MarketSearchServer) {
+	s.RegisterService(&_MarketSearch_serviceDesc, srv)
+}
+
+func _MarketSearch_Orders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarketOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketSearchServer).Orders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.MarketSearch/Orders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketSearchServer).Orders(ctx, req.(*MarketOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketSearch_Matching_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchingOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketSearchServer).Matching(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.MarketSearch/Matching",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketSearchServer).Matching(ctx, req.(*MatchingOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarketSearch_MatchBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MatchBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarketSearchServer).MatchBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.MarketSearch/MatchBid",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarketSearchServer).MatchBid(ctx, req.(*MatchBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _MarketSearch_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sonm.MarketSearch",
+	HandlerType: (*MarketSearchServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Orders",
+			Handler:    _MarketSearch_Orders_Handler,
+		},
+		{
+			MethodName: "Matching",
+			Handler:    _MarketSearch_Matching_Handler,
+		},
+		{
+			MethodName: "MatchBid",
+			Handler:    _MarketSearch_MatchBid_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "node.proto",
+}
+
+// Client API for NPPDiagnostics service
+
+type NPPDiagnosticsClient interface {
+	// Diagnose runs each NPP connection stage on its own, reporting their
+	// results with timings, and then measures the quality of the chosen
+	// path.
+	Diagnose(ctx context.Context, in *NPPDiagnoseRequest, opts ...grpc.CallOption) (*NPPReport, error)
+}
+
+type nPPDiagnosticsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewNPPDiagnosticsClient(cc *grpc.ClientConn) NPPDiagnosticsClient {
+	return &nPPDiagnosticsClient{cc}
+}
+
+func (c *nPPDiagnosticsClient) Diagnose(ctx context.Context, in *NPPDiagnoseRequest, opts ...grpc.CallOption) (*NPPReport, error) {
+	out := new(NPPReport)
+	err := grpc.Invoke(ctx, "/sonm.NPPDiagnostics/Diagnose", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for NPPDiagnostics service
+
+type NPPDiagnosticsServer interface {
+	// Diagnose runs each NPP connection stage on its own, reporting their
+	// results with timings, and then measures the quality of the chosen
+	// path.
+	Diagnose(context.Context, *NPPDiagnoseRequest) (*NPPReport, error)
+}
+
+func RegisterNPPDiagnosticsServer(s *grpc.Server, srv NPPDiagnosticsServer) {
+	s.RegisterService(&_NPPDiagnostics_serviceDesc, srv)
+}
+
+func _NPPDiagnostics_Diagnose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NPPDiagnoseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NPPDiagnosticsServer).Diagnose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.NPPDiagnostics/Diagnose",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NPPDiagnosticsServer).Diagnose(ctx, req.(*NPPDiagnoseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _NPPDiagnostics_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sonm.NPPDiagnostics",
+	HandlerType: (*NPPDiagnosticsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Diagnose",
+			Handler:    _NPPDiagnostics_Diagnose_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "node.proto",
+}
+
 // Begin grpccmd
 var _ = grpccmd.RunE
 
@@ -2013,6 +2603,44 @@ var _DealManagement_QuickBuyCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.QuickBuyRequest"),
 }
 
+var _DealManagement_DelegationTokensCmd = &cobra.Command{
+	Use:   "delegationTokens",
+	Short: "Make the DelegationTokens method call, input-type: sonm.BigInt output-type: sonm.DelegationTokensReply",
+	RunE: grpccmd.RunE(
+		"DelegationTokens",
+		"sonm.BigInt",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDealManagementClient(cc)
+		},
+	),
+}
+
+var _DealManagement_DelegationTokensCmd_gen = &cobra.Command{
+	Use:   "delegationTokens-gen",
+	Short: "Generate JSON for method call of DelegationTokens (input-type: sonm.BigInt)",
+	RunE:  grpccmd.TypeToJson("sonm.BigInt"),
+}
+
+var _DealManagement_RevokeDelegationTokenCmd = &cobra.Command{
+	Use:   "revokeDelegationToken",
+	Short: "Make the RevokeDelegationToken method call, input-type: sonm.RevokeDelegationTokenRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"RevokeDelegationToken",
+		"sonm.RevokeDelegationTokenRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewDealManagementClient(cc)
+		},
+	),
+}
+
+var _DealManagement_RevokeDelegationTokenCmd_gen = &cobra.Command{
+	Use:   "revokeDelegationToken-gen",
+	Short: "Generate JSON for method call of RevokeDelegationToken (input-type: sonm.RevokeDelegationTokenRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.RevokeDelegationTokenRequest"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_DealManagementCmd)
@@ -2035,6 +2663,10 @@ func init() {
 		_DealManagement_CancelChangeRequestCmd_gen,
 		_DealManagement_QuickBuyCmd,
 		_DealManagement_QuickBuyCmd_gen,
+		_DealManagement_DelegationTokensCmd,
+		_DealManagement_DelegationTokensCmd_gen,
+		_DealManagement_RevokeDelegationTokenCmd,
+		_DealManagement_RevokeDelegationTokenCmd_gen,
 	)
 }
 
@@ -2384,75 +3016,222 @@ func init() {
 	)
 }
 
+// MarketSearch
+var _MarketSearchCmd = &cobra.Command{
+	Use:   "marketSearch [method]",
+	Short: "Subcommand for the MarketSearch service.",
+}
+
+var _MarketSearch_OrdersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Make the Orders method call, input-type: sonm.MarketOrdersRequest output-type: sonm.DWHOrdersReply",
+	RunE: grpccmd.RunE(
+		"Orders",
+		"sonm.MarketOrdersRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMarketSearchClient(cc)
+		},
+	),
+}
+
+var _MarketSearch_OrdersCmd_gen = &cobra.Command{
+	Use:   "orders-gen",
+	Short: "Generate JSON for method call of Orders (input-type: sonm.MarketOrdersRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.MarketOrdersRequest"),
+}
+
+var _MarketSearch_MatchingCmd = &cobra.Command{
+	Use:   "matching",
+	Short: "Make the Matching method call, input-type: sonm.MatchingOrdersRequest output-type: sonm.DWHOrdersReply",
+	RunE: grpccmd.RunE(
+		"Matching",
+		"sonm.MatchingOrdersRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMarketSearchClient(cc)
+		},
+	),
+}
+
+var _MarketSearch_MatchingCmd_gen = &cobra.Command{
+	Use:   "matching-gen",
+	Short: "Generate JSON for method call of Matching (input-type: sonm.MatchingOrdersRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.MatchingOrdersRequest"),
+}
+
+var _MarketSearch_MatchBidCmd = &cobra.Command{
+	Use:   "matchBid",
+	Short: "Make the MatchBid method call, input-type: sonm.MatchBidRequest output-type: sonm.DWHOrdersReply",
+	RunE: grpccmd.RunE(
+		"MatchBid",
+		"sonm.MatchBidRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewMarketSearchClient(cc)
+		},
+	),
+}
+
+var _MarketSearch_MatchBidCmd_gen = &cobra.Command{
+	Use:   "matchBid-gen",
+	Short: "Generate JSON for method call of MatchBid (input-type: sonm.MatchBidRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.MatchBidRequest"),
+}
+
+// Register commands with the root command and service command
+func init() {
+	grpccmd.RegisterServiceCmd(_MarketSearchCmd)
+	_MarketSearchCmd.AddCommand(
+		_MarketSearch_OrdersCmd,
+		_MarketSearch_OrdersCmd_gen,
+		_MarketSearch_MatchingCmd,
+		_MarketSearch_MatchingCmd_gen,
+		_MarketSearch_MatchBidCmd,
+		_MarketSearch_MatchBidCmd_gen,
+	)
+}
+
+// NPPDiagnostics
+var _NPPDiagnosticsCmd = &cobra.Command{
+	Use:   "nPPDiagnostics [method]",
+	Short: "Subcommand for the NPPDiagnostics service.",
+}
+
+var _NPPDiagnostics_DiagnoseCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Make the Diagnose method call, input-type: sonm.NPPDiagnoseRequest output-type: sonm.NPPReport",
+	RunE: grpccmd.RunE(
+		"Diagnose",
+		"sonm.NPPDiagnoseRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewNPPDiagnosticsClient(cc)
+		},
+	),
+}
+
+var _NPPDiagnostics_DiagnoseCmd_gen = &cobra.Command{
+	Use:   "diagnose-gen",
+	Short: "Generate JSON for method call of Diagnose (input-type: sonm.NPPDiagnoseRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.NPPDiagnoseRequest"),
+}
+
+// Register commands with the root command and service command
+func init() {
+	grpccmd.RegisterServiceCmd(_NPPDiagnosticsCmd)
+	_NPPDiagnosticsCmd.AddCommand(
+		_NPPDiagnostics_DiagnoseCmd,
+		_NPPDiagnostics_DiagnoseCmd_gen,
+	)
+}
+
 // End grpccmd
 
 func init() { proto.RegisterFile("node.proto", fileDescriptor9) }
 
 var fileDescriptor9 = []byte{
-	// 1032 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x5d, 0x6f, 0xe3, 0x44,
-	0x14, 0x8d, 0xd3, 0x6c, 0x9a, 0xde, 0xb4, 0x4d, 0x3b, 0x2d, 0x50, 0xac, 0x05, 0x45, 0x06, 0xb1,
-	0x59, 0x58, 0x42, 0xe5, 0x5d, 0xd8, 0x45, 0x5a, 0x21, 0xb5, 0x49, 0x11, 0x41, 0x5b, 0x28, 0x6e,
-	0xa4, 0xf2, 0x84, 0x34, 0x89, 0x27, 0xc9, 0x28, 0xce, 0x8c, 0xf1, 0x4c, 0x1a, 0xf5, 0x9f, 0xf0,
-	0x8a, 0xc4, 0x33, 0xcf, 0xfc, 0x2b, 0xfe, 0x02, 0x1a, 0xcf, 0x4c, 0x63, 0x3b, 0xee, 0xae, 0x78,
-	0xcc, 0xb9, 0xe7, 0x7e, 0xcc, 0xb9, 0x33, 0x27, 0x06, 0x60, 0x3c, 0x24, 0xdd, 0x38, 0xe1, 0x92,
-	0xa3, 0x9a, 0xe0, 0x6c, 0xe1, 0xee, 0x8e, 0xe8, 0x94, 0x32, 0xa9, 0x31, 0xb7, 0x35, 0xe6, 0x4c,
-	0x62, 0xca, 0x48, 0x62, 0x80, 0x9d, 0x70, 0x35, 0xb3, 0x31, 0xca, 0x54, 0x06, 0xa3, 0xd8, 0x00,
-	0x87, 0x0b, 0x9c, 0xcc, 0x89, 0x8c, 0x23, 0x3c, 0x36, 0x35, 0xdd, 0xdd, 0x15, 0x4f, 0xe6, 0x36,
-	0xd9, 0xfb, 0x15, 0xd0, 0x8f, 0x9c, 0xb2, 0x9f, 0x88, 0x54, 0x70, 0x40, 0x7e, 0x5f, 0x12, 0x21,
-	0xd1, 0xa7, 0x50, 0x97, 0x58, 0xcc, 0x07, 0xfd, 0x13, 0xa7, 0xed, 0x74, 0x9a, 0xfe, 0x6e, 0x57,
-	0x95, 0xed, 0x0e, 0x53, 0x2c, 0x30, 0x31, 0xf4, 0x18, 0x76, 0x4c, 0xde, 0xa0, 0x7f, 0x52, 0x6d,
-	0x3b, 0x9d, 0x9d, 0x60, 0x0d, 0x78, 0x2f, 0xa1, 0xa5, 0xf8, 0x6f, 0xa8, 0x90, 0x99, 0xb2, 0x21,
-	0xc1, 0x51, 0xb1, 0xec, 0x39, 0x9d, 0x0e, 0x98, 0x0c, 0x4c, 0xcc, 0x5b, 0x41, 0xeb, 0x97, 0x25,
-	0x1d, 0xcf, 0xcf, 0x97, 0x77, 0x36, 0xd1, 0x83, 0x47, 0x25, 0xe3, 0x98, 0x3c, 0x1d, 0x42, 0x9f,
-	0x43, 0x23, 0x5c, 0x26, 0x58, 0x52, 0xce, 0xd2, 0x61, 0x9a, 0xfe, 0xbe, 0xa6, 0xf5, 0x0d, 0x1a,
-	0xdc, 0xc7, 0xd1, 0x31, 0x3c, 0x9a, 0xf0, 0x64, 0x4c, 0x4e, 0xb6, 0xda, 0x4e, 0xa7, 0x11, 0xe8,
-	0x1f, 0x5e, 0x04, 0x87, 0x7d, 0x82, 0xa3, 0xef, 0x29, 0xa3, 0x62, 0x66, 0x5b, 0x3f, 0x86, 0x2a,
-	0x0d, 0x4b, 0xfb, 0x56, 0x69, 0x88, 0xbe, 0x85, 0xbd, 0x51, 0x84, 0xc7, 0xf3, 0x88, 0x0a, 0x39,
-	0xbc, 0x8b, 0x49, 0xda, 0x79, 0xdf, 0x3f, 0x32, 0xc4, 0x6c, 0x28, 0xc8, 0x33, 0xbd, 0x67, 0x00,
-	0xaa, 0x9b, 0x08, 0x48, 0x1c, 0xdd, 0xa1, 0x8f, 0xa1, 0xa6, 0x8e, 0x7f, 0xe2, 0xb4, 0xb7, 0x3a,
-	0x4d, 0x1f, 0xcc, 0xe4, 0x04, 0x47, 0x41, 0x8a, 0x7b, 0x1c, 0x5a, 0x3f, 0xc7, 0x84, 0xa5, 0xc8,
-	0x5a, 0x94, 0x11, 0x0d, 0x1f, 0x12, 0x25, 0x0d, 0xad, 0x85, 0xab, 0x3e, 0x2c, 0x5c, 0xb9, 0x18,
-	0x14, 0x8e, 0x6e, 0xd2, 0x8b, 0x12, 0x90, 0x05, 0xbf, 0x25, 0xb6, 0x69, 0x07, 0xea, 0x0b, 0x2c,
-	0x24, 0x49, 0x4c, 0xd7, 0x03, 0x5d, 0xf1, 0x42, 0xce, 0xce, 0xc2, 0x30, 0x21, 0x42, 0x04, 0x26,
-	0xae, 0x98, 0xfa, 0xa6, 0x99, 0xde, 0x25, 0x4c, 0x1d, 0xf7, 0x5e, 0x43, 0x4b, 0xb7, 0xd2, 0x77,
-	0x45, 0xc9, 0xf1, 0x14, 0xb6, 0x75, 0x50, 0x18, 0x45, 0x5a, 0x46, 0x91, 0x9b, 0x1f, 0xcc, 0x54,
-	0x36, 0xee, 0xfd, 0xe5, 0xc0, 0xee, 0x39, 0x8e, 0x30, 0x1b, 0x13, 0x9d, 0xdb, 0x85, 0x66, 0x44,
-	0x6f, 0x89, 0xc1, 0x4a, 0xd5, 0xc9, 0x12, 0x14, 0x5f, 0xd0, 0xf0, 0x9e, 0x5f, 0xa6, 0x54, 0x96,
-	0x80, 0x5e, 0xc0, 0xbe, 0x4a, 0xbf, 0x90, 0x33, 0x9b, 0xb2, 0x55, 0x92, 0x52, 0xe0, 0x78, 0xbf,
-	0xc1, 0xf1, 0x90, 0xcf, 0x09, 0x1b, 0x26, 0x98, 0x89, 0x89, 0x92, 0x55, 0x0b, 0xda, 0x86, 0xaa,
-	0xe4, 0x0f, 0x8a, 0x59, 0x95, 0x5c, 0xbd, 0x1a, 0xbc, 0xe0, 0x4b, 0x26, 0x4b, 0x47, 0x33, 0x31,
-	0xff, 0xef, 0x2d, 0xd8, 0x57, 0xef, 0xed, 0x12, 0x33, 0x3c, 0x25, 0x0b, 0xc2, 0x24, 0x7a, 0x01,
-	0x35, 0xa5, 0x28, 0x7a, 0x6f, 0xfd, 0x7a, 0x33, 0xaf, 0xd1, 0x3d, 0x2a, 0xc2, 0x71, 0x74, 0xe7,
-	0x55, 0xd0, 0x97, 0xd0, 0xb8, 0x5a, 0x8a, 0x99, 0x82, 0x51, 0x53, 0x53, 0x7a, 0xb3, 0x25, 0x9b,
-	0xbb, 0xe6, 0x39, 0x5d, 0x25, 0x7c, 0xaa, 0x66, 0xf3, 0x2a, 0x1d, 0xe7, 0xd4, 0x41, 0x2f, 0xe1,
-	0xd1, 0xb5, 0xc4, 0x89, 0x44, 0xef, 0xeb, 0x70, 0xfa, 0x43, 0x25, 0xdb, 0x36, 0xc7, 0x1b, 0xb8,
-	0xee, 0xf3, 0x1a, 0x9a, 0x19, 0xe7, 0x41, 0x27, 0x9a, 0xb6, 0x69, 0x46, 0xee, 0xa1, 0x8e, 0x18,
-	0xf4, 0x3a, 0x26, 0x63, 0xaf, 0x82, 0xbe, 0x82, 0xfa, 0xb5, 0xc4, 0x72, 0x29, 0x50, 0xce, 0x9b,
-	0xdc, 0xcc, 0x59, 0x75, 0xdc, 0xb6, 0xfb, 0x06, 0x6a, 0x6f, 0xf8, 0x54, 0xe4, 0xc4, 0xe0, 0x53,
-	0x51, 0x26, 0x06, 0x9f, 0x8a, 0xf4, 0xc4, 0x5e, 0xe5, 0xd4, 0x41, 0x9f, 0x40, 0xed, 0x5a, 0xf2,
-	0xb8, 0xd0, 0xc6, 0x08, 0x73, 0xb1, 0x88, 0xa5, 0x2a, 0xee, 0x2b, 0xcd, 0xa2, 0x28, 0xd5, 0xcc,
-	0x34, 0xb0, 0xbf, 0x6d, 0x83, 0xac, 0x94, 0xaa, 0xb0, 0xff, 0xef, 0x16, 0xec, 0xab, 0xe7, 0x9c,
-	0x59, 0xd8, 0x13, 0xb3, 0x30, 0xcb, 0x55, 0x8b, 0x75, 0x0f, 0xd6, 0x5e, 0x20, 0xd6, 0x3b, 0x2a,
-	0x9c, 0x5e, 0x5f, 0x06, 0x7b, 0x0a, 0xc5, 0x1d, 0xb0, 0x09, 0xb7, 0xf4, 0x53, 0xa8, 0x6b, 0x53,
-	0x43, 0x1f, 0xac, 0x09, 0x39, 0x9b, 0x2b, 0x1e, 0xe8, 0x0b, 0xa8, 0x29, 0xbb, 0xb1, 0x87, 0x29,
-	0x58, 0x8f, 0x9b, 0xf1, 0x27, 0xaf, 0x82, 0x7a, 0x80, 0x7a, 0x33, 0xcc, 0xa6, 0xd6, 0x24, 0x44,
-	0x7a, 0x88, 0xfc, 0x64, 0x1f, 0xad, 0x33, 0xf2, 0x5c, 0x3b, 0xe3, 0x77, 0x70, 0xd4, 0x4b, 0x08,
-	0x96, 0x24, 0x17, 0xce, 0x0e, 0x9c, 0x0b, 0xb8, 0xb9, 0xf2, 0x5e, 0x05, 0x3d, 0x87, 0xe3, 0xb3,
-	0x38, 0x4e, 0xf8, 0x6d, 0xa1, 0x40, 0x7e, 0x8c, 0x8d, 0xbd, 0x1d, 0xf5, 0xd4, 0xeb, 0x8c, 0xfe,
-	0x47, 0xce, 0x2b, 0x68, 0xd8, 0xbf, 0x27, 0x2b, 0x4f, 0xe1, 0xef, 0xea, 0x81, 0x35, 0xf8, 0xff,
-	0x38, 0x70, 0x70, 0x99, 0x9a, 0x63, 0x66, 0xe7, 0xaf, 0xa0, 0xa9, 0x1d, 0x4d, 0xab, 0xb6, 0x61,
-	0x01, 0xf6, 0x46, 0x17, 0x1c, 0x32, 0xdd, 0xea, 0x9e, 0x06, 0x7b, 0x9c, 0x4d, 0x68, 0xb2, 0x28,
-	0xc9, 0xdd, 0x18, 0x7d, 0x37, 0xeb, 0xe9, 0xe8, 0xc3, 0x6c, 0xe9, 0x9c, 0xcf, 0x17, 0x32, 0xfd,
-	0x3f, 0xab, 0xd0, 0x4a, 0xed, 0x2b, 0x33, 0x79, 0x07, 0x60, 0x48, 0x84, 0x4c, 0x61, 0x81, 0xb2,
-	0x09, 0xc5, 0xbe, 0xcf, 0x60, 0xdb, 0x9a, 0x67, 0x8e, 0x86, 0x8c, 0xce, 0x19, 0xf7, 0xf6, 0x2a,
-	0xe8, 0x33, 0xd8, 0xee, 0x93, 0x98, 0x0b, 0xfa, 0x8e, 0x45, 0x3c, 0x81, 0xc6, 0x0d, 0x95, 0xb3,
-	0x30, 0xc1, 0xab, 0xb7, 0x13, 0xbb, 0xd0, 0xba, 0x4c, 0x3f, 0x83, 0xce, 0xa2, 0x88, 0xaf, 0x36,
-	0xc7, 0x28, 0x5e, 0xa5, 0xaf, 0xa1, 0x61, 0x5d, 0x1a, 0xb9, 0xe6, 0xd9, 0x97, 0x58, 0x77, 0x51,
-	0xa3, 0x19, 0xec, 0xdc, 0xff, 0xe1, 0xa3, 0x53, 0xf3, 0x94, 0x37, 0x77, 0x72, 0x5c, 0xf8, 0x36,
-	0xb0, 0xc7, 0x7e, 0x0a, 0x75, 0xb3, 0x96, 0x77, 0xed, 0xd1, 0xff, 0xc3, 0x81, 0xc6, 0x55, 0xc2,
-	0x27, 0x34, 0x22, 0xa2, 0xe8, 0xf2, 0x16, 0x2f, 0xdc, 0xc5, 0x35, 0x6c, 0x45, 0xb6, 0x0e, 0xd2,
-	0xbc, 0xef, 0x36, 0xe8, 0xbb, 0x7b, 0x39, 0xb6, 0xd6, 0x4e, 0x4f, 0x75, 0x26, 0x65, 0x42, 0x47,
-	0x4b, 0x49, 0xde, 0xaa, 0xf5, 0xa8, 0x9e, 0x7e, 0x56, 0x3e, 0xff, 0x2f, 0x00, 0x00, 0xff, 0xff,
-	0x79, 0x38, 0x2c, 0xda, 0xc6, 0x0a, 0x00, 0x00,
+	// 1619 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x58, 0x5d, 0x6f, 0x63, 0x47,
+	0x19, 0xce, 0xb1, 0x1d, 0xaf, 0xf3, 0xc6, 0x6b, 0x67, 0x27, 0xd9, 0x62, 0xdc, 0x52, 0x59, 0x07,
+	0x44, 0xbd, 0xed, 0x92, 0x2e, 0xee, 0xd2, 0xdd, 0x42, 0x05, 0x4a, 0xe2, 0x45, 0x4d, 0xd5, 0xec,
+	0xba, 0x27, 0x41, 0xe1, 0x0a, 0x69, 0xec, 0x33, 0xb6, 0x47, 0x3e, 0x9e, 0x39, 0xcc, 0x8c, 0x13,
+	0xc2, 0x2d, 0x7f, 0x82, 0x5b, 0x24, 0x6e, 0x41, 0x5c, 0xf2, 0x0f, 0x10, 0xff, 0x82, 0x1f, 0xc2,
+	0x05, 0x9a, 0x2f, 0x9f, 0x0f, 0x3b, 0x2d, 0xab, 0xde, 0x79, 0xde, 0xf7, 0x79, 0x3f, 0xe6, 0x79,
+	0x67, 0xe6, 0x3c, 0x09, 0x00, 0xe3, 0x31, 0x39, 0x4e, 0x05, 0x57, 0x1c, 0xd5, 0x24, 0x67, 0xcb,
+	0x6e, 0x73, 0x4c, 0x67, 0x94, 0x29, 0x6b, 0xeb, 0xb6, 0x27, 0x9c, 0x29, 0x4c, 0x19, 0x11, 0xce,
+	0xb0, 0x17, 0xdf, 0xce, 0xbd, 0x8f, 0x32, 0x1d, 0xc1, 0x28, 0x76, 0x86, 0x47, 0x4b, 0x2c, 0x16,
+	0x44, 0xa5, 0x09, 0x9e, 0x10, 0x0f, 0x67, 0xc4, 0xa7, 0x6a, 0xde, 0x72, 0xb1, 0xf0, 0x79, 0xc2,
+	0xdf, 0x02, 0xfa, 0x92, 0x53, 0xf6, 0x9a, 0x28, 0x6d, 0x8e, 0xc8, 0xef, 0x57, 0x44, 0x2a, 0xf4,
+	0x23, 0xa8, 0x2b, 0x2c, 0x17, 0xe7, 0xc3, 0x4e, 0xd0, 0x0b, 0xfa, 0xfb, 0x83, 0xe6, 0xb1, 0xae,
+	0x70, 0x7c, 0x65, 0x6c, 0x91, 0xf3, 0xa1, 0xf7, 0x60, 0xcf, 0xc5, 0x9d, 0x0f, 0x3b, 0x95, 0x5e,
+	0xd0, 0xdf, 0x8b, 0x32, 0x43, 0xf8, 0x02, 0xda, 0x1a, 0xff, 0x15, 0x95, 0x2a, 0x97, 0x36, 0x26,
+	0x38, 0x29, 0xa7, 0x3d, 0xa5, 0xb3, 0x73, 0xa6, 0x22, 0xe7, 0x0b, 0x6f, 0xa1, 0xfd, 0xf5, 0x8a,
+	0x4e, 0x16, 0xa7, 0xab, 0x3b, 0x1f, 0x18, 0xc2, 0xee, 0x96, 0x76, 0x5c, 0x9c, 0x75, 0xa1, 0x0f,
+	0xa1, 0x11, 0xaf, 0x04, 0x56, 0x94, 0x33, 0xd3, 0xcc, 0xfe, 0xa0, 0x65, 0x61, 0x43, 0x67, 0x8d,
+	0xd6, 0x7e, 0x74, 0x04, 0xbb, 0x53, 0x2e, 0x26, 0xa4, 0x53, 0xed, 0x05, 0xfd, 0x46, 0x64, 0x17,
+	0x61, 0x02, 0x8f, 0x86, 0x04, 0x27, 0xbf, 0xa6, 0x8c, 0xca, 0xb9, 0x2f, 0xfd, 0x1e, 0x54, 0x68,
+	0xbc, 0xb5, 0x6e, 0x85, 0xc6, 0xe8, 0x33, 0x78, 0x38, 0x4e, 0xf0, 0x64, 0x91, 0x50, 0xa9, 0xae,
+	0xee, 0x52, 0x62, 0x2a, 0xb7, 0x06, 0x87, 0x0e, 0x98, 0x77, 0x45, 0x45, 0x64, 0xf8, 0x14, 0x40,
+	0x57, 0x93, 0x11, 0x49, 0x93, 0x3b, 0xf4, 0x3e, 0xd4, 0xf4, 0xf6, 0x3b, 0x41, 0xaf, 0xda, 0xdf,
+	0x1f, 0x80, 0xeb, 0x9c, 0xe0, 0x24, 0x32, 0xf6, 0x90, 0x43, 0xfb, 0x4d, 0x4a, 0x98, 0xb1, 0x64,
+	0xa4, 0x8c, 0x69, 0x7c, 0x1f, 0x29, 0xc6, 0x95, 0x11, 0x57, 0xb9, 0x9f, 0xb8, 0xed, 0x64, 0x50,
+	0x38, 0xbc, 0x36, 0x07, 0x25, 0x22, 0x4b, 0x7e, 0x43, 0x7c, 0xd1, 0x3e, 0xd4, 0x97, 0x58, 0x2a,
+	0x22, 0x5c, 0xd5, 0x03, 0x9b, 0xf1, 0x95, 0x9a, 0x9f, 0xc4, 0xb1, 0x20, 0x52, 0x46, 0xce, 0xaf,
+	0x91, 0xf6, 0xa4, 0x75, 0x2a, 0xf7, 0x21, 0xad, 0x3f, 0xfc, 0x1c, 0xda, 0xb6, 0x94, 0x3d, 0x2b,
+	0x9a, 0x8e, 0x27, 0xf0, 0xc0, 0x3a, 0xa5, 0x63, 0xa4, 0xed, 0x18, 0xb9, 0xfe, 0xc2, 0x75, 0xe5,
+	0xfd, 0xe1, 0x5f, 0x03, 0x68, 0x9e, 0xe2, 0x04, 0xb3, 0x09, 0xb1, 0xb1, 0xc7, 0xb0, 0x9f, 0xd0,
+	0x1b, 0xe2, 0x6c, 0x5b, 0xd9, 0xc9, 0x03, 0x34, 0x5e, 0xd2, 0x78, 0x8d, 0xdf, 0xc6, 0x54, 0x1e,
+	0x80, 0x9e, 0x43, 0x4b, 0x87, 0xbf, 0x52, 0x73, 0x1f, 0x52, 0xdd, 0x12, 0x52, 0xc2, 0x84, 0xff,
+	0x09, 0xe0, 0xf0, 0xc2, 0xdc, 0xcb, 0x37, 0x22, 0x26, 0x42, 0x7a, 0x42, 0x3f, 0x82, 0xfa, 0x94,
+	0x26, 0x19, 0xa1, 0xee, 0xe8, 0x14, 0x40, 0x91, 0x83, 0xa0, 0x73, 0x80, 0x31, 0x61, 0x93, 0xb9,
+	0xbe, 0xe0, 0xb2, 0x53, 0x31, 0xcc, 0x3c, 0xb1, 0x01, 0x5b, 0x72, 0x1f, 0x9f, 0xae, 0xb1, 0xaf,
+	0x98, 0x12, 0x77, 0x51, 0x2e, 0xb8, 0xfb, 0x35, 0xb4, 0x4b, 0x6e, 0x74, 0x00, 0xd5, 0x05, 0xb9,
+	0x33, 0x7d, 0xec, 0x45, 0xfa, 0x27, 0xea, 0xc3, 0xee, 0x0d, 0x4e, 0x56, 0x9e, 0x14, 0xe4, 0x4b,
+	0xfd, 0xe1, 0x82, 0xb2, 0xdf, 0x50, 0xa6, 0x3e, 0x7d, 0x1e, 0x59, 0xc0, 0xcf, 0x2b, 0x2f, 0x83,
+	0xf0, 0x1f, 0x01, 0xb4, 0x2f, 0xb0, 0x9a, 0xcc, 0x4f, 0x69, 0xec, 0xb7, 0xd7, 0x83, 0xea, 0x78,
+	0x7d, 0x7f, 0x5a, 0x9e, 0xa1, 0xd8, 0xf4, 0x19, 0x69, 0x97, 0x3e, 0x7e, 0x09, 0x5d, 0x52, 0x65,
+	0x6a, 0xd4, 0x22, 0xbb, 0x40, 0xef, 0x40, 0x9d, 0x4f, 0xa7, 0x92, 0x28, 0x43, 0x6e, 0x2d, 0x72,
+	0x2b, 0xf4, 0x31, 0x34, 0x24, 0x17, 0x8a, 0xb2, 0x99, 0xec, 0xd4, 0x7a, 0xd5, 0x8c, 0xb0, 0x4b,
+	0x6b, 0x7d, 0x93, 0xda, 0xab, 0xee, 0x41, 0xfa, 0x91, 0xba, 0xa5, 0x6a, 0x7e, 0xc6, 0x57, 0x4c,
+	0x75, 0x76, 0xcd, 0x09, 0xcf, 0x0c, 0xe1, 0xef, 0xe0, 0xe8, 0x8a, 0x2f, 0x08, 0xbb, 0x12, 0x98,
+	0xc9, 0x29, 0x11, 0x59, 0xdb, 0x15, 0xc5, 0xef, 0x3d, 0xe2, 0x15, 0xc5, 0xf5, 0x5b, 0x86, 0x97,
+	0x26, 0xe9, 0xb6, 0x03, 0xe3, 0x7c, 0x61, 0x04, 0xe8, 0xf5, 0x68, 0x34, 0xa4, 0x78, 0xc6, 0xb8,
+	0x5c, 0x5f, 0x22, 0x04, 0x35, 0x1c, 0xc7, 0xc2, 0x31, 0x6d, 0x7e, 0x6b, 0x1a, 0x52, 0xb3, 0x2b,
+	0x9d, 0xee, 0x61, 0x64, 0x17, 0x1a, 0x29, 0xe9, 0x1f, 0x89, 0x23, 0xc1, 0xfc, 0x0e, 0xff, 0x1d,
+	0xc0, 0xde, 0xeb, 0xd1, 0x28, 0x22, 0x29, 0x17, 0x0a, 0x3d, 0x85, 0xba, 0x54, 0x78, 0x46, 0xfc,
+	0x45, 0x39, 0xb2, 0x7d, 0xbc, 0x1e, 0x8d, 0x2e, 0xb5, 0xd9, 0xa2, 0x22, 0x87, 0xd1, 0xf9, 0x52,
+	0xac, 0xe6, 0xee, 0xb5, 0x36, 0xbf, 0xf5, 0x88, 0xa2, 0xab, 0xab, 0x4e, 0xb5, 0x57, 0xcd, 0x46,
+	0xb4, 0x7e, 0x33, 0xb5, 0x0b, 0xbd, 0x0f, 0xb0, 0x4a, 0x13, 0x8e, 0xe3, 0x08, 0x2b, 0xd2, 0xa9,
+	0x99, 0x5e, 0x72, 0x16, 0x14, 0x42, 0x33, 0xe6, 0xb7, 0x6c, 0x8d, 0xd8, 0x35, 0x88, 0x82, 0x4d,
+	0xef, 0x8f, 0x08, 0xc1, 0x45, 0xa7, 0x6e, 0x4a, 0xdb, 0x45, 0xf8, 0xb7, 0x0a, 0xb4, 0x8a, 0xad,
+	0xea, 0x16, 0x19, 0x5e, 0x12, 0x4f, 0x8e, 0xfe, 0xfd, 0xb6, 0x6f, 0xbb, 0x2d, 0x54, 0xcd, 0x15,
+	0xd2, 0x5b, 0x10, 0x64, 0xc9, 0x15, 0xd1, 0x33, 0x34, 0x5b, 0xd8, 0x8b, 0x72, 0x16, 0x7d, 0xde,
+	0x24, 0x11, 0x37, 0x44, 0x98, 0xe6, 0xf7, 0x22, 0xb7, 0x42, 0x1f, 0x02, 0xa4, 0xab, 0x71, 0x42,
+	0x27, 0x26, 0xae, 0xde, 0x0b, 0xb2, 0xd7, 0x59, 0x5b, 0xa2, 0x9c, 0x17, 0x1d, 0x43, 0x33, 0x15,
+	0xf4, 0x06, 0xdb, 0x94, 0xb2, 0xf3, 0x20, 0xff, 0x96, 0x1b, 0x74, 0xc1, 0x8f, 0x7e, 0x0a, 0x0d,
+	0xac, 0x14, 0x59, 0xa6, 0x4a, 0x76, 0x1a, 0x06, 0xfb, 0x78, 0x3d, 0xbc, 0xd1, 0x8a, 0x4d, 0xe6,
+	0x27, 0xd6, 0x1b, 0xad, 0x61, 0xe1, 0x9f, 0x02, 0x68, 0x97, 0xbc, 0x5b, 0x4f, 0xd3, 0x77, 0x27,
+	0xac, 0x0b, 0x0d, 0xca, 0x26, 0x7c, 0x49, 0xd9, 0xcc, 0xd0, 0xd5, 0x88, 0xd6, 0xeb, 0xc1, 0xdf,
+	0xab, 0xd0, 0xd2, 0xdf, 0xf6, 0x0b, 0xcc, 0xf0, 0x8c, 0x2c, 0x09, 0x53, 0xe8, 0x39, 0xd4, 0xf4,
+	0xeb, 0x8d, 0x1e, 0x67, 0x4a, 0x21, 0xf7, 0xe5, 0xef, 0x1e, 0x96, 0xcd, 0x69, 0x72, 0x17, 0xee,
+	0xa0, 0x9f, 0x40, 0x63, 0xb4, 0x92, 0x73, 0x6d, 0x46, 0xfb, 0x16, 0x72, 0x36, 0x5f, 0xb1, 0x45,
+	0xd7, 0x75, 0x3b, 0x12, 0x7c, 0xa6, 0x6f, 0x5c, 0xb8, 0xd3, 0x0f, 0x9e, 0x05, 0xe8, 0x05, 0xec,
+	0x5e, 0x2a, 0x2c, 0x14, 0x7a, 0xc7, 0xdd, 0x79, 0xbd, 0xd0, 0xc1, 0xbe, 0xcc, 0xd1, 0x86, 0xdd,
+	0xd6, 0xf9, 0x1c, 0xf6, 0x73, 0x2a, 0x07, 0x75, 0x2c, 0x6c, 0x53, 0xf8, 0x74, 0x1f, 0xb9, 0x01,
+	0x58, 0xeb, 0x65, 0x4a, 0x26, 0xe1, 0x0e, 0xfa, 0x18, 0xea, 0x97, 0x0a, 0xab, 0x95, 0x44, 0x05,
+	0x1d, 0xd4, 0xcd, 0xed, 0xd5, 0xfa, 0x7d, 0xb9, 0x4f, 0xa1, 0xf6, 0x15, 0x9f, 0xc9, 0x02, 0x19,
+	0x7c, 0x26, 0xb7, 0x91, 0xc1, 0x67, 0xd2, 0xec, 0x38, 0xdc, 0x79, 0x16, 0xa0, 0x1f, 0x42, 0xed,
+	0x52, 0xf1, 0xb4, 0x54, 0xc6, 0x11, 0xf3, 0x6a, 0x99, 0x2a, 0x9d, 0x7c, 0xa0, 0x39, 0x4b, 0x12,
+	0xc3, 0x99, 0x2b, 0xe0, 0xd7, 0xbe, 0x40, 0x9e, 0x4a, 0x9d, 0x78, 0xf0, 0xdf, 0x1a, 0xb4, 0xb4,
+	0x74, 0xc8, 0x0d, 0xec, 0x03, 0x37, 0x30, 0x8f, 0xd5, 0xcf, 0x55, 0xf7, 0x20, 0xd3, 0x1d, 0x32,
+	0x9b, 0x51, 0x69, 0xf7, 0xf6, 0x89, 0xeb, 0x1e, 0x66, 0xd8, 0x73, 0x36, 0xe5, 0x1e, 0xfe, 0x0c,
+	0xea, 0x56, 0x40, 0xa1, 0xef, 0x65, 0x80, 0x82, 0xa4, 0x2a, 0x6f, 0xe8, 0x23, 0xa8, 0x69, 0x69,
+	0xe3, 0x37, 0x53, 0x92, 0x39, 0xdd, 0x9c, 0x16, 0x0a, 0x77, 0xd0, 0x19, 0xa0, 0xb3, 0x39, 0x66,
+	0x33, 0xff, 0x96, 0x4a, 0xb3, 0x89, 0x62, 0x67, 0x3f, 0xc8, 0x22, 0x8a, 0x58, 0xdf, 0xe3, 0x2f,
+	0xe1, 0xf0, 0x4c, 0x10, 0xac, 0x48, 0xc1, 0x9d, 0x6f, 0xb8, 0xe0, 0xe8, 0x16, 0xd2, 0x87, 0x3b,
+	0xe8, 0x13, 0x38, 0x3a, 0x49, 0x53, 0xc1, 0x6f, 0x4a, 0x09, 0x8a, 0x6d, 0x6c, 0xcc, 0xed, 0xf0,
+	0x4c, 0x2b, 0x81, 0xe4, 0x2d, 0x62, 0x5e, 0x42, 0xc3, 0x4b, 0x61, 0x4f, 0x4f, 0x49, 0x1a, 0xdf,
+	0x37, 0x86, 0x5f, 0xc1, 0xc1, 0x90, 0x24, 0x64, 0x66, 0xae, 0xb8, 0xf9, 0xc4, 0x95, 0xe7, 0xf7,
+	0xae, 0x0f, 0x2c, 0xa2, 0x7c, 0x82, 0x2f, 0xe1, 0x71, 0x44, 0x6e, 0xf8, 0x82, 0x94, 0x00, 0x28,
+	0xb4, 0x71, 0x5b, 0x9d, 0xdb, 0x27, 0x3c, 0xf8, 0x67, 0x00, 0x07, 0x17, 0x46, 0x15, 0xe6, 0x0e,
+	0xe0, 0x4b, 0xd8, 0xb7, 0x52, 0xce, 0x8e, 0x70, 0xe3, 0x2b, 0xeb, 0xaf, 0x57, 0x49, 0x1a, 0x9a,
+	0x23, 0xf6, 0xd0, 0x1a, 0xcf, 0x38, 0x9b, 0x52, 0xb1, 0xdc, 0x12, 0xbb, 0xc1, 0x63, 0x33, 0x2f,
+	0x66, 0xd1, 0xf7, 0xf3, 0xa9, 0x0b, 0x02, 0xb7, 0xdc, 0xfa, 0x5f, 0x2a, 0xd0, 0x36, 0x5b, 0xcb,
+	0x75, 0xde, 0x07, 0xb8, 0x22, 0x52, 0x39, 0x56, 0xf3, 0x01, 0xe5, 0xba, 0x4f, 0xe1, 0x81, 0x57,
+	0x8d, 0x05, 0x98, 0x13, 0x52, 0x79, 0xd9, 0x1a, 0xee, 0xa0, 0x1f, 0xc3, 0x83, 0x21, 0x49, 0xb9,
+	0xa4, 0xdf, 0x72, 0x2a, 0x3e, 0x80, 0xc6, 0x35, 0x55, 0xf3, 0x58, 0xe0, 0xdb, 0x6f, 0x06, 0x1e,
+	0x43, 0xdb, 0xca, 0xc2, 0x93, 0x24, 0xe1, 0xb7, 0x9b, 0x6d, 0x94, 0xcf, 0xf5, 0xcf, 0xa0, 0xe1,
+	0x85, 0x10, 0xea, 0xba, 0x37, 0x68, 0x8b, 0x3a, 0x2a, 0x73, 0x34, 0x87, 0xbd, 0xf5, 0x5f, 0x3a,
+	0xe8, 0x99, 0x7b, 0x57, 0x36, 0x67, 0x72, 0x54, 0xfa, 0xa3, 0xc8, 0x6f, 0xfb, 0x09, 0xd4, 0xdd,
+	0x58, 0xbe, 0x6d, 0x8e, 0x83, 0x3f, 0x07, 0xd0, 0x18, 0x09, 0x3e, 0xa5, 0x09, 0x91, 0xe5, 0x4f,
+	0x8e, 0xb7, 0x97, 0x2e, 0x46, 0x66, 0xf6, 0x24, 0xfb, 0xe7, 0x6c, 0x7f, 0x5d, 0xed, 0x7c, 0xd8,
+	0x7d, 0x58, 0x40, 0x5b, 0xee, 0x6c, 0x57, 0x27, 0x4a, 0x09, 0x3a, 0x5e, 0x29, 0xf2, 0x8d, 0x5c,
+	0x0f, 0xfe, 0x15, 0x40, 0xd3, 0x92, 0x7d, 0x49, 0xb0, 0x98, 0xcc, 0xd1, 0x2f, 0xa0, 0x6e, 0xd5,
+	0xb8, 0x3f, 0x6d, 0x5b, 0x14, 0xba, 0xe7, 0x64, 0x78, 0xfd, 0x85, 0xb7, 0xfb, 0xeb, 0xdb, 0x30,
+	0x4a, 0x9a, 0xb2, 0x19, 0x7a, 0xd7, 0x87, 0xdb, 0xf5, 0xff, 0x97, 0xe0, 0x33, 0x97, 0xe0, 0x94,
+	0xc6, 0x9e, 0xa0, 0x92, 0x34, 0xbf, 0x2f, 0x74, 0x70, 0x0e, 0xad, 0x4c, 0xb3, 0x2a, 0x3a, 0x91,
+	0xe8, 0x05, 0x34, 0xdc, 0x92, 0xf8, 0x6f, 0xe7, 0xa6, 0xaa, 0xed, 0xb6, 0xd7, 0x1e, 0xab, 0xe4,
+	0xc2, 0x9d, 0x71, 0xdd, 0xfc, 0x93, 0xe1, 0x93, 0xff, 0x0d, 0x00, 0x17, 0xa0, 0x9a, 0x09, 0xdf,
+	0x10, 0x00, 0x00,
 }
@@ -0,0 +1,16 @@
+package sonm
+
+import (
+	"fmt"
+)
+
+func (m *OptimusPriceThresholdRequest) Validate() error {
+	if m.GetAddr().IsZero() {
+		return fmt.Errorf("worker address must not be zero")
+	}
+	if m.GetThreshold().GetPerSecond().Unwrap().Sign() <= 0 {
+		return fmt.Errorf("price threshold must be a positive number")
+	}
+
+	return nil
+}
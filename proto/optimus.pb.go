@@ -0,0 +1,774 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: optimus.proto
+
+package sonm
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+
+import (
+	context "golang.org/x/net/context"
+	grpc "google.golang.org/grpc"
+)
+
+// grpccmd imports
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+	"github.com/sshaman1101/grpccmd"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+type OptimizationStrategy int32
+
+const (
+	// No plans were created, for example when the epoch has failed.
+	OptimizationStrategy_STRATEGY_NONE OptimizationStrategy = 0
+	// Plans were created using natural free devices only.
+	OptimizationStrategy_STRATEGY_APPENDING OptimizationStrategy = 1
+	// Some plans were replaced with more profitable ones.
+	OptimizationStrategy_STRATEGY_REPLACEMENT OptimizationStrategy = 2
+)
+
+var OptimizationStrategy_name = map[int32]string{
+	0: "STRATEGY_NONE",
+	1: "STRATEGY_APPENDING",
+	2: "STRATEGY_REPLACEMENT",
+}
+var OptimizationStrategy_value = map[string]int32{
+	"STRATEGY_NONE":        0,
+	"STRATEGY_APPENDING":   1,
+	"STRATEGY_REPLACEMENT": 2,
+}
+
+func (x OptimizationStrategy) String() string {
+	return proto.EnumName(OptimizationStrategy_name, int32(x))
+}
+func (OptimizationStrategy) EnumDescriptor() ([]byte, []int) { return fileDescriptor10, []int{0} }
+
+type OptimusWorker struct {
+	Addr       *EthAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	MasterAddr *EthAddress `protobuf:"bytes,2,opt,name=masterAddr" json:"masterAddr,omitempty"`
+	// Paused is true if the worker management is paused.
+	Paused         bool   `protobuf:"varint,3,opt,name=paused" json:"paused,omitempty"`
+	PriceThreshold *Price `protobuf:"bytes,4,opt,name=priceThreshold" json:"priceThreshold,omitempty"`
+	// LastEpoch is the time when the last optimization epoch has started.
+	LastEpoch *Timestamp `protobuf:"bytes,5,opt,name=lastEpoch" json:"lastEpoch,omitempty"`
+	// Error describes the last optimization epoch error if any.
+	Error string `protobuf:"bytes,6,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *OptimusWorker) Reset()                    { *m = OptimusWorker{} }
+func (m *OptimusWorker) String() string            { return proto.CompactTextString(m) }
+func (*OptimusWorker) ProtoMessage()               {}
+func (*OptimusWorker) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{0} }
+
+func (m *OptimusWorker) GetAddr() *EthAddress {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+func (m *OptimusWorker) GetMasterAddr() *EthAddress {
+	if m != nil {
+		return m.MasterAddr
+	}
+	return nil
+}
+
+func (m *OptimusWorker) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
+func (m *OptimusWorker) GetPriceThreshold() *Price {
+	if m != nil {
+		return m.PriceThreshold
+	}
+	return nil
+}
+
+func (m *OptimusWorker) GetLastEpoch() *Timestamp {
+	if m != nil {
+		return m.LastEpoch
+	}
+	return nil
+}
+
+func (m *OptimusWorker) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type OptimusWorkersReply struct {
+	Workers []*OptimusWorker `protobuf:"bytes,1,rep,name=workers" json:"workers,omitempty"`
+}
+
+func (m *OptimusWorkersReply) Reset()                    { *m = OptimusWorkersReply{} }
+func (m *OptimusWorkersReply) String() string            { return proto.CompactTextString(m) }
+func (*OptimusWorkersReply) ProtoMessage()               {}
+func (*OptimusWorkersReply) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{1} }
+
+func (m *OptimusWorkersReply) GetWorkers() []*OptimusWorker {
+	if m != nil {
+		return m.Workers
+	}
+	return nil
+}
+
+type OptimusPriceThresholdRequest struct {
+	Addr      *EthAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	Threshold *Price      `protobuf:"bytes,2,opt,name=threshold" json:"threshold,omitempty"`
+}
+
+func (m *OptimusPriceThresholdRequest) Reset()                    { *m = OptimusPriceThresholdRequest{} }
+func (m *OptimusPriceThresholdRequest) String() string            { return proto.CompactTextString(m) }
+func (*OptimusPriceThresholdRequest) ProtoMessage()               {}
+func (*OptimusPriceThresholdRequest) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{2} }
+
+func (m *OptimusPriceThresholdRequest) GetAddr() *EthAddress {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+func (m *OptimusPriceThresholdRequest) GetThreshold() *Price {
+	if m != nil {
+		return m.Threshold
+	}
+	return nil
+}
+
+// OptimusKnapsack describes optimization result.
+type OptimusKnapsack struct {
+	// Price is the total price of all plans.
+	Price *Price     `protobuf:"bytes,1,opt,name=price" json:"price,omitempty"`
+	Plans []*AskPlan `protobuf:"bytes,2,rep,name=plans" json:"plans,omitempty"`
+}
+
+func (m *OptimusKnapsack) Reset()                    { *m = OptimusKnapsack{} }
+func (m *OptimusKnapsack) String() string            { return proto.CompactTextString(m) }
+func (*OptimusKnapsack) ProtoMessage()               {}
+func (*OptimusKnapsack) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{3} }
+
+func (m *OptimusKnapsack) GetPrice() *Price {
+	if m != nil {
+		return m.Price
+	}
+	return nil
+}
+
+func (m *OptimusKnapsack) GetPlans() []*AskPlan {
+	if m != nil {
+		return m.Plans
+	}
+	return nil
+}
+
+type OptimusEpochReport struct {
+	Addr       *EthAddress `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
+	StartedAt  *Timestamp  `protobuf:"bytes,2,opt,name=startedAt" json:"startedAt,omitempty"`
+	FinishedAt *Timestamp  `protobuf:"bytes,3,opt,name=finishedAt" json:"finishedAt,omitempty"`
+	// OrdersCount is the number of market orders used as an input.
+	OrdersCount uint64        `protobuf:"varint,4,opt,name=ordersCount" json:"ordersCount,omitempty"`
+	Devices     *DevicesReply `protobuf:"bytes,5,opt,name=devices" json:"devices,omitempty"`
+	// Plans are worker plans at the beginning of the epoch.
+	Plans map[string]*AskPlan `protobuf:"bytes,6,rep,name=plans" json:"plans,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// CurrentPrice is the total price of worker plans at the beginning of the
+	// epoch.
+	CurrentPrice *Price `protobuf:"bytes,7,opt,name=currentPrice" json:"currentPrice,omitempty"`
+	// RemovedPlans are IDs of plans removed during this epoch either because
+	// they were unsold for too long or replaced.
+	RemovedPlans []string `protobuf:"bytes,8,rep,name=removedPlans" json:"removedPlans,omitempty"`
+	// Natural is the optimization result using natural free devices.
+	Natural *OptimusKnapsack `protobuf:"bytes,9,opt,name=natural" json:"natural,omitempty"`
+	// Virtual is the optimization result using free devices plus devices
+	// occupied by plans that can be replaced.
+	Virtual  *OptimusKnapsack     `protobuf:"bytes,10,opt,name=virtual" json:"virtual,omitempty"`
+	Strategy OptimizationStrategy `protobuf:"varint,11,opt,name=strategy,enum=sonm.OptimizationStrategy" json:"strategy,omitempty"`
+	// CreatedPlans maps order IDs to created plan IDs.
+	CreatedPlans map[string]string `protobuf:"bytes,12,rep,name=createdPlans" json:"createdPlans,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// PlanErrors maps order IDs to errors occurred while creating plans.
+	PlanErrors map[string]string `protobuf:"bytes,13,rep,name=planErrors" json:"planErrors,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Error describes the epoch error if any.
+	Error string `protobuf:"bytes,14,opt,name=error" json:"error,omitempty"`
+}
+
+func (m *OptimusEpochReport) Reset()                    { *m = OptimusEpochReport{} }
+func (m *OptimusEpochReport) String() string            { return proto.CompactTextString(m) }
+func (*OptimusEpochReport) ProtoMessage()               {}
+func (*OptimusEpochReport) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{4} }
+
+func (m *OptimusEpochReport) GetAddr() *EthAddress {
+	if m != nil {
+		return m.Addr
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetStartedAt() *Timestamp {
+	if m != nil {
+		return m.StartedAt
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetFinishedAt() *Timestamp {
+	if m != nil {
+		return m.FinishedAt
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetOrdersCount() uint64 {
+	if m != nil {
+		return m.OrdersCount
+	}
+	return 0
+}
+
+func (m *OptimusEpochReport) GetDevices() *DevicesReply {
+	if m != nil {
+		return m.Devices
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetPlans() map[string]*AskPlan {
+	if m != nil {
+		return m.Plans
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetCurrentPrice() *Price {
+	if m != nil {
+		return m.CurrentPrice
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetRemovedPlans() []string {
+	if m != nil {
+		return m.RemovedPlans
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetNatural() *OptimusKnapsack {
+	if m != nil {
+		return m.Natural
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetVirtual() *OptimusKnapsack {
+	if m != nil {
+		return m.Virtual
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetStrategy() OptimizationStrategy {
+	if m != nil {
+		return m.Strategy
+	}
+	return OptimizationStrategy_STRATEGY_NONE
+}
+
+func (m *OptimusEpochReport) GetCreatedPlans() map[string]string {
+	if m != nil {
+		return m.CreatedPlans
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetPlanErrors() map[string]string {
+	if m != nil {
+		return m.PlanErrors
+	}
+	return nil
+}
+
+func (m *OptimusEpochReport) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*OptimusWorker)(nil), "sonm.OptimusWorker")
+	proto.RegisterType((*OptimusWorkersReply)(nil), "sonm.OptimusWorkersReply")
+	proto.RegisterType((*OptimusPriceThresholdRequest)(nil), "sonm.OptimusPriceThresholdRequest")
+	proto.RegisterType((*OptimusKnapsack)(nil), "sonm.OptimusKnapsack")
+	proto.RegisterType((*OptimusEpochReport)(nil), "sonm.OptimusEpochReport")
+	proto.RegisterEnum("sonm.OptimizationStrategy", OptimizationStrategy_name, OptimizationStrategy_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// Client API for Optimus service
+
+type OptimusClient interface {
+	// Workers returns the list of workers managed by this Optimus instance.
+	Workers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OptimusWorkersReply, error)
+	// LastEpoch returns the report of the last optimization epoch performed
+	// for the specified worker.
+	LastEpoch(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*OptimusEpochReport, error)
+	// Execute triggers an immediate optimization epoch for the specified
+	// worker.
+	Execute(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*Empty, error)
+	// Pause stops managing the specified worker until resumed.
+	Pause(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*Empty, error)
+	// Resume continues managing the specified worker.
+	Resume(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*Empty, error)
+	// SetPriceThreshold changes the price threshold of the specified worker
+	// in runtime.
+	SetPriceThreshold(ctx context.Context, in *OptimusPriceThresholdRequest, opts ...grpc.CallOption) (*Empty, error)
+}
+
+type optimusClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewOptimusClient(cc *grpc.ClientConn) OptimusClient {
+	return &optimusClient{cc}
+}
+
+func (c *optimusClient) Workers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*OptimusWorkersReply, error) {
+	out := new(OptimusWorkersReply)
+	err := grpc.Invoke(ctx, "/sonm.Optimus/Workers", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimusClient) LastEpoch(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*OptimusEpochReport, error) {
+	out := new(OptimusEpochReport)
+	err := grpc.Invoke(ctx, "/sonm.Optimus/LastEpoch", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimusClient) Execute(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Optimus/Execute", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimusClient) Pause(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Optimus/Pause", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimusClient) Resume(ctx context.Context, in *EthAddress, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Optimus/Resume", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *optimusClient) SetPriceThreshold(ctx context.Context, in *OptimusPriceThresholdRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Optimus/SetPriceThreshold", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Server API for Optimus service
+
+type OptimusServer interface {
+	// Workers returns the list of workers managed by this Optimus instance.
+	Workers(context.Context, *Empty) (*OptimusWorkersReply, error)
+	// LastEpoch returns the report of the last optimization epoch performed
+	// for the specified worker.
+	LastEpoch(context.Context, *EthAddress) (*OptimusEpochReport, error)
+	// Execute triggers an immediate optimization epoch for the specified
+	// worker.
+	Execute(context.Context, *EthAddress) (*Empty, error)
+	// Pause stops managing the specified worker until resumed.
+	Pause(context.Context, *EthAddress) (*Empty, error)
+	// Resume continues managing the specified worker.
+	Resume(context.Context, *EthAddress) (*Empty, error)
+	// SetPriceThreshold changes the price threshold of the specified worker
+	// in runtime.
+	SetPriceThreshold(context.Context, *OptimusPriceThresholdRequest) (*Empty, error)
+}
+
+func RegisterOptimusServer(s *grpc.Server, srv OptimusServer) {
+	s.RegisterService(&_Optimus_serviceDesc, srv)
+}
+
+func _Optimus_Workers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimusServer).Workers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Optimus/Workers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimusServer).Workers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimus_LastEpoch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EthAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimusServer).LastEpoch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Optimus/LastEpoch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimusServer).LastEpoch(ctx, req.(*EthAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimus_Execute_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EthAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimusServer).Execute(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Optimus/Execute",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimusServer).Execute(ctx, req.(*EthAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimus_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EthAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimusServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Optimus/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimusServer).Pause(ctx, req.(*EthAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimus_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EthAddress)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimusServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Optimus/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimusServer).Resume(ctx, req.(*EthAddress))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Optimus_SetPriceThreshold_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OptimusPriceThresholdRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OptimusServer).SetPriceThreshold(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Optimus/SetPriceThreshold",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OptimusServer).SetPriceThreshold(ctx, req.(*OptimusPriceThresholdRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Optimus_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "sonm.Optimus",
+	HandlerType: (*OptimusServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Workers",
+			Handler:    _Optimus_Workers_Handler,
+		},
+		{
+			MethodName: "LastEpoch",
+			Handler:    _Optimus_LastEpoch_Handler,
+		},
+		{
+			MethodName: "Execute",
+			Handler:    _Optimus_Execute_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Optimus_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Optimus_Resume_Handler,
+		},
+		{
+			MethodName: "SetPriceThreshold",
+			Handler:    _Optimus_SetPriceThreshold_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "optimus.proto",
+}
+
+// Begin grpccmd
+var _ = grpccmd.RunE
+
+// Optimus
+var _OptimusCmd = &cobra.Command{
+	Use:   "optimus [method]",
+	Short: "Subcommand for the Optimus service.",
+}
+
+var _Optimus_WorkersCmd = &cobra.Command{
+	Use:   "workers",
+	Short: "Make the Workers method call, input-type: sonm.Empty output-type: sonm.OptimusWorkersReply",
+	RunE: grpccmd.RunE(
+		"Workers",
+		"sonm.Empty",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewOptimusClient(cc)
+		},
+	),
+}
+
+var _Optimus_WorkersCmd_gen = &cobra.Command{
+	Use:   "workers-gen",
+	Short: "Generate JSON for method call of Workers (input-type: sonm.Empty)",
+	RunE:  grpccmd.TypeToJson("sonm.Empty"),
+}
+
+var _Optimus_LastEpochCmd = &cobra.Command{
+	Use:   "lastEpoch",
+	Short: "Make the LastEpoch method call, input-type: sonm.EthAddress output-type: sonm.OptimusEpochReport",
+	RunE: grpccmd.RunE(
+		"LastEpoch",
+		"sonm.EthAddress",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewOptimusClient(cc)
+		},
+	),
+}
+
+var _Optimus_LastEpochCmd_gen = &cobra.Command{
+	Use:   "lastEpoch-gen",
+	Short: "Generate JSON for method call of LastEpoch (input-type: sonm.EthAddress)",
+	RunE:  grpccmd.TypeToJson("sonm.EthAddress"),
+}
+
+var _Optimus_ExecuteCmd = &cobra.Command{
+	Use:   "execute",
+	Short: "Make the Execute method call, input-type: sonm.EthAddress output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"Execute",
+		"sonm.EthAddress",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewOptimusClient(cc)
+		},
+	),
+}
+
+var _Optimus_ExecuteCmd_gen = &cobra.Command{
+	Use:   "execute-gen",
+	Short: "Generate JSON for method call of Execute (input-type: sonm.EthAddress)",
+	RunE:  grpccmd.TypeToJson("sonm.EthAddress"),
+}
+
+var _Optimus_PauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Make the Pause method call, input-type: sonm.EthAddress output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"Pause",
+		"sonm.EthAddress",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewOptimusClient(cc)
+		},
+	),
+}
+
+var _Optimus_PauseCmd_gen = &cobra.Command{
+	Use:   "pause-gen",
+	Short: "Generate JSON for method call of Pause (input-type: sonm.EthAddress)",
+	RunE:  grpccmd.TypeToJson("sonm.EthAddress"),
+}
+
+var _Optimus_ResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Make the Resume method call, input-type: sonm.EthAddress output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"Resume",
+		"sonm.EthAddress",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewOptimusClient(cc)
+		},
+	),
+}
+
+var _Optimus_ResumeCmd_gen = &cobra.Command{
+	Use:   "resume-gen",
+	Short: "Generate JSON for method call of Resume (input-type: sonm.EthAddress)",
+	RunE:  grpccmd.TypeToJson("sonm.EthAddress"),
+}
+
+var _Optimus_SetPriceThresholdCmd = &cobra.Command{
+	Use:   "setPriceThreshold",
+	Short: "Make the SetPriceThreshold method call, input-type: sonm.OptimusPriceThresholdRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"SetPriceThreshold",
+		"sonm.OptimusPriceThresholdRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewOptimusClient(cc)
+		},
+	),
+}
+
+var _Optimus_SetPriceThresholdCmd_gen = &cobra.Command{
+	Use:   "setPriceThreshold-gen",
+	Short: "Generate JSON for method call of SetPriceThreshold (input-type: sonm.OptimusPriceThresholdRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.OptimusPriceThresholdRequest"),
+}
+
+// Register commands with the root command and service command
+func init() {
+	grpccmd.RegisterServiceCmd(_OptimusCmd)
+	_OptimusCmd.AddCommand(
+		_Optimus_WorkersCmd,
+		_Optimus_WorkersCmd_gen,
+		_Optimus_LastEpochCmd,
+		_Optimus_LastEpochCmd_gen,
+		_Optimus_ExecuteCmd,
+		_Optimus_ExecuteCmd_gen,
+		_Optimus_PauseCmd,
+		_Optimus_PauseCmd_gen,
+		_Optimus_ResumeCmd,
+		_Optimus_ResumeCmd_gen,
+		_Optimus_SetPriceThresholdCmd,
+		_Optimus_SetPriceThresholdCmd_gen,
+	)
+}
+
+// End grpccmd
+
+func init() { proto.RegisterFile("optimus.proto", fileDescriptor10) }
+
+var fileDescriptor10 = []byte{
+	// 795 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0x5f, 0x6f, 0xe3, 0x44,
+	0x10, 0xaf, 0x93, 0x26, 0xa9, 0x27, 0x49, 0x9b, 0xce, 0x95, 0xd3, 0x12, 0xf1, 0x10, 0x7c, 0x3c,
+	0xf8, 0x2a, 0xda, 0xa2, 0x56, 0x42, 0x1c, 0x12, 0x42, 0x51, 0x6b, 0x0a, 0xe2, 0xc8, 0x45, 0xdb,
+	0x48, 0xe8, 0xc4, 0xc3, 0x69, 0x89, 0x17, 0x62, 0xc5, 0xff, 0xd8, 0x5d, 0x17, 0xc2, 0x77, 0xe0,
+	0x4b, 0xf1, 0xad, 0x78, 0x43, 0xde, 0xb5, 0x53, 0xbb, 0x49, 0x8e, 0xf2, 0x96, 0x9d, 0xf9, 0xcd,
+	0x2f, 0x33, 0xbf, 0xf9, 0x63, 0xe8, 0x27, 0xa9, 0x0a, 0xa2, 0x4c, 0x9e, 0xa7, 0x22, 0x51, 0x09,
+	0xee, 0xcb, 0x24, 0x8e, 0x86, 0x87, 0x4c, 0x2e, 0xdf, 0xa5, 0x21, 0x8b, 0x8d, 0x75, 0x78, 0x14,
+	0xc4, 0xb9, 0x3d, 0x0e, 0x58, 0x69, 0x50, 0x41, 0xc4, 0xa5, 0x62, 0x51, 0x5a, 0x18, 0x7a, 0xbf,
+	0x27, 0x62, 0xc9, 0x85, 0x79, 0x39, 0xff, 0x58, 0xd0, 0x7f, 0x63, 0x78, 0x7f, 0xd4, 0x76, 0xfc,
+	0x04, 0xf6, 0x99, 0xef, 0x0b, 0x62, 0x8d, 0x2c, 0xb7, 0x7b, 0x39, 0x38, 0xcf, 0xe9, 0xce, 0x3d,
+	0xb5, 0x18, 0xfb, 0xbe, 0xe0, 0x52, 0x52, 0xed, 0xc5, 0xcf, 0x00, 0x22, 0x26, 0x15, 0x17, 0xb9,
+	0x99, 0x34, 0x76, 0x60, 0x2b, 0x18, 0x7c, 0x0e, 0xed, 0x94, 0x65, 0x92, 0xfb, 0xa4, 0x39, 0xb2,
+	0xdc, 0x03, 0x5a, 0xbc, 0xf0, 0x0a, 0x0e, 0x53, 0x11, 0xcc, 0xf9, 0x6c, 0x21, 0xb8, 0x5c, 0x24,
+	0xa1, 0x4f, 0xf6, 0x35, 0x5b, 0xd7, 0xb0, 0x4d, 0x73, 0x1f, 0x7d, 0x04, 0xc1, 0x33, 0xb0, 0x43,
+	0x26, 0x95, 0x97, 0x26, 0xf3, 0x05, 0x69, 0x69, 0xfc, 0x91, 0xc1, 0xcf, 0xca, 0x72, 0xe9, 0x03,
+	0x02, 0x4f, 0xa0, 0xc5, 0x85, 0x48, 0x04, 0x69, 0x8f, 0x2c, 0xd7, 0xa6, 0xe6, 0xe1, 0xdc, 0xc0,
+	0xb3, 0x5a, 0xe9, 0x92, 0xf2, 0x34, 0x5c, 0xe1, 0x19, 0x74, 0x8c, 0x44, 0x92, 0x58, 0xa3, 0xa6,
+	0xdb, 0xbd, 0x7c, 0x66, 0x98, 0x6b, 0x58, 0x5a, 0x62, 0x9c, 0x04, 0x3e, 0x2a, 0x3c, 0xd3, 0x5a,
+	0x8e, 0x94, 0xff, 0x96, 0x71, 0xa9, 0x9e, 0xa8, 0xe7, 0x4b, 0xb0, 0xd5, 0x5a, 0x80, 0xc6, 0xa6,
+	0x00, 0x0f, 0x5e, 0xe7, 0x2d, 0x1c, 0x15, 0x7f, 0xf8, 0x7d, 0xcc, 0x52, 0xc9, 0xe6, 0x4b, 0xfc,
+	0x18, 0x5a, 0x5a, 0x20, 0x62, 0x6d, 0x46, 0x1a, 0x0f, 0xbe, 0x80, 0x56, 0x3e, 0x26, 0x92, 0x34,
+	0x74, 0x4d, 0x7d, 0x03, 0x19, 0xcb, 0xe5, 0x34, 0x64, 0x31, 0x35, 0x3e, 0xe7, 0xaf, 0x0e, 0x60,
+	0xc1, 0xad, 0x85, 0xa3, 0x3c, 0x4d, 0xc4, 0x53, 0x4b, 0x38, 0x03, 0x5b, 0x2a, 0x26, 0x14, 0xf7,
+	0xc7, 0x8a, 0x34, 0x76, 0xf4, 0x64, 0x8d, 0xc0, 0x0b, 0x80, 0x5f, 0x82, 0x38, 0x90, 0x0b, 0x8d,
+	0x6f, 0x6e, 0xc7, 0x57, 0x20, 0x38, 0x82, 0x6e, 0x22, 0x7c, 0x2e, 0xe4, 0x75, 0x92, 0xc5, 0x4a,
+	0x4f, 0xc9, 0x3e, 0xad, 0x9a, 0xf0, 0x53, 0xe8, 0xf8, 0xfc, 0x3e, 0x98, 0x73, 0x59, 0xcc, 0x04,
+	0x1a, 0xbe, 0x1b, 0x63, 0xd4, 0xed, 0xa5, 0x25, 0x04, 0x5f, 0x95, 0x8a, 0xb4, 0xb5, 0x22, 0x2f,
+	0x6a, 0x5d, 0xae, 0x94, 0x7f, 0x9e, 0x2b, 0x24, 0xbd, 0x58, 0x89, 0x55, 0xa1, 0x13, 0x5e, 0x40,
+	0x6f, 0x9e, 0x09, 0xc1, 0x63, 0xa5, 0x35, 0x26, 0x9d, 0x4d, 0xd9, 0x6b, 0x00, 0x74, 0xa0, 0x27,
+	0x78, 0x94, 0xdc, 0x73, 0x5f, 0x93, 0x91, 0x83, 0x51, 0xd3, 0xb5, 0x69, 0xcd, 0x86, 0x17, 0xd0,
+	0x89, 0x99, 0xca, 0x04, 0x0b, 0x89, 0xad, 0xf9, 0x3e, 0xa8, 0x65, 0x54, 0x36, 0x9b, 0x96, 0xa8,
+	0x3c, 0xe0, 0x3e, 0x10, 0x2a, 0x63, 0x21, 0x81, 0xf7, 0x06, 0x14, 0x28, 0xfc, 0x1c, 0x0e, 0xa4,
+	0x12, 0x4c, 0xf1, 0x5f, 0x57, 0xa4, 0x3b, 0xb2, 0xdc, 0xc3, 0xcb, 0x61, 0x25, 0x22, 0xf8, 0x93,
+	0xa9, 0x20, 0x89, 0xef, 0x0a, 0x04, 0x5d, 0x63, 0x71, 0x02, 0xbd, 0xb9, 0xe0, 0x4c, 0x95, 0xd9,
+	0xf7, 0xb4, 0x60, 0xa7, 0x3b, 0x05, 0xbb, 0xae, 0x80, 0x8d, 0x6e, 0xb5, 0x78, 0xfc, 0x16, 0x20,
+	0xd7, 0xd1, 0xcb, 0xb7, 0x50, 0x92, 0xbe, 0x66, 0x73, 0xdf, 0x2b, 0xbf, 0x81, 0x1a, 0xae, 0x4a,
+	0xec, 0xc3, 0x62, 0x1f, 0x56, 0x16, 0x7b, 0x78, 0x0b, 0xf0, 0xf0, 0xdf, 0x38, 0x80, 0xe6, 0x92,
+	0xaf, 0xf4, 0xf0, 0xda, 0x34, 0xff, 0x99, 0xef, 0xc2, 0x3d, 0x0b, 0x33, 0x5e, 0x4c, 0xe9, 0xe3,
+	0x5d, 0xd0, 0xbe, 0x2f, 0x1b, 0x5f, 0x58, 0xc3, 0xaf, 0xe1, 0x78, 0xa3, 0x96, 0x2d, 0x7c, 0x27,
+	0x55, 0x3e, 0xbb, 0x4a, 0xf0, 0x15, 0x1c, 0x3d, 0x4a, 0xff, 0xff, 0x84, 0x9f, 0xfe, 0x04, 0x27,
+	0xdb, 0x5a, 0x83, 0xc7, 0xd0, 0xbf, 0x9b, 0xd1, 0xf1, 0xcc, 0xbb, 0x7d, 0xfb, 0x6e, 0xf2, 0x66,
+	0xe2, 0x0d, 0xf6, 0xf0, 0x39, 0xe0, 0xda, 0x34, 0x9e, 0x4e, 0xbd, 0xc9, 0xcd, 0x77, 0x93, 0xdb,
+	0x81, 0x85, 0x04, 0x4e, 0xd6, 0x76, 0xea, 0x4d, 0x5f, 0x8f, 0xaf, 0xbd, 0x1f, 0xbc, 0xc9, 0x6c,
+	0xd0, 0xb8, 0xfc, 0xbb, 0x01, 0x9d, 0x42, 0x6e, 0xbc, 0x82, 0x4e, 0x71, 0x03, 0xb1, 0x98, 0x62,
+	0x2f, 0x4a, 0xd5, 0x6a, 0xf8, 0xe1, 0x96, 0xd3, 0x67, 0xf6, 0xc8, 0xd9, 0xc3, 0x57, 0x60, 0xbf,
+	0x5e, 0x9f, 0xd8, 0x8d, 0xab, 0x30, 0x24, 0xbb, 0x3a, 0xea, 0xec, 0xe1, 0x29, 0x74, 0xbc, 0x3f,
+	0xf8, 0x3c, 0x53, 0x7c, 0x4b, 0x60, 0x35, 0x03, 0x67, 0x0f, 0x5d, 0x68, 0x4d, 0xf3, 0x4f, 0xc5,
+	0x7f, 0x23, 0x5f, 0x42, 0x9b, 0x72, 0x99, 0x45, 0x4f, 0x80, 0x7e, 0x03, 0xc7, 0x77, 0x5c, 0xd5,
+	0x2f, 0x36, 0x3a, 0xb5, 0x8c, 0xb7, 0x9e, 0xf3, 0x47, 0x3c, 0x3f, 0xb7, 0xf5, 0x67, 0xf4, 0xea,
+	0xdf, 0x01, 0x00, 0xdd, 0x00, 0xef, 0x19, 0x9d, 0x07, 0x00, 0x00,
+}
@@ -2,6 +2,7 @@ package sonm
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
 
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
@@ -14,6 +15,12 @@ func (m *HandshakeRequest) Validate() error {
 		return fmt.Errorf("address must have exactly 20 bytes format")
 	}
 
+	switch m.Protocol {
+	case "", DefaultNPPProtocol, UDPNPPProtocol:
+	default:
+		return fmt.Errorf("unsupported protocol: %s", m.Protocol)
+	}
+
 	switch m.PeerType {
 	case PeerType_SERVER:
 		if len(m.Sign) == 0 {
@@ -29,9 +36,42 @@ func (m *HandshakeRequest) Validate() error {
 		if !bytes.Equal(crypto.PubkeyToAddress(*publicKey).Bytes(), m.Addr) {
 			return fmt.Errorf("invalid signature for provided ETH address")
 		}
+
+		if len(m.ClientAddr) != 0 {
+			return fmt.Errorf("client address must be empty for server-side handshake")
+		}
+
+		if err := m.ACL.Validate(); err != nil {
+			return err
+		}
 	case PeerType_CLIENT:
-		if len(m.Sign) != 0 {
-			return fmt.Errorf("sign field must be empty for client-side handshake")
+		if m.ACL != nil {
+			return fmt.Errorf("ACL must be empty for client-side handshake")
+		}
+
+		if len(m.Sign) == 0 {
+			if len(m.ClientAddr) != 0 {
+				return fmt.Errorf("client address must be signed")
+			}
+
+			return nil
+		}
+
+		if len(m.ClientAddr) != 20 {
+			return fmt.Errorf("client address must have exactly 20 bytes format")
+		}
+
+		if len(m.Nonce) == 0 {
+			return fmt.Errorf("nonce must not be empty for signed client-side handshake")
+		}
+
+		publicKey, err := crypto.SigToPub(m.ClientSignHash(), m.Sign)
+		if err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+
+		if !bytes.Equal(crypto.PubkeyToAddress(*publicKey).Bytes(), m.ClientAddr) {
+			return fmt.Errorf("invalid signature for provided client ETH address")
 		}
 	default:
 		return fmt.Errorf("unknown peer type: %s", m.PeerType)
@@ -40,7 +80,77 @@ func (m *HandshakeRequest) Validate() error {
 	return nil
 }
 
+// ClientSignHash returns the hash of client handshake fields that must be
+// signed by the client.
+//
+// Fields are length-prefixed, so the signature can't be reused for another
+// handshake by moving bytes between adjacent fields.
+func (m *HandshakeRequest) ClientSignHash() []byte {
+	timestamp := make([]byte, 8)
+	binary.BigEndian.PutUint64(timestamp, uint64(m.Timestamp))
+
+	var data []byte
+	data = appendSignField(data, m.ClientAddr)
+	data = appendSignField(data, m.Addr)
+	data = appendSignField(data, timestamp)
+	data = appendSignField(data, m.Nonce)
+	data = appendSignField(data, []byte(m.UUID))
+	data = appendSignField(data, []byte(m.Protocol))
+
+	return chainhash.DoubleHashB(data)
+}
+
+// appendSignField appends the length-prefixed field to the data being
+// signed.
+func appendSignField(data, field []byte) []byte {
+	size := make([]byte, 4)
+	binary.BigEndian.PutUint32(size, uint32(len(field)))
+
+	data = append(data, size...)
+	return append(data, field...)
+}
+
+// IsSigned returns true if the handshake is signed by the client.
+func (m *HandshakeRequest) IsSigned() bool {
+	return len(m.Sign) != 0
+}
+
 // HasUUID returns true if a request has UUID provided.
 func (m *HandshakeRequest) HasUUID() bool {
 	return len(m.UUID) != 0
 }
+
+// Validate validates the ACL, which is optional.
+func (m *RelayACL) Validate() error {
+	if m == nil {
+		return nil
+	}
+
+	for _, addr := range m.Addrs {
+		if len(addr) != 20 {
+			return fmt.Errorf("ACL address must have exactly 20 bytes format")
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty returns true if the ACL allows anyone to connect.
+func (m *RelayACL) IsEmpty() bool {
+	return m == nil || (len(m.Addrs) == 0 && len(m.Verifiers) == 0)
+}
+
+// Contains checks whether the given ETH address is explicitly allowed.
+func (m *RelayACL) Contains(addr []byte) bool {
+	if m == nil {
+		return false
+	}
+
+	for _, allowed := range m.Addrs {
+		if bytes.Equal(allowed, addr) {
+			return true
+		}
+	}
+
+	return false
+}
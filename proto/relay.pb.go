@@ -47,7 +47,7 @@ var PeerType_value = map[string]int32{
 func (x PeerType) String() string {
 	return proto.EnumName(PeerType_name, int32(x))
 }
-func (PeerType) EnumDescriptor() ([]byte, []int) { return fileDescriptor10, []int{0} }
+func (PeerType) EnumDescriptor() ([]byte, []int) { return fileDescriptor11, []int{0} }
 
 type HandshakeRequest struct {
 	// PeerType describes a peer's source.
@@ -63,7 +63,8 @@ type HandshakeRequest struct {
 	// It is done in the Handshake method.
 	Addr []byte `protobuf:"bytes,2,opt,name=addr,proto3" json:"addr,omitempty"`
 	// Signature for ETH address.
-	// Should be empty for clients.
+	// Optional for clients, in which case it signs the client handshake
+	// including "clientAddr", "addr", "timestamp" and "nonce" fields.
 	Sign []byte `protobuf:"bytes,3,opt,name=sign,proto3" json:"sign,omitempty"`
 	// Optional connection id.
 	// It is used when a client wants to connect to a specific server avoiding
@@ -73,12 +74,25 @@ type HandshakeRequest struct {
 	// Protocol describes the network protocol the peer wants to publish or to
 	// resolve.
 	Protocol string `protobuf:"bytes,5,opt,name=protocol" json:"protocol,omitempty"`
+	// ClientAddr is the client's own ETH address, which is required to
+	// connect to servers that have published an ACL.
+	// Must be signed if provided. Should be empty for servers.
+	ClientAddr []byte `protobuf:"bytes,6,opt,name=clientAddr,proto3" json:"clientAddr,omitempty"`
+	// Timestamp is the client handshake creation time in Unix nanoseconds.
+	// Signed client handshakes are accepted only within a limited time window.
+	Timestamp int64 `protobuf:"varint,7,opt,name=timestamp" json:"timestamp,omitempty"`
+	// Nonce is a random value protecting signed client handshakes from being
+	// replayed within the time window.
+	Nonce []byte `protobuf:"bytes,8,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	// ACL describes which clients are allowed to connect to the server.
+	// Empty ACL means that anyone is allowed. Should be empty for clients.
+	ACL *RelayACL `protobuf:"bytes,9,opt,name=ACL" json:"ACL,omitempty"`
 }
 
 func (m *HandshakeRequest) Reset()                    { *m = HandshakeRequest{} }
 func (m *HandshakeRequest) String() string            { return proto.CompactTextString(m) }
 func (*HandshakeRequest) ProtoMessage()               {}
-func (*HandshakeRequest) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{0} }
+func (*HandshakeRequest) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{0} }
 
 func (m *HandshakeRequest) GetPeerType() PeerType {
 	if m != nil {
@@ -115,6 +129,63 @@ func (m *HandshakeRequest) GetProtocol() string {
 	return ""
 }
 
+func (m *HandshakeRequest) GetClientAddr() []byte {
+	if m != nil {
+		return m.ClientAddr
+	}
+	return nil
+}
+
+func (m *HandshakeRequest) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *HandshakeRequest) GetNonce() []byte {
+	if m != nil {
+		return m.Nonce
+	}
+	return nil
+}
+
+func (m *HandshakeRequest) GetACL() *RelayACL {
+	if m != nil {
+		return m.ACL
+	}
+	return nil
+}
+
+type RelayACL struct {
+	// Addrs is a list of ETH addresses allowed to connect.
+	Addrs [][]byte `protobuf:"bytes,1,rep,name=addrs,proto3" json:"addrs,omitempty"`
+	// Verifiers is a list of Relay-side verifier names, which are asked
+	// whether the client is allowed to connect, for example "deals" allows
+	// only clients having an accepted deal with the server.
+	// It's enough for any of them to allow the client.
+	Verifiers []string `protobuf:"bytes,2,rep,name=verifiers" json:"verifiers,omitempty"`
+}
+
+func (m *RelayACL) Reset()                    { *m = RelayACL{} }
+func (m *RelayACL) String() string            { return proto.CompactTextString(m) }
+func (*RelayACL) ProtoMessage()               {}
+func (*RelayACL) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{1} }
+
+func (m *RelayACL) GetAddrs() [][]byte {
+	if m != nil {
+		return m.Addrs
+	}
+	return nil
+}
+
+func (m *RelayACL) GetVerifiers() []string {
+	if m != nil {
+		return m.Verifiers
+	}
+	return nil
+}
+
 type DiscoverResponse struct {
 	// Addr represents network address in form "host:port".
 	Addr string `protobuf:"bytes,1,opt,name=addr" json:"addr,omitempty"`
@@ -123,7 +194,7 @@ type DiscoverResponse struct {
 func (m *DiscoverResponse) Reset()                    { *m = DiscoverResponse{} }
 func (m *DiscoverResponse) String() string            { return proto.CompactTextString(m) }
 func (*DiscoverResponse) ProtoMessage()               {}
-func (*DiscoverResponse) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{1} }
+func (*DiscoverResponse) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{2} }
 
 func (m *DiscoverResponse) GetAddr() string {
 	if m != nil {
@@ -143,7 +214,7 @@ type HandshakeResponse struct {
 func (m *HandshakeResponse) Reset()                    { *m = HandshakeResponse{} }
 func (m *HandshakeResponse) String() string            { return proto.CompactTextString(m) }
 func (*HandshakeResponse) ProtoMessage()               {}
-func (*HandshakeResponse) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{2} }
+func (*HandshakeResponse) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{3} }
 
 func (m *HandshakeResponse) GetError() int32 {
 	if m != nil {
@@ -166,7 +237,7 @@ type RelayClusterReply struct {
 func (m *RelayClusterReply) Reset()                    { *m = RelayClusterReply{} }
 func (m *RelayClusterReply) String() string            { return proto.CompactTextString(m) }
 func (*RelayClusterReply) ProtoMessage()               {}
-func (*RelayClusterReply) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{3} }
+func (*RelayClusterReply) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{4} }
 
 func (m *RelayClusterReply) GetMembers() []string {
 	if m != nil {
@@ -179,12 +250,15 @@ type RelayMetrics struct {
 	ConnCurrent uint64                 `protobuf:"varint,1,opt,name=connCurrent" json:"connCurrent,omitempty"`
 	Net         map[string]*NetMetrics `protobuf:"bytes,2,rep,name=net" json:"net,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Uptime      uint64                 `protobuf:"varint,3,opt,name=uptime" json:"uptime,omitempty"`
+	// Usage contains per-ETH address traffic usage, including both TCP and
+	// UDP relaying.
+	Usage map[string]*RelayUsage `protobuf:"bytes,4,rep,name=usage" json:"usage,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 }
 
 func (m *RelayMetrics) Reset()                    { *m = RelayMetrics{} }
 func (m *RelayMetrics) String() string            { return proto.CompactTextString(m) }
 func (*RelayMetrics) ProtoMessage()               {}
-func (*RelayMetrics) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{4} }
+func (*RelayMetrics) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{5} }
 
 func (m *RelayMetrics) GetConnCurrent() uint64 {
 	if m != nil {
@@ -207,6 +281,68 @@ func (m *RelayMetrics) GetUptime() uint64 {
 	return 0
 }
 
+func (m *RelayMetrics) GetUsage() map[string]*RelayUsage {
+	if m != nil {
+		return m.Usage
+	}
+	return nil
+}
+
+type RelayUsage struct {
+	// BytesToday shows the number of bytes relayed since the UTC midnight.
+	BytesToday uint64 `protobuf:"varint,1,opt,name=bytesToday" json:"bytesToday,omitempty"`
+	// BytesTotal shows the number of bytes relayed since the Relay start.
+	BytesTotal uint64 `protobuf:"varint,2,opt,name=bytesTotal" json:"bytesTotal,omitempty"`
+	// DailyQuota shows the maximum number of bytes allowed to be relayed
+	// per day. Zero means unlimited.
+	DailyQuota uint64 `protobuf:"varint,3,opt,name=dailyQuota" json:"dailyQuota,omitempty"`
+	// Rate shows the bandwidth limit in bytes per second. Zero means
+	// unlimited.
+	Rate uint64 `protobuf:"varint,4,opt,name=rate" json:"rate,omitempty"`
+	// Privileged shows whether the address is exempt from limits.
+	Privileged bool `protobuf:"varint,5,opt,name=privileged" json:"privileged,omitempty"`
+}
+
+func (m *RelayUsage) Reset()                    { *m = RelayUsage{} }
+func (m *RelayUsage) String() string            { return proto.CompactTextString(m) }
+func (*RelayUsage) ProtoMessage()               {}
+func (*RelayUsage) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{6} }
+
+func (m *RelayUsage) GetBytesToday() uint64 {
+	if m != nil {
+		return m.BytesToday
+	}
+	return 0
+}
+
+func (m *RelayUsage) GetBytesTotal() uint64 {
+	if m != nil {
+		return m.BytesTotal
+	}
+	return 0
+}
+
+func (m *RelayUsage) GetDailyQuota() uint64 {
+	if m != nil {
+		return m.DailyQuota
+	}
+	return 0
+}
+
+func (m *RelayUsage) GetRate() uint64 {
+	if m != nil {
+		return m.Rate
+	}
+	return 0
+}
+
+func (m *RelayUsage) GetPrivileged() bool {
+	if m != nil {
+		return m.Privileged
+	}
+	return false
+}
+
 type NetMetrics struct {
 	TxBytes uint64 `protobuf:"varint,1,opt,name=txBytes" json:"txBytes,omitempty"`
 	RxBytes uint64 `protobuf:"varint,2,opt,name=rxBytes" json:"rxBytes,omitempty"`
@@ -215,7 +351,7 @@ type NetMetrics struct {
 func (m *NetMetrics) Reset()                    { *m = NetMetrics{} }
 func (m *NetMetrics) String() string            { return proto.CompactTextString(m) }
 func (*NetMetrics) ProtoMessage()               {}
-func (*NetMetrics) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{5} }
+func (*NetMetrics) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{7} }
 
 func (m *NetMetrics) GetTxBytes() uint64 {
 	if m != nil {
@@ -233,10 +369,12 @@ func (m *NetMetrics) GetRxBytes() uint64 {
 
 func init() {
 	proto.RegisterType((*HandshakeRequest)(nil), "sonm.HandshakeRequest")
+	proto.RegisterType((*RelayACL)(nil), "sonm.RelayACL")
 	proto.RegisterType((*DiscoverResponse)(nil), "sonm.DiscoverResponse")
 	proto.RegisterType((*HandshakeResponse)(nil), "sonm.HandshakeResponse")
 	proto.RegisterType((*RelayClusterReply)(nil), "sonm.RelayClusterReply")
 	proto.RegisterType((*RelayMetrics)(nil), "sonm.RelayMetrics")
+	proto.RegisterType((*RelayUsage)(nil), "sonm.RelayUsage")
 	proto.RegisterType((*NetMetrics)(nil), "sonm.NetMetrics")
 	proto.RegisterEnum("sonm.PeerType", PeerType_name, PeerType_value)
 }
@@ -406,38 +544,49 @@ func init() {
 
 // End grpccmd
 
-func init() { proto.RegisterFile("relay.proto", fileDescriptor10) }
-
-var fileDescriptor10 = []byte{
-	// 466 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x52, 0x51, 0x6f, 0xd3, 0x3c,
-	0x14, 0xad, 0xdb, 0xa6, 0x4d, 0x6f, 0xaa, 0x7d, 0x99, 0xf5, 0x09, 0xa2, 0xf2, 0x12, 0xe5, 0x61,
-	0x8a, 0x26, 0x56, 0x41, 0x79, 0x41, 0x3c, 0x21, 0xda, 0x48, 0xab, 0x80, 0x82, 0xbc, 0x8d, 0xf7,
-	0x2c, 0xbd, 0x62, 0xd1, 0x12, 0x27, 0xd8, 0xce, 0x44, 0xfe, 0x08, 0xff, 0x87, 0x7f, 0x86, 0x6c,
-	0x27, 0x5b, 0x10, 0x4f, 0xb9, 0xf7, 0x9c, 0x6b, 0x9f, 0x73, 0x4f, 0x0c, 0x9e, 0xc0, 0x22, 0x6d,
-	0xd7, 0xb5, 0xa8, 0x54, 0x45, 0xa7, 0xb2, 0xe2, 0xe5, 0xea, 0xbf, 0x9c, 0xeb, 0x2f, 0xcf, 0x53,
-	0x0b, 0x47, 0xbf, 0x08, 0xf8, 0x97, 0x29, 0x3f, 0xca, 0xbb, 0xf4, 0x1e, 0x19, 0xfe, 0x68, 0x50,
-	0x2a, 0x7a, 0x0e, 0x6e, 0x8d, 0x28, 0xae, 0xdb, 0x1a, 0x03, 0x12, 0x92, 0xf8, 0x64, 0x73, 0xb2,
-	0xd6, 0xc7, 0xd6, 0x5f, 0x3b, 0x94, 0x3d, 0xf2, 0x94, 0xc2, 0x34, 0x3d, 0x1e, 0x45, 0x30, 0x0e,
-	0x49, 0xbc, 0x64, 0xa6, 0xd6, 0x98, 0xcc, 0xbf, 0xf3, 0x60, 0x62, 0x31, 0x5d, 0x6b, 0xec, 0xe6,
-	0x66, 0xbf, 0x0b, 0xa6, 0x21, 0x89, 0x17, 0xcc, 0xd4, 0x74, 0x05, 0xae, 0x71, 0x91, 0x55, 0x45,
-	0xe0, 0x18, 0xfc, 0xb1, 0x8f, 0xce, 0xc0, 0xdf, 0xe5, 0x32, 0xab, 0x1e, 0x50, 0x30, 0x94, 0x75,
-	0xc5, 0xe5, 0x93, 0x16, 0xb1, 0x77, 0xe8, 0x3a, 0xfa, 0x08, 0xa7, 0x03, 0xff, 0xdd, 0xe0, 0xff,
-	0xe0, 0xa0, 0x10, 0x95, 0x9d, 0x74, 0x98, 0x6d, 0x68, 0x08, 0xde, 0x11, 0x65, 0x26, 0xf2, 0x5a,
-	0xe5, 0x15, 0x37, 0x8e, 0x17, 0x6c, 0x08, 0x45, 0x17, 0x70, 0xca, 0x74, 0x66, 0xdb, 0xa2, 0x91,
-	0x4a, 0x0b, 0xd7, 0x45, 0x4b, 0x03, 0x98, 0x97, 0x58, 0xde, 0xa2, 0x90, 0x01, 0x09, 0x27, 0xf1,
-	0x82, 0xf5, 0x6d, 0xf4, 0x9b, 0xc0, 0xd2, 0xcc, 0x7f, 0x46, 0x25, 0xf2, 0x4c, 0x6a, 0x85, 0xac,
-	0xe2, 0x7c, 0xdb, 0x08, 0x81, 0x5c, 0x19, 0xf5, 0x29, 0x1b, 0x42, 0xf4, 0x02, 0x26, 0x1c, 0x55,
-	0x30, 0x0e, 0x27, 0xb1, 0xb7, 0x79, 0x61, 0x53, 0x1d, 0x5e, 0xb1, 0x3e, 0xa0, 0x4a, 0xb8, 0x12,
-	0x2d, 0xd3, 0x73, 0xf4, 0x19, 0xcc, 0x9a, 0x5a, 0xe5, 0x25, 0x9a, 0x2c, 0xa7, 0xac, 0xeb, 0x56,
-	0x97, 0xe0, 0xf6, 0x83, 0xd4, 0x87, 0xc9, 0x3d, 0xb6, 0x5d, 0x28, 0xba, 0xa4, 0x67, 0xe0, 0x3c,
-	0xa4, 0x45, 0x83, 0x66, 0x45, 0x6f, 0xe3, 0x5b, 0x99, 0x03, 0xaa, 0x4e, 0x84, 0x59, 0xfa, 0xdd,
-	0xf8, 0x2d, 0x89, 0xde, 0x03, 0x3c, 0x11, 0x7a, 0x57, 0xf5, 0xf3, 0x43, 0xab, 0x50, 0x76, 0xe6,
-	0xfb, 0x56, 0x33, 0xa2, 0x63, 0xc6, 0x96, 0xe9, 0xda, 0xf3, 0x57, 0xe0, 0xf6, 0xef, 0x82, 0x02,
-	0xcc, 0xae, 0x12, 0xf6, 0x2d, 0x61, 0xfe, 0x48, 0xd7, 0xdb, 0x4f, 0xfb, 0xe4, 0x70, 0xed, 0x13,
-	0xba, 0x04, 0x77, 0xb7, 0xbf, 0xda, 0x7e, 0xd1, 0xcc, 0x78, 0x73, 0x07, 0x8e, 0xd9, 0x99, 0xbe,
-	0x86, 0x79, 0x17, 0x35, 0xf5, 0xac, 0xc9, 0xa4, 0xac, 0x55, 0xbb, 0x7a, 0x3e, 0x08, 0x66, 0xf8,
-	0x2f, 0xa2, 0x11, 0x7d, 0x09, 0xf3, 0xde, 0xec, 0x5f, 0x47, 0xe8, 0xbf, 0x59, 0x46, 0xa3, 0xdb,
-	0x99, 0x79, 0x4f, 0x6f, 0xfe, 0x04, 0x00, 0x00, 0xff, 0xff, 0x1f, 0x03, 0x51, 0xd4, 0x0b, 0x03,
-	0x00, 0x00,
+func init() { proto.RegisterFile("relay.proto", fileDescriptor11) }
+
+var fileDescriptor11 = []byte{
+	// 653 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xae, 0x13, 0x3b, 0x71, 0x26, 0x55, 0x49, 0x57, 0x15, 0x58, 0xe1, 0x47, 0x96, 0x0f, 0x55,
+	0x54, 0xd1, 0x08, 0xd2, 0x0b, 0xe2, 0x80, 0x28, 0x69, 0xa4, 0x16, 0x4a, 0x81, 0x6d, 0xcb, 0x7d,
+	0x6b, 0x0f, 0xed, 0xaa, 0xce, 0xda, 0xec, 0xae, 0x23, 0xfc, 0x0e, 0x3c, 0x04, 0xcf, 0xc2, 0x93,
+	0xa1, 0x5d, 0xdb, 0x8d, 0x2b, 0x38, 0x70, 0xca, 0xcc, 0xf7, 0xed, 0x7c, 0x33, 0xfb, 0x65, 0xd6,
+	0x30, 0x94, 0x98, 0xb2, 0x72, 0x9a, 0xcb, 0x4c, 0x67, 0xc4, 0x55, 0x99, 0x58, 0x8e, 0x1f, 0x70,
+	0x61, 0x7e, 0x05, 0x67, 0x15, 0x1c, 0xfd, 0xec, 0xc0, 0xe8, 0x98, 0x89, 0x44, 0xdd, 0xb0, 0x5b,
+	0xa4, 0xf8, 0xbd, 0x40, 0xa5, 0xc9, 0x1e, 0xf8, 0x39, 0xa2, 0xbc, 0x28, 0x73, 0x0c, 0x9c, 0xd0,
+	0x99, 0x6c, 0xcd, 0xb6, 0xa6, 0xa6, 0x6c, 0xfa, 0xb9, 0x46, 0xe9, 0x1d, 0x4f, 0x08, 0xb8, 0x2c,
+	0x49, 0x64, 0xd0, 0x09, 0x9d, 0xc9, 0x26, 0xb5, 0xb1, 0xc1, 0x14, 0xbf, 0x16, 0x41, 0xb7, 0xc2,
+	0x4c, 0x6c, 0xb0, 0xcb, 0xcb, 0x93, 0xa3, 0xc0, 0x0d, 0x9d, 0xc9, 0x80, 0xda, 0x98, 0x8c, 0xc1,
+	0xb7, 0x53, 0xc4, 0x59, 0x1a, 0x78, 0x16, 0xbf, 0xcb, 0xc9, 0x33, 0x80, 0x38, 0xe5, 0x28, 0xf4,
+	0xa1, 0x51, 0xef, 0x59, 0xa5, 0x16, 0x42, 0x9e, 0xc0, 0x40, 0xf3, 0x25, 0x2a, 0xcd, 0x96, 0x79,
+	0xd0, 0x0f, 0x9d, 0x49, 0x97, 0xae, 0x01, 0xb2, 0x03, 0x9e, 0xc8, 0x44, 0x8c, 0x81, 0x6f, 0x0b,
+	0xab, 0x84, 0x84, 0xd0, 0x3d, 0x9c, 0x9f, 0x06, 0x83, 0xd0, 0x99, 0x0c, 0x9b, 0x2b, 0x51, 0xe3,
+	0xd1, 0xe1, 0xfc, 0x94, 0x1a, 0x2a, 0x7a, 0x03, 0x7e, 0x03, 0x18, 0x0d, 0x73, 0x1b, 0x15, 0x38,
+	0x61, 0xd7, 0x68, 0xd8, 0xc4, 0xf4, 0x5d, 0xa1, 0xe4, 0xdf, 0x38, 0x4a, 0x15, 0x74, 0xc2, 0xee,
+	0x64, 0x40, 0xd7, 0x40, 0xb4, 0x0b, 0xa3, 0x23, 0xae, 0xe2, 0x6c, 0x85, 0x92, 0xa2, 0xca, 0x33,
+	0xa1, 0xd6, 0x0e, 0x39, 0xd5, 0xcd, 0x4d, 0x1c, 0x7d, 0x80, 0xed, 0x96, 0xeb, 0xf5, 0xc1, 0x1d,
+	0xf0, 0x50, 0xca, 0xac, 0x3a, 0xe9, 0xd1, 0x2a, 0x21, 0x21, 0x0c, 0x13, 0x54, 0xb1, 0xe4, 0xb9,
+	0xe6, 0x99, 0xb0, 0x3e, 0x0f, 0x68, 0x1b, 0x8a, 0xf6, 0x61, 0xdb, 0x0e, 0x3d, 0x4f, 0x0b, 0xa5,
+	0x4d, 0xe3, 0x3c, 0x2d, 0x49, 0x00, 0xfd, 0x25, 0x2e, 0xaf, 0xb0, 0x9e, 0x7f, 0x40, 0x9b, 0x34,
+	0xfa, 0xdd, 0x81, 0x4d, 0x7b, 0xfe, 0x23, 0x6a, 0xc9, 0x63, 0x65, 0x3a, 0xc4, 0x99, 0x10, 0xf3,
+	0x42, 0x4a, 0x14, 0xda, 0x76, 0x77, 0x69, 0x1b, 0x22, 0xfb, 0xd0, 0x15, 0xa8, 0xed, 0x75, 0x87,
+	0xb3, 0xc7, 0x2d, 0xe3, 0x6a, 0x89, 0xe9, 0x19, 0xea, 0x85, 0xd0, 0xb2, 0xa4, 0xe6, 0x1c, 0x79,
+	0x08, 0xbd, 0x22, 0x37, 0x7f, 0x86, 0xdd, 0x00, 0x97, 0xd6, 0x19, 0x39, 0x00, 0xaf, 0x50, 0xec,
+	0x1a, 0x03, 0xd7, 0x0a, 0x3d, 0xfd, 0x87, 0xd0, 0xa5, 0xe1, 0x2b, 0xa9, 0xea, 0xec, 0xf8, 0x18,
+	0xfc, 0x46, 0x9d, 0x8c, 0xa0, 0x7b, 0x8b, 0x65, 0xed, 0xa4, 0x09, 0xc9, 0x2e, 0x78, 0x2b, 0x96,
+	0x16, 0x68, 0x7d, 0x19, 0xce, 0x46, 0x95, 0xe4, 0x19, 0xea, 0x5a, 0x90, 0x56, 0xf4, 0xeb, 0xce,
+	0x2b, 0x67, 0xfc, 0x1e, 0x60, 0x2d, 0xff, 0xdf, 0x5a, 0x76, 0x3c, 0x5b, 0xd7, 0xd2, 0x8a, 0x7e,
+	0x39, 0x00, 0x6b, 0xc6, 0x6c, 0xeb, 0x55, 0xa9, 0x51, 0x5d, 0x64, 0x09, 0x2b, 0x6b, 0x07, 0x5b,
+	0x48, 0x8b, 0xd7, 0x2c, 0x0d, 0x3a, 0xf7, 0x78, 0xcd, 0xec, 0xb6, 0x27, 0x8c, 0xa7, 0xe5, 0x97,
+	0x22, 0xd3, 0xac, 0x76, 0xad, 0x85, 0x98, 0x1d, 0x92, 0x4c, 0xa3, 0x7d, 0x3d, 0x2e, 0xb5, 0xb1,
+	0xa9, 0xc9, 0x25, 0x5f, 0xf1, 0x14, 0xaf, 0x31, 0xb1, 0xef, 0xc7, 0xa7, 0x2d, 0x24, 0x7a, 0x0b,
+	0xb0, 0xf6, 0xc1, 0xec, 0x83, 0xfe, 0xf1, 0xce, 0x74, 0xac, 0xc7, 0x6b, 0x52, 0xc3, 0xc8, 0x9a,
+	0xa9, 0x06, 0x6b, 0xd2, 0xbd, 0x17, 0xe0, 0x37, 0x2f, 0x9e, 0x00, 0xf4, 0xce, 0x17, 0xf4, 0xeb,
+	0x82, 0x8e, 0x36, 0x4c, 0x3c, 0x3f, 0x3d, 0x59, 0x9c, 0x5d, 0x8c, 0x1c, 0xb2, 0x09, 0xfe, 0xd1,
+	0xc9, 0xf9, 0xfc, 0x93, 0x61, 0x3a, 0xb3, 0x1b, 0xf0, 0xac, 0x2b, 0xe4, 0x25, 0xf4, 0xeb, 0x75,
+	0x24, 0xc3, 0xca, 0xc7, 0xc5, 0x32, 0xd7, 0xe5, 0xf8, 0x51, 0xcb, 0xd4, 0xf6, 0xbe, 0x46, 0x1b,
+	0xe4, 0x39, 0xf4, 0x9b, 0x61, 0xef, 0x95, 0x90, 0xbf, 0xd7, 0x24, 0xda, 0xb8, 0xea, 0xd9, 0x2f,
+	0xc5, 0xc1, 0x9f, 0x01, 0x00, 0x2d, 0x63, 0x8d, 0xa1, 0xe5, 0x04, 0x00, 0x00,
 }
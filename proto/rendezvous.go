@@ -6,6 +6,7 @@ import (
 
 const (
 	DefaultNPPProtocol = "tcp"
+	UDPNPPProtocol     = "udp"
 )
 
 func (m *PublishRequest) Validate() error {
@@ -13,7 +14,7 @@ func (m *PublishRequest) Validate() error {
 		m.Protocol = DefaultNPPProtocol
 	}
 
-	return nil
+	return validatePublicAddr(m.Protocol, m.PublicAddr)
 }
 
 func (m *ConnectRequest) Validate() error {
@@ -24,6 +25,14 @@ func (m *ConnectRequest) Validate() error {
 		return fmt.Errorf("destination ID must have exactly 20 bytes format")
 	}
 
+	return validatePublicAddr(m.Protocol, m.PublicAddr)
+}
+
+func validatePublicAddr(protocol string, addr *Addr) error {
+	if protocol == UDPNPPProtocol && !addr.IsValid() {
+		return fmt.Errorf("public address is required for %s protocol", protocol)
+	}
+
 	return nil
 }
 
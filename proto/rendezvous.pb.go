@@ -34,12 +34,22 @@ type ConnectRequest struct {
 	Protocol string `protobuf:"bytes,2,opt,name=protocol" json:"protocol,omitempty"`
 	// PrivateAddrs describes source private addresses.
 	PrivateAddrs []*Addr `protobuf:"bytes,3,rep,name=privateAddrs" json:"privateAddrs,omitempty"`
+	// PublicAddr describes source public address observed by the rendezvous
+	// UDP reflector.
+	//
+	// Required for UDP, because the address observed from the gRPC
+	// connection belongs to another NAT mapping.
+	PublicAddr *Addr `protobuf:"bytes,4,opt,name=publicAddr" json:"publicAddr,omitempty"`
+	// Families describes IP address families the source is able to connect
+	// with. Only remote addresses of these families are returned. Empty
+	// means no filtering.
+	Families []AddrFamily `protobuf:"varint,5,rep,packed,name=families,enum=sonm.AddrFamily" json:"families,omitempty"`
 }
 
 func (m *ConnectRequest) Reset()                    { *m = ConnectRequest{} }
 func (m *ConnectRequest) String() string            { return proto.CompactTextString(m) }
 func (*ConnectRequest) ProtoMessage()               {}
-func (*ConnectRequest) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{0} }
+func (*ConnectRequest) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{0} }
 
 func (m *ConnectRequest) GetID() []byte {
 	if m != nil {
@@ -62,17 +72,41 @@ func (m *ConnectRequest) GetPrivateAddrs() []*Addr {
 	return nil
 }
 
+func (m *ConnectRequest) GetPublicAddr() *Addr {
+	if m != nil {
+		return m.PublicAddr
+	}
+	return nil
+}
+
+func (m *ConnectRequest) GetFamilies() []AddrFamily {
+	if m != nil {
+		return m.Families
+	}
+	return nil
+}
+
 type PublishRequest struct {
 	// Protocol describes network protocol the peer wants to publish.
 	Protocol string `protobuf:"bytes,1,opt,name=protocol" json:"protocol,omitempty"`
 	// PrivateAddrs describes source private addresses.
 	PrivateAddrs []*Addr `protobuf:"bytes,2,rep,name=privateAddrs" json:"privateAddrs,omitempty"`
+	// PublicAddr describes source public address observed by the rendezvous
+	// UDP reflector.
+	//
+	// Required for UDP, because the address observed from the gRPC
+	// connection belongs to another NAT mapping.
+	PublicAddr *Addr `protobuf:"bytes,3,opt,name=publicAddr" json:"publicAddr,omitempty"`
+	// Families describes IP address families the source is able to accept
+	// connections with. Only remote addresses of these families are
+	// returned. Empty means no filtering.
+	Families []AddrFamily `protobuf:"varint,4,rep,packed,name=families,enum=sonm.AddrFamily" json:"families,omitempty"`
 }
 
 func (m *PublishRequest) Reset()                    { *m = PublishRequest{} }
 func (m *PublishRequest) String() string            { return proto.CompactTextString(m) }
 func (*PublishRequest) ProtoMessage()               {}
-func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{1} }
+func (*PublishRequest) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{1} }
 
 func (m *PublishRequest) GetProtocol() string {
 	if m != nil {
@@ -88,6 +122,20 @@ func (m *PublishRequest) GetPrivateAddrs() []*Addr {
 	return nil
 }
 
+func (m *PublishRequest) GetPublicAddr() *Addr {
+	if m != nil {
+		return m.PublicAddr
+	}
+	return nil
+}
+
+func (m *PublishRequest) GetFamilies() []AddrFamily {
+	if m != nil {
+		return m.Families
+	}
+	return nil
+}
+
 // RendezvousReply describes a rendezvous point reply.
 type RendezvousReply struct {
 	// PublicAddr is a public network address of a target.
@@ -102,7 +150,7 @@ type RendezvousReply struct {
 func (m *RendezvousReply) Reset()                    { *m = RendezvousReply{} }
 func (m *RendezvousReply) String() string            { return proto.CompactTextString(m) }
 func (*RendezvousReply) ProtoMessage()               {}
-func (*RendezvousReply) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{2} }
+func (*RendezvousReply) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{2} }
 
 func (m *RendezvousReply) GetPublicAddr() *Addr {
 	if m != nil {
@@ -126,7 +174,7 @@ type RendezvousState struct {
 func (m *RendezvousState) Reset()                    { *m = RendezvousState{} }
 func (m *RendezvousState) String() string            { return proto.CompactTextString(m) }
 func (*RendezvousState) ProtoMessage()               {}
-func (*RendezvousState) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{3} }
+func (*RendezvousState) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{3} }
 
 func (m *RendezvousState) GetState() map[string]*RendezvousMeeting {
 	if m != nil {
@@ -144,7 +192,7 @@ type RendezvousMeeting struct {
 func (m *RendezvousMeeting) Reset()                    { *m = RendezvousMeeting{} }
 func (m *RendezvousMeeting) String() string            { return proto.CompactTextString(m) }
 func (*RendezvousMeeting) ProtoMessage()               {}
-func (*RendezvousMeeting) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{4} }
+func (*RendezvousMeeting) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{4} }
 
 func (m *RendezvousMeeting) GetClients() map[string]*RendezvousReply {
 	if m != nil {
@@ -167,7 +215,7 @@ type ResolveMetaReply struct {
 func (m *ResolveMetaReply) Reset()                    { *m = ResolveMetaReply{} }
 func (m *ResolveMetaReply) String() string            { return proto.CompactTextString(m) }
 func (*ResolveMetaReply) ProtoMessage()               {}
-func (*ResolveMetaReply) Descriptor() ([]byte, []int) { return fileDescriptor11, []int{5} }
+func (*ResolveMetaReply) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{5} }
 
 func (m *ResolveMetaReply) GetIDs() []string {
 	if m != nil {
@@ -480,37 +528,39 @@ func init() {
 
 // End grpccmd
 
-func init() { proto.RegisterFile("rendezvous.proto", fileDescriptor11) }
-
-var fileDescriptor11 = []byte{
-	// 456 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcd, 0x6a, 0xdb, 0x40,
-	0x10, 0xf6, 0xca, 0x49, 0x13, 0x8f, 0x8d, 0xe3, 0x2e, 0xfd, 0x31, 0x3a, 0x19, 0x91, 0x83, 0xe9,
-	0x8f, 0x28, 0x2e, 0x94, 0xd0, 0x43, 0x21, 0xc4, 0x39, 0xe8, 0x10, 0x68, 0x36, 0xd7, 0x5e, 0x14,
-	0x79, 0xda, 0x8a, 0xae, 0x77, 0x55, 0xed, 0x4a, 0xa0, 0x3e, 0x4b, 0x5f, 0xa4, 0x6f, 0xd3, 0x47,
-	0x29, 0xbb, 0x2b, 0x39, 0x92, 0x13, 0x63, 0x0a, 0xb9, 0xd8, 0xa3, 0xd9, 0xef, 0x67, 0x66, 0x76,
-	0x07, 0x26, 0x39, 0x8a, 0x15, 0xfe, 0x2a, 0x65, 0xa1, 0xc2, 0x2c, 0x97, 0x5a, 0xd2, 0x03, 0x25,
-	0xc5, 0xda, 0x3f, 0x49, 0x85, 0xf9, 0x17, 0x69, 0xec, 0xd2, 0xfe, 0x40, 0xa0, 0x76, 0x61, 0xc0,
-	0x61, 0x7c, 0x21, 0x85, 0xc0, 0x44, 0x33, 0xfc, 0x59, 0xa0, 0xd2, 0x74, 0x0c, 0x5e, 0xb4, 0x9c,
-	0x92, 0x19, 0x99, 0x8f, 0x98, 0x17, 0x2d, 0xa9, 0x0f, 0xc7, 0x16, 0x9a, 0x48, 0x3e, 0xf5, 0x66,
-	0x64, 0x3e, 0x60, 0x9b, 0x6f, 0x1a, 0xc2, 0x28, 0xcb, 0xd3, 0x32, 0xd6, 0x78, 0xbe, 0x5a, 0xe5,
-	0x6a, 0xda, 0x9f, 0xf5, 0xe7, 0xc3, 0x05, 0x84, 0xc6, 0x2e, 0x34, 0x29, 0xd6, 0x39, 0x0f, 0xbe,
-	0xc0, 0xf8, 0x73, 0x71, 0xcb, 0x53, 0xf5, 0xbd, 0x71, 0x6b, 0xab, 0x93, 0x3d, 0xea, 0xde, 0x1e,
-	0xf5, 0x35, 0x9c, 0xb0, 0xcd, 0x04, 0x18, 0x66, 0xbc, 0xa2, 0xaf, 0x00, 0x32, 0x63, 0x98, 0x18,
-	0x84, 0x35, 0xe8, 0x0a, 0xb4, 0x4e, 0xff, 0xdb, 0xee, 0x37, 0x69, 0xfb, 0xdd, 0xe8, 0x58, 0x23,
-	0xfd, 0x00, 0x87, 0xca, 0x04, 0x53, 0x62, 0xc9, 0x33, 0x47, 0xde, 0x42, 0x85, 0xf6, 0xf7, 0x52,
-	0xe8, 0xbc, 0x62, 0x0e, 0xee, 0x5f, 0x03, 0xdc, 0x25, 0xe9, 0x04, 0xfa, 0x3f, 0xb0, 0xaa, 0xe7,
-	0x61, 0x42, 0xfa, 0x16, 0x0e, 0xcb, 0x98, 0x17, 0x68, 0x6f, 0x60, 0xb8, 0x78, 0xb9, 0xad, 0x7b,
-	0x85, 0xa8, 0x53, 0xf1, 0x8d, 0x39, 0xd4, 0x47, 0xef, 0x8c, 0x04, 0x7f, 0x3c, 0x78, 0x7a, 0x0f,
-	0x40, 0x3f, 0xc1, 0x51, 0xc2, 0x53, 0x14, 0x5a, 0xd5, 0x25, 0x9e, 0xee, 0x90, 0x0a, 0x2f, 0x1c,
-	0xcc, 0x95, 0xd9, 0x90, 0x0c, 0x5f, 0x61, 0x5e, 0xe2, 0x66, 0x3e, 0x3b, 0xf9, 0x37, 0x0e, 0x56,
-	0xf3, 0x6b, 0x92, 0x7f, 0x0d, 0xa3, 0xb6, 0xf0, 0x03, 0xad, 0xbe, 0xee, 0xb6, 0xfa, 0x7c, 0x5b,
-	0xdf, 0x5e, 0x6c, 0xab, 0x51, 0x23, 0xd9, 0xf6, 0x7a, 0x04, 0xc9, 0xe0, 0x14, 0x26, 0x0c, 0x95,
-	0xe4, 0x25, 0x5e, 0xa1, 0x8e, 0xdd, 0x53, 0x9a, 0x40, 0x3f, 0x5a, 0xba, 0xa9, 0x0d, 0x98, 0x09,
-	0x17, 0x7f, 0x09, 0xc0, 0x9d, 0x08, 0x3d, 0x83, 0xa3, 0x9a, 0x44, 0x9f, 0x39, 0x87, 0xee, 0x66,
-	0xf9, 0x0f, 0xfb, 0x06, 0x3d, 0xfa, 0xce, 0xe8, 0x58, 0xe6, 0x39, 0xe7, 0xf4, 0xd8, 0xc1, 0xa2,
-	0xa5, 0xff, 0xa2, 0x21, 0x74, 0x4b, 0x09, 0x7a, 0xc6, 0xab, 0x5e, 0xa4, 0xc6, 0xab, 0xbb, 0x57,
-	0xbb, 0xbd, 0xde, 0xc0, 0x41, 0x24, 0xbe, 0x4a, 0x3a, 0x74, 0x80, 0xcb, 0x75, 0xa6, 0xab, 0xfb,
-	0x68, 0xfb, 0x18, 0x83, 0xde, 0xed, 0x13, 0xbb, 0x8c, 0xef, 0xff, 0x05, 0x00, 0x00, 0xff, 0xff,
-	0xeb, 0x0f, 0xce, 0x01, 0x5b, 0x04, 0x00, 0x00,
+func init() { proto.RegisterFile("rendezvous.proto", fileDescriptor12) }
+
+var fileDescriptor12 = []byte{
+	// 494 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0xdd, 0x6e, 0xd3, 0x30,
+	0x14, 0xae, 0x93, 0x96, 0xb5, 0xa7, 0x55, 0x57, 0x2c, 0x7e, 0xa2, 0x5c, 0x45, 0xd1, 0x2e, 0x2a,
+	0x18, 0x11, 0x2a, 0x12, 0x9a, 0xb8, 0x40, 0x9a, 0xd6, 0x21, 0xe5, 0x62, 0x12, 0xf3, 0x9e, 0x20,
+	0x6b, 0xcf, 0x20, 0xc2, 0x75, 0x42, 0xec, 0x46, 0x0a, 0xcf, 0xc2, 0x73, 0x20, 0xf1, 0x02, 0x3c,
+	0x07, 0x8f, 0x82, 0x1c, 0xa7, 0x6d, 0x92, 0xad, 0x8c, 0x49, 0xdc, 0xb4, 0x27, 0x3e, 0xdf, 0xdf,
+	0x71, 0xec, 0xc0, 0x24, 0x43, 0xb1, 0xc4, 0x6f, 0x79, 0xb2, 0x96, 0x41, 0x9a, 0x25, 0x2a, 0xa1,
+	0x5d, 0x99, 0x88, 0x95, 0x7b, 0x18, 0x0b, 0xfd, 0x2f, 0xe2, 0xc8, 0x2c, 0xbb, 0x03, 0x81, 0xca,
+	0x94, 0xfe, 0x2f, 0x02, 0xe3, 0xb3, 0x44, 0x08, 0x5c, 0x28, 0x86, 0x5f, 0xd7, 0x28, 0x15, 0x1d,
+	0x83, 0x15, 0xce, 0x1d, 0xe2, 0x91, 0xe9, 0x88, 0x59, 0xe1, 0x9c, 0xba, 0xd0, 0x2f, 0xb1, 0x8b,
+	0x84, 0x3b, 0x96, 0x47, 0xa6, 0x03, 0xb6, 0x7d, 0xa6, 0x01, 0x8c, 0xd2, 0x2c, 0xce, 0x23, 0x85,
+	0xa7, 0xcb, 0x65, 0x26, 0x1d, 0xdb, 0xb3, 0xa7, 0xc3, 0x19, 0x04, 0xda, 0x2f, 0xd0, 0x4b, 0xac,
+	0xd1, 0xa7, 0x2f, 0x00, 0xd2, 0xf5, 0x35, 0x8f, 0x17, 0xfa, 0xd1, 0xe9, 0x7a, 0xa4, 0x85, 0xae,
+	0x75, 0xe9, 0x31, 0xf4, 0x6f, 0xa2, 0x55, 0xcc, 0x63, 0x94, 0x4e, 0xcf, 0xb3, 0xa7, 0xe3, 0xd9,
+	0x64, 0x87, 0xfc, 0xa0, 0x3b, 0x05, 0xdb, 0x22, 0xfc, 0x1f, 0x04, 0xc6, 0x1f, 0x35, 0x59, 0x7e,
+	0xde, 0x0c, 0x52, 0x0f, 0x4e, 0xee, 0x09, 0x6e, 0x3d, 0x28, 0xb8, 0xfd, 0xcf, 0xc1, 0xbb, 0xf7,
+	0x06, 0x5f, 0xc1, 0x21, 0xdb, 0xbe, 0x37, 0x86, 0x29, 0x2f, 0x5a, 0x66, 0xe4, 0xaf, 0x66, 0x0f,
+	0x1c, 0xc4, 0xff, 0x4e, 0xea, 0x7e, 0x57, 0x2a, 0x52, 0x48, 0xdf, 0x42, 0x4f, 0xea, 0xc2, 0x21,
+	0x25, 0xd9, 0x33, 0xe4, 0x16, 0x2a, 0x28, 0x7f, 0xcf, 0x85, 0xca, 0x0a, 0x66, 0xe0, 0xee, 0x25,
+	0xc0, 0x6e, 0x91, 0x4e, 0xc0, 0xfe, 0x82, 0x45, 0xb5, 0xd3, 0xba, 0xa4, 0xaf, 0xa0, 0x97, 0x47,
+	0x7c, 0x8d, 0xe5, 0xb1, 0x19, 0xce, 0x9e, 0xb7, 0x75, 0x2f, 0x10, 0x55, 0x2c, 0x3e, 0x31, 0x83,
+	0x7a, 0x67, 0x9d, 0x10, 0xff, 0xa7, 0x05, 0x8f, 0x6f, 0x01, 0xe8, 0x7b, 0x38, 0x58, 0xf0, 0x18,
+	0x85, 0x92, 0x55, 0xc4, 0xa3, 0x3d, 0x52, 0xc1, 0x99, 0x81, 0x99, 0x98, 0x1b, 0x92, 0xe6, 0x4b,
+	0xcc, 0x72, 0xdc, 0xee, 0xcf, 0x5e, 0xfe, 0x95, 0x81, 0x55, 0xfc, 0x8a, 0xe4, 0x5e, 0xc2, 0xa8,
+	0x2e, 0x7c, 0xc7, 0xa8, 0x2f, 0x9b, 0xa3, 0x3e, 0x6d, 0xeb, 0x97, 0x2f, 0xb6, 0x36, 0xa8, 0x96,
+	0xac, 0x7b, 0xfd, 0x07, 0x49, 0xff, 0x08, 0x26, 0x0c, 0x65, 0xc2, 0x73, 0xbc, 0x40, 0x15, 0x95,
+	0x6d, 0x2d, 0x1b, 0xce, 0xcd, 0xae, 0x0d, 0x98, 0x2e, 0x67, 0xbf, 0x09, 0xc0, 0x4e, 0x84, 0x9e,
+	0xc0, 0x41, 0x45, 0xa2, 0x4f, 0x8c, 0x43, 0xf3, 0x73, 0xe0, 0xde, 0xed, 0xeb, 0x77, 0xe8, 0x6b,
+	0x80, 0x8a, 0x79, 0xca, 0x39, 0xed, 0x1b, 0x58, 0x38, 0x77, 0x9f, 0x6d, 0x08, 0xcd, 0x28, 0x7e,
+	0x47, 0x7b, 0x55, 0x57, 0x74, 0xe3, 0xd5, 0xbc, 0xb1, 0xfb, 0xbd, 0x8e, 0xa1, 0x1b, 0x8a, 0x9b,
+	0x84, 0x0e, 0x0d, 0xe0, 0x7c, 0x95, 0xaa, 0xe2, 0x36, 0xba, 0x3c, 0x8c, 0x7e, 0xe7, 0xfa, 0x51,
+	0x79, 0xcd, 0xdf, 0xfc, 0x19, 0x00, 0x36, 0x20, 0xea, 0x8a, 0x11, 0x05, 0x00, 0x00,
 }
@@ -0,0 +1,40 @@
+package sonm
+
+import (
+	"strings"
+
+	"google.golang.org/grpc"
+)
+
+// streamingServices contains descriptions of services that have streaming
+// methods. Services with new streaming methods must be added here to make
+// them available for gateways.
+var streamingServices = []*grpc.ServiceDesc{
+	&_TaskManagement_serviceDesc,
+	&_Worker_serviceDesc,
+}
+
+// StreamDesc returns the description of the given streaming method, which
+// allows to serve it without gRPC server, for example, over HTTP.
+//
+// The method must be fully-qualified, i.e. "/sonm.TaskManagement/Logs".
+func StreamDesc(fullMethod string) (grpc.StreamDesc, bool) {
+	parts := strings.Split(fullMethod, "/")
+	if len(parts) != 3 || len(parts[0]) != 0 {
+		return grpc.StreamDesc{}, false
+	}
+
+	for _, service := range streamingServices {
+		if service.ServiceName != parts[1] {
+			continue
+		}
+
+		for _, stream := range service.Streams {
+			if stream.StreamName == parts[2] {
+				return stream, true
+			}
+		}
+	}
+
+	return grpc.StreamDesc{}, false
+}
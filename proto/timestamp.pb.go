@@ -26,7 +26,7 @@ type Timestamp struct {
 func (m *Timestamp) Reset()                    { *m = Timestamp{} }
 func (m *Timestamp) String() string            { return proto.CompactTextString(m) }
 func (*Timestamp) ProtoMessage()               {}
-func (*Timestamp) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{0} }
+func (*Timestamp) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{0} }
 
 func (m *Timestamp) GetSeconds() int64 {
 	if m != nil {
@@ -46,15 +46,14 @@ func init() {
 	proto.RegisterType((*Timestamp)(nil), "sonm.Timestamp")
 }
 
-func init() { proto.RegisterFile("timestamp.proto", fileDescriptor12) }
+func init() { proto.RegisterFile("timestamp.proto", fileDescriptor13) }
 
-var fileDescriptor12 = []byte{
-	// 97 bytes of a gzipped FileDescriptorProto
+var fileDescriptor13 = []byte{
+	// 93 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2f, 0xc9, 0xcc, 0x4d,
 	0x2d, 0x2e, 0x49, 0xcc, 0x2d, 0xd0, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x29, 0xce, 0xcf,
 	0xcb, 0x55, 0xb2, 0xe6, 0xe2, 0x0c, 0x81, 0x49, 0x08, 0x49, 0x70, 0xb1, 0x17, 0xa7, 0x26, 0xe7,
 	0xe7, 0xa5, 0x14, 0x4b, 0x30, 0x2a, 0x30, 0x6a, 0x30, 0x07, 0xc1, 0xb8, 0x42, 0x22, 0x5c, 0xac,
 	0x79, 0x89, 0x79, 0xf9, 0xc5, 0x12, 0x4c, 0x0a, 0x8c, 0x1a, 0xac, 0x41, 0x10, 0x4e, 0x12, 0x1b,
-	0xd8, 0x24, 0x63, 0x40, 0x00, 0x00, 0x00, 0xff, 0xff, 0xb4, 0x57, 0xae, 0x82, 0x5c, 0x00, 0x00,
-	0x00,
+	0xd8, 0x24, 0x63, 0xc0, 0x00, 0xb4, 0x57, 0xae, 0x82, 0x5c, 0x00, 0x00, 0x00,
 }
@@ -23,7 +23,7 @@ type Volume struct {
 func (m *Volume) Reset()                    { *m = Volume{} }
 func (m *Volume) String() string            { return proto.CompactTextString(m) }
 func (*Volume) ProtoMessage()               {}
-func (*Volume) Descriptor() ([]byte, []int) { return fileDescriptor13, []int{0} }
+func (*Volume) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{0} }
 
 func (m *Volume) GetType() string {
 	if m != nil {
@@ -43,10 +43,10 @@ func init() {
 	proto.RegisterType((*Volume)(nil), "sonm.Volume")
 }
 
-func init() { proto.RegisterFile("volume.proto", fileDescriptor13) }
+func init() { proto.RegisterFile("volume.proto", fileDescriptor14) }
 
-var fileDescriptor13 = []byte{
-	// 146 bytes of a gzipped FileDescriptorProto
+var fileDescriptor14 = []byte{
+	// 143 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x29, 0xcb, 0xcf, 0x29,
 	0xcd, 0x4d, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x29, 0xce, 0xcf, 0xcb, 0x55, 0xea,
 	0x65, 0xe4, 0x62, 0x0b, 0x03, 0x0b, 0x0b, 0x09, 0x71, 0xb1, 0x94, 0x54, 0x16, 0xa4, 0x4a, 0x30,
@@ -55,6 +55,5 @@ var fileDescriptor13 = []byte{
 	0x7a, 0xfe, 0x10, 0x39, 0xd7, 0xbc, 0x92, 0xa2, 0xca, 0x20, 0x98, 0x4a, 0x29, 0x2b, 0x2e, 0x1e,
 	0x64, 0x09, 0x21, 0x01, 0x2e, 0xe6, 0xec, 0xd4, 0x4a, 0xa8, 0xb9, 0x20, 0xa6, 0x90, 0x08, 0x17,
 	0x6b, 0x59, 0x62, 0x4e, 0x69, 0xaa, 0x04, 0x13, 0x58, 0x0c, 0xc2, 0xb1, 0x62, 0xb2, 0x60, 0x4c,
-	0x62, 0x03, 0x3b, 0xce, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0x68, 0x46, 0x97, 0x02, 0xac, 0x00,
-	0x00, 0x00,
+	0x62, 0x03, 0x3b, 0xce, 0x18, 0x30, 0x00, 0x68, 0x46, 0x97, 0x02, 0xac, 0x00, 0x00, 0x00,
 }
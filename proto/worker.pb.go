@@ -56,7 +56,192 @@ var TaskStatusReply_Status_value = map[string]int32{
 func (x TaskStatusReply_Status) String() string {
 	return proto.EnumName(TaskStatusReply_Status_name, int32(x))
 }
-func (TaskStatusReply_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor14, []int{10, 0} }
+func (TaskStatusReply_Status) EnumDescriptor() ([]byte, []int) { return fileDescriptor15, []int{16, 0} }
+
+type PingRequest struct {
+	// Payload is an arbitrary data used to measure the upload throughput.
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// ReplySize is the number of bytes to reply with, used to measure the
+	// download throughput. Must not exceed 1 MiB.
+	ReplySize uint64 `protobuf:"varint,2,opt,name=replySize" json:"replySize,omitempty"`
+}
+
+func (m *PingRequest) Reset()                    { *m = PingRequest{} }
+func (m *PingRequest) String() string            { return proto.CompactTextString(m) }
+func (*PingRequest) ProtoMessage()               {}
+func (*PingRequest) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{0} }
+
+func (m *PingRequest) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (m *PingRequest) GetReplySize() uint64 {
+	if m != nil {
+		return m.ReplySize
+	}
+	return 0
+}
+
+type PingReply struct {
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (m *PingReply) Reset()                    { *m = PingReply{} }
+func (m *PingReply) String() string            { return proto.CompactTextString(m) }
+func (*PingReply) ProtoMessage()               {}
+func (*PingReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{1} }
+
+func (m *PingReply) GetPayload() []byte {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+// DelegationToken allows the delegate to call the specified Worker methods
+// within the deal on behalf of the deal's consumer, which is the issuer.
+type DelegationToken struct {
+	// ID is a random token identifier.
+	Id       string      `protobuf:"bytes,1,opt,name=id" json:"id,omitempty"`
+	DealID   *BigInt     `protobuf:"bytes,2,opt,name=dealID" json:"dealID,omitempty"`
+	Issuer   *EthAddress `protobuf:"bytes,3,opt,name=issuer" json:"issuer,omitempty"`
+	Delegate *EthAddress `protobuf:"bytes,4,opt,name=delegate" json:"delegate,omitempty"`
+	// Methods contains names of allowed Worker methods, for example
+	// "TaskLogs".
+	Methods   []string   `protobuf:"bytes,5,rep,name=methods" json:"methods,omitempty"`
+	ExpiresAt *Timestamp `protobuf:"bytes,6,opt,name=expiresAt" json:"expiresAt,omitempty"`
+	// Sign is the issuer's signature of all fields above.
+	Sign []byte `protobuf:"bytes,7,opt,name=sign,proto3" json:"sign,omitempty"`
+}
+
+func (m *DelegationToken) Reset()                    { *m = DelegationToken{} }
+func (m *DelegationToken) String() string            { return proto.CompactTextString(m) }
+func (*DelegationToken) ProtoMessage()               {}
+func (*DelegationToken) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{2} }
+
+func (m *DelegationToken) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DelegationToken) GetDealID() *BigInt {
+	if m != nil {
+		return m.DealID
+	}
+	return nil
+}
+
+func (m *DelegationToken) GetIssuer() *EthAddress {
+	if m != nil {
+		return m.Issuer
+	}
+	return nil
+}
+
+func (m *DelegationToken) GetDelegate() *EthAddress {
+	if m != nil {
+		return m.Delegate
+	}
+	return nil
+}
+
+func (m *DelegationToken) GetMethods() []string {
+	if m != nil {
+		return m.Methods
+	}
+	return nil
+}
+
+func (m *DelegationToken) GetExpiresAt() *Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *DelegationToken) GetSign() []byte {
+	if m != nil {
+		return m.Sign
+	}
+	return nil
+}
+
+type DelegationTokenStatus struct {
+	Token      *DelegationToken `protobuf:"bytes,1,opt,name=token" json:"token,omitempty"`
+	LastUsedAt *Timestamp       `protobuf:"bytes,2,opt,name=lastUsedAt" json:"lastUsedAt,omitempty"`
+	Revoked    bool             `protobuf:"varint,3,opt,name=revoked" json:"revoked,omitempty"`
+}
+
+func (m *DelegationTokenStatus) Reset()                    { *m = DelegationTokenStatus{} }
+func (m *DelegationTokenStatus) String() string            { return proto.CompactTextString(m) }
+func (*DelegationTokenStatus) ProtoMessage()               {}
+func (*DelegationTokenStatus) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{3} }
+
+func (m *DelegationTokenStatus) GetToken() *DelegationToken {
+	if m != nil {
+		return m.Token
+	}
+	return nil
+}
+
+func (m *DelegationTokenStatus) GetLastUsedAt() *Timestamp {
+	if m != nil {
+		return m.LastUsedAt
+	}
+	return nil
+}
+
+func (m *DelegationTokenStatus) GetRevoked() bool {
+	if m != nil {
+		return m.Revoked
+	}
+	return false
+}
+
+type DelegationTokensReply struct {
+	Tokens []*DelegationTokenStatus `protobuf:"bytes,1,rep,name=tokens" json:"tokens,omitempty"`
+}
+
+func (m *DelegationTokensReply) Reset()                    { *m = DelegationTokensReply{} }
+func (m *DelegationTokensReply) String() string            { return proto.CompactTextString(m) }
+func (*DelegationTokensReply) ProtoMessage()               {}
+func (*DelegationTokensReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{4} }
+
+func (m *DelegationTokensReply) GetTokens() []*DelegationTokenStatus {
+	if m != nil {
+		return m.Tokens
+	}
+	return nil
+}
+
+type RevokeDelegationTokenRequest struct {
+	DealID *BigInt `protobuf:"bytes,1,opt,name=dealID" json:"dealID,omitempty"`
+	Id     string  `protobuf:"bytes,2,opt,name=id" json:"id,omitempty"`
+}
+
+func (m *RevokeDelegationTokenRequest) Reset()                    { *m = RevokeDelegationTokenRequest{} }
+func (m *RevokeDelegationTokenRequest) String() string            { return proto.CompactTextString(m) }
+func (*RevokeDelegationTokenRequest) ProtoMessage()               {}
+func (*RevokeDelegationTokenRequest) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{5} }
+
+func (m *RevokeDelegationTokenRequest) GetDealID() *BigInt {
+	if m != nil {
+		return m.DealID
+	}
+	return nil
+}
+
+func (m *RevokeDelegationTokenRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
 
 type TaskSpec struct {
 	// Container describes container settings.
@@ -69,7 +254,7 @@ type TaskSpec struct {
 func (m *TaskSpec) Reset()                    { *m = TaskSpec{} }
 func (m *TaskSpec) String() string            { return proto.CompactTextString(m) }
 func (*TaskSpec) ProtoMessage()               {}
-func (*TaskSpec) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{0} }
+func (*TaskSpec) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{6} }
 
 func (m *TaskSpec) GetContainer() *Container {
 	if m != nil {
@@ -109,7 +294,7 @@ type StartTaskRequest struct {
 func (m *StartTaskRequest) Reset()                    { *m = StartTaskRequest{} }
 func (m *StartTaskRequest) String() string            { return proto.CompactTextString(m) }
 func (*StartTaskRequest) ProtoMessage()               {}
-func (*StartTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{1} }
+func (*StartTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{7} }
 
 func (m *StartTaskRequest) GetDealID() *BigInt {
 	if m != nil {
@@ -133,7 +318,7 @@ type WorkerJoinNetworkRequest struct {
 func (m *WorkerJoinNetworkRequest) Reset()                    { *m = WorkerJoinNetworkRequest{} }
 func (m *WorkerJoinNetworkRequest) String() string            { return proto.CompactTextString(m) }
 func (*WorkerJoinNetworkRequest) ProtoMessage()               {}
-func (*WorkerJoinNetworkRequest) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{2} }
+func (*WorkerJoinNetworkRequest) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{8} }
 
 func (m *WorkerJoinNetworkRequest) GetTaskID() string {
 	if m != nil {
@@ -158,7 +343,7 @@ type StartTaskReply struct {
 func (m *StartTaskReply) Reset()                    { *m = StartTaskReply{} }
 func (m *StartTaskReply) String() string            { return proto.CompactTextString(m) }
 func (*StartTaskReply) ProtoMessage()               {}
-func (*StartTaskReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{3} }
+func (*StartTaskReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{9} }
 
 func (m *StartTaskReply) GetId() string {
 	if m != nil {
@@ -194,7 +379,7 @@ type StatusReply struct {
 func (m *StatusReply) Reset()                    { *m = StatusReply{} }
 func (m *StatusReply) String() string            { return proto.CompactTextString(m) }
 func (*StatusReply) ProtoMessage()               {}
-func (*StatusReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{4} }
+func (*StatusReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{10} }
 
 func (m *StatusReply) GetUptime() uint64 {
 	if m != nil {
@@ -252,7 +437,7 @@ type AskPlansReply struct {
 func (m *AskPlansReply) Reset()                    { *m = AskPlansReply{} }
 func (m *AskPlansReply) String() string            { return proto.CompactTextString(m) }
 func (*AskPlansReply) ProtoMessage()               {}
-func (*AskPlansReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{5} }
+func (*AskPlansReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{11} }
 
 func (m *AskPlansReply) GetAskPlans() map[string]*AskPlan {
 	if m != nil {
@@ -268,7 +453,7 @@ type TaskListReply struct {
 func (m *TaskListReply) Reset()                    { *m = TaskListReply{} }
 func (m *TaskListReply) String() string            { return proto.CompactTextString(m) }
 func (*TaskListReply) ProtoMessage()               {}
-func (*TaskListReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{6} }
+func (*TaskListReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{12} }
 
 func (m *TaskListReply) GetInfo() map[string]*TaskStatusReply {
 	if m != nil {
@@ -283,12 +468,14 @@ type DevicesReply struct {
 	RAM     *RAM     `protobuf:"bytes,3,opt,name=RAM" json:"RAM,omitempty"`
 	Network *Network `protobuf:"bytes,4,opt,name=network" json:"network,omitempty"`
 	Storage *Storage `protobuf:"bytes,5,opt,name=storage" json:"storage,omitempty"`
+	// Isolation is the strongest isolation level tasks can be run with.
+	Isolation IsolationLevel `protobuf:"varint,6,opt,name=isolation,enum=sonm.IsolationLevel" json:"isolation,omitempty"`
 }
 
 func (m *DevicesReply) Reset()                    { *m = DevicesReply{} }
 func (m *DevicesReply) String() string            { return proto.CompactTextString(m) }
 func (*DevicesReply) ProtoMessage()               {}
-func (*DevicesReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{7} }
+func (*DevicesReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{13} }
 
 func (m *DevicesReply) GetCPU() *CPU {
 	if m != nil {
@@ -325,6 +512,13 @@ func (m *DevicesReply) GetStorage() *Storage {
 	return nil
 }
 
+func (m *DevicesReply) GetIsolation() IsolationLevel {
+	if m != nil {
+		return m.Isolation
+	}
+	return IsolationLevel_CONTAINER
+}
+
 type PullTaskRequest struct {
 	DealId string `protobuf:"bytes,1,opt,name=dealId" json:"dealId,omitempty"`
 	TaskId string `protobuf:"bytes,2,opt,name=taskId" json:"taskId,omitempty"`
@@ -333,7 +527,7 @@ type PullTaskRequest struct {
 func (m *PullTaskRequest) Reset()                    { *m = PullTaskRequest{} }
 func (m *PullTaskRequest) String() string            { return proto.CompactTextString(m) }
 func (*PullTaskRequest) ProtoMessage()               {}
-func (*PullTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{8} }
+func (*PullTaskRequest) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{14} }
 
 func (m *PullTaskRequest) GetDealId() string {
 	if m != nil {
@@ -363,7 +557,7 @@ type DealInfoReply struct {
 func (m *DealInfoReply) Reset()                    { *m = DealInfoReply{} }
 func (m *DealInfoReply) String() string            { return proto.CompactTextString(m) }
 func (*DealInfoReply) ProtoMessage()               {}
-func (*DealInfoReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{9} }
+func (*DealInfoReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{15} }
 
 func (m *DealInfoReply) GetDeal() *Deal {
 	if m != nil {
@@ -407,7 +601,7 @@ type TaskStatusReply struct {
 func (m *TaskStatusReply) Reset()                    { *m = TaskStatusReply{} }
 func (m *TaskStatusReply) String() string            { return proto.CompactTextString(m) }
 func (*TaskStatusReply) ProtoMessage()               {}
-func (*TaskStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{10} }
+func (*TaskStatusReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{16} }
 
 func (m *TaskStatusReply) GetStatus() TaskStatusReply_Status {
 	if m != nil {
@@ -466,7 +660,7 @@ type ResourcePool struct {
 func (m *ResourcePool) Reset()                    { *m = ResourcePool{} }
 func (m *ResourcePool) String() string            { return proto.CompactTextString(m) }
 func (*ResourcePool) ProtoMessage()               {}
-func (*ResourcePool) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{11} }
+func (*ResourcePool) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{17} }
 
 func (m *ResourcePool) GetAll() *AskPlanResources {
 	if m != nil {
@@ -491,7 +685,7 @@ type SchedulerData struct {
 func (m *SchedulerData) Reset()                    { *m = SchedulerData{} }
 func (m *SchedulerData) String() string            { return proto.CompactTextString(m) }
 func (*SchedulerData) ProtoMessage()               {}
-func (*SchedulerData) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{12} }
+func (*SchedulerData) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{18} }
 
 func (m *SchedulerData) GetTaskToAskPlan() map[string]string {
 	if m != nil {
@@ -523,7 +717,7 @@ type SalesmanData struct {
 func (m *SalesmanData) Reset()                    { *m = SalesmanData{} }
 func (m *SalesmanData) String() string            { return proto.CompactTextString(m) }
 func (*SalesmanData) ProtoMessage()               {}
-func (*SalesmanData) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{13} }
+func (*SalesmanData) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{19} }
 
 func (m *SalesmanData) GetAskPlanCGroups() map[string]string {
 	if m != nil {
@@ -554,7 +748,7 @@ type DebugStateReply struct {
 func (m *DebugStateReply) Reset()                    { *m = DebugStateReply{} }
 func (m *DebugStateReply) String() string            { return proto.CompactTextString(m) }
 func (*DebugStateReply) ProtoMessage()               {}
-func (*DebugStateReply) Descriptor() ([]byte, []int) { return fileDescriptor14, []int{14} }
+func (*DebugStateReply) Descriptor() ([]byte, []int) { return fileDescriptor15, []int{20} }
 
 func (m *DebugStateReply) GetSchedulerData() *SchedulerData {
 	if m != nil {
@@ -571,6 +765,12 @@ func (m *DebugStateReply) GetSalesmanData() *SalesmanData {
 }
 
 func init() {
+	proto.RegisterType((*PingRequest)(nil), "sonm.PingRequest")
+	proto.RegisterType((*PingReply)(nil), "sonm.PingReply")
+	proto.RegisterType((*DelegationToken)(nil), "sonm.DelegationToken")
+	proto.RegisterType((*DelegationTokenStatus)(nil), "sonm.DelegationTokenStatus")
+	proto.RegisterType((*DelegationTokensReply)(nil), "sonm.DelegationTokensReply")
+	proto.RegisterType((*RevokeDelegationTokenRequest)(nil), "sonm.RevokeDelegationTokenRequest")
 	proto.RegisterType((*TaskSpec)(nil), "sonm.TaskSpec")
 	proto.RegisterType((*StartTaskRequest)(nil), "sonm.StartTaskRequest")
 	proto.RegisterType((*WorkerJoinNetworkRequest)(nil), "sonm.WorkerJoinNetworkRequest")
@@ -630,6 +830,9 @@ type WorkerManagementClient interface {
 	RemoveBenchmark(ctx context.Context, in *NumericID, opts ...grpc.CallOption) (*Empty, error)
 	// Schedule full rebenchmarking on next restart
 	PurgeBenchmarks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	// Ping replies with the requested amount of data, allowing to measure
+	// the connection quality. Can be called by anyone.
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingReply, error)
 }
 
 type workerManagementClient struct {
@@ -757,6 +960,15 @@ func (c *workerManagementClient) PurgeBenchmarks(ctx context.Context, in *Empty,
 	return out, nil
 }
 
+func (c *workerManagementClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingReply, error) {
+	out := new(PingReply)
+	err := grpc.Invoke(ctx, "/sonm.WorkerManagement/Ping", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for WorkerManagement service
 
 type WorkerManagementServer interface {
@@ -790,6 +1002,9 @@ type WorkerManagementServer interface {
 	RemoveBenchmark(context.Context, *NumericID) (*Empty, error)
 	// Schedule full rebenchmarking on next restart
 	PurgeBenchmarks(context.Context, *Empty) (*Empty, error)
+	// Ping replies with the requested amount of data, allowing to measure
+	// the connection quality. Can be called by anyone.
+	Ping(context.Context, *PingRequest) (*PingReply, error)
 }
 
 func RegisterWorkerManagementServer(s *grpc.Server, srv WorkerManagementServer) {
@@ -1030,6 +1245,24 @@ func _WorkerManagement_PurgeBenchmarks_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _WorkerManagement_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerManagementServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.WorkerManagement/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerManagementServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _WorkerManagement_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.WorkerManagement",
 	HandlerType: (*WorkerManagementServer)(nil),
@@ -1086,6 +1319,10 @@ var _WorkerManagement_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PurgeBenchmarks",
 			Handler:    _WorkerManagement_PurgeBenchmarks_Handler,
 		},
+		{
+			MethodName: "Ping",
+			Handler:    _WorkerManagement_Ping_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "worker.proto",
@@ -1104,6 +1341,12 @@ type WorkerClient interface {
 	TaskLogs(ctx context.Context, in *TaskLogsRequest, opts ...grpc.CallOption) (Worker_TaskLogsClient, error)
 	// Note: currently used for testing pusposes.
 	GetDealInfo(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DealInfoReply, error)
+	// DelegationTokens returns delegation tokens that were used to access
+	// the deal with the given ID.
+	DelegationTokens(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DelegationTokensReply, error)
+	// RevokeDelegationToken revokes the delegation token, making the worker
+	// to reject it until the token expires.
+	RevokeDelegationToken(ctx context.Context, in *RevokeDelegationTokenRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type workerClient struct {
@@ -1254,6 +1497,24 @@ func (c *workerClient) GetDealInfo(ctx context.Context, in *ID, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *workerClient) DelegationTokens(ctx context.Context, in *ID, opts ...grpc.CallOption) (*DelegationTokensReply, error) {
+	out := new(DelegationTokensReply)
+	err := grpc.Invoke(ctx, "/sonm.Worker/DelegationTokens", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *workerClient) RevokeDelegationToken(ctx context.Context, in *RevokeDelegationTokenRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := grpc.Invoke(ctx, "/sonm.Worker/RevokeDelegationToken", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Worker service
 
 type WorkerServer interface {
@@ -1267,6 +1528,12 @@ type WorkerServer interface {
 	TaskLogs(*TaskLogsRequest, Worker_TaskLogsServer) error
 	// Note: currently used for testing pusposes.
 	GetDealInfo(context.Context, *ID) (*DealInfoReply, error)
+	// DelegationTokens returns delegation tokens that were used to access
+	// the deal with the given ID.
+	DelegationTokens(context.Context, *ID) (*DelegationTokensReply, error)
+	// RevokeDelegationToken revokes the delegation token, making the worker
+	// to reject it until the token expires.
+	RevokeDelegationToken(context.Context, *RevokeDelegationTokenRequest) (*Empty, error)
 }
 
 func RegisterWorkerServer(s *grpc.Server, srv WorkerServer) {
@@ -1431,6 +1698,42 @@ func _Worker_GetDealInfo_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Worker_DelegationTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).DelegationTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Worker/DelegationTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).DelegationTokens(ctx, req.(*ID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Worker_RevokeDelegationToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDelegationTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WorkerServer).RevokeDelegationToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/sonm.Worker/RevokeDelegationToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WorkerServer).RevokeDelegationToken(ctx, req.(*RevokeDelegationTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Worker_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "sonm.Worker",
 	HandlerType: (*WorkerServer)(nil),
@@ -1455,6 +1758,14 @@ var _Worker_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetDealInfo",
 			Handler:    _Worker_GetDealInfo_Handler,
 		},
+		{
+			MethodName: "DelegationTokens",
+			Handler:    _Worker_DelegationTokens_Handler,
+		},
+		{
+			MethodName: "RevokeDelegationToken",
+			Handler:    _Worker_RevokeDelegationToken_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1733,6 +2044,25 @@ var _WorkerManagement_PurgeBenchmarksCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.Empty"),
 }
 
+var _WorkerManagement_PingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Make the Ping method call, input-type: sonm.PingRequest output-type: sonm.PingReply",
+	RunE: grpccmd.RunE(
+		"Ping",
+		"sonm.PingRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewWorkerManagementClient(cc)
+		},
+	),
+}
+
+var _WorkerManagement_PingCmd_gen = &cobra.Command{
+	Use:   "ping-gen",
+	Short: "Generate JSON for method call of Ping (input-type: sonm.PingRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.PingRequest"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_WorkerManagementCmd)
@@ -1763,6 +2093,8 @@ func init() {
 		_WorkerManagement_RemoveBenchmarkCmd_gen,
 		_WorkerManagement_PurgeBenchmarksCmd,
 		_WorkerManagement_PurgeBenchmarksCmd_gen,
+		_WorkerManagement_PingCmd,
+		_WorkerManagement_PingCmd_gen,
 	)
 }
 
@@ -1924,6 +2256,44 @@ var _Worker_GetDealInfoCmd_gen = &cobra.Command{
 	RunE:  grpccmd.TypeToJson("sonm.ID"),
 }
 
+var _Worker_DelegationTokensCmd = &cobra.Command{
+	Use:   "delegationTokens",
+	Short: "Make the DelegationTokens method call, input-type: sonm.ID output-type: sonm.DelegationTokensReply",
+	RunE: grpccmd.RunE(
+		"DelegationTokens",
+		"sonm.ID",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewWorkerClient(cc)
+		},
+	),
+}
+
+var _Worker_DelegationTokensCmd_gen = &cobra.Command{
+	Use:   "delegationTokens-gen",
+	Short: "Generate JSON for method call of DelegationTokens (input-type: sonm.ID)",
+	RunE:  grpccmd.TypeToJson("sonm.ID"),
+}
+
+var _Worker_RevokeDelegationTokenCmd = &cobra.Command{
+	Use:   "revokeDelegationToken",
+	Short: "Make the RevokeDelegationToken method call, input-type: sonm.RevokeDelegationTokenRequest output-type: sonm.Empty",
+	RunE: grpccmd.RunE(
+		"RevokeDelegationToken",
+		"sonm.RevokeDelegationTokenRequest",
+		func(c io.Closer) interface{} {
+			cc := c.(*grpc.ClientConn)
+			return NewWorkerClient(cc)
+		},
+	),
+}
+
+var _Worker_RevokeDelegationTokenCmd_gen = &cobra.Command{
+	Use:   "revokeDelegationToken-gen",
+	Short: "Generate JSON for method call of RevokeDelegationToken (input-type: sonm.RevokeDelegationTokenRequest)",
+	RunE:  grpccmd.TypeToJson("sonm.RevokeDelegationTokenRequest"),
+}
+
 // Register commands with the root command and service command
 func init() {
 	grpccmd.RegisterServiceCmd(_WorkerCmd)
@@ -1944,117 +2314,140 @@ func init() {
 		_Worker_TaskLogsCmd_gen,
 		_Worker_GetDealInfoCmd,
 		_Worker_GetDealInfoCmd_gen,
+		_Worker_DelegationTokensCmd,
+		_Worker_DelegationTokensCmd_gen,
+		_Worker_RevokeDelegationTokenCmd,
+		_Worker_RevokeDelegationTokenCmd_gen,
 	)
 }
 
 // End grpccmd
 
-func init() { proto.RegisterFile("worker.proto", fileDescriptor14) }
-
-var fileDescriptor14 = []byte{
-	// 1663 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0xcd, 0x6e, 0x1b, 0xbb,
-	0x15, 0xd6, 0x58, 0xb2, 0x7e, 0x8e, 0x24, 0x4b, 0xa1, 0x13, 0x43, 0x98, 0x26, 0x81, 0x33, 0x69,
-	0x5a, 0xd5, 0x49, 0x54, 0x47, 0x49, 0x83, 0x36, 0x69, 0x81, 0xc8, 0x96, 0xed, 0x28, 0xb1, 0x65,
-	0x75, 0x14, 0xc1, 0x5d, 0x14, 0x28, 0x68, 0x89, 0x96, 0x07, 0x1a, 0x71, 0xa6, 0x33, 0x1c, 0xb7,
-	0xee, 0xba, 0xbb, 0xee, 0xba, 0x2c, 0xfa, 0x0a, 0xed, 0xbe, 0xe8, 0x1b, 0xdc, 0x77, 0xb8, 0xeb,
-	0xbb, 0xbc, 0x6f, 0x70, 0x71, 0xc1, 0x21, 0x39, 0x3f, 0xf2, 0xf8, 0xe2, 0x06, 0xc8, 0x4e, 0x3c,
-	0xfc, 0xbe, 0xc3, 0xc3, 0x8f, 0xe7, 0x9c, 0x21, 0x05, 0xb5, 0xbf, 0x38, 0xde, 0x82, 0x78, 0x1d,
-	0xd7, 0x73, 0x98, 0x83, 0x0a, 0xbe, 0x43, 0x97, 0xfa, 0x06, 0xf6, 0x17, 0x7f, 0x72, 0x6d, 0x4c,
-	0x85, 0x55, 0xaf, 0x9d, 0x5b, 0x73, 0x8b, 0x32, 0x39, 0x42, 0x53, 0xec, 0xe2, 0x73, 0xcb, 0xb6,
-	0x98, 0x45, 0x7c, 0x69, 0x6b, 0x4c, 0x1d, 0xca, 0xb0, 0x45, 0x95, 0x23, 0xbd, 0x61, 0x51, 0xee,
-	0x8a, 0x5a, 0x58, 0x1a, 0xee, 0x2c, 0xb1, 0xb7, 0x20, 0xcc, 0xb5, 0xf1, 0x94, 0x48, 0x53, 0x85,
-	0x12, 0xe5, 0xb3, 0xc1, 0xac, 0x25, 0xf1, 0x19, 0x5e, 0xba, 0xc2, 0x60, 0xfc, 0x47, 0x83, 0xf2,
-	0x27, 0xec, 0x2f, 0xc6, 0x2e, 0x99, 0xa2, 0xe7, 0x50, 0x89, 0xfc, 0xb7, 0xb4, 0x6d, 0xad, 0x5d,
-	0xed, 0x36, 0x3a, 0xdc, 0x7d, 0x67, 0x5f, 0x99, 0xcd, 0x18, 0x81, 0x76, 0xa0, 0xec, 0x91, 0xb9,
-	0xe5, 0x33, 0xef, 0xba, 0xb5, 0x16, 0xa2, 0x37, 0x04, 0xda, 0x94, 0x56, 0x33, 0x9a, 0x47, 0xaf,
-	0xa0, 0xe2, 0x11, 0xdf, 0x09, 0xbc, 0x29, 0xf1, 0x5b, 0xf9, 0x10, 0xbc, 0x25, 0xc0, 0x3d, 0x7f,
-	0x31, 0xb2, 0x31, 0x35, 0xd5, 0xac, 0x19, 0x03, 0x51, 0x13, 0xf2, 0x0c, 0xcf, 0x5b, 0x85, 0x6d,
-	0xad, 0x5d, 0x31, 0xf9, 0x4f, 0xe3, 0x8f, 0xd0, 0x1c, 0x33, 0xec, 0x31, 0x1e, 0xb3, 0x49, 0xfe,
-	0x1c, 0x10, 0x9f, 0xa1, 0x9f, 0x42, 0x71, 0x46, 0xb0, 0x3d, 0xe8, 0xcb, 0x98, 0x6b, 0xc2, 0xf1,
-	0x9e, 0x35, 0x1f, 0x50, 0x66, 0xca, 0x39, 0x64, 0x40, 0xc1, 0x77, 0xc9, 0x34, 0x1d, 0xa9, 0xda,
-	0xba, 0x19, 0xce, 0x19, 0x23, 0x68, 0x9d, 0x85, 0xc7, 0xf4, 0xc1, 0xb1, 0xe8, 0x90, 0x30, 0x7e,
-	0x66, 0x6a, 0x95, 0x2d, 0x28, 0x32, 0xec, 0x2f, 0xe4, 0x2a, 0x15, 0x53, 0x8e, 0xd0, 0x7d, 0xe0,
-	0xfa, 0x72, 0xe4, 0xa0, 0x1f, 0x3a, 0xaf, 0x98, 0xb1, 0xc1, 0xf8, 0x4a, 0x83, 0x8d, 0x44, 0xc0,
-	0xae, 0x7d, 0x8d, 0x36, 0x60, 0xcd, 0x9a, 0x49, 0x27, 0x6b, 0xd6, 0x0c, 0xbd, 0x85, 0x92, 0xeb,
-	0x78, 0xec, 0x04, 0xbb, 0xad, 0xb5, 0xed, 0x7c, 0xbb, 0xda, 0x7d, 0x24, 0x62, 0x4b, 0xd3, 0x3a,
-	0x23, 0x81, 0x39, 0xa0, 0x5c, 0x58, 0xc5, 0x40, 0x0f, 0x01, 0xa2, 0xc5, 0xb8, 0xb0, 0xf9, 0x76,
-	0xc5, 0x4c, 0x58, 0xf4, 0x8f, 0x50, 0x4b, 0x12, 0xb9, 0xa2, 0x0b, 0x72, 0x2d, 0x57, 0xe7, 0x3f,
-	0xd1, 0x13, 0x58, 0xbf, 0xc2, 0x76, 0x40, 0xa4, 0x30, 0xf2, 0xc0, 0x0f, 0xe8, 0xcc, 0x75, 0x2c,
-	0xca, 0x7c, 0x53, 0xcc, 0xbe, 0x59, 0xfb, 0xb5, 0x66, 0x7c, 0xad, 0x41, 0x75, 0xcc, 0x30, 0x0b,
-	0x7c, 0xb1, 0x93, 0x2d, 0x28, 0x06, 0x2e, 0xcf, 0xa8, 0xd0, 0x5f, 0xc1, 0x94, 0x23, 0xd4, 0x82,
-	0xd2, 0x15, 0xf1, 0x7c, 0xcb, 0xa1, 0x52, 0x10, 0x35, 0x44, 0x3a, 0x94, 0x5d, 0x1b, 0xb3, 0x0b,
-	0xc7, 0x5b, 0x86, 0x59, 0x50, 0x31, 0xa3, 0x31, 0x67, 0x11, 0x76, 0xd9, 0x9b, 0xcd, 0x3c, 0x79,
-	0xe0, 0x6a, 0xc8, 0x25, 0xe6, 0x62, 0xef, 0x3b, 0x01, 0x65, 0xad, 0xf5, 0x6d, 0xad, 0x5d, 0x37,
-	0x63, 0x03, 0x9f, 0xed, 0x9f, 0xbd, 0x17, 0x71, 0xb5, 0x8a, 0xe2, 0x00, 0x22, 0x03, 0xda, 0x81,
-	0xa6, 0x47, 0xe8, 0x8c, 0xfc, 0xed, 0xca, 0x09, 0x7c, 0x09, 0x2a, 0x85, 0xa0, 0x1b, 0x76, 0xe3,
-	0x5f, 0x1a, 0xd4, 0x65, 0x3a, 0xca, 0x1d, 0xfe, 0x0e, 0xca, 0x58, 0x1a, 0x5a, 0x5a, 0xf2, 0x70,
-	0x52, 0xb0, 0x68, 0x24, 0x0e, 0x27, 0xa2, 0xe8, 0x1f, 0x62, 0x7f, 0xb7, 0xc9, 0xff, 0x38, 0x2d,
-	0x7f, 0x3d, 0x5d, 0x14, 0x09, 0xf1, 0xff, 0xa9, 0x41, 0x9d, 0x67, 0xc3, 0xb1, 0xe5, 0x33, 0x11,
-	0xdc, 0x0b, 0x28, 0x58, 0xf4, 0xc2, 0x91, 0x81, 0x3d, 0x88, 0x33, 0x3a, 0x82, 0x74, 0x06, 0xf4,
-	0xc2, 0x11, 0x41, 0x85, 0x50, 0x7d, 0x08, 0x95, 0xc8, 0x94, 0x11, 0xcc, 0xd3, 0x74, 0x30, 0xf7,
-	0x12, 0x45, 0x12, 0x1f, 0x7b, 0x32, 0xa8, 0xff, 0x69, 0x50, 0xeb, 0x93, 0x2b, 0x8b, 0xd7, 0x6d,
-	0x18, 0xd3, 0x4f, 0x20, 0xbf, 0x3f, 0x9a, 0xc8, 0x42, 0xac, 0xc8, 0xe6, 0x31, 0x9a, 0x98, 0xdc,
-	0x8a, 0x1e, 0x40, 0xe1, 0x68, 0x34, 0xf1, 0x65, 0x9a, 0xcb, 0xd9, 0xa3, 0xd1, 0xc4, 0x0c, 0xcd,
-	0x9c, 0x6b, 0xf6, 0x4e, 0x64, 0x77, 0x90, 0xb3, 0x66, 0xef, 0xc4, 0xe4, 0x56, 0xf4, 0x73, 0x28,
-	0xc9, 0xb4, 0x0e, 0xb3, 0x23, 0x52, 0x4a, 0x55, 0xa9, 0x9a, 0xe5, 0x40, 0x9f, 0x39, 0x1e, 0x9e,
-	0x93, 0x30, 0x55, 0x22, 0xe0, 0x58, 0x18, 0x4d, 0x35, 0x6b, 0xf4, 0xa0, 0x31, 0x0a, 0x6c, 0x3b,
-	0xd9, 0x49, 0xb6, 0x64, 0x27, 0x51, 0xe5, 0x29, 0x47, 0x51, 0xed, 0xcf, 0x64, 0x3e, 0xcb, 0x91,
-	0xf1, 0x8f, 0x3c, 0xd4, 0xfb, 0x1c, 0x42, 0x2f, 0x1c, 0xb1, 0xff, 0x87, 0x50, 0xe0, 0x1c, 0x29,
-	0x00, 0x88, 0xa5, 0x39, 0xc4, 0x0c, 0xed, 0xe8, 0x0d, 0x94, 0xbc, 0x80, 0x52, 0x8b, 0xce, 0xa5,
-	0x0a, 0xdb, 0x31, 0x24, 0xf2, 0xd2, 0x31, 0x05, 0x44, 0xd6, 0xba, 0x24, 0xa0, 0x77, 0xbc, 0x3d,
-	0x2f, 0x5d, 0x9b, 0x30, 0x32, 0x0b, 0x4b, 0xbd, 0xda, 0x35, 0xb2, 0xd8, 0xfb, 0x0a, 0x24, 0xf8,
-	0x31, 0x29, 0xdd, 0x85, 0x0b, 0x3f, 0xb2, 0x0b, 0xeb, 0xbf, 0x87, 0x5a, 0x32, 0xa0, 0x2f, 0x90,
-	0x37, 0xfa, 0x18, 0x36, 0xd2, 0x51, 0x7e, 0x89, 0x64, 0xfc, 0x26, 0x0f, 0x8d, 0x95, 0x69, 0xf4,
-	0x0a, 0x8a, 0xbe, 0x28, 0x7a, 0xee, 0x79, 0xa3, 0x7b, 0x3f, 0xd3, 0x4b, 0x47, 0xfe, 0x96, 0x58,
-	0xde, 0x52, 0xac, 0x25, 0x9e, 0x93, 0x21, 0x5e, 0x12, 0xd5, 0xd3, 0x23, 0x03, 0xfa, 0x6d, 0xdc,
-	0xb0, 0x53, 0xa7, 0xb0, 0xea, 0x34, 0xbb, 0x63, 0xc7, 0x4d, 0xb3, 0x90, 0x6a, 0x9a, 0xbf, 0x80,
-	0xf5, 0xc0, 0x8f, 0xb3, 0x76, 0x53, 0x7d, 0x4a, 0xc5, 0x29, 0x4c, 0xf8, 0x94, 0x29, 0x10, 0xe8,
-	0x10, 0x10, 0xb6, 0x6d, 0x67, 0x8a, 0x19, 0x99, 0x45, 0x27, 0x16, 0xb6, 0xbe, 0xdb, 0xcf, 0x33,
-	0x83, 0xa1, 0x3e, 0xaf, 0xa5, 0xe8, 0xf3, 0xfa, 0x65, 0x3f, 0x17, 0x7f, 0x80, 0xa2, 0x6c, 0xc2,
-	0x55, 0x28, 0x4d, 0x86, 0x1f, 0x87, 0xa7, 0x67, 0xc3, 0x66, 0x0e, 0xd5, 0xa0, 0x3c, 0x1e, 0x9d,
-	0x9e, 0x1e, 0x0f, 0x86, 0x47, 0x4d, 0x4d, 0x8c, 0x7a, 0x67, 0x43, 0x3e, 0x5a, 0xe3, 0x40, 0x73,
-	0x32, 0x0c, 0x07, 0x79, 0x3e, 0x75, 0x38, 0x18, 0x0e, 0xc6, 0xef, 0x0f, 0xfa, 0xcd, 0x02, 0x02,
-	0x28, 0xee, 0x99, 0xa7, 0x1f, 0x0f, 0x86, 0xcd, 0x75, 0xe3, 0xff, 0x1a, 0xd4, 0xd4, 0x36, 0x46,
-	0x8e, 0x63, 0xa3, 0x36, 0xe4, 0xb1, 0xad, 0xaa, 0xee, 0x36, 0x09, 0x38, 0x04, 0xed, 0x42, 0x21,
-	0xf0, 0xc9, 0x4c, 0x56, 0xdf, 0xfd, 0xb4, 0xca, 0xdc, 0x57, 0x67, 0xe2, 0xab, 0xca, 0x09, 0x91,
-	0xfa, 0x29, 0x54, 0x22, 0x53, 0x86, 0x20, 0xcf, 0xd2, 0x82, 0xdc, 0xb6, 0x78, 0x42, 0x97, 0x6f,
-	0xd7, 0xa0, 0x3e, 0x9e, 0x5e, 0x92, 0x59, 0x60, 0x13, 0xaf, 0x8f, 0x19, 0x46, 0xc7, 0x50, 0xe7,
-	0x1d, 0xe5, 0x93, 0x23, 0x69, 0xb2, 0xa5, 0xff, 0x4c, 0x76, 0xae, 0x24, 0x36, 0xcc, 0xb2, 0x08,
-	0x28, 0xe2, 0x4c, 0x93, 0x51, 0x07, 0xca, 0x4b, 0x6c, 0x51, 0xbe, 0x19, 0x19, 0x14, 0xba, 0xb9,
-	0x4d, 0x33, 0xc2, 0xa0, 0x01, 0xd4, 0xe4, 0x17, 0x8b, 0x0f, 0x7d, 0x99, 0xd4, 0x4f, 0xb2, 0x16,
-	0xef, 0x25, 0x70, 0x62, 0xed, 0x14, 0x55, 0x7f, 0x07, 0xe8, 0x66, 0x7c, 0x19, 0xa2, 0xdd, 0x4d,
-	0x8a, 0x56, 0x49, 0x77, 0x86, 0x3b, 0x37, 0x16, 0xc9, 0x70, 0xd0, 0x4e, 0xab, 0x9e, 0xb5, 0xc1,
-	0x84, 0xe2, 0xff, 0xce, 0x43, 0x6d, 0x8c, 0x6d, 0xe2, 0x2f, 0x31, 0x0d, 0x05, 0x1f, 0xc2, 0x86,
-	0x8c, 0x7b, 0xff, 0xc8, 0x73, 0x02, 0x57, 0x7d, 0x93, 0x94, 0xe2, 0x09, 0xac, 0xda, 0xb3, 0x04,
-	0x8a, 0x5d, 0xaf, 0xb0, 0xd1, 0x4b, 0x58, 0xe7, 0xed, 0x5d, 0x69, 0xf7, 0x20, 0xc3, 0x0d, 0xef,
-	0xd1, 0x92, 0x2d, 0xb0, 0xe8, 0x35, 0x14, 0x1d, 0x6f, 0x46, 0x3c, 0xde, 0x8a, 0x39, 0xeb, 0x61,
-	0x06, 0xeb, 0x34, 0x04, 0x08, 0x9a, 0x44, 0xeb, 0x3d, 0xd8, 0xcc, 0x88, 0xe9, 0xb3, 0x54, 0xee,
-	0x03, 0xc4, 0xf1, 0x64, 0x30, 0xb7, 0xd3, 0xf2, 0x26, 0xbf, 0x63, 0x09, 0x2f, 0x87, 0x50, 0x4d,
-	0xc4, 0x97, 0xe1, 0xe6, 0x51, 0xda, 0x4d, 0x55, 0xb8, 0x09, 0x39, 0xc9, 0xe3, 0xf9, 0xbb, 0x06,
-	0x8d, 0x3e, 0x39, 0x0f, 0xe6, 0xbc, 0x5d, 0x10, 0xd1, 0xb8, 0x7f, 0x03, 0x75, 0x3f, 0x99, 0x7a,
-	0xb2, 0xb6, 0x37, 0x33, 0xb2, 0xd2, 0x4c, 0x23, 0xd1, 0x6b, 0xa8, 0xf9, 0x09, 0x0d, 0xd3, 0x29,
-	0x92, 0x54, 0xd7, 0x4c, 0xe1, 0xba, 0xdf, 0x15, 0xa0, 0x29, 0xae, 0xff, 0x27, 0x98, 0xe2, 0x39,
-	0x59, 0x12, 0xca, 0xd0, 0x4e, 0xdc, 0xc4, 0x64, 0xab, 0x5b, 0xba, 0xec, 0x5a, 0xbf, 0x13, 0xdd,
-	0xd1, 0x55, 0xbb, 0x37, 0x72, 0xe8, 0x19, 0x94, 0xe4, 0x65, 0x28, 0x0d, 0x46, 0x4a, 0xbe, 0xf8,
-	0xa2, 0x64, 0xe4, 0xd0, 0x2e, 0x54, 0x0f, 0x3d, 0x42, 0x3e, 0x83, 0xf1, 0x14, 0xd6, 0x79, 0x75,
-	0xad, 0x60, 0x37, 0x33, 0x2e, 0x7e, 0x46, 0x8e, 0x77, 0x01, 0x75, 0xf7, 0xcc, 0xc4, 0xa7, 0x6e,
-	0xb0, 0x46, 0x0e, 0xed, 0x40, 0x7d, 0xdf, 0x23, 0x98, 0x11, 0xd5, 0x46, 0xd2, 0x57, 0x51, 0xbd,
-	0x2c, 0x86, 0x83, 0xbe, 0x91, 0x43, 0x6d, 0xa8, 0x9b, 0x64, 0xe9, 0x5c, 0x45, 0xd8, 0x68, 0x52,
-	0x4f, 0x2e, 0x15, 0x86, 0x5c, 0x1f, 0x05, 0xde, 0x9c, 0x64, 0x87, 0xb2, 0x02, 0xfe, 0x15, 0x6c,
-	0xaa, 0x83, 0x3d, 0xc1, 0x16, 0x65, 0x84, 0x62, 0x3a, 0x25, 0x48, 0x7e, 0x63, 0x3e, 0xa9, 0xa7,
-	0xeb, 0x2a, 0xed, 0x05, 0x34, 0x86, 0xe4, 0xaf, 0x2c, 0x49, 0x49, 0xad, 0xb2, 0xca, 0x37, 0x72,
-	0xa8, 0xcb, 0xf3, 0x5f, 0x25, 0x5c, 0x1a, 0x7d, 0x4f, 0x49, 0x9f, 0xca, 0x47, 0xb1, 0x8c, 0xd8,
-	0xf4, 0x1e, 0xa1, 0xd3, 0x4b, 0xfe, 0xcc, 0x56, 0x91, 0x0d, 0x83, 0x25, 0xf1, 0xac, 0xe9, 0xcd,
-	0xdd, 0x3f, 0xe7, 0x57, 0x4c, 0x6f, 0x1e, 0x33, 0x7e, 0x70, 0xff, 0xdd, 0xff, 0xe6, 0xa1, 0x28,
-	0x12, 0x10, 0x3d, 0x87, 0xf2, 0x28, 0xf0, 0x2f, 0xf9, 0xa1, 0x2a, 0xca, 0xfe, 0x65, 0x40, 0x17,
-	0xba, 0x7c, 0xb8, 0x8e, 0x3c, 0x67, 0xee, 0x11, 0xdf, 0x37, 0x72, 0x6d, 0x6d, 0x57, 0x43, 0x5d,
-	0x0e, 0x17, 0x77, 0x59, 0x24, 0x37, 0xb0, 0x72, 0xb7, 0xd5, 0x93, 0x5e, 0x8c, 0xdc, 0xae, 0x86,
-	0xde, 0x42, 0x25, 0x7a, 0x62, 0xa2, 0xad, 0x1b, 0x6f, 0x4e, 0xc1, 0xba, 0x9b, 0xf5, 0x16, 0x35,
-	0x72, 0xe8, 0x31, 0x94, 0xc7, 0xcc, 0x71, 0x43, 0xee, 0xad, 0x87, 0xff, 0x4b, 0x80, 0xf8, 0x4e,
-	0x94, 0x80, 0x65, 0x5f, 0xe5, 0x8c, 0x1c, 0xda, 0x83, 0x6a, 0xe2, 0xe5, 0x8d, 0x64, 0x43, 0xbc,
-	0xed, 0x49, 0xae, 0x8a, 0x50, 0x5a, 0xf9, 0x3b, 0xde, 0xc8, 0xa1, 0x37, 0xe2, 0x0f, 0x8d, 0x63,
-	0x67, 0xee, 0xa3, 0xc4, 0x42, 0x7c, 0xac, 0x78, 0x9b, 0x69, 0x73, 0x2c, 0x49, 0x07, 0xaa, 0x47,
-	0x84, 0xa9, 0xdb, 0x74, 0x22, 0xe2, 0xcd, 0x8c, 0x7b, 0xb6, 0x91, 0x3b, 0x2f, 0x86, 0x7f, 0xa2,
-	0xbc, 0xfc, 0x3e, 0x00, 0x00, 0xff, 0xff, 0x1b, 0xbd, 0x6e, 0x7e, 0xdd, 0x11, 0x00, 0x00,
+func init() { proto.RegisterFile("worker.proto", fileDescriptor15) }
+
+var fileDescriptor15 = []byte{
+	// 1960 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x58, 0x4f, 0x6f, 0x1b, 0xb9,
+	0x15, 0xd7, 0x58, 0x7f, 0x2c, 0x3d, 0x49, 0xb6, 0x42, 0x27, 0xc1, 0x40, 0x9b, 0x04, 0xde, 0xd9,
+	0xa6, 0x55, 0xf3, 0x47, 0x9b, 0x55, 0xb6, 0x8b, 0x36, 0x69, 0x81, 0x55, 0x6c, 0xc7, 0xab, 0xc4,
+	0x96, 0x55, 0xca, 0x46, 0x7a, 0x28, 0x50, 0x30, 0x12, 0x23, 0x0f, 0x34, 0x9a, 0x99, 0x0e, 0x39,
+	0xee, 0x7a, 0xcf, 0xbd, 0xf5, 0x50, 0xa0, 0xc7, 0xa2, 0x1f, 0xa1, 0x3d, 0xf7, 0xd0, 0x6f, 0xd0,
+	0xef, 0xd0, 0x73, 0x4f, 0x45, 0xbf, 0x40, 0x0f, 0x05, 0x87, 0xe4, 0x0c, 0x47, 0x1e, 0x07, 0x1b,
+	0x20, 0xb7, 0x79, 0x8f, 0xbf, 0xf7, 0xf8, 0xde, 0xe3, 0xe3, 0x8f, 0x1c, 0x42, 0xeb, 0x77, 0x41,
+	0xb4, 0xa4, 0x51, 0x3f, 0x8c, 0x02, 0x1e, 0xa0, 0x0a, 0x0b, 0xfc, 0x55, 0x77, 0x8b, 0xb0, 0xe5,
+	0x6f, 0x42, 0x8f, 0xf8, 0x52, 0xdb, 0x6d, 0xbd, 0x75, 0x17, 0xae, 0xcf, 0x95, 0x84, 0x66, 0x24,
+	0x24, 0x6f, 0x5d, 0xcf, 0xe5, 0x2e, 0x65, 0x4a, 0xb7, 0x3d, 0x0b, 0x7c, 0x4e, 0x5c, 0x5f, 0x3b,
+	0xea, 0x6e, 0xbb, 0xbe, 0x70, 0xe5, 0xbb, 0x44, 0x29, 0x6e, 0xac, 0x48, 0xb4, 0xa4, 0x3c, 0xf4,
+	0xc8, 0x8c, 0x2a, 0x55, 0xc3, 0xa7, 0xda, 0xe7, 0x36, 0x77, 0x57, 0x94, 0x71, 0xb2, 0x0a, 0xa5,
+	0xc2, 0x39, 0x80, 0xe6, 0xc4, 0xf5, 0x17, 0x98, 0xfe, 0x36, 0xa6, 0x8c, 0x23, 0x1b, 0x36, 0x43,
+	0x72, 0xe9, 0x05, 0x64, 0x6e, 0x5b, 0xbb, 0x56, 0xaf, 0x85, 0xb5, 0x88, 0xee, 0x40, 0x23, 0xa2,
+	0xa1, 0x77, 0x39, 0x75, 0xbf, 0xa3, 0xf6, 0xc6, 0xae, 0xd5, 0xab, 0xe0, 0x4c, 0xe1, 0xdc, 0x87,
+	0x86, 0x74, 0x13, 0x7a, 0x97, 0xd7, 0x3b, 0x71, 0xfe, 0x67, 0xc1, 0xf6, 0x3e, 0xf5, 0xe8, 0x82,
+	0x70, 0x37, 0xf0, 0x4f, 0x83, 0x25, 0xf5, 0xd1, 0x16, 0x6c, 0xb8, 0x12, 0xd8, 0xc0, 0x1b, 0xee,
+	0x1c, 0xfd, 0x00, 0x6a, 0x73, 0x4a, 0xbc, 0xd1, 0x7e, 0x32, 0x4b, 0x73, 0xd0, 0xea, 0x8b, 0x04,
+	0xfb, 0x2f, 0xdc, 0xc5, 0xc8, 0xe7, 0x58, 0x8d, 0xa1, 0x1e, 0xd4, 0x5c, 0xc6, 0x62, 0x1a, 0xd9,
+	0xe5, 0x04, 0xd5, 0x91, 0xa8, 0x03, 0x7e, 0x3e, 0x9c, 0xcf, 0x23, 0xca, 0x18, 0x56, 0xe3, 0xe8,
+	0x11, 0xd4, 0xe7, 0x72, 0x4a, 0x6a, 0x57, 0xae, 0xc1, 0xa6, 0x08, 0x11, 0xfb, 0x8a, 0xf2, 0xf3,
+	0x60, 0xce, 0xec, 0xea, 0x6e, 0xb9, 0xd7, 0xc0, 0x5a, 0x44, 0x8f, 0xa1, 0x41, 0xbf, 0x0d, 0xdd,
+	0x88, 0xb2, 0x21, 0xb7, 0x6b, 0x89, 0xa3, 0x6d, 0xe9, 0xe8, 0x54, 0xd7, 0x14, 0x67, 0x08, 0x84,
+	0xa0, 0xc2, 0xdc, 0x85, 0x6f, 0x6f, 0x26, 0x15, 0x48, 0xbe, 0x9d, 0x3f, 0x5a, 0x70, 0x6b, 0x2d,
+	0xfd, 0x29, 0x27, 0x3c, 0x66, 0xe8, 0x21, 0x54, 0xb9, 0x10, 0x93, 0x3a, 0x34, 0x07, 0xb7, 0xa4,
+	0xe3, 0x35, 0x2c, 0x96, 0x18, 0xf4, 0x39, 0x80, 0x47, 0x18, 0x3f, 0x63, 0x74, 0x3e, 0xe4, 0xf6,
+	0x46, 0x71, 0x28, 0x06, 0x44, 0x24, 0x15, 0xd1, 0x8b, 0x60, 0x49, 0xe7, 0x49, 0xb5, 0xea, 0x58,
+	0x8b, 0xce, 0xd1, 0x95, 0x80, 0x98, 0x5c, 0xc3, 0xa7, 0x50, 0x4b, 0x26, 0x63, 0xb6, 0xb5, 0x5b,
+	0xee, 0x35, 0x07, 0x9f, 0x14, 0x46, 0x24, 0xa3, 0xc7, 0x0a, 0xea, 0x9c, 0xc2, 0x1d, 0x9c, 0x38,
+	0x5e, 0x0f, 0x5c, 0x75, 0x57, 0xb6, 0xb4, 0xd6, 0x7b, 0x96, 0x56, 0x36, 0xc4, 0x86, 0x6e, 0x08,
+	0xe7, 0x6f, 0x16, 0xd4, 0x4f, 0x09, 0x5b, 0x4e, 0x43, 0x3a, 0x13, 0xab, 0x90, 0x6e, 0x01, 0xdb,
+	0x32, 0x53, 0xdf, 0xd3, 0x6a, 0x9c, 0x21, 0xd0, 0x03, 0xa8, 0x47, 0x74, 0xe1, 0x32, 0x1e, 0x5d,
+	0xaa, 0x42, 0x6d, 0x49, 0x34, 0x56, 0x5a, 0x9c, 0x8e, 0xa3, 0x2f, 0x45, 0x87, 0xb3, 0x20, 0x8e,
+	0x66, 0x94, 0xa9, 0xae, 0xba, 0x2d, 0xc1, 0x43, 0xb6, 0x9c, 0x78, 0xc4, 0xc7, 0x7a, 0x14, 0x67,
+	0x40, 0xd4, 0x81, 0x32, 0x27, 0x8b, 0xa4, 0xb3, 0x1a, 0x58, 0x7c, 0x3a, 0xbf, 0x86, 0xce, 0x94,
+	0x93, 0x88, 0x8b, 0x98, 0x3f, 0x2c, 0x73, 0x07, 0x2a, 0x2c, 0xa4, 0xb3, 0x7c, 0xa4, 0x3a, 0x75,
+	0x9c, 0x8c, 0x39, 0x13, 0xb0, 0xdf, 0x24, 0x4c, 0xf2, 0x2a, 0x70, 0xfd, 0x31, 0xe5, 0x82, 0x56,
+	0xf4, 0x2c, 0xb7, 0xa1, 0xc6, 0x09, 0x5b, 0xaa, 0x59, 0x1a, 0x58, 0x49, 0x62, 0xef, 0xfa, 0x12,
+	0xa9, 0x76, 0x55, 0x03, 0x67, 0x0a, 0xe7, 0x9f, 0x16, 0x6c, 0x19, 0x01, 0x8b, 0xd5, 0x5f, 0xdf,
+	0x93, 0xcf, 0x61, 0x33, 0x0c, 0x22, 0x7e, 0x4c, 0x42, 0x7b, 0x23, 0x69, 0x87, 0x4f, 0x65, 0x6c,
+	0x79, 0xb3, 0xfe, 0x44, 0x62, 0x0e, 0x7c, 0x51, 0x58, 0x6d, 0x81, 0xee, 0x01, 0xa4, 0x93, 0x89,
+	0xc2, 0x8a, 0x5d, 0x65, 0x68, 0xba, 0xaf, 0xa1, 0x65, 0x1a, 0x8a, 0x8a, 0x2e, 0xe9, 0xa5, 0x9a,
+	0x5d, 0x7c, 0xa2, 0xfb, 0x50, 0xbd, 0x20, 0x5e, 0x4c, 0xf3, 0xbd, 0x7e, 0xe0, 0xcf, 0xc3, 0xc0,
+	0xf5, 0x39, 0xc3, 0x72, 0xf4, 0xd9, 0xc6, 0x4f, 0x2d, 0xe7, 0x5f, 0x16, 0x34, 0x55, 0x57, 0x26,
+	0x99, 0xdc, 0x86, 0x5a, 0x1c, 0x0a, 0xd2, 0x4b, 0xfc, 0x55, 0xb0, 0x92, 0xc4, 0x96, 0xb8, 0xa0,
+	0x11, 0x73, 0x03, 0x5f, 0x15, 0x44, 0x8b, 0xa8, 0x0b, 0xf5, 0xd0, 0x23, 0xfc, 0x5d, 0x10, 0xad,
+	0x92, 0x2e, 0x68, 0xe0, 0x54, 0x16, 0x56, 0x54, 0xb2, 0x86, 0x5a, 0x70, 0x2d, 0x8a, 0x12, 0x8b,
+	0x62, 0xef, 0x05, 0xb1, 0xcf, 0xed, 0xea, 0xae, 0xd5, 0x6b, 0xe3, 0x4c, 0x21, 0x46, 0xf7, 0xdf,
+	0x7c, 0x23, 0xe3, 0x4a, 0xb8, 0xa3, 0x81, 0x33, 0x05, 0x7a, 0x00, 0x9d, 0x88, 0xfa, 0x73, 0xfa,
+	0xdd, 0x45, 0x10, 0x33, 0x05, 0xda, 0x4c, 0x40, 0x57, 0xf4, 0xce, 0x9f, 0x2d, 0x68, 0xab, 0x76,
+	0x54, 0x19, 0xfe, 0x02, 0xea, 0x44, 0x29, 0x6c, 0xcb, 0x5c, 0x9c, 0x1c, 0x2c, 0x95, 0xe4, 0xe2,
+	0xa4, 0x26, 0xdd, 0x57, 0xd0, 0xce, 0x0d, 0x15, 0x94, 0xff, 0xb3, 0x7c, 0xf9, 0xdb, 0xf9, 0x4d,
+	0x61, 0x14, 0xff, 0x4f, 0x16, 0xb4, 0x45, 0x37, 0x1c, 0xb9, 0x8c, 0xcb, 0xe0, 0xbe, 0x80, 0x8a,
+	0xeb, 0xbf, 0x0b, 0x54, 0x60, 0x77, 0xb3, 0x8e, 0x4e, 0x21, 0xfd, 0x91, 0xff, 0x2e, 0x90, 0x41,
+	0x25, 0xd0, 0xee, 0x18, 0x1a, 0xa9, 0xaa, 0x20, 0x98, 0x87, 0xf9, 0x60, 0x6e, 0x19, 0x9b, 0x24,
+	0x5b, 0x76, 0x33, 0xa8, 0xff, 0x58, 0xd0, 0xda, 0xa7, 0x17, 0xee, 0x8c, 0xca, 0x31, 0xf4, 0x09,
+	0x94, 0xf7, 0x26, 0x67, 0x6a, 0x23, 0x36, 0x14, 0x79, 0x4c, 0xce, 0xb0, 0xd0, 0xa2, 0xbb, 0x50,
+	0x39, 0x9c, 0x9c, 0x31, 0xd5, 0xe6, 0x6a, 0xf4, 0x70, 0x72, 0x86, 0x13, 0xb5, 0xb0, 0xc5, 0xc3,
+	0x63, 0xc5, 0x0e, 0x6a, 0x14, 0x0f, 0x8f, 0xb1, 0xd0, 0xa2, 0x1f, 0xc1, 0xa6, 0x6a, 0x6b, 0xbb,
+	0x62, 0x56, 0x4a, 0xef, 0x52, 0x3d, 0x2a, 0x80, 0x8c, 0x07, 0x11, 0x59, 0x50, 0xbb, 0x6a, 0x02,
+	0xa7, 0x52, 0x89, 0xf5, 0x28, 0x1a, 0x40, 0xc3, 0x65, 0x81, 0x97, 0x30, 0x69, 0xd2, 0x37, 0x5b,
+	0x83, 0x9b, 0x12, 0x3a, 0xd2, 0xea, 0x23, 0x7a, 0x41, 0x3d, 0x9c, 0xc1, 0x9c, 0x21, 0x6c, 0x4f,
+	0x62, 0xcf, 0x33, 0xd9, 0xe7, 0xb6, 0x62, 0x1f, 0xbd, 0xa5, 0x95, 0x94, 0xf2, 0x85, 0x66, 0x5b,
+	0x25, 0x39, 0x7f, 0x28, 0x43, 0x7b, 0x5f, 0x40, 0xfc, 0x77, 0x81, 0xac, 0xd9, 0x3d, 0xa8, 0x08,
+	0x1b, 0x55, 0x34, 0xd0, 0x87, 0x01, 0xf1, 0x70, 0xa2, 0x47, 0xcf, 0x60, 0x33, 0x8a, 0x7d, 0xdf,
+	0xf5, 0x17, 0xaa, 0x72, 0xbb, 0x19, 0x24, 0xf5, 0xd2, 0xc7, 0x12, 0xa2, 0xf8, 0x41, 0x19, 0xa0,
+	0xaf, 0x05, 0xa5, 0xaf, 0x42, 0x8f, 0xf2, 0xe4, 0x7c, 0x12, 0xd6, 0x4e, 0x91, 0xf5, 0x9e, 0x06,
+	0x49, 0xfb, 0xcc, 0x28, 0xcf, 0xdc, 0x95, 0xef, 0xc9, 0xdc, 0xdd, 0x5f, 0x42, 0xcb, 0x0c, 0xe8,
+	0x23, 0xf4, 0x5a, 0x77, 0x0a, 0x5b, 0xf9, 0x28, 0x3f, 0x46, 0x03, 0xff, 0xbb, 0x0c, 0xdb, 0x6b,
+	0xc3, 0xe8, 0x4b, 0xa8, 0xb1, 0x44, 0x4c, 0x3c, 0x6f, 0x0d, 0xee, 0x14, 0x7a, 0xe9, 0xeb, 0xf3,
+	0x59, 0x62, 0x05, 0x0d, 0xb9, 0x2b, 0xb2, 0xa0, 0x63, 0xb2, 0xa2, 0xfa, 0x1c, 0x48, 0x15, 0xe8,
+	0xe7, 0x19, 0xc9, 0xe7, 0x56, 0x61, 0xdd, 0x69, 0x31, 0xcb, 0x67, 0x44, 0x5b, 0xc9, 0x11, 0xed,
+	0x8f, 0xa1, 0x1a, 0xb3, 0xac, 0xd3, 0x77, 0xf4, 0xf1, 0x2b, 0x57, 0xe1, 0x4c, 0x0c, 0x61, 0x89,
+	0x40, 0x2f, 0x01, 0x11, 0xcf, 0x0b, 0x66, 0x84, 0xd3, 0x79, 0xba, 0x62, 0x76, 0xed, 0xbd, 0xeb,
+	0x59, 0x60, 0xa1, 0x8f, 0xe4, 0xcd, 0xf4, 0x48, 0xfe, 0xb8, 0x47, 0xcc, 0xaf, 0xa0, 0xa6, 0x88,
+	0xbb, 0x09, 0x9b, 0x67, 0xe3, 0xd7, 0xe3, 0x93, 0x37, 0xe3, 0x4e, 0x09, 0xb5, 0xa0, 0x3e, 0x9d,
+	0x9c, 0x9c, 0x1c, 0x8d, 0xc6, 0x87, 0x1d, 0x4b, 0x4a, 0xc3, 0x37, 0x63, 0x21, 0x6d, 0x08, 0x20,
+	0x3e, 0x1b, 0x27, 0x42, 0x59, 0x0c, 0xbd, 0x1c, 0x8d, 0x47, 0xd3, 0x6f, 0x0e, 0xf6, 0x3b, 0x15,
+	0x04, 0x50, 0x7b, 0x81, 0x4f, 0x5e, 0x1f, 0x8c, 0x3b, 0x55, 0xe7, 0x1f, 0x16, 0xb4, 0x74, 0x1a,
+	0x93, 0x20, 0xf0, 0x50, 0x0f, 0xca, 0xc4, 0xd3, 0xbb, 0xee, 0xba, 0x12, 0x08, 0x08, 0x7a, 0x02,
+	0x95, 0x98, 0xd1, 0xb9, 0xda, 0x7d, 0x77, 0xf2, 0x55, 0x16, 0xbe, 0xfa, 0xe2, 0x2e, 0xa8, 0x78,
+	0x56, 0x20, 0xbb, 0x27, 0xd0, 0x48, 0x55, 0x05, 0x05, 0x79, 0x94, 0x2f, 0xc8, 0x75, 0x93, 0x1b,
+	0x75, 0xf9, 0xef, 0x06, 0xb4, 0xa7, 0xb3, 0x73, 0x3a, 0x8f, 0x3d, 0x1a, 0xed, 0x13, 0x4e, 0xd0,
+	0x11, 0xb4, 0x05, 0xa3, 0x9c, 0x06, 0xca, 0x4c, 0x1d, 0x03, 0x3f, 0x54, 0x6c, 0x67, 0x62, 0xfb,
+	0xa7, 0x26, 0x50, 0xc6, 0x99, 0x37, 0x46, 0x7d, 0xa8, 0xaf, 0x88, 0xeb, 0x8b, 0x64, 0x54, 0x50,
+	0xe8, 0x6a, 0x9a, 0x38, 0xc5, 0xa0, 0x11, 0xb4, 0xd4, 0x29, 0x27, 0x44, 0xa6, 0x9a, 0xfa, 0x7e,
+	0xd1, 0xe4, 0x43, 0x03, 0x27, 0xe7, 0xce, 0x99, 0x76, 0xbf, 0x06, 0x74, 0x35, 0xbe, 0x82, 0xa2,
+	0xdd, 0x34, 0x8b, 0xd6, 0xc8, 0x33, 0xc3, 0x8d, 0x2b, 0x93, 0x14, 0x38, 0xe8, 0xe5, 0xab, 0x5e,
+	0x94, 0xa0, 0x51, 0xf1, 0xbf, 0x94, 0xa1, 0x35, 0x25, 0x1e, 0x65, 0x2b, 0xe2, 0x27, 0x05, 0x1f,
+	0xc3, 0x96, 0x8a, 0x7b, 0xef, 0x30, 0x0a, 0xe2, 0x50, 0x9f, 0x63, 0xba, 0xe2, 0x06, 0xb6, 0x3f,
+	0xcc, 0x01, 0x65, 0xd6, 0x6b, 0xd6, 0xe8, 0x29, 0x54, 0x05, 0xbd, 0xeb, 0xda, 0xdd, 0x2d, 0x70,
+	0x23, 0x38, 0x5a, 0x59, 0x4b, 0x2c, 0xfa, 0x0a, 0x6a, 0x41, 0x34, 0xa7, 0x91, 0xa0, 0x62, 0x61,
+	0x75, 0xaf, 0xc0, 0xea, 0x24, 0x01, 0x48, 0x33, 0x85, 0xee, 0x0e, 0x61, 0xa7, 0x20, 0xa6, 0x0f,
+	0xaa, 0xf2, 0x3e, 0x40, 0x16, 0x4f, 0x81, 0xe5, 0x6e, 0xbe, 0xbc, 0xe6, 0x39, 0x66, 0x78, 0x79,
+	0x09, 0x4d, 0x23, 0xbe, 0x02, 0x37, 0x9f, 0xe6, 0xdd, 0x34, 0xa5, 0x9b, 0xc4, 0xc6, 0x5c, 0x9e,
+	0xdf, 0x27, 0x7f, 0xbb, 0x6f, 0xe3, 0x85, 0xa0, 0x0b, 0x2a, 0x89, 0xfb, 0x67, 0xd0, 0x66, 0x66,
+	0xeb, 0xd9, 0x96, 0x49, 0x8b, 0xb9, 0xae, 0xc4, 0x79, 0x24, 0xfa, 0x0a, 0x5a, 0xcc, 0xa8, 0x61,
+	0xbe, 0x45, 0xcc, 0xea, 0xe2, 0x1c, 0x6e, 0xf0, 0xd7, 0x2a, 0x74, 0xe4, 0x2f, 0xc3, 0x31, 0xf1,
+	0xc9, 0x82, 0xae, 0xa8, 0xcf, 0xd1, 0x83, 0x8c, 0xc4, 0x14, 0xd5, 0xad, 0x42, 0x7e, 0xd9, 0xbd,
+	0x91, 0xde, 0xeb, 0x35, 0xdd, 0x3b, 0x25, 0xf4, 0x08, 0x36, 0xd5, 0x05, 0x2a, 0x0f, 0x46, 0xba,
+	0x7c, 0xd9, 0xe5, 0xca, 0x29, 0xa1, 0x27, 0xd0, 0x7c, 0x19, 0x51, 0xfa, 0x01, 0x16, 0x0f, 0xa1,
+	0x2a, 0x76, 0xd7, 0x1a, 0x76, 0xa7, 0xe0, 0xb2, 0xe8, 0x94, 0x04, 0x0b, 0xe8, 0xfb, 0x6a, 0x21,
+	0x3e, 0x77, 0xeb, 0x75, 0x4a, 0xe8, 0x01, 0xb4, 0xf7, 0x22, 0x4a, 0x38, 0x55, 0x03, 0x28, 0x7f,
+	0x7d, 0xed, 0xd6, 0xa5, 0x38, 0xda, 0x77, 0x4a, 0xa8, 0x07, 0x6d, 0x4c, 0x57, 0xc1, 0x45, 0x8a,
+	0x4d, 0x07, 0xbb, 0xe6, 0x54, 0x49, 0xc8, 0xed, 0x49, 0x1c, 0x2d, 0x68, 0x71, 0x28, 0x6b, 0xe0,
+	0x9f, 0xc0, 0x8e, 0x5e, 0xd8, 0x63, 0xe2, 0xfa, 0x9c, 0xfa, 0xc4, 0x9f, 0x51, 0xb4, 0xfe, 0xcb,
+	0xbe, 0x6e, 0xf6, 0x05, 0x6c, 0x8f, 0xe9, 0xb7, 0xdc, 0x34, 0xc9, 0xcd, 0xb2, 0x6e, 0xef, 0x94,
+	0xd0, 0x00, 0x20, 0x6b, 0xb8, 0x3c, 0x3a, 0x7d, 0x52, 0xc8, 0xf5, 0xa3, 0x9c, 0x46, 0x26, 0xfd,
+	0x82, 0xfa, 0xb3, 0x73, 0xf1, 0x7a, 0xa4, 0x23, 0x1b, 0xc7, 0x2b, 0x1a, 0xb9, 0xb3, 0xab, 0xd9,
+	0x3f, 0x16, 0x57, 0xcc, 0x68, 0x91, 0x59, 0xbc, 0x3f, 0xff, 0x47, 0x50, 0x11, 0x8f, 0x43, 0x48,
+	0x35, 0x97, 0xf1, 0xde, 0xd4, 0xdd, 0x36, 0x55, 0x49, 0x3c, 0x83, 0xbf, 0x57, 0xa0, 0x26, 0xdb,
+	0x15, 0x3d, 0x86, 0xfa, 0x24, 0x66, 0xe7, 0xa2, 0x05, 0xf4, 0x04, 0x7b, 0xe7, 0xb1, 0xbf, 0xec,
+	0xaa, 0x5f, 0xe3, 0x49, 0x14, 0x2c, 0x22, 0xca, 0x98, 0x53, 0xea, 0x59, 0x4f, 0x2c, 0x34, 0x10,
+	0x70, 0x79, 0xf3, 0x45, 0x2a, 0xdd, 0xb5, 0x9b, 0x70, 0xd7, 0xf4, 0xe2, 0x94, 0x9e, 0x58, 0xe8,
+	0x39, 0x34, 0xd2, 0x9f, 0x58, 0x74, 0xfb, 0xca, 0x5f, 0xad, 0xb4, 0xba, 0x59, 0xf4, 0xb7, 0xeb,
+	0x94, 0xd0, 0x67, 0x50, 0x9f, 0xf2, 0x20, 0x4c, 0x6c, 0xaf, 0x6d, 0x95, 0xcf, 0x01, 0xb2, 0x1b,
+	0x94, 0x01, 0x2b, 0xbe, 0xf8, 0x39, 0x25, 0xf4, 0x02, 0x9a, 0xc6, 0xbf, 0x3d, 0x52, 0xf4, 0x79,
+	0xdd, 0x4f, 0xbf, 0xde, 0xb2, 0x4a, 0x2b, 0x5e, 0x0a, 0x9c, 0x12, 0x7a, 0x26, 0x9f, 0x4c, 0x8e,
+	0x82, 0x05, 0x43, 0xc6, 0x44, 0x42, 0xd6, 0x76, 0x3b, 0x79, 0x75, 0x56, 0x92, 0x3e, 0x34, 0x0f,
+	0x29, 0xd7, 0x77, 0x6f, 0x23, 0xe2, 0x9d, 0x82, 0x5b, 0xb9, 0x53, 0x42, 0xcf, 0xa1, 0xb3, 0xfe,
+	0x86, 0x64, 0x18, 0x15, 0x3f, 0x1c, 0xa5, 0xc9, 0xbe, 0x82, 0x5b, 0x85, 0x4f, 0x46, 0xc8, 0xd1,
+	0x47, 0xdf, 0xf5, 0xef, 0x49, 0x6b, 0x95, 0x7e, 0x5b, 0x4b, 0x9e, 0x34, 0x9f, 0xfe, 0x7f, 0x00,
+	0x93, 0xc6, 0x62, 0xa8, 0x6b, 0x15, 0x00, 0x00,
 }
@@ -5,6 +5,7 @@ import (
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
 	"errors"
 	"io"
 	"io/ioutil"
@@ -49,6 +50,31 @@ func (d *AESDecoderEncoder) Encode(rw http.ResponseWriter) (http.ResponseWriter,
 	return &AESResponseWriter{rw, d.cipherBlock}, nil
 }
 
+// EncodeStream wraps the response writer of a streaming method, prefixing
+// each encrypted message with its length as a 4-byte big-endian integer,
+// because clients can't tell where one message ends and the next one
+// begins otherwise.
+func (d *AESDecoderEncoder) EncodeStream(rw http.ResponseWriter) (http.ResponseWriter, error) {
+	return &AESResponseWriter{&aesFrameWriter{rw}, d.cipherBlock}, nil
+}
+
+// aesFrameWriter writes each encrypted message as a length-prefixed frame.
+type aesFrameWriter struct {
+	http.ResponseWriter
+}
+
+func (m *aesFrameWriter) Write(ciphertext []byte) (int, error) {
+	frame := make([]byte, 4+len(ciphertext))
+	binary.BigEndian.PutUint32(frame, uint32(len(ciphertext)))
+	copy(frame[4:], ciphertext)
+
+	if _, err := m.ResponseWriter.Write(frame); err != nil {
+		return 0, err
+	}
+
+	return len(ciphertext), nil
+}
+
 type AESResponseWriter struct {
 	http.ResponseWriter
 	cipherBlock cipher.Block
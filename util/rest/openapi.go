@@ -0,0 +1,230 @@
+package rest
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+const (
+	// OpenAPIPath is the path the OpenAPI 3 document describing all
+	// registered services is served at.
+	OpenAPIPath = "/openapi.json"
+)
+
+var (
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+type openAPIDocument struct {
+	OpenAPI    string                      `json:"openapi"`
+	Info       openAPIInfo                 `json:"info"`
+	Paths      map[string]*openAPIPathItem `json:"paths"`
+	Components openAPIComponents           `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIComponents struct {
+	Schemas map[string]*openAPISchema `json:"schemas"`
+}
+
+type openAPIPathItem struct {
+	Post *openAPIOperation `json:"post"`
+}
+
+type openAPIOperation struct {
+	Tags        []string                    `json:"tags"`
+	OperationID string                      `json:"operationId"`
+	Description string                      `json:"description,omitempty"`
+	RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIRequestBody struct {
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Description string                       `json:"description"`
+	Content     map[string]*openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                    `json:"$ref,omitempty"`
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Items                *openAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+	AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+}
+
+func newOpenAPIDocument() *openAPIDocument {
+	return &openAPIDocument{
+		OpenAPI: "3.0.0",
+		Info: openAPIInfo{
+			Title:   "REST API",
+			Version: "1.0.0",
+		},
+		Paths: map[string]*openAPIPathItem{},
+		Components: openAPIComponents{
+			Schemas: map[string]*openAPISchema{},
+		},
+	}
+}
+
+// addMethod describes the given method served at the specified path.
+func (m *openAPIDocument) addMethod(path string, service *Service, method *Method) {
+	operation := &openAPIOperation{
+		Tags:        []string{strings.TrimPrefix(service.fullName, "/")},
+		OperationID: strings.Replace(strings.TrimPrefix(method.fullName, "/"), "/", ".", -1),
+		Responses: map[string]*openAPIResponse{
+			"default": {
+				Description: "Error description",
+				Content:     map[string]*openAPIMediaType{"text/plain": {Schema: &openAPISchema{Type: "string"}}},
+			},
+		},
+	}
+
+	requestContent := map[string]*openAPIMediaType{}
+	if method.stream != nil && method.stream.ClientStreams {
+		requestContent[contentTypeNDJSON] = &openAPIMediaType{Schema: m.schema(method.recvType)}
+		if _, ok := bytesField(method.recvType); ok {
+			requestContent[contentTypeOctetStream] = &openAPIMediaType{Schema: &openAPISchema{Type: "string", Format: "binary"}}
+			requestContent[contentTypeMultipart] = &openAPIMediaType{Schema: &openAPISchema{
+				Type:       "object",
+				Properties: map[string]*openAPISchema{"file": {Type: "string", Format: "binary"}},
+			}}
+		}
+		operation.Description = "Client-streaming method accepting newline-delimited JSON messages or raw uploads."
+	} else {
+		requestContent[contentTypeJSON] = &openAPIMediaType{Schema: m.schema(method.messageType)}
+	}
+	operation.RequestBody = &openAPIRequestBody{Required: true, Content: requestContent}
+
+	responseContent := map[string]*openAPIMediaType{}
+	if method.stream != nil && method.stream.ServerStreams {
+		responseContent[contentTypeNDJSON] = &openAPIMediaType{Schema: m.schema(method.responseType)}
+		responseContent[contentTypeEventStream] = &openAPIMediaType{Schema: &openAPISchema{Type: "string"}}
+		if _, ok := bytesField(method.responseType); ok {
+			responseContent[contentTypeOctetStream] = &openAPIMediaType{Schema: &openAPISchema{Type: "string", Format: "binary"}}
+		}
+		if len(operation.Description) == 0 {
+			operation.Description = "Server-streaming method."
+		}
+		operation.Description += " Responses are streamed depending on \"Accept\" header."
+	} else {
+		responseContent[contentTypeJSON] = &openAPIMediaType{Schema: m.schema(method.responseType)}
+	}
+	operation.Responses["200"] = &openAPIResponse{Description: "Successful response", Content: responseContent}
+
+	m.Paths[path] = &openAPIPathItem{Post: operation}
+}
+
+// schema returns the schema of values of the given type as they are
+// marshaled by "encoding/json". Structures are placed into components and
+// referenced.
+func (m *openAPIDocument) schema(valueType reflect.Type) *openAPISchema {
+	if valueType.Implements(textMarshalerType) || reflect.PtrTo(valueType).Implements(textMarshalerType) {
+		return &openAPISchema{Type: "string"}
+	}
+	if valueType.Implements(jsonMarshalerType) || reflect.PtrTo(valueType).Implements(jsonMarshalerType) {
+		return &openAPISchema{}
+	}
+
+	switch valueType.Kind() {
+	case reflect.Ptr:
+		return m.schema(valueType.Elem())
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if valueType.Elem().Kind() == reflect.Uint8 {
+			return &openAPISchema{Type: "string", Format: "byte"}
+		}
+		return &openAPISchema{Type: "array", Items: m.schema(valueType.Elem())}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: m.schema(valueType.Elem())}
+	case reflect.Struct:
+		return m.structSchema(valueType)
+	default:
+		// Interfaces, i.e. "oneof" fields.
+		return &openAPISchema{Type: "object"}
+	}
+}
+
+func (m *openAPIDocument) structSchema(valueType reflect.Type) *openAPISchema {
+	name := valueType.Name()
+	if len(name) == 0 {
+		return &openAPISchema{Type: "object"}
+	}
+
+	ref := &openAPISchema{Ref: "#/components/schemas/" + name}
+	if _, ok := m.Components.Schemas[name]; ok {
+		return ref
+	}
+
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+	// Register before visiting fields to stop on recursive types.
+	m.Components.Schemas[name] = schema
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if len(field.PkgPath) != 0 || strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+
+		fieldName := field.Name
+		if tag, ok := field.Tag.Lookup("json"); ok {
+			tagName := strings.Split(tag, ",")[0]
+			if tagName == "-" {
+				continue
+			}
+			if len(tagName) != 0 {
+				fieldName = tagName
+			}
+		}
+
+		schema.Properties[fieldName] = m.schema(field.Type)
+	}
+
+	return ref
+}
+
+func (s *Server) serveOpenAPI(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := json.Marshal(s.spec)
+	if err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+		rw.Write([]byte(err.Error()))
+		return
+	}
+
+	rw.Header().Set("Content-Type", contentTypeJSON)
+	rw.WriteHeader(http.StatusOK)
+	rw.Write(data)
+}
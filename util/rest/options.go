@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"context"
+	"crypto/tls"
 	"io"
 	"net/http"
 
@@ -10,10 +12,14 @@ import (
 
 // options for building rest-server instance
 type options struct {
-	decoder     Decoder
-	encoder     Encoder
-	interceptor grpc.UnaryServerInterceptor
-	log         *zap.Logger
+	decoder           Decoder
+	encoder           Encoder
+	interceptor       grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+	streamLookup      StreamLookup
+	authenticator     Authenticator
+	tlsConfig         *tls.Config
+	log               *zap.Logger
 }
 
 func defaultOptions() *options {
@@ -47,6 +53,18 @@ type Encoder interface {
 	Encode(rw http.ResponseWriter) (http.ResponseWriter, error)
 }
 
+// StreamEncoder is an optional interface of encoders that require special
+// framing for responses of streaming methods, which consist of multiple
+// messages written one by one.
+type StreamEncoder interface {
+	EncodeStream(rw http.ResponseWriter) (http.ResponseWriter, error)
+}
+
+// Authenticator authenticates HTTP requests, returning the context that is
+// passed to the interceptor and handlers. Requests are rejected when an
+// error is returned.
+type Authenticator func(request *http.Request) (context.Context, error)
+
 func WithLog(log *zap.Logger) Option {
 	return func(o *options) {
 		o.log = log
@@ -65,6 +83,41 @@ func WithEncoder(e Encoder) Option {
 	}
 }
 
+// StreamLookup returns the description of the given fully-qualified
+// streaming method.
+type StreamLookup func(fullMethod string) (grpc.StreamDesc, bool)
+
+// WithStreamLookup enables streaming methods registration. Streaming
+// methods, which descriptions can not be found, are skipped.
+func WithStreamLookup(lookup StreamLookup) Option {
+	return func(o *options) {
+		o.streamLookup = lookup
+	}
+}
+
+// WithStreamInterceptor specifies the interceptor for streaming methods.
+func WithStreamInterceptor(interceptor grpc.StreamServerInterceptor) Option {
+	return func(o *options) {
+		o.streamInterceptor = interceptor
+	}
+}
+
+// WithAuthenticator specifies the authenticator that is called for each
+// request before decoding its body.
+func WithAuthenticator(authenticator Authenticator) Option {
+	return func(o *options) {
+		o.authenticator = authenticator
+	}
+}
+
+// WithTLS makes the server accept TLS connections only, using the given
+// config.
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
 func WithInterceptor(interceptor grpc.UnaryServerInterceptor) Option {
 	return func(o *options) {
 		o.interceptor = interceptor
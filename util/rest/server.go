@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,6 +15,7 @@ import (
 	"golang.org/x/net/context"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type Method struct {
@@ -21,6 +23,10 @@ type Method struct {
 	responseType reflect.Type
 	methodValue  reflect.Value
 	fullName     string
+	// Stream describes streaming methods and is nil for unary ones.
+	stream *grpc.StreamDesc
+	// RecvType is the type of messages received by client-streaming methods.
+	recvType reflect.Type
 }
 
 type Service struct {
@@ -30,12 +36,17 @@ type Service struct {
 }
 
 type Server struct {
-	servers     []*http.Server
-	services    map[string]*Service
-	decoder     Decoder
-	encoder     Encoder
-	interceptor grpc.UnaryServerInterceptor
-	log         *zap.SugaredLogger
+	servers           []*http.Server
+	services          map[string]*Service
+	decoder           Decoder
+	encoder           Encoder
+	interceptor       grpc.UnaryServerInterceptor
+	streamInterceptor grpc.StreamServerInterceptor
+	streamLookup      StreamLookup
+	authenticator     Authenticator
+	tlsConfig         *tls.Config
+	spec              *openAPIDocument
+	log               *zap.SugaredLogger
 }
 
 func NewServer(opts ...Option) *Server {
@@ -44,11 +55,16 @@ func NewServer(opts ...Option) *Server {
 		opt(o)
 	}
 	return &Server{
-		log:         o.log.Sugar(),
-		services:    map[string]*Service{},
-		decoder:     o.decoder,
-		encoder:     o.encoder,
-		interceptor: o.interceptor,
+		log:               o.log.Sugar(),
+		services:          map[string]*Service{},
+		decoder:           o.decoder,
+		encoder:           o.encoder,
+		interceptor:       o.interceptor,
+		streamInterceptor: o.streamInterceptor,
+		streamLookup:      o.streamLookup,
+		authenticator:     o.authenticator,
+		tlsConfig:         o.tlsConfig,
+		spec:              newOpenAPIDocument(),
 	}
 }
 
@@ -86,17 +102,27 @@ func (s *Server) RegisterService(interfacePtr, concretePtr interface{}) error {
 	}
 	for i := 0; i < iface.NumMethod(); i++ {
 		method := iface.Method(i)
+		fullName := fullServiceName + "/" + method.Name
+		path := "/" + serviceName + "/" + method.Name
 
-		//TODO: handle streaming
-		if method.Type.NumIn() != 2 {
-			s.log.Debugf("skipping streaming for method %s.%s", serviceName, method.Name)
+		if isStreamingMethod(method.Type) {
+			m, err := s.newStreamMethod(method, concrete, fullName)
+			if err != nil {
+				return fmt.Errorf("could not register method %s for service %s: %v", method.Name, serviceName, err)
+			}
+
+			if m == nil {
+				s.log.Debugf("skipping streaming for method %s.%s - no stream description found", serviceName, method.Name)
+				continue
+			}
+
+			service.methods[method.Name] = m
+			s.spec.addMethod(path, service, m)
 			continue
 		}
-		messageType := method.Type.In(1)
-		sstreamType := reflect.TypeOf((*grpc.ServerStream)(nil)).Elem()
-		if messageType.Implements(sstreamType) {
-			s.log.Debugf("skipping streaming for method %s.%s", serviceName, method.Name)
-			continue
+
+		if method.Type.NumIn() != 2 {
+			return fmt.Errorf("could not register method %s for service %s - invalid number of arguments", method.Name, serviceName)
 		}
 		if method.Type.NumOut() != 2 {
 			return fmt.Errorf("could not register method %s for service %s - invalid number of returned arguments", method.Name, serviceName)
@@ -105,13 +131,19 @@ func (s *Server) RegisterService(interfacePtr, concretePtr interface{}) error {
 			messageType:  method.Type.In(1),
 			responseType: method.Type.Out(0),
 			methodValue:  concrete.MethodByName(method.Name),
-			fullName:     fullServiceName + "/" + method.Name,
+			fullName:     fullName,
 		}
+		s.spec.addMethod(path, service, service.methods[method.Name])
 	}
 	return nil
 }
 
 func (s *Server) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == OpenAPIPath {
+		s.serveOpenAPI(rw, r)
+		return
+	}
+
 	parts := strings.Split(r.RequestURI, "/")
 	s.log.Debugf("serving URI: %s", r.RequestURI)
 	if len(parts) < 3 {
@@ -143,6 +175,27 @@ func (s *Server) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ctx := r.Context()
+	if s.authenticator != nil {
+		authCtx, err := s.authenticator(r)
+		if err != nil {
+			s.log.Warnf("could not authenticate request: %s", err)
+			rw.WriteHeader(http.StatusUnauthorized)
+			rw.Write([]byte(fmt.Sprintf("could not authenticate request: %s", err)))
+			return
+		}
+		ctx = authCtx
+	}
+
+	if md := headerMetadata(r.Header); len(md) != 0 {
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	if method.stream != nil {
+		s.serveStream(ctx, rw, r, service, method)
+		return
+	}
+
 	decodedReader, err := s.decoder.DecodeBody(r)
 	if err != nil {
 		s.log.Errorf("could not decode body: %s", err)
@@ -199,9 +252,9 @@ func (s *Server) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
 			Server:     service.service,
 			FullMethod: method.fullName,
 		}
-		result, err = s.interceptor(r.Context(), reflect.Indirect(requestValue).Interface(), info, grpc.UnaryHandler(h))
+		result, err = s.interceptor(ctx, reflect.Indirect(requestValue).Interface(), info, grpc.UnaryHandler(h))
 	} else {
-		result, err = h(r.Context(), reflect.Indirect(requestValue).Interface())
+		result, err = h(ctx, reflect.Indirect(requestValue).Interface())
 	}
 
 	if err != nil {
@@ -223,6 +276,9 @@ func (s *Server) Serve(listeners ...net.Listener) error {
 	group := errgroup.Group{}
 	for _, lis := range listeners {
 		l := lis
+		if s.tlsConfig != nil {
+			l = tls.NewListener(l, s.tlsConfig)
+		}
 		srv := http.Server{}
 		srv.Handler = s
 		group.Go(func() error {
@@ -0,0 +1,413 @@
+package rest
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/sonm-io/core/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type testMessage struct {
+	Text string `json:"text"`
+	Size int64  `json:"size,omitempty"`
+}
+
+type testChunk struct {
+	Chunk []byte `json:"chunk"`
+}
+
+type testServer interface {
+	Echo(context.Context, *testMessage) (*testMessage, error)
+	Repeat(*testMessage, testRepeatServer) error
+	Upload(testUploadServer) error
+}
+
+type testRepeatServer interface {
+	Send(*testMessage) error
+	grpc.ServerStream
+}
+
+type testUploadServer interface {
+	Send(*testMessage) error
+	Recv() (*testChunk, error)
+	grpc.ServerStream
+}
+
+type testRepeatStream struct {
+	grpc.ServerStream
+}
+
+func (m *testRepeatStream) Send(msg *testMessage) error {
+	return m.SendMsg(msg)
+}
+
+type testUploadStream struct {
+	grpc.ServerStream
+}
+
+func (m *testUploadStream) Send(msg *testMessage) error {
+	return m.SendMsg(msg)
+}
+
+func (m *testUploadStream) Recv() (*testChunk, error) {
+	msg := &testChunk{}
+	if err := m.RecvMsg(msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+var testStreams = map[string]grpc.StreamDesc{
+	"/rest.test/Repeat": {
+		StreamName:    "Repeat",
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			msg := &testMessage{}
+			if err := stream.RecvMsg(msg); err != nil {
+				return err
+			}
+
+			return srv.(testServer).Repeat(msg, &testRepeatStream{stream})
+		},
+	},
+	"/rest.test/Upload": {
+		StreamName:    "Upload",
+		ServerStreams: true,
+		ClientStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			return srv.(testServer).Upload(&testUploadStream{stream})
+		},
+	},
+}
+
+func testStreamLookup(fullMethod string) (grpc.StreamDesc, bool) {
+	desc, ok := testStreams[fullMethod]
+	return desc, ok
+}
+
+type testService struct{}
+
+func (testService) Echo(ctx context.Context, msg *testMessage) (*testMessage, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return &testMessage{Text: strings.Join(append([]string{msg.Text}, md["greeting"]...), " ")}, nil
+}
+
+func (testService) Repeat(msg *testMessage, stream testRepeatServer) error {
+	if msg.Text == "fail" {
+		return status.Error(codes.InvalidArgument, "failed")
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (testService) Upload(stream testUploadServer) error {
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			stream.SetTrailer(metadata.Pairs("id", "42"))
+			return nil
+		}
+
+		if err := stream.Send(&testMessage{Size: int64(len(chunk.Chunk))}); err != nil {
+			return err
+		}
+	}
+}
+
+func newTestServer(t *testing.T, options ...Option) *httptest.Server {
+	server := NewServer(append([]Option{WithStreamLookup(testStreamLookup)}, options...)...)
+	require.NoError(t, server.RegisterService((*testServer)(nil), testService{}))
+
+	return httptest.NewServer(server)
+}
+
+func post(t *testing.T, url string, body []byte, header http.Header) *http.Response {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	require.NoError(t, err)
+	for key, values := range header {
+		request.Header[key] = values
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+
+	return response
+}
+
+func readLines(t *testing.T, response *http.Response) []string {
+	defer response.Body.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	require.NoError(t, scanner.Err())
+
+	return lines
+}
+
+func TestServerUnary(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	response := post(t, server.URL+"/testServer/Echo", []byte(`{"text":"hello"}`), http.Header{"Grpc-Metadata-Greeting": {"world"}})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	assert.Equal(t, []string{`{"text":"hello world"}`}, readLines(t, response))
+}
+
+func TestServerServerStreaming(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	response := post(t, server.URL+"/testServer/Repeat", []byte(`{"text":"hello"}`), nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, contentTypeNDJSON, response.Header.Get("Content-Type"))
+	assert.Equal(t, []string{`{"text":"hello"}`, `{"text":"hello"}`, `{"text":"hello"}`}, readLines(t, response))
+
+	response = post(t, server.URL+"/testServer/Repeat", []byte(`{"text":"hello"}`), http.Header{"Accept": {contentTypeEventStream}})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, contentTypeEventStream, response.Header.Get("Content-Type"))
+	assert.Equal(t, []string{
+		`data: {"text":"hello"}`, "",
+		`data: {"text":"hello"}`, "",
+		`data: {"text":"hello"}`, "",
+	}, readLines(t, response))
+}
+
+func TestServerServerStreamingError(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	response := post(t, server.URL+"/testServer/Repeat", []byte(`{"text":"fail"}`), nil)
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestServerClientStreaming(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	body := make([]byte, uploadChunkSize+42)
+	response := post(t, server.URL+"/testServer/Upload", body, http.Header{"Content-Type": {contentTypeOctetStream}})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	assert.Equal(t, []string{`{"text":"","size":1048576}`, `{"text":"","size":42}`}, readLines(t, response))
+	assert.Equal(t, "42", response.Trailer.Get(MetadataHeaderPrefix+"Id"))
+}
+
+func TestServerClientStreamingMultipart(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	buf := &bytes.Buffer{}
+	writer := multipart.NewWriter(buf)
+	part, err := writer.CreateFormFile("file", "image.tar")
+	require.NoError(t, err)
+	_, err = part.Write([]byte("content"))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	response := post(t, server.URL+"/testServer/Upload", buf.Bytes(), http.Header{"Content-Type": {writer.FormDataContentType()}})
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	assert.Equal(t, []string{`{"text":"","size":7}`}, readLines(t, response))
+}
+
+func TestServerOpenAPI(t *testing.T) {
+	server := newTestServer(t)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + OpenAPIPath)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+
+	doc := &openAPIDocument{}
+	require.NoError(t, json.Unmarshal(body, doc))
+
+	require.Contains(t, doc.Paths, "/testServer/Echo")
+	require.Contains(t, doc.Paths, "/testServer/Repeat")
+	require.Contains(t, doc.Paths, "/testServer/Upload")
+
+	upload := doc.Paths["/testServer/Upload"].Post
+	assert.Contains(t, upload.RequestBody.Content, contentTypeOctetStream)
+	assert.Contains(t, upload.Responses["200"].Content, contentTypeNDJSON)
+
+	require.Contains(t, doc.Components.Schemas, "testMessage")
+	message := doc.Components.Schemas["testMessage"]
+	assert.Equal(t, "string", message.Properties["text"].Type)
+	assert.Equal(t, "int64", message.Properties["size"].Format)
+	assert.Equal(t, "byte", doc.Components.Schemas["testChunk"].Properties["chunk"].Format)
+}
+
+var testAESKey = []byte("0123456789abcdef")
+
+func newTestAESServer(t *testing.T) *httptest.Server {
+	codec, err := NewAESDecoderEncoder(testAESKey)
+	require.NoError(t, err)
+
+	return newTestServer(t, WithDecoder(codec), WithEncoder(codec))
+}
+
+func aesEncrypt(t *testing.T, plaintext []byte) []byte {
+	block, err := aes.NewCipher(testAESKey)
+	require.NoError(t, err)
+
+	ciphertext := make([]byte, aes.BlockSize+len(plaintext))
+	_, err = io.ReadFull(rand.Reader, ciphertext[:aes.BlockSize])
+	require.NoError(t, err)
+
+	cipher.NewCFBEncrypter(block, ciphertext[:aes.BlockSize]).XORKeyStream(ciphertext[aes.BlockSize:], plaintext)
+	return ciphertext
+}
+
+func aesDecrypt(t *testing.T, ciphertext []byte) []byte {
+	block, err := aes.NewCipher(testAESKey)
+	require.NoError(t, err)
+	require.True(t, len(ciphertext) >= aes.BlockSize)
+
+	plaintext := make([]byte, len(ciphertext)-aes.BlockSize)
+	cipher.NewCFBDecrypter(block, ciphertext[:aes.BlockSize]).XORKeyStream(plaintext, ciphertext[aes.BlockSize:])
+	return plaintext
+}
+
+// readAESFrames reads length-prefixed encrypted messages of a streaming
+// response.
+func readAESFrames(t *testing.T, response *http.Response) []string {
+	defer response.Body.Close()
+
+	var messages []string
+	for {
+		var size uint32
+		err := binary.Read(response.Body, binary.BigEndian, &size)
+		if err == io.EOF {
+			return messages
+		}
+		require.NoError(t, err)
+
+		frame := make([]byte, size)
+		_, err = io.ReadFull(response.Body, frame)
+		require.NoError(t, err)
+
+		messages = append(messages, string(aesDecrypt(t, frame)))
+	}
+}
+
+func TestServerAESUnary(t *testing.T) {
+	server := newTestAESServer(t)
+	defer server.Close()
+
+	response := post(t, server.URL+"/testServer/Echo", aesEncrypt(t, []byte(`{"text":"hello"}`)), nil)
+	defer response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	body, err := ioutil.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, `{"text":"hello"}`, string(aesDecrypt(t, body)))
+}
+
+func TestServerAESServerStreaming(t *testing.T) {
+	server := newTestAESServer(t)
+	defer server.Close()
+
+	response := post(t, server.URL+"/testServer/Repeat", aesEncrypt(t, []byte(`{"text":"hello"}`)), nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	assert.Equal(t, []string{"{\"text\":\"hello\"}\n", "{\"text\":\"hello\"}\n", "{\"text\":\"hello\"}\n"}, readAESFrames(t, response))
+}
+
+func TestServerAESClientStreaming(t *testing.T) {
+	server := newTestAESServer(t)
+	defer server.Close()
+
+	body := aesEncrypt(t, []byte(`{"chunk":"Y29udGVudA=="}`+"\n"+`{"chunk":"AA=="}`))
+	response := post(t, server.URL+"/testServer/Upload", body, nil)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	// Responses of client-streaming methods are buffered, thus sent as a
+	// single frame.
+	assert.Equal(t, []string{"{\"text\":\"\",\"size\":7}\n{\"text\":\"\",\"size\":1}\n"}, readAESFrames(t, response))
+}
+
+func TestServerAESRejectsRawUploads(t *testing.T) {
+	server := newTestAESServer(t)
+	defer server.Close()
+
+	response := post(t, server.URL+"/testServer/Upload", aesEncrypt(t, []byte("content")), http.Header{"Content-Type": {contentTypeOctetStream}})
+	defer response.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+}
+
+func TestServerTLS(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, tlsConfig, err := util.NewHitlessCertRotator(ctx, key)
+	require.NoError(t, err)
+	tlsConfig = tlsConfig.Clone()
+	tlsConfig.ClientAuth = tls.NoClientCert
+
+	server := NewServer(WithTLS(tlsConfig))
+	require.NoError(t, server.RegisterService((*testServer)(nil), testService{}))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go server.Serve(listener)
+	defer server.Close()
+
+	url := "://" + listener.Addr().String() + "/testServer/Echo"
+
+	// Plaintext requests are not served.
+	response, err := http.Post("http"+url, "application/json", strings.NewReader(`{"text":"hello"}`))
+	if err == nil {
+		response.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, response.StatusCode)
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}}
+	response, err = client.Post("https"+url, "application/json", strings.NewReader(`{"text":"hello"}`))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	assert.Equal(t, []string{`{"text":"hello"}`}, readLines(t, response))
+}
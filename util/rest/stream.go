@@ -0,0 +1,483 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// MetadataHeaderPrefix is the prefix of HTTP headers that are mapped
+	// into gRPC metadata and vice versa, i.e. "Grpc-Metadata-Deal: 42" is
+	// available for handlers as "deal" metadata key.
+	MetadataHeaderPrefix = "Grpc-Metadata-"
+
+	contentTypeJSON        = "application/json"
+	contentTypeNDJSON      = "application/x-ndjson"
+	contentTypeEventStream = "text/event-stream"
+	contentTypeOctetStream = "application/octet-stream"
+	contentTypeMultipart   = "multipart/form-data"
+
+	// uploadChunkSize is the maximum size of messages constructed from raw
+	// uploads.
+	uploadChunkSize = 1 << 20
+)
+
+var (
+	serverStreamType = reflect.TypeOf((*grpc.ServerStream)(nil)).Elem()
+)
+
+// headerMetadata extracts gRPC metadata from HTTP headers.
+func headerMetadata(header http.Header) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range header {
+		if !strings.HasPrefix(key, MetadataHeaderPrefix) {
+			continue
+		}
+
+		name := strings.ToLower(strings.TrimPrefix(key, MetadataHeaderPrefix))
+		md[name] = append(md[name], values...)
+	}
+
+	return md
+}
+
+// isStreamingMethod checks whether the given interface method type is a
+// gRPC streaming one, i.e. its last argument is a stream.
+func isStreamingMethod(methodType reflect.Type) bool {
+	return methodType.NumIn() > 0 && methodType.In(methodType.NumIn()-1).Implements(serverStreamType)
+}
+
+// newStreamMethod constructs the streaming method. Nil is returned when
+// there is no stream description for the method.
+func (s *Server) newStreamMethod(method reflect.Method, concrete reflect.Value, fullName string) (*Method, error) {
+	if s.streamLookup == nil {
+		return nil, nil
+	}
+
+	desc, ok := s.streamLookup(fullName)
+	if !ok {
+		return nil, nil
+	}
+
+	if method.Type.NumOut() != 1 {
+		return nil, fmt.Errorf("invalid number of returned arguments")
+	}
+
+	streamType := method.Type.In(method.Type.NumIn() - 1)
+	send, ok := streamType.MethodByName("Send")
+	if !ok || send.Type.NumIn() != 1 {
+		return nil, fmt.Errorf("stream %s has no valid Send method", streamType)
+	}
+
+	m := &Method{
+		responseType: send.Type.In(0),
+		methodValue:  concrete.MethodByName(method.Name),
+		fullName:     fullName,
+		stream:       &desc,
+	}
+
+	if desc.ClientStreams {
+		recv, ok := streamType.MethodByName("Recv")
+		if !ok || recv.Type.NumOut() != 2 {
+			return nil, fmt.Errorf("stream %s has no valid Recv method", streamType)
+		}
+
+		m.recvType = recv.Type.Out(0)
+	} else {
+		if method.Type.NumIn() != 2 {
+			return nil, fmt.Errorf("invalid number of arguments")
+		}
+
+		m.messageType = method.Type.In(0)
+	}
+
+	return m, nil
+}
+
+// bytesField returns the index of the only exported field of the given
+// message type if it is a byte slice, which allows to transfer such
+// messages as raw bytes.
+func bytesField(messageType reflect.Type) (int, bool) {
+	for messageType.Kind() == reflect.Ptr {
+		messageType = messageType.Elem()
+	}
+
+	if messageType.Kind() != reflect.Struct {
+		return 0, false
+	}
+
+	index := -1
+	for i := 0; i < messageType.NumField(); i++ {
+		field := messageType.Field(i)
+		if len(field.PkgPath) != 0 || strings.HasPrefix(field.Name, "XXX_") {
+			continue
+		}
+
+		if index >= 0 || field.Type.Kind() != reflect.Slice || field.Type.Elem().Kind() != reflect.Uint8 {
+			return 0, false
+		}
+
+		index = i
+	}
+
+	return index, index >= 0
+}
+
+func mediaType(value string) string {
+	mediaType, _, err := mime.ParseMediaType(value)
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
+
+// responseFormat selects the streaming response format using "Accept"
+// header.
+func responseFormat(r *http.Request, method *Method) string {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		switch mediaType(strings.TrimSpace(accept)) {
+		case contentTypeEventStream:
+			return contentTypeEventStream
+		case contentTypeOctetStream:
+			if _, ok := bytesField(method.responseType); ok {
+				return contentTypeOctetStream
+			}
+		}
+	}
+
+	return contentTypeNDJSON
+}
+
+func (s *Server) serveStream(ctx context.Context, rw http.ResponseWriter, r *http.Request, service *Service, method *Method) {
+	reader, err := s.decoder.DecodeBody(r)
+	if err != nil {
+		s.log.Errorf("could not decode body: %s", err)
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(fmt.Sprintf("could not decode body: %s", err)))
+		return
+	}
+
+	flusher, _ := rw.(http.Flusher)
+
+	var encodedWriter http.ResponseWriter
+	if encoder, ok := s.encoder.(StreamEncoder); ok {
+		encodedWriter, err = encoder.EncodeStream(rw)
+	} else {
+		encodedWriter, err = s.encoder.Encode(rw)
+	}
+	if err != nil {
+		s.log.Errorf("could not encode response writer: %s", err)
+		return
+	}
+
+	// Raw bytes can't be validated, unlike JSON messages, thus they are
+	// accepted only as is, because for example decrypting with a wrong key
+	// would silently produce garbage.
+	_, rawAllowed := s.decoder.(*nilDecoder)
+
+	stream, err := newHTTPStream(ctx, encodedWriter, flusher, r, reader, method, rawAllowed)
+	if err != nil {
+		s.log.Warnf("could not start stream: %s", err)
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(err.Error()))
+		return
+	}
+
+	if s.streamInterceptor != nil {
+		info := &grpc.StreamServerInfo{
+			FullMethod:     method.fullName,
+			IsClientStream: method.stream.ClientStreams,
+			IsServerStream: method.stream.ServerStreams,
+		}
+		err = s.streamInterceptor(service.service, stream, info, method.stream.Handler)
+	} else {
+		err = method.stream.Handler(service.service, stream)
+	}
+
+	if err := stream.finish(err); err != nil {
+		s.log.Warnf("could not finish stream for method %s: %s", method.fullName, err)
+	}
+}
+
+// httpStream implements "grpc.ServerStream" over a single HTTP request.
+//
+// Requests of client-streaming methods are either newline-delimited JSON
+// messages, or raw bytes (including multipart uploads) for messages having
+// the only bytes field, like "Chunk". Responses are newline-delimited JSON,
+// server-sent events or raw bytes depending on "Accept" header.
+//
+// Since HTTP/1.x does not allow to read requests after the response has
+// been started, responses of client-streaming methods are buffered until
+// the request is read completely.
+//
+// Raw uploads are accepted only if the request body is not encoded, because
+// decoded raw bytes can't be validated. Encoders may frame the response
+// messages by implementing StreamEncoder.
+type httpStream struct {
+	ctx     context.Context
+	rw      http.ResponseWriter
+	flusher http.Flusher
+	method  *Method
+	format  string
+
+	recvDone bool
+	jsonDec  *json.Decoder
+	rawBody  io.Reader
+	rawField int
+
+	header     metadata.MD
+	trailer    metadata.MD
+	headerSent bool
+	pending    bytes.Buffer
+}
+
+func newHTTPStream(ctx context.Context, rw http.ResponseWriter, flusher http.Flusher, r *http.Request, body io.Reader, method *Method, rawAllowed bool) (*httpStream, error) {
+	m := &httpStream{
+		ctx:     ctx,
+		rw:      rw,
+		flusher: flusher,
+		method:  method,
+		format:  responseFormat(r, method),
+		jsonDec: json.NewDecoder(body),
+		header:  metadata.MD{},
+		trailer: metadata.MD{},
+	}
+
+	if !method.stream.ClientStreams {
+		return m, nil
+	}
+
+	contentType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		contentType = ""
+	}
+
+	switch contentType {
+	case contentTypeOctetStream, contentTypeMultipart:
+		if !rawAllowed {
+			return nil, fmt.Errorf("raw uploads are not supported with encoded bodies, send newline-delimited JSON messages instead")
+		}
+
+		field, ok := bytesField(method.recvType)
+		if !ok {
+			return nil, fmt.Errorf("method %s does not accept raw uploads", method.fullName)
+		}
+
+		m.rawField = field
+		m.rawBody = body
+	}
+
+	if contentType == contentTypeMultipart {
+		part, err := multipart.NewReader(body, params["boundary"]).NextPart()
+		if err != nil {
+			return nil, fmt.Errorf("could not read multipart body: %s", err)
+		}
+
+		m.rawBody = part
+	}
+
+	return m, nil
+}
+
+func (m *httpStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *httpStream) SetHeader(md metadata.MD) error {
+	if m.headerSent {
+		return fmt.Errorf("header has been already sent")
+	}
+
+	m.header = metadata.Join(m.header, md)
+	return nil
+}
+
+func (m *httpStream) SendHeader(md metadata.MD) error {
+	if err := m.SetHeader(md); err != nil {
+		return err
+	}
+
+	if !m.method.stream.ClientStreams || m.recvDone {
+		m.writeHeader(http.StatusOK)
+	}
+
+	return nil
+}
+
+func (m *httpStream) SetTrailer(md metadata.MD) {
+	m.trailer = metadata.Join(m.trailer, md)
+}
+
+func (m *httpStream) SendMsg(msg interface{}) error {
+	data, err := m.marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	if m.method.stream.ClientStreams && !m.recvDone {
+		m.pending.Write(data)
+		return nil
+	}
+
+	return m.write(data)
+}
+
+func (m *httpStream) RecvMsg(msg interface{}) error {
+	if m.recvDone {
+		return io.EOF
+	}
+
+	if !m.method.stream.ClientStreams {
+		m.recvDone = true
+		if err := m.jsonDec.Decode(msg); err != nil {
+			if err == io.EOF {
+				return status.Error(codes.InvalidArgument, "missing required body")
+			}
+			return status.Errorf(codes.InvalidArgument, "could not unmarshal body: %s", err)
+		}
+
+		return nil
+	}
+
+	err := m.recv(msg)
+	if err == io.EOF {
+		m.recvDone = true
+		if err := m.write(m.pending.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return err
+}
+
+func (m *httpStream) recv(msg interface{}) error {
+	if m.rawBody == nil {
+		if err := m.jsonDec.Decode(msg); err != nil {
+			if err == io.EOF {
+				return err
+			}
+			return status.Errorf(codes.InvalidArgument, "could not unmarshal message: %s", err)
+		}
+
+		return nil
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	n, err := io.ReadFull(m.rawBody, buf)
+	switch err {
+	case nil, io.ErrUnexpectedEOF:
+	case io.EOF:
+		return io.EOF
+	default:
+		return status.Errorf(codes.InvalidArgument, "could not read body: %s", err)
+	}
+
+	reflect.Indirect(reflect.ValueOf(msg)).Field(m.rawField).SetBytes(buf[:n])
+	return nil
+}
+
+func (m *httpStream) marshal(msg interface{}) ([]byte, error) {
+	if m.format == contentTypeOctetStream {
+		field, _ := bytesField(m.method.responseType)
+		return reflect.Indirect(reflect.ValueOf(msg)).Field(field).Bytes(), nil
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.format == contentTypeEventStream {
+		return []byte(fmt.Sprintf("data: %s\n\n", data)), nil
+	}
+
+	return append(data, '\n'), nil
+}
+
+func (m *httpStream) writeHeader(code int) {
+	if m.headerSent {
+		return
+	}
+
+	m.headerSent = true
+	for key, values := range m.header {
+		for _, value := range values {
+			m.rw.Header().Add(MetadataHeaderPrefix+key, value)
+		}
+	}
+
+	if code == http.StatusOK {
+		m.rw.Header().Set("Content-Type", m.format)
+	}
+	m.rw.WriteHeader(code)
+}
+
+func (m *httpStream) write(data []byte) error {
+	m.writeHeader(http.StatusOK)
+	if len(data) == 0 {
+		return nil
+	}
+
+	if _, err := m.rw.Write(data); err != nil {
+		return err
+	}
+
+	if m.flusher != nil {
+		m.flusher.Flush()
+	}
+
+	return nil
+}
+
+// finish completes the response after the handler returns. Errors are
+// reported using HTTP status codes if nothing has been sent yet, otherwise
+// using "Grpc-Status" and "Grpc-Message" trailers and an "error" event for
+// server-sent events.
+func (m *httpStream) finish(err error) error {
+	if !m.headerSent {
+		m.header = metadata.Join(m.header, m.trailer)
+
+		if err != nil {
+			m.writeHeader(HTTPStatusFromError(err))
+			_, err = m.rw.Write([]byte(err.Error()))
+			return err
+		}
+
+		m.recvDone = true
+		return m.write(m.pending.Bytes())
+	}
+
+	for key, values := range m.trailer {
+		for _, value := range values {
+			m.rw.Header().Add(http.TrailerPrefix+MetadataHeaderPrefix+key, value)
+		}
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	st := status.Convert(err)
+	m.rw.Header().Set(http.TrailerPrefix+"Grpc-Status", fmt.Sprintf("%d", st.Code()))
+	m.rw.Header().Set(http.TrailerPrefix+"Grpc-Message", st.Message())
+
+	if m.format == contentTypeEventStream {
+		return m.write([]byte(fmt.Sprintf("event: error\ndata: %s\n\n", strings.Replace(st.Message(), "\n", " ", -1))))
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package util
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/ed25519"
+	"golang.org/x/crypto/ssh"
+)
+
+// NewSSHHostSigner derives SSH host key from the given Ethereum private key,
+// because secp256k1 keys are not supported by SSH.
+//
+// The derived key is the same for the same Ethereum key, allowing SSH
+// clients to remember it.
+func NewSSHHostSigner(ethPriv *ecdsa.PrivateKey) (ssh.Signer, error) {
+	seed := sha256.Sum256(append([]byte("sonm-ssh-host-key:"), ethcrypto.FromECDSA(ethPriv)...))
+
+	_, key, err := ed25519.GenerateKey(bytes.NewReader(seed[:]))
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.NewSignerFromKey(key)
+}
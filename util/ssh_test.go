@@ -0,0 +1,27 @@
+package util
+
+import (
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSSHHostSignerDeterministic(t *testing.T) {
+	key, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := ethcrypto.GenerateKey()
+	require.NoError(t, err)
+
+	signer, err := NewSSHHostSigner(key)
+	require.NoError(t, err)
+	sameSigner, err := NewSSHHostSigner(key)
+	require.NoError(t, err)
+	otherSigner, err := NewSSHHostSigner(otherKey)
+	require.NoError(t, err)
+
+	assert.Equal(t, "ssh-ed25519", signer.PublicKey().Type())
+	assert.Equal(t, signer.PublicKey().Marshal(), sameSigner.PublicKey().Marshal())
+	assert.NotEqual(t, signer.PublicKey().Marshal(), otherSigner.PublicKey().Marshal())
+}
@@ -168,7 +168,10 @@ func hex(v interface{}) string {
 	return fmt.Sprintf("%x", v)
 }
 
-func authUnaryInterceptor(router *auth.AuthRouter) grpc.UnaryServerInterceptor {
+// AuthUnaryServerInterceptor returns an interceptor authorizing unary calls
+// using the given router, which is useful for servers that have no
+// interceptors chain built in, like the REST one.
+func AuthUnaryServerInterceptor(router *auth.AuthRouter) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		if err := router.Authorize(ctx, auth.Event(info.FullMethod), req); err != nil {
 			return nil, err
@@ -177,11 +180,33 @@ func authUnaryInterceptor(router *auth.AuthRouter) grpc.UnaryServerInterceptor {
 	}
 }
 
+// StreamAuthorizationInterceptor authorizes streaming calls using the given
+// router.
+//
+// Streams are authorized before receiving any messages, so authorizations
+// used for streaming methods must not depend on the request, which is nil.
+func StreamAuthorizationInterceptor(router *auth.AuthRouter) ServerOption {
+	return func(o *options) {
+		o.interceptors.s = append(o.interceptors.s, AuthStreamServerInterceptor(router))
+	}
+}
+
+// AuthStreamServerInterceptor returns an interceptor authorizing streaming
+// calls using the given router, see StreamAuthorizationInterceptor.
+func AuthStreamServerInterceptor(router *auth.AuthRouter) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := router.Authorize(ss.Context(), auth.Event(info.FullMethod), nil); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
 func AuthorizationInterceptor(router *auth.AuthRouter) ServerOption {
 	return func(o *options) {
-		o.interceptors.u = append(o.interceptors.u, authUnaryInterceptor(router))
+		o.interceptors.u = append(o.interceptors.u, AuthUnaryServerInterceptor(router))
 		// TODO: Stream interceptors.
-		// o.interceptors.s = append(o.interceptors.s, authStreamInterceptor(router))
+		// o.interceptors.s = append(o.interceptors.s, AuthStreamServerInterceptor(router))
 	}
 }
 
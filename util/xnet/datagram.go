@@ -0,0 +1,261 @@
+package xnet
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// MaxDatagramSize is the maximum size of a UDP datagram payload.
+	MaxDatagramSize = 65507
+
+	datagramHandshakeOK = "OK"
+)
+
+// activity tracks the last time datagrams were transferred in either
+// direction, so one-way traffic does not look idle.
+type activity struct {
+	timeout time.Duration
+	last    int64
+}
+
+func newActivity(timeout time.Duration) *activity {
+	return &activity{
+		timeout: timeout,
+		last:    time.Now().UnixNano(),
+	}
+}
+
+func (m *activity) Touch() {
+	atomic.StoreInt64(&m.last, time.Now().UnixNano())
+}
+
+func (m *activity) Idle() bool {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&m.last))) >= m.timeout
+}
+
+// read reads a datagram from the connection until the activity becomes
+// idle.
+func (m *activity) read(conn net.Conn, buf []byte) (int, error) {
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(m.timeout)); err != nil {
+			return 0, err
+		}
+
+		n, err := conn.Read(buf)
+		if err != nil {
+			if netErr, ok := err.(net.Error); ok && netErr.Timeout() && !m.Idle() {
+				continue
+			}
+
+			return 0, err
+		}
+
+		m.Touch()
+		return n, nil
+	}
+}
+
+// PipeDatagrams copies datagrams between two datagram-oriented connections
+// until either of them fails or no datagrams are transferred for the given
+// timeout. Both connections are closed after.
+func PipeDatagrams(a, b net.Conn, idleTimeout time.Duration) error {
+	activity := newActivity(idleTimeout)
+
+	pipe := func(dst, src net.Conn) error {
+		buf := make([]byte, MaxDatagramSize)
+		for {
+			n, err := activity.read(src, buf)
+			if err != nil {
+				return err
+			}
+
+			if _, err := dst.Write(buf[:n]); err != nil {
+				return err
+			}
+		}
+	}
+
+	errs := make(chan error, 2)
+	go func() {
+		errs <- pipe(a, b)
+	}()
+	go func() {
+		errs <- pipe(b, a)
+	}()
+
+	err := <-errs
+	a.Close()
+	b.Close()
+	<-errs
+
+	return err
+}
+
+// DatagramDialer connects a new datagram session, receiving its first
+// datagram, which is not forwarded automatically.
+type DatagramDialer func(addr net.Addr, datagram []byte) (net.Conn, error)
+
+type datagramSession struct {
+	activity *activity
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+}
+
+func (m *datagramSession) Conn() net.Conn {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.conn
+}
+
+// SetConn sets the dialed connection, returning false if the session has
+// been closed meanwhile.
+func (m *datagramSession) SetConn(conn net.Conn) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return false
+	}
+
+	m.conn = conn
+	return true
+}
+
+func (m *datagramSession) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	if m.conn != nil {
+		m.conn.Close()
+	}
+}
+
+// ServeDatagrams splits datagrams received on the packet connection into
+// sessions by their source addresses, forwarding each session through its
+// own connection obtained using the dialer, until reading from the packet
+// connection fails.
+//
+// Datagrams received while the session is being dialed are dropped, like
+// sessions idle for the given timeout.
+func ServeDatagrams(conn net.PacketConn, dial DatagramDialer, idleTimeout time.Duration) error {
+	mu := sync.Mutex{}
+	sessions := map[string]*datagramSession{}
+
+	remove := func(key string, session *datagramSession) {
+		session.Close()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		if sessions[key] == session {
+			delete(sessions, key)
+		}
+	}
+
+	defer func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, session := range sessions {
+			session.Close()
+		}
+	}()
+
+	buf := make([]byte, MaxDatagramSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		key := addr.String()
+
+		mu.Lock()
+		session, ok := sessions[key]
+		if !ok {
+			session = &datagramSession{activity: newActivity(idleTimeout)}
+			sessions[key] = session
+		}
+		mu.Unlock()
+
+		if ok {
+			if remote := session.Conn(); remote != nil {
+				session.activity.Touch()
+				remote.Write(buf[:n])
+			}
+			continue
+		}
+
+		datagram := append([]byte{}, buf[:n]...)
+
+		go func() {
+			defer remove(key, session)
+
+			remote, err := dial(addr, datagram)
+			if err != nil {
+				return
+			}
+
+			if !session.SetConn(remote) {
+				remote.Close()
+				return
+			}
+
+			buf := make([]byte, MaxDatagramSize)
+			for {
+				n, err := session.activity.read(remote, buf)
+				if err != nil {
+					return
+				}
+
+				if _, err := conn.WriteTo(buf[:n], addr); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// DatagramHandshake sends the request as the first datagram of the session,
+// waiting for the reply. Any reply except "OK" is treated as an error
+// message.
+func DatagramHandshake(conn net.Conn, request string, timeout time.Duration) error {
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+	defer conn.SetDeadline(time.Time{})
+
+	if _, err := conn.Write([]byte(request)); err != nil {
+		return err
+	}
+
+	buf := make([]byte, MaxDatagramSize)
+	n, err := conn.Read(buf)
+	if err != nil {
+		return err
+	}
+
+	if reply := string(buf[:n]); reply != datagramHandshakeOK {
+		return errors.New(reply)
+	}
+
+	return nil
+}
+
+// ReplyDatagramHandshake replies to the datagram session handshake with
+// either "OK" or the error message.
+func ReplyDatagramHandshake(reply func([]byte) error, err error) error {
+	if err != nil {
+		return reply([]byte(err.Error()))
+	}
+
+	return reply([]byte(datagramHandshakeOK))
+}
@@ -0,0 +1,101 @@
+package xnet
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEchoServer(t *testing.T) net.PacketConn {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go func() {
+		buf := make([]byte, MaxDatagramSize)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			conn.WriteTo(buf[:n], addr)
+		}
+	}()
+
+	return conn
+}
+
+func TestServeDatagrams(t *testing.T) {
+	echo := newEchoServer(t)
+	defer echo.Close()
+
+	proxy, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer proxy.Close()
+
+	go ServeDatagrams(proxy, func(addr net.Addr, datagram []byte) (net.Conn, error) {
+		err := ReplyDatagramHandshake(func(reply []byte) error {
+			_, err := proxy.WriteTo(reply, addr)
+			return err
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return net.Dial("udp", echo.LocalAddr().String())
+	}, time.Minute)
+
+	client, err := net.Dial("udp", proxy.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	require.NoError(t, DatagramHandshake(client, "echo", time.Second))
+
+	buf := make([]byte, 64)
+	// The session may be not ready right after the handshake reply, so
+	// early datagrams may be dropped.
+	for {
+		_, err = client.Write([]byte("ping"))
+		require.NoError(t, err)
+
+		client.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+		n, err := client.Read(buf)
+		if err == nil {
+			assert.Equal(t, "ping", string(buf[:n]))
+			break
+		}
+	}
+}
+
+func TestDatagramHandshakeError(t *testing.T) {
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	go func() {
+		buf := make([]byte, 64)
+		server.Read(buf)
+		ReplyDatagramHandshake(func(reply []byte) error {
+			_, err := server.Write(reply)
+			return err
+		}, assert.AnError)
+	}()
+
+	err := DatagramHandshake(client, "request", time.Second)
+	require.Error(t, err)
+	assert.Equal(t, assert.AnError.Error(), err.Error())
+}
+
+func TestPipeDatagramsIdle(t *testing.T) {
+	a, b := net.Pipe()
+	c, d := net.Pipe()
+	defer b.Close()
+	defer d.Close()
+
+	start := time.Now()
+	assert.Error(t, PipeDatagrams(a, c, 50*time.Millisecond))
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+}
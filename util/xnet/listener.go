@@ -38,3 +38,36 @@ func ListenLoopback(network string, port uint16) ([]net.Listener, error) {
 
 	return listeners, nil
 }
+
+// ListenLoopbackPacket announces on the loopback network address.
+//
+// The network must be "udp", "udp4" or "udp6".
+func ListenLoopbackPacket(network string, port uint16) ([]net.PacketConn, error) {
+	if network != "udp" && network != "udp4" && network != "udp6" {
+		return nil, fmt.Errorf("unexpected network type: %s", network)
+	}
+
+	ips, err := LookupLoopbackIP()
+	if err != nil {
+		return nil, err
+	}
+
+	onFail := func(conns []net.PacketConn) {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+
+	var conns []net.PacketConn
+	for _, ip := range ips {
+		conn, err := net.ListenPacket(network, net.JoinHostPort(ip.String(), strconv.Itoa(int(port))))
+		if err != nil {
+			onFail(conns)
+			return nil, err
+		}
+
+		conns = append(conns, conn)
+	}
+
+	return conns, nil
+}